@@ -0,0 +1,474 @@
+// Package abi parses contract ABI JSON, encodes method calls for
+// AsimovCall/AsimovSendTransaction and decodes return data and event logs.
+//
+// Go values map to ABI types as follows: integers - *big.Int (int, uint and
+// their sized variants are accepted when encoding), address - 0x-prefixed hex
+// string, bool - bool, bytes and bytesN - []byte (hex strings are accepted when
+// encoding), string - string, arrays and tuples - []interface{}.
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Argument - named input or output of a method or event
+type Argument struct {
+	Name    string
+	Type    Type
+	Indexed bool
+}
+
+type argumentJSON struct {
+	Name       string         `json:"name"`
+	Type       string         `json:"type"`
+	Indexed    bool           `json:"indexed"`
+	Components []argumentJSON `json:"components"`
+}
+
+func (a argumentJSON) argument() (Argument, error) {
+	components := make([]Argument, len(a.Components))
+	for i, component := range a.Components {
+		argument, err := component.argument()
+		if err != nil {
+			return Argument{}, err
+		}
+		components[i] = argument
+	}
+
+	t, err := NewType(a.Type, components)
+	if err != nil {
+		return Argument{}, err
+	}
+
+	return Argument{Name: a.Name, Type: t, Indexed: a.Indexed}, nil
+}
+
+// Method - contract function or constructor
+type Method struct {
+	Name            string
+	Inputs          []Argument
+	Outputs         []Argument
+	StateMutability string
+}
+
+// Signature returns canonical signature such as transfer(address,uint256)
+func (m Method) Signature() string {
+	return m.Name + signature(m.Inputs)
+}
+
+// ID returns 4-byte function selector
+func (m Method) ID() []byte {
+	return keccak256([]byte(m.Signature()))[:4]
+}
+
+// Constant returns true if method does not modify state
+func (m Method) Constant() bool {
+	return m.StateMutability == "view" || m.StateMutability == "pure"
+}
+
+// Event - contract event
+type Event struct {
+	Name      string
+	Inputs    []Argument
+	Anonymous bool
+}
+
+// Signature returns canonical signature such as Transfer(address,address,uint256)
+func (e Event) Signature() string {
+	return e.Name + signature(e.Inputs)
+}
+
+// ID returns event topic, Keccak-256 of the signature
+func (e Event) ID() []byte {
+	return keccak256([]byte(e.Signature()))
+}
+
+func signature(arguments []Argument) string {
+	types := make([]string, len(arguments))
+	for i, argument := range arguments {
+		types[i] = argument.Type.String()
+	}
+
+	return "(" + strings.Join(types, ",") + ")"
+}
+
+// ABI - parsed contract interface. Overloaded methods and events get a numeric suffix
+// in order of appearance: transfer, transfer0, transfer1...
+type ABI struct {
+	Constructor *Method
+	Methods     map[string]Method
+	Events      map[string]Event
+}
+
+type entryJSON struct {
+	Type            string         `json:"type"`
+	Name            string         `json:"name"`
+	Inputs          []argumentJSON `json:"inputs"`
+	Outputs         []argumentJSON `json:"outputs"`
+	StateMutability string         `json:"stateMutability"`
+	Constant        bool           `json:"constant"`
+	Anonymous       bool           `json:"anonymous"`
+}
+
+// Parse parses ABI JSON
+func Parse(data []byte) (*ABI, error) {
+	var entries []entryJSON
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	abi := &ABI{Methods: map[string]Method{}, Events: map[string]Event{}}
+	for _, entry := range entries {
+		inputs, err := arguments(entry.Inputs)
+		if err != nil {
+			return nil, fmt.Errorf("abi: %s: %v", entry.Name, err)
+		}
+		outputs, err := arguments(entry.Outputs)
+		if err != nil {
+			return nil, fmt.Errorf("abi: %s: %v", entry.Name, err)
+		}
+
+		mutability := entry.StateMutability
+		if mutability == "" && entry.Constant {
+			// ABI produced by solidity before 0.5
+			mutability = "view"
+		}
+
+		switch entry.Type {
+		case "function", "":
+			name := overloadName(entry.Name, func(name string) bool { _, ok := abi.Methods[name]; return ok })
+			abi.Methods[name] = Method{Name: entry.Name, Inputs: inputs, Outputs: outputs, StateMutability: mutability}
+		case "constructor":
+			abi.Constructor = &Method{Inputs: inputs, StateMutability: mutability}
+		case "event":
+			name := overloadName(entry.Name, func(name string) bool { _, ok := abi.Events[name]; return ok })
+			abi.Events[name] = Event{Name: entry.Name, Inputs: inputs, Anonymous: entry.Anonymous}
+		}
+	}
+
+	return abi, nil
+}
+
+// Read parses ABI JSON from r
+func Read(r io.Reader) (*ABI, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data)
+}
+
+func arguments(entries []argumentJSON) ([]Argument, error) {
+	result := make([]Argument, len(entries))
+	for i, entry := range entries {
+		argument, err := entry.argument()
+		if err != nil {
+			return nil, err
+		}
+		result[i] = argument
+	}
+
+	return result, nil
+}
+
+func overloadName(name string, exists func(string) bool) string {
+	candidate := name
+	for i := 0; exists(candidate); i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+
+	return candidate
+}
+
+// Pack encodes call of method: selector followed by arguments.
+// Empty name packs constructor arguments, which are appended to contract bytecode.
+func (abi *ABI) Pack(name string, args ...interface{}) ([]byte, error) {
+	if name == "" {
+		if abi.Constructor == nil {
+			if len(args) > 0 {
+				return nil, fmt.Errorf("abi: constructor takes no arguments")
+			}
+			return nil, nil
+		}
+		return packArguments(abi.Constructor.Inputs, args)
+	}
+
+	method, ok := abi.Methods[name]
+	if !ok {
+		return nil, fmt.Errorf("abi: method %q not found", name)
+	}
+
+	data, err := packArguments(method.Inputs, args)
+	if err != nil {
+		return nil, fmt.Errorf("abi: %s: %v", method.Signature(), err)
+	}
+
+	return append(method.ID(), data...), nil
+}
+
+// EncodeCall returns 0x-prefixed call data usable as T.Data
+func (abi *ABI) EncodeCall(name string, args ...interface{}) (string, error) {
+	data, err := abi.Pack(name, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return "0x" + hex.EncodeToString(data), nil
+}
+
+// Unpack decodes return data of method
+func (abi *ABI) Unpack(name string, data []byte) ([]interface{}, error) {
+	method, ok := abi.Methods[name]
+	if !ok {
+		return nil, fmt.Errorf("abi: method %q not found", name)
+	}
+
+	values, err := unpackArguments(method.Outputs, data)
+	if err != nil {
+		return nil, fmt.Errorf("abi: %s: %v", method.Signature(), err)
+	}
+
+	return values, nil
+}
+
+// DecodeOutput decodes 0x-prefixed hex return data of method, as returned by AsimovCall
+func (abi *ABI) DecodeOutput(name string, output string) ([]interface{}, error) {
+	data, err := decodeHex(output)
+	if err != nil {
+		return nil, err
+	}
+
+	return abi.Unpack(name, data)
+}
+
+// MethodByID returns method whose selector starts call data
+func (abi *ABI) MethodByID(data []byte) (*Method, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("abi: call data too short")
+	}
+
+	for _, method := range abi.Methods {
+		if string(method.ID()) == string(data[:4]) {
+			method := method
+			return &method, nil
+		}
+	}
+
+	return nil, fmt.Errorf("abi: no method with selector %x", data[:4])
+}
+
+// DecodeInput decodes 0x-prefixed hex call data into method and its arguments
+func (abi *ABI) DecodeInput(input string) (*Method, []interface{}, error) {
+	data, err := decodeHex(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	method, err := abi.MethodByID(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	args, err := unpackArguments(method.Inputs, data[4:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("abi: %s: %v", method.Signature(), err)
+	}
+
+	return method, args, nil
+}
+
+// DecodeLog decodes log topics and data into event and its arguments keyed by name
+// (unnamed arguments are keyed arg0, arg1...). Indexed arguments of dynamic types
+// are only available as their 32-byte Keccak-256 hash.
+func (abi *ABI) DecodeLog(topics []string, data string) (*Event, map[string]interface{}, error) {
+	if len(topics) == 0 {
+		return nil, nil, fmt.Errorf("abi: anonymous logs are not supported")
+	}
+
+	topic, err := decodeHex(topics[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var event *Event
+	for _, e := range abi.Events {
+		if !e.Anonymous && string(e.ID()) == string(topic) {
+			e := e
+			event = &e
+			break
+		}
+	}
+	if event == nil {
+		return nil, nil, fmt.Errorf("abi: no event with topic %s", topics[0])
+	}
+
+	values, err := event.decode(topics, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return event, values, nil
+}
+
+// decode decodes log topics, including the event topic, and data of event
+func (e Event) decode(topics []string, data string) (map[string]interface{}, error) {
+	var indexed, unindexed []int
+	for i, input := range e.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, i)
+		} else {
+			unindexed = append(unindexed, i)
+		}
+	}
+	if len(topics) != len(indexed)+1 {
+		return nil, fmt.Errorf("abi: %s expects %d topics, got %d", e.Signature(), len(indexed)+1, len(topics))
+	}
+
+	values := map[string]interface{}{}
+	for i, index := range indexed {
+		input := e.Inputs[index]
+		topic, err := decodeHex(topics[i+1])
+		if err != nil {
+			return nil, err
+		}
+		if len(topic) != 32 {
+			return nil, fmt.Errorf("abi: topic %s is not 32 bytes", topics[i+1])
+		}
+
+		var value interface{} = topic
+		if !input.Type.Dynamic() && input.Type.Kind != ArrayKind && input.Type.Kind != TupleKind {
+			if value, err = decode(input.Type, topic, 0); err != nil {
+				return nil, err
+			}
+		}
+		values[argumentName(input, index)] = value
+	}
+
+	raw, err := decodeHex(data)
+	if err != nil {
+		return nil, err
+	}
+	arguments := make([]Argument, len(unindexed))
+	for i, index := range unindexed {
+		arguments[i] = e.Inputs[index]
+	}
+	decoded, err := unpackArguments(arguments, raw)
+	if err != nil {
+		return nil, err
+	}
+	for i, index := range unindexed {
+		values[argumentName(e.Inputs[index], index)] = decoded[i]
+	}
+
+	return values, nil
+}
+
+// DecodedEvent - event log decoded for non-Go consumers, e.g. stored or sent as JSON: integers
+// are decimal strings, bytes 0x-prefixed hex, arrays and tuples JSON arrays
+type DecodedEvent struct {
+	Name      string                 `json:"name"`
+	Signature string                 `json:"signature"`
+	Values    map[string]interface{} `json:"values"`
+}
+
+// DecodeEvent decodes log like DecodeLog into DecodedEvent
+func (abi *ABI) DecodeEvent(topics []string, data string) (*DecodedEvent, error) {
+	event, values, err := abi.DecodeLog(topics, data)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range values {
+		values[name] = jsonValue(value)
+	}
+
+	return &DecodedEvent{Name: event.Name, Signature: event.Signature(), Values: values}, nil
+}
+
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	case *big.Int:
+		return v.String()
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, element := range v {
+			values[i] = jsonValue(element)
+		}
+		return values
+	}
+
+	return value
+}
+
+func argumentName(argument Argument, index int) string {
+	if argument.Name != "" {
+		return argument.Name
+	}
+
+	return fmt.Sprintf("arg%d", index)
+}
+
+func decodeHex(value string) ([]byte, error) {
+	value = strings.TrimPrefix(value, "0x")
+	if len(value)%2 == 1 {
+		value = "0" + value
+	}
+
+	return hex.DecodeString(value)
+}
+
+func keccak256(data []byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(data)
+
+	return hash.Sum(nil)
+}
+
+// DescribeCall renders call data as readable call such as "transfer(0x6247cf04..., 1000)"
+func (abi *ABI) DescribeCall(input string) (string, bool) {
+	method, args, err := abi.DecodeInput(input)
+	if err != nil {
+		return "", false
+	}
+
+	values := make([]string, len(args))
+	for i, arg := range args {
+		values[i] = describe(arg)
+	}
+
+	return method.Name + "(" + strings.Join(values, ", ") + ")", true
+}
+
+// MethodDecoder returns DescribeCall as asimovrpc.MethodDecoder for traces and explanations of a single contract,
+// the called address is ignored
+func (abi *ABI) MethodDecoder() func(to, input string) (string, bool) {
+	return func(to, input string) (string, bool) {
+		return abi.DescribeCall(input)
+	}
+}
+
+func describe(value interface{}) string {
+	switch v := value.(type) {
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	case []interface{}:
+		values := make([]string, len(v))
+		for i, element := range v {
+			values[i] = describe(element)
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	}
+
+	return fmt.Sprint(value)
+}
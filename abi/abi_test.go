@@ -0,0 +1,200 @@
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const erc20 = `[
+	{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"balanceOf","constant":true,"inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"f","inputs":[{"name":"a","type":"uint256"},{"name":"b","type":"uint32[]"},{"name":"c","type":"bytes10"},{"name":"d","type":"bytes"}],"outputs":[]},
+	{"type":"function","name":"g","inputs":[{"name":"p","type":"tuple","components":[{"name":"x","type":"int8"},{"name":"s","type":"string"}]},{"name":"q","type":"bool[2]"}],"outputs":[{"name":"","type":"tuple","components":[{"name":"x","type":"int8"},{"name":"s","type":"string"}]},{"name":"","type":"bool[2]"}]},
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"}],"outputs":[]},
+	{"type":"constructor","inputs":[{"name":"supply","type":"uint256"}]},
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+func parse(t *testing.T) *ABI {
+	abi, err := Read(strings.NewReader(erc20))
+	require.Nil(t, err)
+	return abi
+}
+
+func words(hexWords ...string) string {
+	return strings.Join(hexWords, "")
+}
+
+func TestParse(t *testing.T) {
+	abi := parse(t)
+
+	require.Len(t, abi.Methods, 5)
+	require.Equal(t, "transfer(address,uint256)", abi.Methods["transfer"].Signature())
+	require.Equal(t, "transfer(address,uint256,bytes)", abi.Methods["transfer0"].Signature())
+	require.Equal(t, "g((int8,string),bool[2])", abi.Methods["g"].Signature())
+	require.Equal(t, "a9059cbb", hex.EncodeToString(abi.Methods["transfer"].ID()))
+	require.True(t, abi.Methods["balanceOf"].Constant())
+	require.False(t, abi.Methods["transfer"].Constant())
+	require.Equal(t, "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", hex.EncodeToString(abi.Events["Transfer"].ID()))
+	require.Len(t, abi.Constructor.Inputs, 1)
+
+	for _, invalid := range []string{"uint7", "int264", "bytes33", "fixed128x18", "uint[0]", "tuple"} {
+		_, err := NewType(invalid, nil)
+		require.NotNil(t, err, invalid)
+	}
+	_, err := Parse([]byte(`[{"type":"function","name":"h","inputs":[{"type":"uint7"}]}]`))
+	require.NotNil(t, err)
+}
+
+func TestPack(t *testing.T) {
+	abi := parse(t)
+
+	data, err := abi.EncodeCall("transfer", "0x6247cf0412c6462da2a51d05139e2a3c6c630f0a", 1000)
+	require.Nil(t, err)
+	require.Equal(t, "0xa9059cbb"+words(
+		"0000000000000000000000006247cf0412c6462da2a51d05139e2a3c6c630f0a",
+		"00000000000000000000000000000000000000000000000000000000000003e8",
+	), data)
+
+	// example from the Solidity ABI specification
+	packed, err := abi.Pack("f", big.NewInt(0x123), []uint32{0x456, 0x789}, []byte("1234567890"), []byte("Hello, world!"))
+	require.Nil(t, err)
+	require.Equal(t, "8be65246"+words(
+		"0000000000000000000000000000000000000000000000000000000000000123",
+		"0000000000000000000000000000000000000000000000000000000000000080",
+		"3132333435363738393000000000000000000000000000000000000000000000",
+		"00000000000000000000000000000000000000000000000000000000000000e0",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000456",
+		"0000000000000000000000000000000000000000000000000000000000000789",
+		"000000000000000000000000000000000000000000000000000000000000000d",
+		"48656c6c6f2c20776f726c642100000000000000000000000000000000000000",
+	), hex.EncodeToString(packed))
+
+	constructor, err := abi.Pack("", 1)
+	require.Nil(t, err)
+	require.Len(t, constructor, 32)
+
+	_, err = abi.Pack("transfer", "0x6247", 1)
+	require.NotNil(t, err)
+	_, err = abi.Pack("transfer", "0x6247cf0412c6462da2a51d05139e2a3c6c630f0a", -1)
+	require.NotNil(t, err)
+	_, err = abi.Pack("transfer", "0x6247cf0412c6462da2a51d05139e2a3c6c630f0a")
+	require.NotNil(t, err)
+	_, err = abi.Pack("g", []interface{}{128, "s"}, []bool{true, false})
+	require.NotNil(t, err)
+	_, err = abi.Pack("missing")
+	require.NotNil(t, err)
+}
+
+func TestUnpack(t *testing.T) {
+	abi := parse(t)
+
+	method, args, err := abi.DecodeInput("0x8be65246" + words(
+		"0000000000000000000000000000000000000000000000000000000000000123",
+		"0000000000000000000000000000000000000000000000000000000000000080",
+		"3132333435363738393000000000000000000000000000000000000000000000",
+		"00000000000000000000000000000000000000000000000000000000000000e0",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000456",
+		"0000000000000000000000000000000000000000000000000000000000000789",
+		"000000000000000000000000000000000000000000000000000000000000000d",
+		"48656c6c6f2c20776f726c642100000000000000000000000000000000000000",
+	))
+	require.Nil(t, err)
+	require.Equal(t, "f", method.Name)
+	require.Equal(t, []interface{}{
+		big.NewInt(0x123),
+		[]interface{}{big.NewInt(0x456), big.NewInt(0x789)},
+		[]byte("1234567890"),
+		[]byte("Hello, world!"),
+	}, args)
+
+	packed, err := abi.Pack("g", []interface{}{-5, "tuple"}, []bool{true, false})
+	require.Nil(t, err)
+	values, err := abi.Unpack("g", packed[4:])
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{
+		[]interface{}{big.NewInt(-5), "tuple"},
+		[]interface{}{true, false},
+	}, values)
+
+	values, err = abi.DecodeOutput("balanceOf", "0x00000000000000000000000000000000000000000000000000000000000003e8")
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{big.NewInt(1000)}, values)
+
+	_, err = abi.DecodeOutput("balanceOf", "0x03e8")
+	require.NotNil(t, err)
+	_, err = abi.Unpack("g", packed[4:40])
+	require.NotNil(t, err)
+	_, _, err = abi.DecodeInput("0xdeadbeef")
+	require.NotNil(t, err)
+}
+
+func TestDecodeLog(t *testing.T) {
+	abi := parse(t)
+
+	event, values, err := abi.DecodeLog([]string{
+		"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+		"0x0000000000000000000000006247cf0412c6462da2a51d05139e2a3c6c630f0a",
+		"0x000000000000000000000000c1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4",
+	}, "0x00000000000000000000000000000000000000000000000000000000000003e8")
+	require.Nil(t, err)
+	require.Equal(t, "Transfer", event.Name)
+	require.Equal(t, map[string]interface{}{
+		"from":  "0x6247cf0412c6462da2a51d05139e2a3c6c630f0a",
+		"to":    "0xc1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4",
+		"value": big.NewInt(1000),
+	}, values)
+
+	_, _, err = abi.DecodeLog([]string{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"}, "0x")
+	require.NotNil(t, err)
+	_, _, err = abi.DecodeLog([]string{"0x00"}, "0x")
+	require.NotNil(t, err)
+	_, _, err = abi.DecodeLog(nil, "0x")
+	require.NotNil(t, err)
+}
+
+func TestDecodeEvent(t *testing.T) {
+	abi := parse(t)
+
+	event, err := abi.DecodeEvent([]string{
+		"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+		"0x0000000000000000000000006247cf0412c6462da2a51d05139e2a3c6c630f0a",
+		"0x000000000000000000000000c1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4",
+	}, "0x00000000000000000000000000000000000000000000000000000000000003e8")
+	require.Nil(t, err)
+
+	data, err := json.Marshal(event)
+	require.Nil(t, err)
+	require.JSONEq(t, `{
+		"name": "Transfer",
+		"signature": "Transfer(address,address,uint256)",
+		"values": {"from": "0x6247cf0412c6462da2a51d05139e2a3c6c630f0a", "to": "0xc1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4", "value": "1000"}
+	}`, string(data))
+
+	_, err = abi.DecodeEvent([]string{"0x00"}, "0x")
+	require.NotNil(t, err)
+}
+
+func TestDescribeCall(t *testing.T) {
+	abi := parse(t)
+
+	input, err := abi.EncodeCall("f", 1, []int{2, 3}, "0x31323334353637383930", []byte{0xca, 0xfe})
+	require.Nil(t, err)
+	call, ok := abi.DescribeCall(input)
+	require.True(t, ok)
+	require.Equal(t, "f(1, [2, 3], 0x31323334353637383930, 0xcafe)", call)
+
+	_, ok = abi.DescribeCall("0x")
+	require.False(t, ok)
+
+	call, ok = abi.MethodDecoder()("0x1", input)
+	require.True(t, ok)
+	require.Equal(t, "f(1, [2, 3], 0x31323334353637383930, 0xcafe)", call)
+}
@@ -0,0 +1,341 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+var (
+	one      = big.NewInt(1)
+	wordMod  = new(big.Int).Lsh(one, 256)
+	maxWord  = new(big.Int).Sub(wordMod, one)
+	maxInt64 = big.NewInt(1<<63 - 1)
+)
+
+func packArguments(arguments []Argument, args []interface{}) ([]byte, error) {
+	if len(args) != len(arguments) {
+		return nil, fmt.Errorf("expected %d arguments, got %d", len(arguments), len(args))
+	}
+
+	types := make([]Type, len(arguments))
+	for i, argument := range arguments {
+		types[i] = argument.Type
+	}
+
+	return encodeTuple(types, args)
+}
+
+func unpackArguments(arguments []Argument, data []byte) ([]interface{}, error) {
+	types := make([]Type, len(arguments))
+	for i, argument := range arguments {
+		types[i] = argument.Type
+	}
+
+	return decodeTuple(types, data)
+}
+
+// encodeTuple encodes values as a sequence of heads followed by tails of dynamic values
+func encodeTuple(types []Type, values []interface{}) ([]byte, error) {
+	headSize := 0
+	for _, t := range types {
+		headSize += t.headSize()
+	}
+
+	var head, tail []byte
+	for i, t := range types {
+		encoded, err := encode(t, values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if t.Dynamic() {
+			head = append(head, word(big.NewInt(int64(headSize+len(tail))))...)
+			tail = append(tail, encoded...)
+		} else {
+			head = append(head, encoded...)
+		}
+	}
+
+	return append(head, tail...), nil
+}
+
+func encode(t Type, value interface{}) ([]byte, error) {
+	switch t.Kind {
+	case UintKind, IntKind:
+		n, err := toBig(value)
+		if err != nil {
+			return nil, err
+		}
+		if !fits(t, n) {
+			return nil, fmt.Errorf("%s out of range for %s", n, t)
+		}
+		if n.Sign() < 0 {
+			n = new(big.Int).Add(n, wordMod)
+		}
+		return word(n), nil
+	case AddressKind:
+		address, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("address must be string, got %T", value)
+		}
+		data, err := decodeHex(address)
+		if err != nil || len(data) != 20 {
+			return nil, fmt.Errorf("invalid address %q", address)
+		}
+		return leftPad(data), nil
+	case BoolKind:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("bool expected, got %T", value)
+		}
+		if b {
+			return word(one), nil
+		}
+		return word(new(big.Int)), nil
+	case FixedBytesKind:
+		data, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) != t.Size {
+			return nil, fmt.Errorf("%s expects %d bytes, got %d", t, t.Size, len(data))
+		}
+		return rightPad(data), nil
+	case BytesKind, StringKind:
+		var data []byte
+		if s, ok := value.(string); ok && t.Kind == StringKind {
+			data = []byte(s)
+		} else if t.Kind == BytesKind {
+			var err error
+			if data, err = toBytes(value); err != nil {
+				return nil, err
+			}
+		} else {
+			return nil, fmt.Errorf("string expected, got %T", value)
+		}
+		return append(word(big.NewInt(int64(len(data)))), rightPad(data)...), nil
+	case SliceKind, ArrayKind:
+		elements, err := toSlice(value)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == ArrayKind && len(elements) != t.Size {
+			return nil, fmt.Errorf("%s expects %d elements, got %d", t, t.Size, len(elements))
+		}
+		types := make([]Type, len(elements))
+		for i := range types {
+			types[i] = *t.Elem
+		}
+		encoded, err := encodeTuple(types, elements)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == SliceKind {
+			encoded = append(word(big.NewInt(int64(len(elements)))), encoded...)
+		}
+		return encoded, nil
+	case TupleKind:
+		fields, err := toSlice(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) != len(t.Components) {
+			return nil, fmt.Errorf("%s expects %d fields, got %d", t, len(t.Components), len(fields))
+		}
+		types := make([]Type, len(t.Components))
+		for i, component := range t.Components {
+			types[i] = component.Type
+		}
+		return encodeTuple(types, fields)
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+func decodeTuple(types []Type, data []byte) ([]interface{}, error) {
+	values := make([]interface{}, len(types))
+	offset := 0
+	for i, t := range types {
+		var err error
+		if t.Dynamic() {
+			var position int
+			if position, err = readOffset(data, offset); err != nil {
+				return nil, err
+			}
+			values[i], err = decode(t, data, position)
+		} else {
+			values[i], err = decode(t, data, offset)
+		}
+		if err != nil {
+			return nil, err
+		}
+		offset += t.headSize()
+	}
+
+	return values, nil
+}
+
+func decode(t Type, data []byte, offset int) (interface{}, error) {
+	switch t.Kind {
+	case UintKind, IntKind, AddressKind, BoolKind, FixedBytesKind:
+		if offset+32 > len(data) {
+			return nil, fmt.Errorf("data too short for %s", t)
+		}
+		w := data[offset : offset+32]
+		switch t.Kind {
+		case UintKind:
+			return new(big.Int).SetBytes(w), nil
+		case IntKind:
+			n := new(big.Int).SetBytes(w)
+			if w[0]&0x80 != 0 {
+				n.Sub(n, wordMod)
+			}
+			return n, nil
+		case AddressKind:
+			return fmt.Sprintf("0x%x", w[12:]), nil
+		case BoolKind:
+			return w[31] == 1, nil
+		default:
+			return append([]byte{}, w[:t.Size]...), nil
+		}
+	case BytesKind, StringKind:
+		length, err := readOffset(data, offset)
+		if err != nil {
+			return nil, err
+		}
+		start := offset + 32
+		if start+length > len(data) {
+			return nil, fmt.Errorf("data too short for %s", t)
+		}
+		if t.Kind == StringKind {
+			return string(data[start : start+length]), nil
+		}
+		return append([]byte{}, data[start:start+length]...), nil
+	case SliceKind, ArrayKind:
+		length := t.Size
+		if t.Kind == SliceKind {
+			var err error
+			if length, err = readOffset(data, offset); err != nil {
+				return nil, err
+			}
+			offset += 32
+		}
+		if length*32 > len(data)-offset {
+			return nil, fmt.Errorf("data too short for %s", t)
+		}
+		types := make([]Type, length)
+		for i := range types {
+			types[i] = *t.Elem
+		}
+		return decodeTuple(types, data[offset:])
+	case TupleKind:
+		types := make([]Type, len(t.Components))
+		for i, component := range t.Components {
+			types[i] = component.Type
+		}
+		if offset > len(data) {
+			return nil, fmt.Errorf("data too short for %s", t)
+		}
+		return decodeTuple(types, data[offset:])
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// readOffset reads word at offset as a length or offset
+func readOffset(data []byte, offset int) (int, error) {
+	if offset < 0 || offset+32 > len(data) {
+		return 0, fmt.Errorf("data too short")
+	}
+
+	n := new(big.Int).SetBytes(data[offset : offset+32])
+	if n.Cmp(maxInt64) > 0 || n.Int64() > int64(len(data)) {
+		return 0, fmt.Errorf("offset %s out of range", n)
+	}
+
+	return int(n.Int64()), nil
+}
+
+func fits(t Type, n *big.Int) bool {
+	if t.Kind == UintKind {
+		return n.Sign() >= 0 && n.BitLen() <= t.Size
+	}
+
+	limit := new(big.Int).Lsh(one, uint(t.Size-1))
+	return n.Cmp(limit) < 0 && n.Cmp(new(big.Int).Neg(limit)) >= 0
+}
+
+func word(n *big.Int) []byte {
+	return leftPad(new(big.Int).And(n, maxWord).Bytes())
+}
+
+func leftPad(data []byte) []byte {
+	padded := make([]byte, 32)
+	copy(padded[32-len(data):], data)
+	return padded
+}
+
+func rightPad(data []byte) []byte {
+	padded := make([]byte, (len(data)+31)/32*32)
+	copy(padded, data)
+	return padded
+}
+
+func toBig(value interface{}) (*big.Int, error) {
+	switch n := value.(type) {
+	case *big.Int:
+		if n == nil {
+			return nil, fmt.Errorf("nil integer")
+		}
+		return n, nil
+	case big.Int:
+		return &n, nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(v.Uint()), nil
+	}
+
+	return nil, fmt.Errorf("integer expected, got %T", value)
+}
+
+func toBytes(value interface{}) ([]byte, error) {
+	switch data := value.(type) {
+	case []byte:
+		return data, nil
+	case string:
+		return decodeHex(data)
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8 {
+		data := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(data), v)
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("bytes expected, got %T", value)
+}
+
+func toSlice(value interface{}) ([]interface{}, error) {
+	if elements, ok := value.([]interface{}); ok {
+		return elements, nil
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("slice expected, got %T", value)
+	}
+
+	elements := make([]interface{}, v.Len())
+	for i := range elements {
+		elements[i] = v.Index(i).Interface()
+	}
+
+	return elements, nil
+}
@@ -0,0 +1,212 @@
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Logger - receives registry warnings, *log.Logger satisfies it
+type Logger interface {
+	Println(v ...interface{})
+}
+
+// RegisteredEvent - event of an ABI registered under a name
+type RegisteredEvent struct {
+	ABI   string
+	Event Event
+}
+
+func (e RegisteredEvent) String() string {
+	return e.ABI + "." + layout(e.Event)
+}
+
+// Collision - events of registered ABIs sharing a topic but not the layout: indexed arguments or
+// argument names differ, e.g. Transfer of ERC-20 and ERC-721. Logs with the topic may be decoded
+// with the event of the wrong contract.
+type Collision struct {
+	Topic  string
+	Events []RegisteredEvent
+}
+
+func (c Collision) String() string {
+	return fmt.Sprintf("topic %s is declared by %s", c.Topic, joinEvents(c.Events))
+}
+
+// AmbiguousDecodeError - log decodes with events of more than one registered ABI
+type AmbiguousDecodeError struct {
+	Topic  string
+	Events []RegisteredEvent
+}
+
+func (err AmbiguousDecodeError) Error() string {
+	return fmt.Sprintf("abi: log with topic %s decodes as %s", err.Topic, joinEvents(err.Events))
+}
+
+// DecodedLog - log decoded by Registry
+type DecodedLog struct {
+	ABI    string
+	Event  Event
+	Values map[string]interface{}
+}
+
+// Registry - decodes logs of contracts with different ABIs. Events of registered ABIs are keyed by
+// topic, topic collisions are returned by Register and Collisions and logged as warnings.
+type Registry struct {
+	log Logger
+
+	mu     sync.RWMutex
+	names  map[string]bool
+	events map[string][]RegisteredEvent // by topic, one event per layout
+	topics []string                     // colliding topics in order of detection
+}
+
+// NewRegistry creates empty registry logging warnings to log (nil - not logged)
+func NewRegistry(log Logger) *Registry {
+	return &Registry{log: log, names: map[string]bool{}, events: map[string][]RegisteredEvent{}}
+}
+
+// Register adds events of abi under name and returns collisions it introduced
+func (r *Registry) Register(name string, abi *ABI) ([]Collision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.names[name] {
+		return nil, fmt.Errorf("abi: %s is already registered", name)
+	}
+	r.names[name] = true
+
+	events := make([]string, 0, len(abi.Events))
+	for key := range abi.Events {
+		events = append(events, key)
+	}
+	sort.Strings(events)
+
+	var collisions []Collision
+	for _, key := range events {
+		event := abi.Events[key]
+		if event.Anonymous {
+			continue
+		}
+
+		topic := "0x" + hex.EncodeToString(event.ID())
+		known := r.events[topic]
+		if hasLayout(known, event) {
+			continue
+		}
+		r.events[topic] = append(known, RegisteredEvent{ABI: name, Event: event})
+		if len(known) == 0 {
+			continue
+		}
+
+		if len(known) == 1 {
+			r.topics = append(r.topics, topic)
+		}
+		collision := Collision{Topic: topic, Events: append([]RegisteredEvent{}, r.events[topic]...)}
+		collisions = append(collisions, collision)
+		r.warn(collision.String())
+	}
+
+	return collisions, nil
+}
+
+// Collisions returns topics declared by registered ABIs with different layouts
+func (r *Registry) Collisions() []Collision {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	collisions := make([]Collision, len(r.topics))
+	for i, topic := range r.topics {
+		collisions[i] = Collision{Topic: topic, Events: append([]RegisteredEvent{}, r.events[topic]...)}
+	}
+
+	return collisions
+}
+
+// DecodeLog decodes log with the registered event of its topic. Of colliding events, the one
+// the log decodes with is used; AmbiguousDecodeError is returned if it decodes with several.
+func (r *Registry) DecodeLog(topics []string, data string) (*DecodedLog, error) {
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("abi: anonymous logs are not supported")
+	}
+
+	raw, err := decodeHex(topics[0])
+	if err != nil {
+		return nil, err
+	}
+	topic := "0x" + hex.EncodeToString(raw)
+
+	r.mu.RLock()
+	candidates := r.events[topic]
+	r.mu.RUnlock()
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("abi: no event with topic %s", topics[0])
+	}
+
+	var decoded []DecodedLog
+	var matched []RegisteredEvent
+	var first error
+	for _, candidate := range candidates {
+		values, err := candidate.Event.decode(topics, data)
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			continue
+		}
+		decoded = append(decoded, DecodedLog{ABI: candidate.ABI, Event: candidate.Event, Values: values})
+		matched = append(matched, candidate)
+	}
+
+	switch len(decoded) {
+	case 0:
+		return nil, first
+	case 1:
+		return &decoded[0], nil
+	}
+
+	err = AmbiguousDecodeError{Topic: topic, Events: matched}
+	r.warn(err.Error())
+	return nil, err
+}
+
+func (r *Registry) warn(message string) {
+	if r.log != nil {
+		r.log.Println("abi: warning: " + strings.TrimPrefix(message, "abi: "))
+	}
+}
+
+// layout returns signature of event with argument names and indexed flags
+func layout(event Event) string {
+	arguments := make([]string, len(event.Inputs))
+	for i, input := range event.Inputs {
+		arguments[i] = input.Type.String()
+		if input.Indexed {
+			arguments[i] += " indexed"
+		}
+		arguments[i] += " " + argumentName(input, i)
+	}
+
+	return event.Name + "(" + strings.Join(arguments, ",") + ")"
+}
+
+func hasLayout(events []RegisteredEvent, event Event) bool {
+	for _, known := range events {
+		if layout(known.Event) == layout(event) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func joinEvents(events []RegisteredEvent) string {
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = event.String()
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,82 @@
+package abi
+
+import (
+	"bytes"
+	"log"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const erc721 = `[
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"tokenId","type":"uint256","indexed":true}]}
+]`
+
+const weth = `[
+	{"type":"event","name":"Transfer","inputs":[{"name":"src","type":"address","indexed":true},{"name":"dst","type":"address","indexed":true},{"name":"wad","type":"uint256","indexed":false}]}
+]`
+
+func TestRegistry(t *testing.T) {
+	var warnings bytes.Buffer
+	registry := NewRegistry(log.New(&warnings, "", 0))
+
+	collisions, err := registry.Register("erc20", parse(t))
+	require.Nil(t, err)
+	require.Empty(t, collisions)
+
+	// identical events of another token are not a collision
+	collisions, err = registry.Register("token", parse(t))
+	require.Nil(t, err)
+	require.Empty(t, collisions)
+
+	_, err = registry.Register("erc20", parse(t))
+	require.EqualError(t, err, "abi: erc20 is already registered")
+
+	nft, err := Read(strings.NewReader(erc721))
+	require.Nil(t, err)
+	collisions, err = registry.Register("erc721", nft)
+	require.Nil(t, err)
+	require.Len(t, collisions, 1)
+	require.Equal(t, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", collisions[0].Topic)
+	require.Equal(t, "topic 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef is declared by "+
+		"erc20.Transfer(address indexed from,address indexed to,uint256 value), "+
+		"erc721.Transfer(address indexed from,address indexed to,uint256 indexed tokenId)", collisions[0].String())
+	require.Equal(t, "abi: warning: "+collisions[0].String()+"\n", warnings.String())
+	require.Equal(t, collisions, registry.Collisions())
+
+	from := "0x000000000000000000000000" + strings.Repeat("11", 20)
+	to := "0x000000000000000000000000" + strings.Repeat("22", 20)
+	amount := "0x" + words("0000000000000000000000000000000000000000000000000000000000000064")
+
+	// the topic count tells the colliding events apart
+	decoded, err := registry.DecodeLog([]string{collisions[0].Topic, from, to}, amount)
+	require.Nil(t, err)
+	require.Equal(t, "erc20", decoded.ABI)
+	require.Equal(t, big.NewInt(100), decoded.Values["value"])
+
+	decoded, err = registry.DecodeLog([]string{collisions[0].Topic, from, to, amount}, "0x")
+	require.Nil(t, err)
+	require.Equal(t, "erc721", decoded.ABI)
+	require.Equal(t, big.NewInt(100), decoded.Values["tokenId"])
+
+	wrapped, err := Read(strings.NewReader(weth))
+	require.Nil(t, err)
+	collisions, err = registry.Register("weth", wrapped)
+	require.Nil(t, err)
+	require.Len(t, collisions, 1)
+	require.Len(t, collisions[0].Events, 3)
+	require.Len(t, registry.Collisions(), 1)
+
+	warnings.Reset()
+	_, err = registry.DecodeLog([]string{collisions[0].Topic, from, to}, amount)
+	require.Equal(t, AmbiguousDecodeError{Topic: collisions[0].Topic, Events: []RegisteredEvent{
+		{ABI: "erc20", Event: parse(t).Events["Transfer"]},
+		{ABI: "weth", Event: wrapped.Events["Transfer"]},
+	}}, err)
+	require.Contains(t, warnings.String(), "abi: warning: log with topic 0xddf252ad")
+
+	_, err = registry.DecodeLog([]string{"0x" + strings.Repeat("00", 32)}, "0x")
+	require.EqualError(t, err, "abi: no event with topic 0x"+strings.Repeat("00", 32))
+}
@@ -0,0 +1,175 @@
+package abi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind - kind of ABI type
+type Kind int
+
+// ABI type kinds
+const (
+	UintKind Kind = iota
+	IntKind
+	AddressKind
+	BoolKind
+	FixedBytesKind
+	BytesKind
+	StringKind
+	SliceKind
+	ArrayKind
+	TupleKind
+)
+
+// Type - parsed ABI type
+type Type struct {
+	Kind       Kind
+	Size       int // bits of integers, length of fixed bytes and arrays
+	Elem       *Type
+	Components []Argument // tuple fields
+}
+
+// NewType parses ABI type, components describe tuple fields
+func NewType(name string, components []Argument) (Type, error) {
+	if strings.HasSuffix(name, "]") {
+		open := strings.LastIndex(name, "[")
+		if open < 0 {
+			return Type{}, fmt.Errorf("abi: invalid type %q", name)
+		}
+		elem, err := NewType(name[:open], components)
+		if err != nil {
+			return Type{}, err
+		}
+
+		size := name[open+1 : len(name)-1]
+		if size == "" {
+			return Type{Kind: SliceKind, Elem: &elem}, nil
+		}
+		length, err := strconv.Atoi(size)
+		if err != nil || length <= 0 {
+			return Type{}, fmt.Errorf("abi: invalid array length in %q", name)
+		}
+		return Type{Kind: ArrayKind, Size: length, Elem: &elem}, nil
+	}
+
+	switch {
+	case name == "address":
+		return Type{Kind: AddressKind, Size: 160}, nil
+	case name == "bool":
+		return Type{Kind: BoolKind}, nil
+	case name == "string":
+		return Type{Kind: StringKind}, nil
+	case name == "bytes":
+		return Type{Kind: BytesKind}, nil
+	case name == "tuple":
+		if len(components) == 0 {
+			return Type{}, fmt.Errorf("abi: tuple without components")
+		}
+		return Type{Kind: TupleKind, Components: components}, nil
+	case strings.HasPrefix(name, "bytes"):
+		size, err := strconv.Atoi(name[len("bytes"):])
+		if err != nil || size < 1 || size > 32 {
+			return Type{}, fmt.Errorf("abi: invalid type %q", name)
+		}
+		return Type{Kind: FixedBytesKind, Size: size}, nil
+	case strings.HasPrefix(name, "uint"):
+		size, err := integerSize(name[len("uint"):])
+		if err != nil {
+			return Type{}, fmt.Errorf("abi: invalid type %q", name)
+		}
+		return Type{Kind: UintKind, Size: size}, nil
+	case strings.HasPrefix(name, "int"):
+		size, err := integerSize(name[len("int"):])
+		if err != nil {
+			return Type{}, fmt.Errorf("abi: invalid type %q", name)
+		}
+		return Type{Kind: IntKind, Size: size}, nil
+	}
+
+	return Type{}, fmt.Errorf("abi: unsupported type %q", name)
+}
+
+func integerSize(bits string) (int, error) {
+	if bits == "" {
+		return 256, nil
+	}
+
+	size, err := strconv.Atoi(bits)
+	if err != nil || size < 8 || size > 256 || size%8 != 0 {
+		return 0, fmt.Errorf("invalid integer size %q", bits)
+	}
+
+	return size, nil
+}
+
+// String returns canonical type name used in signatures
+func (t Type) String() string {
+	switch t.Kind {
+	case UintKind:
+		return fmt.Sprintf("uint%d", t.Size)
+	case IntKind:
+		return fmt.Sprintf("int%d", t.Size)
+	case AddressKind:
+		return "address"
+	case BoolKind:
+		return "bool"
+	case FixedBytesKind:
+		return fmt.Sprintf("bytes%d", t.Size)
+	case BytesKind:
+		return "bytes"
+	case StringKind:
+		return "string"
+	case SliceKind:
+		return t.Elem.String() + "[]"
+	case ArrayKind:
+		return fmt.Sprintf("%s[%d]", t.Elem, t.Size)
+	case TupleKind:
+		names := make([]string, len(t.Components))
+		for i, component := range t.Components {
+			names[i] = component.Type.String()
+		}
+		return "(" + strings.Join(names, ",") + ")"
+	}
+
+	return fmt.Sprintf("Kind(%d)", int(t.Kind))
+}
+
+// Dynamic returns true if encoding of type is stored in the tail, referenced by offset
+func (t Type) Dynamic() bool {
+	switch t.Kind {
+	case BytesKind, StringKind, SliceKind:
+		return true
+	case ArrayKind:
+		return t.Elem.Dynamic()
+	case TupleKind:
+		for _, component := range t.Components {
+			if component.Type.Dynamic() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// headSize returns number of bytes type occupies in the head of enclosing tuple
+func (t Type) headSize() int {
+	if t.Dynamic() {
+		return 32
+	}
+
+	switch t.Kind {
+	case ArrayKind:
+		return t.Size * t.Elem.headSize()
+	case TupleKind:
+		size := 0
+		for _, component := range t.Components {
+			size += component.Type.headSize()
+		}
+		return size
+	}
+
+	return 32
+}
@@ -0,0 +1,69 @@
+package asimovrpc
+
+import (
+	"fmt"
+	"path"
+)
+
+// MethodNotAllowedError - method rejected by WithAllowedMethods or WithDeniedMethods
+type MethodNotAllowedError struct {
+	Method string
+}
+
+func (err MethodNotAllowedError) Error() string {
+	return fmt.Sprintf("Method %s is not allowed", err.Method)
+}
+
+// WithAllowedMethods allow only methods matching glob patterns such as "flow_get*" (see path.Match)
+func WithAllowedMethods(patterns ...string) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if !validPatterns(patterns) {
+			rpc.invalidOption("WithAllowedMethods", "malformed pattern")
+			return
+		}
+		rpc.allowedMethods = append(rpc.allowedMethods, patterns...)
+	}
+}
+
+// WithDeniedMethods reject methods matching glob patterns such as "personal_*", denials take precedence over WithAllowedMethods
+func WithDeniedMethods(patterns ...string) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if !validPatterns(patterns) {
+			rpc.invalidOption("WithDeniedMethods", "malformed pattern")
+			return
+		}
+		rpc.deniedMethods = append(rpc.deniedMethods, patterns...)
+	}
+}
+
+func validPatterns(patterns []string) bool {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchAny(patterns []string, method string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, method); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allowed checks method against read-only mode and method lists before it is sent
+func (rpc *AsimovRPC) allowed(method string) error {
+	if rpc.readOnly && StateChanging(method) {
+		return ReadOnlyError{Method: method}
+	}
+	if matchAny(rpc.deniedMethods, method) || len(rpc.allowedMethods) > 0 && !matchAny(rpc.allowedMethods, method) {
+		return MethodNotAllowedError{Method: method}
+	}
+
+	return nil
+}
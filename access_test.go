@@ -0,0 +1,38 @@
+package asimovrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodLists(t *testing.T) {
+	rpc := New("http://a:8545", WithAllowedMethods("flow_get*", "net_*"), WithDeniedMethods("flow_getWork"))
+
+	require.Nil(t, rpc.allowed("flow_getBalance"))
+	require.Nil(t, rpc.allowed("net_version"))
+	require.Equal(t, MethodNotAllowedError{Method: "flow_getWork"}, rpc.allowed("flow_getWork"))
+	require.Equal(t, MethodNotAllowedError{Method: "flow_call"}, rpc.allowed("flow_call"))
+	require.Equal(t, "Method flow_call is not allowed", rpc.allowed("flow_call").Error())
+
+	rpc = New("http://a:8545", WithDeniedMethods("personal_*", "admin_*"), WithReadOnly(true))
+	require.Nil(t, rpc.allowed("flow_call"))
+	require.Equal(t, MethodNotAllowedError{Method: "admin_peers"}, rpc.allowed("admin_peers"))
+	require.Equal(t, ReadOnlyError{Method: "flow_sendTransaction"}, rpc.allowed("flow_sendTransaction"))
+
+	_, err := NewClient("http://a:8545", WithAllowedMethods("flow_["))
+	require.EqualError(t, err, "asimovrpc: invalid option WithAllowedMethods: malformed pattern")
+}
+
+func (s *AsimovRPCTestSuite) TestDeniedMethods() {
+	rpc := New(s.rpc.url, WithDeniedMethods("flow_send*"))
+
+	calls := 0
+	s.registerResponse(`"0x1"`, func([]byte) { calls++ })
+
+	_, err := rpc.AsimovSendRawTransaction("0x00")
+	s.Require().Equal(MethodNotAllowedError{Method: "flow_sendRawTransaction"}, err)
+	_, err = rpc.AsimovBlockNumber()
+	s.Require().Nil(err)
+	s.Require().Equal(1, calls)
+}
@@ -0,0 +1,96 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"math/big"
+)
+
+// Node and peer information of the admin namespace, for node health dashboards.
+// The node must expose admin over RPC; WithReadOnly rejects AsimovAddPeer.
+
+// NodeInfo - information about the node returned by admin_nodeInfo
+type NodeInfo struct {
+	ID         string                  `json:"id"`
+	Name       string                  `json:"name"`
+	Enode      string                  `json:"enode"`
+	ENR        string                  `json:"enr"`
+	IP         string                  `json:"ip"`
+	Ports      NodePorts               `json:"ports"`
+	ListenAddr string                  `json:"listenAddr"`
+	Protocols  map[string]ProtocolInfo `json:"protocols"`
+}
+
+// NodePorts - ports the node listens on
+type NodePorts struct {
+	Discovery int `json:"discovery"`
+	Listener  int `json:"listener"`
+}
+
+// PeerInfo - connected peer returned by admin_peers
+type PeerInfo struct {
+	ID        string                  `json:"id"`
+	Name      string                  `json:"name"`
+	Enode     string                  `json:"enode"`
+	ENR       string                  `json:"enr"`
+	Caps      []string                `json:"caps"`
+	Network   PeerNetwork             `json:"network"`
+	Protocols map[string]ProtocolInfo `json:"protocols"`
+}
+
+// PeerNetwork - connection to a peer
+type PeerNetwork struct {
+	LocalAddress  string `json:"localAddress"`
+	RemoteAddress string `json:"remoteAddress"`
+	Inbound       bool   `json:"inbound"`
+	Trusted       bool   `json:"trusted"`
+	Static        bool   `json:"static"`
+}
+
+// ProtocolInfo - state of a subprotocol (e.g. "flow") of the node or of a peer. Nodes report a peer
+// protocol still in its handshake as a plain string, it is kept in State with the other fields empty.
+type ProtocolInfo struct {
+	State      string          `json:"-"`
+	Version    int             `json:"version,omitempty"`
+	Network    int             `json:"network,omitempty"`
+	Difficulty *big.Int        `json:"difficulty,omitempty"`
+	Genesis    string          `json:"genesis,omitempty"`
+	Head       string          `json:"head,omitempty"`
+	Config     json.RawMessage `json:"config,omitempty"`
+}
+
+type proxyProtocolInfo ProtocolInfo
+
+// UnmarshalJSON implements json.Unmarshaler
+func (p *ProtocolInfo) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*p = ProtocolInfo{}
+		return json.Unmarshal(data, &p.State)
+	}
+
+	return json.Unmarshal(data, (*proxyProtocolInfo)(p))
+}
+
+// AsimovNodeInfo returns information about the node: identity, listening ports and protocols.
+func (rpc *AsimovRPC) AsimovNodeInfo() (*NodeInfo, error) {
+	info := new(NodeInfo)
+
+	err := rpc.call("admin_nodeInfo", info)
+	return info, err
+}
+
+// AsimovPeers returns peers connected to the node.
+func (rpc *AsimovRPC) AsimovPeers() ([]PeerInfo, error) {
+	peers := []PeerInfo{}
+
+	err := rpc.call("admin_peers", &peers)
+	return peers, err
+}
+
+// AsimovAddPeer asks the node to connect to and keep a connection with the peer at enode URL.
+// True means the peer was added to the node's list, not that it is connected yet.
+func (rpc *AsimovRPC) AsimovAddPeer(enode string) (bool, error) {
+	var added bool
+
+	err := rpc.call("admin_addPeer", &added, enode)
+	return added, err
+}
@@ -0,0 +1,72 @@
+package asimovrpc
+
+func (s *AsimovRPCTestSuite) TestAsimovNodeInfo() {
+	result := `{
+		"id": "44826a5d6a55f88a18298bca4773fca5749cdc3a5c9f308aa7d810e9b31123f3",
+		"name": "Asimov/v0.3.0/linux-amd64/go1.18",
+		"enode": "enode://44826a5d@10.0.0.1:8777",
+		"ip": "10.0.0.1",
+		"ports": {"discovery": 8777, "listener": 8777},
+		"listenAddr": "[::]:8777",
+		"protocols": {"flow": {"network": 1, "difficulty": 131072, "genesis": "0xd4e5", "head": "0xa1b2", "config": {"chainId": 1}}}
+	}`
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "admin_nodeInfo")
+		s.paramsEqual(body, `null`)
+	})
+
+	info, err := s.rpc.AsimovNodeInfo()
+	s.Require().Nil(err)
+	s.Require().Equal("Asimov/v0.3.0/linux-amd64/go1.18", info.Name)
+	s.Require().Equal(8777, info.Ports.Listener)
+	s.Require().Equal(1, info.Protocols["flow"].Network)
+	s.Require().Equal("131072", info.Protocols["flow"].Difficulty.String())
+	s.Require().Equal("0xa1b2", info.Protocols["flow"].Head)
+	s.Require().JSONEq(`{"chainId": 1}`, string(info.Protocols["flow"].Config))
+}
+
+func (s *AsimovRPCTestSuite) TestAsimovPeers() {
+	result := `[{
+		"id": "9e1c",
+		"name": "Asimov/v0.3.0",
+		"enode": "enode://9e1c@10.0.0.2:8777",
+		"caps": ["flow/63"],
+		"network": {"localAddress": "10.0.0.1:52210", "remoteAddress": "10.0.0.2:8777", "inbound": false, "trusted": false, "static": true},
+		"protocols": {"flow": {"version": 63, "difficulty": 131072, "head": "0xa1b2"}}
+	}, {
+		"id": "5f3a",
+		"name": "Asimov/v0.2.9",
+		"caps": ["flow/63"],
+		"network": {"remoteAddress": "10.0.0.3:40112", "inbound": true},
+		"protocols": {"flow": "handshake"}
+	}]`
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "admin_peers")
+	})
+
+	peers, err := s.rpc.Admin().Peers()
+	s.Require().Nil(err)
+	s.Require().Len(peers, 2)
+	s.Require().Equal([]string{"flow/63"}, peers[0].Caps)
+	s.Require().True(peers[0].Network.Static)
+	s.Require().Equal(63, peers[0].Protocols["flow"].Version)
+	s.Require().Equal("", peers[0].Protocols["flow"].State)
+	s.Require().True(peers[1].Network.Inbound)
+	s.Require().Equal("handshake", peers[1].Protocols["flow"].State)
+	s.Require().Nil(peers[1].Protocols["flow"].Difficulty)
+}
+
+func (s *AsimovRPCTestSuite) TestAsimovAddPeer() {
+	enode := "enode://9e1c@10.0.0.2:8777"
+	s.registerResponse(`true`, func(body []byte) {
+		s.methodEqual(body, "admin_addPeer")
+		s.paramsEqual(body, `["`+enode+`"]`)
+	})
+
+	added, err := s.rpc.AsimovAddPeer(enode)
+	s.Require().Nil(err)
+	s.Require().True(added)
+
+	_, err = New(s.rpc.url, WithReadOnly(true)).Admin().AddPeer(enode)
+	s.Require().Equal(ReadOnlyError{Method: "admin_addPeer"}, err)
+}
@@ -0,0 +1,129 @@
+// Package annotations maps addresses to human-readable labels and categories
+// used when rendering reports, traces and transactions.
+package annotations
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Annotation - label attached to an address
+type Annotation struct {
+	Address  string `json:"address"`
+	Label    string `json:"label"`
+	Category string `json:"category,omitempty"`
+}
+
+// Store - pluggable annotation storage, addresses are matched case-insensitively
+type Store interface {
+	Lookup(address string) (Annotation, bool, error)
+	Set(annotation Annotation) error
+	Delete(address string) error
+	All() ([]Annotation, error)
+}
+
+// MemoryStore - in-memory Store safe for concurrent use
+type MemoryStore struct {
+	mu          sync.RWMutex
+	annotations map[string]Annotation
+}
+
+// NewMemoryStore creates store containing given annotations
+func NewMemoryStore(annotations ...Annotation) *MemoryStore {
+	store := &MemoryStore{annotations: map[string]Annotation{}}
+	for _, annotation := range annotations {
+		store.Set(annotation)
+	}
+
+	return store
+}
+
+// Lookup returns annotation of address
+func (s *MemoryStore) Lookup(address string) (Annotation, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	annotation, ok := s.annotations[key(address)]
+	return annotation, ok, nil
+}
+
+// Set adds or replaces annotation
+func (s *MemoryStore) Set(annotation Annotation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.annotations[key(annotation.Address)] = annotation
+	return nil
+}
+
+// Delete removes annotation of address
+func (s *MemoryStore) Delete(address string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.annotations, key(address))
+	return nil
+}
+
+// All returns annotations sorted by address
+func (s *MemoryStore) All() ([]Annotation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]Annotation, 0, len(s.annotations))
+	for _, annotation := range s.annotations {
+		result = append(result, annotation)
+	}
+	sort.Slice(result, func(i, j int) bool { return key(result[i].Address) < key(result[j].Address) })
+
+	return result, nil
+}
+
+// Label returns label of address, or the address itself if it is not annotated
+func Label(store Store, address string) string {
+	if store == nil {
+		return address
+	}
+
+	annotation, ok, err := store.Lookup(address)
+	if err != nil || !ok || annotation.Label == "" {
+		return address
+	}
+
+	return annotation.Label
+}
+
+// Import reads JSON array of annotations into store
+func Import(store Store, r io.Reader) error {
+	var annotations []Annotation
+	if err := json.NewDecoder(r).Decode(&annotations); err != nil {
+		return err
+	}
+
+	for _, annotation := range annotations {
+		if err := store.Set(annotation); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Export writes all annotations of store as JSON array
+func Export(store Store, w io.Writer) error {
+	annotations, err := store.All()
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(annotations)
+}
+
+func key(address string) string {
+	return strings.ToLower(address)
+}
@@ -0,0 +1,54 @@
+package annotations
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore(Annotation{Address: "0xAAA", Label: "Exchange", Category: "cex"})
+
+	annotation, ok, err := store.Lookup("0xaaa")
+	require.Nil(t, err)
+	require.True(t, ok)
+	require.Equal(t, "Exchange", annotation.Label)
+
+	require.Equal(t, "Exchange", Label(store, "0xAaA"))
+	require.Equal(t, "0xbbb", Label(store, "0xbbb"))
+	require.Equal(t, "0xbbb", Label(nil, "0xbbb"))
+
+	require.Nil(t, store.Delete("0xAAA"))
+	_, ok, err = store.Lookup("0xaaa")
+	require.Nil(t, err)
+	require.False(t, ok)
+}
+
+func TestImportExport(t *testing.T) {
+	store := NewMemoryStore()
+	err := Import(store, strings.NewReader(`[
+		{"address": "0xbbb", "label": "Router", "category": "dex"},
+		{"address": "0xaaa", "label": "Treasury"}
+	]`))
+	require.Nil(t, err)
+
+	all, err := store.All()
+	require.Nil(t, err)
+	require.Equal(t, []Annotation{
+		{Address: "0xaaa", Label: "Treasury"},
+		{Address: "0xbbb", Label: "Router", Category: "dex"},
+	}, all)
+
+	buffer := new(bytes.Buffer)
+	require.Nil(t, Export(store, buffer))
+
+	copied := NewMemoryStore()
+	require.Nil(t, Import(copied, buffer))
+	copiedAll, err := copied.All()
+	require.Nil(t, err)
+	require.Equal(t, all, copiedAll)
+
+	require.NotNil(t, Import(store, strings.NewReader(`{`)))
+}
@@ -2,25 +2,41 @@ package asimovrpc
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"math/big"
 	"net/http"
 	"os"
+	"sync"
+	"time"
 )
 
 // AsimovError - ethereum error
 type AsimovError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"` // additional information, such as revert data
 }
 
 func (err AsimovError) Error() string {
 	return fmt.Sprintf("Error %d (%s)", err.Code, err.Message)
 }
 
+// ResponseIDError - response ID does not match the request, for example a cached or shuffled response of a proxy
+type ResponseIDError struct {
+	Method   string
+	Expected int
+	Actual   int
+}
+
+func (err ResponseIDError) Error() string {
+	return fmt.Sprintf("Response ID %d does not match request ID %d of %s", err.Actual, err.Expected, err.Method)
+}
+
 type asimovResponse struct {
 	ID      int             `json:"id"`
 	JSONRPC string          `json:"jsonrpc"`
@@ -37,22 +53,90 @@ type asimovRequest struct {
 
 // AsimovRPC - Ethereum rpc client
 type AsimovRPC struct {
-	url    string
+	mu     *sync.RWMutex
 	client httpClient
 	log    logger
-	Debug  bool
-}
 
-// New create new rpc client with given url
+	*settings       // guarded by mu, shared with copies
+	stats           *stats
+	userAgent       string
+	diagnostics     *diagnostics
+	network         *Network
+	chainConfig     ChainConfig
+	priority        Priority
+	signers         map[string]RequestSigner
+	verifiers       map[string]ResponseVerifier
+	pollInterval    time.Duration
+	clock           Clock
+	ctx             context.Context
+	rand            *lockedRand
+	ws              *wsTransport
+	batchSize       int
+	tags            Tags
+	readOnly        bool
+	allowedMethods  []string
+	deniedMethods   []string
+	failover        *failover
+	resolver        *roundRobinResolver
+	extensions      *extensions
+	interceptors    []CallInterceptor
+	batchObservers  []BatchObserver
+	retries         int
+	schema          *schema
+	rawParams       bool
+	logsChunkSize   int
+	rewardPolicy    RewardPolicy
+	chainID         *chainIDCache
+	headers         http.Header
+	maxResponseSize int64
+	requestID       *int64
+	screener        Screener
+	auditLog        AuditLog
+	metadataStore   MetadataStore
+	structured      structuredLogger
+	feeEstimator    *feeEstimator
+	methods         *methodSupport
+	transport       string
+	optionErrors    []error
+}
+
+// New create new rpc client with given url.
+// It panics if options are invalid or conflict with each other, use NewClient to get an error instead.
 func New(url string, options ...func(rpc *AsimovRPC)) *AsimovRPC {
+	rpc := newClient(url, options...)
+	if err := rpc.validateOptions(); err != nil {
+		panic(err.Error())
+	}
+	if rpc.chainID != nil {
+		rpc.chainID.prefetch(rpc)
+	}
+
+	return rpc
+}
+
+func newClient(url string, options ...func(rpc *AsimovRPC)) *AsimovRPC {
 	rpc := &AsimovRPC{
-		url:    url,
+		mu:     new(sync.RWMutex),
 		client: http.DefaultClient,
 		log:    log.New(os.Stderr, "", log.LstdFlags),
+
+		settings:     &settings{url: url, debugPayloadLimit: DefaultDebugPayloadLimit},
+		stats:        newStats(DefaultLatencyBuckets),
+		userAgent:    DefaultUserAgent(),
+		pollInterval: DefaultPollInterval,
+		clock:        SystemClock,
+		rand:         defaultRand,
+		ws:           newWSTransport(),
+		batchSize:    DefaultBatchSize,
+		requestID:    new(int64),
+		extensions:   &extensions{constructors: map[string]ExtensionConstructor{}},
+		feeEstimator: &feeEstimator{blocks: DefaultFeeBlocks, ttl: DefaultFeeTTL},
+		methods:      &methodSupport{},
 	}
 	for _, option := range options {
 		option(rpc)
 	}
+	rpc.useClock()
 
 	return rpc
 }
@@ -75,44 +159,138 @@ func (rpc *AsimovRPC) call(method string, target interface{}, params ...interfac
 	return json.Unmarshal(result, target)
 }
 
+// Agent returns a copy of the client sending app appended to the default User-Agent header,
+// in place of the one set with WithUserAgent.
+// The copy shares transport, statistics and all other settings with the original client.
+func (rpc *AsimovRPC) Agent(app string) *AsimovRPC {
+	client := *rpc
+	client.userAgent = DefaultUserAgent() + " " + app
+
+	return &client
+}
+
 // URL returns client url
 func (rpc *AsimovRPC) URL() string {
+	rpc.mu.RLock()
+	defer rpc.mu.RUnlock()
+
 	return rpc.url
 }
 
-// Call returns raw response of method call
+// WithContext returns a copy of the client whose calls use ctx for deadlines and cancellation.
+// The copy shares transport, statistics and all other settings with the original client.
+func (rpc *AsimovRPC) WithContext(ctx context.Context) *AsimovRPC {
+	client := *rpc
+	client.ctx = ctx
+
+	return &client
+}
+
+func (rpc *AsimovRPC) context() context.Context {
+	if rpc.ctx == nil {
+		return context.Background()
+	}
+
+	return rpc.ctx
+}
+
+// Call returns raw response of method call, params may include CallOption values
 func (rpc *AsimovRPC) Call(method string, params ...interface{}) (json.RawMessage, error) {
-	request := asimovRequest{
-		ID:      1,
-		JSONRPC: "2.0",
-		Method:  method,
-		Params:  params,
+	return rpc.CallContext(rpc.context(), method, params...)
+}
+
+// CallContext returns raw response of method call, ctx bounds the whole call including budget waits.
+// Params may include CallOption values.
+func (rpc *AsimovRPC) CallContext(ctx context.Context, method string, params ...interface{}) (json.RawMessage, error) {
+	ctx, params = withCallOptions(ctx, params)
+	return rpc.chain()(rpc.tagged(ctx), method, params)
+}
+
+// CallAs returns result of method call decoded into T, for methods without a typed wrapper:
+//
+//	accounts, err := asimovrpc.CallAs[[]string](client, "flow_accounts")
+func CallAs[T any](rpc Client, method string, params ...interface{}) (T, error) {
+	var result, zero T
+	raw, err := rpc.Call(method, params...)
+	if err != nil {
+		return zero, err
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return zero, err
 	}
 
-	body, err := json.Marshal(request)
+	return result, nil
+}
+
+// CallAsContext returns result of method call decoded into T, ctx bounds the whole call
+func CallAsContext[T any](ctx context.Context, rpc Client, method string, params ...interface{}) (T, error) {
+	var result, zero T
+	raw, err := rpc.CallContext(ctx, method, params...)
 	if err != nil {
-		return nil, err
+		return zero, err
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return zero, err
 	}
 
-	response, err := rpc.client.Post(rpc.url, "application/json", bytes.NewBuffer(body))
-	if response != nil {
-		defer response.Body.Close()
+	return result, nil
+}
+
+// invoke is the innermost CallFunc of the interceptor chain
+func (rpc *AsimovRPC) invoke(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+	if err := rpc.allowed(method); err != nil {
+		return nil, err
 	}
-	if err != nil {
+	if err := rpc.screenCall(ctx, method, params); err != nil {
 		return nil, err
 	}
-
-	data, err := ioutil.ReadAll(response.Body)
+	if err := rpc.capGas(method, params); err != nil {
+		return nil, err
+	}
+	if err := rpc.checkMetadataStore(ctx, method); err != nil {
+		return nil, err
+	}
+	params, err := rpc.encodeParams(params)
 	if err != nil {
 		return nil, err
 	}
+	if err := rpc.validate(ctx, method, params); err != nil {
+		return nil, err
+	}
+	if err := rpc.spend(ctx, method); err != nil {
+		return nil, err
+	}
+
+	start := rpc.clock.Now()
+	result, err := rpc.post(ctx, method, params)
+	rpc.observe(ctx, method, params, rpc.clock.Now().Sub(start), err)
+	if err == nil {
+		err = rpc.storeMetadata(ctx, method, result)
+	}
+
+	return result, err
+}
 
-	if rpc.Debug {
-		rpc.log.Println(fmt.Sprintf("%s\nRequest: %s\nResponse: %s\n", method, body, data))
+func (rpc *AsimovRPC) post(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+	request := rpc.newRequest(ctx, method, params)
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
 	}
 
-	resp := new(asimovResponse)
-	if err := json.Unmarshal(data, resp); err != nil {
+	var resp *asimovResponse
+	err = rpc.send(ctx, method, StateChanging(method), request.ID, body, func(r io.Reader) error {
+		resp = new(asimovResponse)
+		if err := json.NewDecoder(r).Decode(resp); err != nil {
+			return err
+		}
+		// errors of requests the node could not parse have null ID
+		if resp.ID != request.ID && !(resp.ID == 0 && resp.Error != nil) {
+			return ResponseIDError{Method: method, Expected: request.ID, Actual: resp.ID}
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -121,7 +299,123 @@ func (rpc *AsimovRPC) Call(method string, params ...interface{}) (json.RawMessag
 	}
 
 	return resp.Result, nil
+}
+
+// send posts request body to the next endpoint and decodes response body with decode.
+// Failover clients try endpoints in order while they fail with connection errors or 5xx responses;
+// state-changing requests (changing) only move on if the request could not have reached the node.
+func (rpc *AsimovRPC) send(ctx context.Context, method string, changing bool, id int, body []byte, decode func(r io.Reader) error) error {
+	if rpc.failover == nil {
+		return rpc.sendTo(ctx, rpc.endpoint(), method, id, body, decode)
+	}
+
+	var err error
+	for _, url := range rpc.failover.order() {
+		err = rpc.sendTo(ctx, url, method, id, body, decode)
+		if err == nil {
+			rpc.failover.success(url)
+			return nil
+		}
+		if ctx.Err() != nil || !endpointFailure(err) {
+			return err
+		}
+		rpc.failover.failure(url, err)
+		if changing && !connectionError(err) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// sendTo posts request body to url and decodes response body with decode while it is read,
+// unless a verifier or debug logging needs the whole body
+func (rpc *AsimovRPC) sendTo(ctx context.Context, url string, method string, id int, body []byte, decode func(r io.Reader) error) error {
+	rpc.mu.RLock()
+	debug, timeout := rpc.debugEnabled, rpc.timeout
+	rpc.mu.RUnlock()
+	if options := callOptionsFromContext(ctx); options.timeout > 0 {
+		timeout = options.timeout
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", rpc.userAgent)
+	rpc.applyHeaders(ctx, req.Header)
+	tags := TagsFromContext(ctx)
+	if id := tags[TagRequestID]; id != "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+	if signer := rpc.signer(url); signer != nil {
+		if err := signer.Sign(req, body); err != nil {
+			return err
+		}
+	}
+	if rpc.diagnostics != nil {
+		req = rpc.diagnostics.trace(req)
+	}
+
+	start := rpc.clock.Now()
+	client := rpc.client
+	if isWebSocket(url) {
+		client = rpc.ws.client(rpc.maxResponseSize)
+	}
+	response, err := client.Do(req)
+	if rpc.diagnostics != nil {
+		rpc.diagnostics.response(url, response)
+	}
+	if response != nil {
+		defer response.Body.Close()
+	}
+	if err != nil {
+		return err
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		httpErr := newHTTPError(response, rpc.clock.Now())
+		if rpc.failover != nil && response.StatusCode >= http.StatusInternalServerError {
+			return EndpointStatusError{Endpoint: url, Status: response.Status, Err: httpErr}
+		}
+		return httpErr
+	}
 
+	reader := &responseBody{r: response.Body, limit: rpc.maxResponseSize}
+	defer func() {
+		for _, s := range rpc.stats.tagged(tags) {
+			s.payload(method, len(body), int(reader.n))
+		}
+	}()
+
+	verifier := rpc.verifier(url)
+	if verifier == nil && !debug {
+		if err := decode(reader); err != nil {
+			return err
+		}
+		_, err := io.Copy(ioutil.Discard, reader) // count the rest and keep the connection reusable
+		return err
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	if verifier != nil {
+		if err := verifier.Verify(response, data); err != nil {
+			return err
+		}
+	}
+	if debug {
+		rpc.debug(method, id, tags, rpc.clock.Now().Sub(start), body, data)
+	}
+
+	return decode(bytes.NewReader(data))
 }
 
 // RawCall returns raw response of method call (Deprecated)
@@ -230,6 +524,37 @@ func (rpc *AsimovRPC) AsimovGasPrice() (big.Int, error) {
 	return ParseBigInt(response)
 }
 
+// AsimovMaxPriorityFeePerGas returns the priority fee per gas in wei suggested for inclusion in the next blocks.
+func (rpc *AsimovRPC) AsimovMaxPriorityFeePerGas() (big.Int, error) {
+	var response string
+	if err := rpc.call("flow_maxPriorityFeePerGas", &response); err != nil {
+		return big.Int{}, err
+	}
+
+	return ParseBigInt(response)
+}
+
+// AsimovFeeHistory returns fee history of blockCount blocks ending with newestBlock (number or tag),
+// with priority fees paid in each block at rewardPercentiles, increasing values between 0 and 100.
+func (rpc *AsimovRPC) AsimovFeeHistory(blockCount int, newestBlock BlockNumber, rewardPercentiles []float64) (*FeeHistory, error) {
+	if rewardPercentiles == nil {
+		rewardPercentiles = []float64{}
+	}
+
+	var history *FeeHistory
+	err := rpc.call("flow_feeHistory", &history, IntToHex(blockCount), newestBlock, rewardPercentiles)
+	return history, err
+}
+
+// AsimovChainID returns the chain ID used for replay-protected signing, cached when WithChainIDCache is enabled.
+func (rpc *AsimovRPC) AsimovChainID() (*big.Int, error) {
+	if rpc.chainID != nil {
+		return rpc.chainID.get(rpc)
+	}
+
+	return rpc.fetchChainID()
+}
+
 // EthAccounts returns a list of addresses owned by client.
 func (rpc *AsimovRPC) AsimovAccounts() ([]string, error) {
 	accounts := []string{}
@@ -249,7 +574,7 @@ func (rpc *AsimovRPC) AsimovBlockNumber() (int, error) {
 }
 
 // EthGetBalance returns the balance of the account of given address in wei.
-func (rpc *AsimovRPC) AsimovGetBalance(address, block string) (big.Int, error) {
+func (rpc *AsimovRPC) AsimovGetBalance(address string, block BlockNumber) (big.Int, error) {
 	var response string
 	if err := rpc.call("flow_getBalance", &response, address, block); err != nil {
 		return big.Int{}, err
@@ -259,15 +584,15 @@ func (rpc *AsimovRPC) AsimovGetBalance(address, block string) (big.Int, error) {
 }
 
 // EthGetStorageAt returns the value from a storage position at a given address.
-func (rpc *AsimovRPC) AsimovGetStorageAt(data string, position int, tag string) (string, error) {
+func (rpc *AsimovRPC) AsimovGetStorageAt(data string, position int, block BlockNumber) (string, error) {
 	var result string
 
-	err := rpc.call("flow_getStorageAt", &result, data, IntToHex(position), tag)
+	err := rpc.call("flow_getStorageAt", &result, data, IntToHex(position), block)
 	return result, err
 }
 
 // EthGetTransactionCount returns the number of transactions sent from an address.
-func (rpc *AsimovRPC) AsimovGetTransactionCount(address, block string) (int, error) {
+func (rpc *AsimovRPC) AsimovGetTransactionCount(address string, block BlockNumber) (int, error) {
 	var response string
 
 	if err := rpc.call("flow_getTransactionCount", &response, address, block); err != nil {
@@ -289,10 +614,10 @@ func (rpc *AsimovRPC) AsimovGetBlockTransactionCountByHash(hash string) (int, er
 }
 
 // EthGetBlockTransactionCountByNumber returns the number of transactions in a block from a block matching the given block
-func (rpc *AsimovRPC) AsimovGetBlockTransactionCountByNumber(number int) (int, error) {
+func (rpc *AsimovRPC) AsimovGetBlockTransactionCountByNumber(number BlockNumber) (int, error) {
 	var response string
 
-	if err := rpc.call("flow_getBlockTransactionCountByNumber", &response, IntToHex(number)); err != nil {
+	if err := rpc.call("flow_getBlockTransactionCountByNumber", &response, number); err != nil {
 		return 0, err
 	}
 
@@ -311,18 +636,30 @@ func (rpc *AsimovRPC) AsimovGetUncleCountByBlockHash(hash string) (int, error) {
 }
 
 // EthGetUncleCountByBlockNumber returns the number of uncles in a block from a block matching the given block number.
-func (rpc *AsimovRPC) AsimovGetUncleCountByBlockNumber(number int) (int, error) {
+func (rpc *AsimovRPC) AsimovGetUncleCountByBlockNumber(number BlockNumber) (int, error) {
 	var response string
 
-	if err := rpc.call("flow_getUncleCountByBlockNumber", &response, IntToHex(number)); err != nil {
+	if err := rpc.call("flow_getUncleCountByBlockNumber", &response, number); err != nil {
 		return 0, err
 	}
 
 	return ParseInt(response)
 }
 
+// AsimovGetUncleByBlockHashAndIndex returns header of the uncle at index of the block with given hash, nil if there is none.
+// Uncles are returned without transactions.
+func (rpc *AsimovRPC) AsimovGetUncleByBlockHashAndIndex(hash string, index int) (*Block, error) {
+	return rpc.getBlock("flow_getUncleByBlockHashAndIndex", false, hash, IntToHex(index))
+}
+
+// AsimovGetUncleByBlockNumberAndIndex returns header of the uncle at index of the block with given number, nil if there is none.
+// Uncles are returned without transactions.
+func (rpc *AsimovRPC) AsimovGetUncleByBlockNumberAndIndex(number BlockNumber, index int) (*Block, error) {
+	return rpc.getBlock("flow_getUncleByBlockNumberAndIndex", false, number, IntToHex(index))
+}
+
 // EthGetCode returns code at a given address.
-func (rpc *AsimovRPC) AsimovGetCode(address, block string) (string, error) {
+func (rpc *AsimovRPC) AsimovGetCode(address string, block BlockNumber) (string, error) {
 	var code string
 
 	err := rpc.call("flow_getCode", &code, address, block)
@@ -343,7 +680,7 @@ func (rpc *AsimovRPC) AsimovSendTransaction(transaction T) (string, error) {
 	var hash string
 
 	err := rpc.call("flow_sendTransaction", &hash, transaction)
-	return hash, err
+	return sentHash(hash, err)
 }
 
 // EthSendRawTransaction creates new message call transaction or a contract creation for signed transactions.
@@ -351,14 +688,33 @@ func (rpc *AsimovRPC) AsimovSendRawTransaction(data string) (string, error) {
 	var hash string
 
 	err := rpc.call("flow_sendRawTransaction", &hash, data)
-	return hash, err
+	return sentHash(hash, err)
+}
+
+// AsimovSignTransaction signs a transaction with a node-managed account without sending it.
+// The returned raw transaction can be broadcast later with AsimovSendRawTransaction.
+func (rpc *AsimovRPC) AsimovSignTransaction(transaction T) (*SignedTransaction, error) {
+	signed := new(SignedTransaction)
+
+	err := rpc.call("flow_signTransaction", signed, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return signed, nil
 }
 
 // EthCall executes a new message call immediately without creating a transaction on the block chain.
-func (rpc *AsimovRPC) AsimovCall(transaction T, tag string) (string, error) {
+// Optional overrides change account state for the call only; several are merged, later ones win.
+func (rpc *AsimovRPC) AsimovCall(transaction T, block BlockNumber, overrides ...StateOverride) (string, error) {
 	var data string
 
-	err := rpc.call("flow_call", &data, transaction, tag)
+	params := []interface{}{transaction, block}
+	if len(overrides) > 0 {
+		params = append(params, mergeOverrides(overrides))
+	}
+
+	err := rpc.call("flow_call", &data, params...)
 	return data, err
 }
 
@@ -396,6 +752,11 @@ func (rpc *AsimovRPC) getBlock(method string, withTransactions bool, params ...i
 	}
 
 	block := response.toBlock()
+	rules := rpc.Rules(block.Number)
+	for i := range block.Transactions {
+		rules.applyTransaction(&block.Transactions[i])
+	}
+
 	return &block, nil
 }
 
@@ -405,14 +766,18 @@ func (rpc *AsimovRPC) AsimovGetBlockByHash(hash string, withTransactions bool) (
 }
 
 // EthGetBlockByNumber returns information about a block by block number.
-func (rpc *AsimovRPC) AsimovGetBlockByNumber(number int, withTransactions bool) (*Block, error) {
-	return rpc.getBlock("flow_getBlockByNumber", withTransactions, IntToHex(number), withTransactions)
+func (rpc *AsimovRPC) AsimovGetBlockByNumber(number BlockNumber, withTransactions bool) (*Block, error) {
+	return rpc.getBlock("flow_getBlockByNumber", withTransactions, number, withTransactions)
 }
 
 func (rpc *AsimovRPC) getTransaction(method string, params ...interface{}) (*Transaction, error) {
 	transaction := new(Transaction)
 
 	err := rpc.call(method, transaction, params...)
+	if err == nil && transaction.BlockNumber != nil {
+		rpc.Rules(*transaction.BlockNumber).applyTransaction(transaction)
+	}
+
 	return transaction, err
 }
 
@@ -427,8 +792,29 @@ func (rpc *AsimovRPC) AsimovGetTransactionByBlockHashAndIndex(blockHash string,
 }
 
 // EthGetTransactionByBlockNumberAndIndex returns information about a transaction by block number and transaction index position.
-func (rpc *AsimovRPC) AsimovGetTransactionByBlockNumberAndIndex(blockNumber, transactionIndex int) (*Transaction, error) {
-	return rpc.getTransaction("flow_getTransactionByBlockNumberAndIndex", IntToHex(blockNumber), IntToHex(transactionIndex))
+func (rpc *AsimovRPC) AsimovGetTransactionByBlockNumberAndIndex(blockNumber BlockNumber, transactionIndex int) (*Transaction, error) {
+	return rpc.getTransaction("flow_getTransactionByBlockNumberAndIndex", blockNumber, IntToHex(transactionIndex))
+}
+
+// AsimovPendingTransactions returns full transaction objects from the node's pending pool.
+// Transactions are kept only if they match all given filters, which are applied client-side.
+func (rpc *AsimovRPC) AsimovPendingTransactions(filters ...TransactionFilter) ([]Transaction, error) {
+	transactions := []Transaction{}
+	if err := rpc.call("flow_pendingTransactions", &transactions); err != nil {
+		return nil, err
+	}
+	if len(filters) == 0 {
+		return transactions, nil
+	}
+
+	filtered := []Transaction{}
+	for _, transaction := range transactions {
+		if matchTransaction(transaction, filters) {
+			filtered = append(filtered, transaction)
+		}
+	}
+
+	return filtered, nil
 }
 
 // EthGetTransactionReceipt returns the receipt of a transaction by transaction hash.
@@ -440,6 +826,9 @@ func (rpc *AsimovRPC) AsimovGetTransactionReceipt(hash string) (*TransactionRece
 	if err != nil {
 		return nil, err
 	}
+	if transactionReceipt.BlockHash != "" {
+		rpc.Rules(transactionReceipt.BlockNumber).applyReceipt(transactionReceipt)
+	}
 
 	return transactionReceipt, nil
 }
@@ -1,13 +1,19 @@
 package asimovrpc
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/big"
 	"net/http"
+	"net/http/httptest"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/require"
@@ -22,10 +28,30 @@ type AsimovRPCTestSuite struct {
 
 func (s *AsimovRPCTestSuite) registerResponse(result string, callback func([]byte)) {
 	httpmock.Reset()
-	response := fmt.Sprintf(`{"jsonrpc":"2.0", "id":1, "result": %s}`, result)
 	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
-		callback(s.getBody(request))
-		return httpmock.NewStringResponse(200, response), nil
+		body := s.getBody(request)
+		callback(body)
+		return httpmock.NewStringResponse(200, fmt.Sprintf(`{"jsonrpc":"2.0", "id":%s, "result": %s}`, requestID(body), result)), nil
+	})
+}
+
+func (s *AsimovRPCTestSuite) registerResponses(results map[string]string, callback func([]byte)) {
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := s.getBody(request)
+		callback(body)
+		method := gjson.GetBytes(body, "method").String()
+		if result, ok := results[method]; ok {
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`{"jsonrpc":"2.0", "id":%s, "result": %s}`, requestID(body), result)), nil
+		}
+		return httpmock.NewStringResponse(200, fmt.Sprintf(`{"jsonrpc":"2.0", "id":%s, "error": {"code": -32601, "message": "method not found"}}`, requestID(body))), nil
+	})
+}
+
+func (s *AsimovRPCTestSuite) registerResponseFunc(result func() string) {
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, fmt.Sprintf(`{"jsonrpc":"2.0", "id":%s, "result": %s}`, requestID(s.getBody(request)), result())), nil
 	})
 }
 
@@ -36,10 +62,27 @@ func (s *AsimovRPCTestSuite) registerResponseError(err error) {
 	})
 }
 
+// requestID returns id of request body to echo it in the response, null for batches
+func requestID(body []byte) string {
+	if id := gjson.GetBytes(body, "id").Raw; id != "" {
+		return id
+	}
+
+	return "null"
+}
+
+// echoID replaces id 1 of response with id of request, the request body can still be read
+func echoID(request *http.Request, response string) string {
+	body, _ := ioutil.ReadAll(request.Body)
+	request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return strings.Replace(response, `"id":1`, `"id":`+requestID(body), 1)
+}
+
 func (s *AsimovRPCTestSuite) getBody(request *http.Request) []byte {
-	defer request.Body.Close()
 	body, err := ioutil.ReadAll(request.Body)
 	s.Require().Nil(err)
+	request.Body = ioutil.NopCloser(bytes.NewReader(body))
 
 	return body
 }
@@ -85,7 +128,7 @@ func (s *AsimovRPCTestSuite) TestWeb3ClientVersion() {
 		s.methodEqual(body, "web3_clientVersion")
 		s.paramsEqual(body, `null`)
 
-		return httpmock.NewStringResponse(200, response), nil
+		return httpmock.NewStringResponse(200, echoID(request, response)), nil
 	})
 
 	v, err := s.rpc.Web3ClientVersion()
@@ -123,6 +166,33 @@ func (s *AsimovRPCTestSuite) TestCall() {
 	s.Require().Equal("eee", AsimovError.Message)
 }
 
+func (s *AsimovRPCTestSuite) TestCallAs() {
+	s.registerResponse(`{"number": "0x10", "miner": "0x1"}`, func(body []byte) {
+		s.methodEqual(body, "custom_head")
+		s.paramsEqual(body, `["latest"]`)
+	})
+
+	type head struct {
+		Number string `json:"number"`
+		Miner  string `json:"miner"`
+	}
+	result, err := CallAs[head](s.rpc, "custom_head", "latest")
+	s.Require().Nil(err)
+	s.Require().Equal(head{Number: "0x10", Miner: "0x1"}, result)
+
+	pointer, err := CallAsContext[*head](context.Background(), s.rpc, "custom_head", "latest")
+	s.Require().Nil(err)
+	s.Require().Equal("0x10", pointer.Number)
+
+	number, err := CallAs[int](s.rpc, "custom_head", "latest")
+	s.Require().NotNil(err)
+	s.Require().Zero(number)
+
+	s.registerResponseError(errors.New("Error"))
+	_, err = CallAs[head](s.rpc, "custom_head")
+	s.Require().NotNil(err)
+}
+
 func (s *AsimovRPCTestSuite) Test_call() {
 	// Test http error
 	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
@@ -150,7 +220,7 @@ func (s *AsimovRPCTestSuite) TestWeb3Sha3() {
 		s.methodEqual(body, "web3_sha3")
 		s.paramsEqual(body, `["0x64617461"]`)
 
-		return httpmock.NewStringResponse(200, response), nil
+		return httpmock.NewStringResponse(200, echoID(request, response)), nil
 	})
 
 	result, err := s.rpc.Web3Sha3([]byte("data"))
@@ -166,7 +236,7 @@ func (s *AsimovRPCTestSuite) TestNetVersion() {
 		s.methodEqual(body, "net_version")
 		s.paramsEqual(body, "null")
 
-		return httpmock.NewStringResponse(200, response), nil
+		return httpmock.NewStringResponse(200, echoID(request, response)), nil
 	})
 
 	v, err := s.rpc.NetVersion()
@@ -181,7 +251,7 @@ func (s *AsimovRPCTestSuite) TestNetListening() {
 		s.methodEqual(body, "net_listening")
 		s.paramsEqual(body, "null")
 
-		return httpmock.NewStringResponse(200, response), nil
+		return httpmock.NewStringResponse(200, echoID(request, response)), nil
 	})
 
 	listening, err := s.rpc.NetListening()
@@ -195,7 +265,7 @@ func (s *AsimovRPCTestSuite) TestNetListening() {
 		s.methodEqual(body, "net_listening")
 		s.paramsEqual(body, "null")
 
-		return httpmock.NewStringResponse(200, response), nil
+		return httpmock.NewStringResponse(200, echoID(request, response)), nil
 	})
 
 	listening, err = s.rpc.NetListening()
@@ -328,6 +398,41 @@ func (s *AsimovRPCTestSuite) TestAsimovGasPrice() {
 	s.Require().Equal(*expected, gasPrice)
 }
 
+func (s *AsimovRPCTestSuite) TestAsimovMaxPriorityFeePerGas() {
+	s.registerResponse(`"0x3b9aca00"`, func(body []byte) {
+		s.methodEqual(body, "flow_maxPriorityFeePerGas")
+		s.paramsEqual(body, "null")
+	})
+
+	tip, err := s.rpc.AsimovMaxPriorityFeePerGas()
+	s.Require().Nil(err)
+	s.Require().Equal(*big.NewInt(1000000000), tip)
+}
+
+func (s *AsimovRPCTestSuite) TestAsimovFeeHistory() {
+	s.registerResponse(`{"oldestBlock": "0xa", "baseFeePerGas": ["0x10", "0x11", "0x12"], "gasUsedRatio": [0.5, 0.25], "reward": [["0x1", "0x2"], ["0x3", "0x4"]]}`, func(body []byte) {
+		s.methodEqual(body, "flow_feeHistory")
+		s.paramsEqual(body, `["0x2", "latest", [10, 90]]`)
+	})
+
+	history, err := s.rpc.AsimovFeeHistory(2, Latest(), []float64{10, 90})
+	s.Require().Nil(err)
+	s.Require().Equal(&FeeHistory{
+		OldestBlock:   10,
+		BaseFeePerGas: []big.Int{*big.NewInt(16), *big.NewInt(17), *big.NewInt(18)},
+		GasUsedRatio:  []float64{0.5, 0.25},
+		Reward:        [][]big.Int{{*big.NewInt(1), *big.NewInt(2)}, {*big.NewInt(3), *big.NewInt(4)}},
+	}, history)
+
+	s.registerResponse(`{"oldestBlock": "0xa", "baseFeePerGas": ["0x10"], "gasUsedRatio": []}`, func(body []byte) {
+		s.paramsEqual(body, `["0x0", "0xa", []]`)
+	})
+
+	history, err = s.rpc.AsimovFeeHistory(0, 10, nil)
+	s.Require().Nil(err)
+	s.Require().Nil(history.Reward)
+}
+
 func (s *AsimovRPCTestSuite) TestAsimovAccounts() {
 	s.registerResponse(`["0x407d73d8a49eeb85d32cf465507dd71d507100c1"]`, func(body []byte) {
 		s.methodEqual(body, "flow_accounts")
@@ -357,7 +462,7 @@ func (s *AsimovRPCTestSuite) TestAsimovBlockNumber() {
 func (s *AsimovRPCTestSuite) TestAsimovGetBalance() {
 	address := "0x66b31cab7d9eb10cfcdb7a3c19dcd45f362e15ba8e"
 	s.registerResponseError(errors.New("Error"))
-	balance, err := s.rpc.AsimovGetBalance(address, "latest")
+	balance, err := s.rpc.AsimovGetBalance(address, Latest())
 	s.Require().NotNil(err)
 
 	s.registerResponse(`"0x486d06b0d08d05909c4"`, func(body []byte) {
@@ -366,7 +471,7 @@ func (s *AsimovRPCTestSuite) TestAsimovGetBalance() {
 	})
 
 	expected, _ := big.NewInt(0).SetString("21376347749069564217796", 10)
-	balance, err = s.rpc.AsimovGetBalance(address, "latest")
+	balance, err = s.rpc.AsimovGetBalance(address, Latest())
 	s.Require().Nil(err)
 	s.Require().Equal(*expected, balance)
 }
@@ -374,14 +479,14 @@ func (s *AsimovRPCTestSuite) TestAsimovGetBalance() {
 func (s *AsimovRPCTestSuite) TestAsimovGetStorageAt() {
 	data := "0x295a70b2de5e3953354a6a8344e616ed314d7251"
 	position := 33
-	tag := "pending"
+	block := Pending()
 
 	s.registerResponse(`"0x00000000000000000000000000000000000000000000000000000000000004d2"`, func(body []byte) {
 		s.methodEqual(body, "flow_getStorageAt")
 		s.paramsEqual(body, fmt.Sprintf(`["%s", "0x21", "pending"]`, data))
 	})
 
-	result, err := s.rpc.AsimovGetStorageAt(data, position, tag)
+	result, err := s.rpc.AsimovGetStorageAt(data, position, block)
 	s.Require().Nil(err)
 	s.Require().Equal("0x00000000000000000000000000000000000000000000000000000000000004d2", result)
 }
@@ -389,7 +494,7 @@ func (s *AsimovRPCTestSuite) TestAsimovGetStorageAt() {
 func (s *AsimovRPCTestSuite) TestAsimovGetTransactionCount() {
 	address := "0x407d73d8a49eeb85d32cf465507dd71d507100c1"
 	s.registerResponseError(errors.New("Error"))
-	count, err := s.rpc.AsimovGetTransactionCount(address, "latest")
+	count, err := s.rpc.AsimovGetTransactionCount(address, Latest())
 	s.Require().NotNil(err)
 
 	s.registerResponse(`"0x10"`, func(body []byte) {
@@ -397,7 +502,7 @@ func (s *AsimovRPCTestSuite) TestAsimovGetTransactionCount() {
 		s.paramsEqual(body, fmt.Sprintf(`["%s", "latest"]`, address))
 	})
 
-	count, err = s.rpc.AsimovGetTransactionCount(address, "latest")
+	count, err = s.rpc.AsimovGetTransactionCount(address, Latest())
 	s.Require().Nil(err)
 	s.Require().Equal(16, count)
 }
@@ -419,7 +524,7 @@ func (s *AsimovRPCTestSuite) TestAsimovGetBlockTransactionCountByHash() {
 }
 
 func (s *AsimovRPCTestSuite) TestAsimovGetBlockTransactionCountByNumber() {
-	number := 2384732
+	number := Number(2384732)
 	s.registerResponseError(errors.New("Error"))
 	count, err := s.rpc.AsimovGetBlockTransactionCountByNumber(number)
 	s.Require().NotNil(err)
@@ -451,7 +556,7 @@ func (s *AsimovRPCTestSuite) TestAsimovGetUncleCountByBlockHash() {
 }
 
 func (s *AsimovRPCTestSuite) TestAsimovGetUncleCountByBlockNumber() {
-	number := 3987434
+	number := Number(3987434)
 	s.registerResponseError(errors.New("Error"))
 	count, err := s.rpc.AsimovGetUncleCountByBlockNumber(number)
 	s.Require().NotNil(err)
@@ -466,6 +571,41 @@ func (s *AsimovRPCTestSuite) TestAsimovGetUncleCountByBlockNumber() {
 	s.Require().Equal(902, count)
 }
 
+func (s *AsimovRPCTestSuite) TestAsimovGetUncleByBlockHashAndIndex() {
+	hash := "0x2bdda43f649c564642101fc990f569dd855e60f88bf83e931f509a92c62700f9"
+	s.registerResponse(`{"hash": "0xf14c", "number": "0x4055d4", "miner": "0xea67", "uncles": []}`, func(body []byte) {
+		s.methodEqual(body, "flow_getUncleByBlockHashAndIndex")
+		s.paramsEqual(body, `["`+hash+`", "0x1"]`)
+	})
+
+	uncle, err := s.rpc.AsimovGetUncleByBlockHashAndIndex(hash, 1)
+	s.Require().Nil(err)
+	s.Require().Equal("0xf14c", uncle.Hash)
+	s.Require().Equal(4216276, uncle.Number)
+	s.Require().Equal("0xea67", uncle.Miner)
+	s.Require().Empty(uncle.Transactions)
+
+	s.registerResponse("null", func(body []byte) {})
+	uncle, err = s.rpc.AsimovGetUncleByBlockHashAndIndex(hash, 5)
+	s.Require().Nil(err)
+	s.Require().Nil(uncle)
+}
+
+func (s *AsimovRPCTestSuite) TestAsimovGetUncleByBlockNumberAndIndex() {
+	s.registerResponseError(errors.New("Error"))
+	_, err := s.rpc.AsimovGetUncleByBlockNumberAndIndex(4216277, 0)
+	s.Require().NotNil(err)
+
+	s.registerResponse(`{"hash": "0xf14c", "number": "0x4055d4"}`, func(body []byte) {
+		s.methodEqual(body, "flow_getUncleByBlockNumberAndIndex")
+		s.paramsEqual(body, `["0x4055d5", "0x0"]`)
+	})
+
+	uncle, err := s.rpc.Flow().GetUncleByBlockNumberAndIndex(4216277, 0)
+	s.Require().Nil(err)
+	s.Require().Equal("0xf14c", uncle.Hash)
+}
+
 func (s *AsimovRPCTestSuite) TestAsimovGetCode() {
 	address := "0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b"
 	result := "0x600160008035811a818181146012578301005b601b6001356025565b8060005260206000f25b600060078202905091905056"
@@ -474,7 +614,7 @@ func (s *AsimovRPCTestSuite) TestAsimovGetCode() {
 		s.paramsEqual(body, fmt.Sprintf(`["%s", "latest"]`, address))
 	})
 
-	code, err := s.rpc.AsimovGetCode(address, "latest")
+	code, err := s.rpc.AsimovGetCode(address, Latest())
 	s.Require().Nil(err)
 	s.Require().Equal(result, code)
 }
@@ -550,6 +690,92 @@ func (s *AsimovRPCTestSuite) TestAsimovSendRawTransaction() {
 	s.Require().Equal(result, txid)
 }
 
+func (s *AsimovRPCTestSuite) TestAsimovSignTransaction() {
+	s.registerResponseError(errors.New("error"))
+	_, err := s.rpc.AsimovSignTransaction(T{From: "0x111"})
+	s.Require().NotNil(err)
+
+	result := `{
+		"raw": "0xf86c0a8502540be400825208",
+		"tx": {
+			"hash": "0x333",
+			"nonce": "0xa",
+			"blockHash": null,
+			"blockNumber": null,
+			"transactionIndex": null,
+			"from": "0x111",
+			"to": "0x222",
+			"value": "0x1",
+			"gas": "0x5208",
+			"gasPrice": "0x2540be400",
+			"input": "0x"
+		}
+	}`
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "flow_signTransaction")
+		s.paramsEqual(body, `[{"from":"0x111","to":"0x222","value":"0x1","nonce":"0xa"}]`)
+	})
+
+	signed, err := s.rpc.AsimovSignTransaction(T{
+		From:  "0x111",
+		To:    "0x222",
+		Value: big.NewInt(1),
+		Nonce: 10,
+	})
+	s.Require().Nil(err)
+	s.Require().Equal("0xf86c0a8502540be400825208", signed.Raw)
+	s.Require().Equal(Transaction{
+		Hash:     "0x333",
+		Nonce:    10,
+		From:     "0x111",
+		To:       "0x222",
+		Value:    newBigInt("1"),
+		Gas:      21000,
+		GasPrice: newBigInt("10000000000"),
+		Input:    "0x",
+	}, signed.Tx)
+}
+
+func (s *AsimovRPCTestSuite) TestAsimovPendingTransactions() {
+	s.registerResponseError(errors.New("error"))
+	_, err := s.rpc.AsimovPendingTransactions()
+	s.Require().NotNil(err)
+
+	result := `[
+		{"hash": "0x1", "nonce": "0x1", "from": "0xAAA", "to": "0xbbb", "value": "0x0", "gas": "0x5208", "gasPrice": "0x1", "input": "0x"},
+		{"hash": "0x2", "nonce": "0x2", "from": "0xccc", "to": "0xaaa", "value": "0x0", "gas": "0x5208", "gasPrice": "0x1", "input": "0x"},
+		{"hash": "0x3", "nonce": "0x3", "from": "0xccc", "to": "0xddd", "value": "0x0", "gas": "0x5208", "gasPrice": "0x1", "input": "0x"}
+	]`
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "flow_pendingTransactions")
+		s.paramsEqual(body, "null")
+	})
+
+	hashes := func(transactions []Transaction) []string {
+		result := []string{}
+		for _, transaction := range transactions {
+			result = append(result, transaction.Hash)
+		}
+		return result
+	}
+
+	transactions, err := s.rpc.AsimovPendingTransactions()
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"0x1", "0x2", "0x3"}, hashes(transactions))
+
+	transactions, err = s.rpc.AsimovPendingTransactions(FromAddress("0xaaa"))
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"0x1"}, hashes(transactions))
+
+	transactions, err = s.rpc.AsimovPendingTransactions(InvolvingAddress("0xAAA"))
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"0x1", "0x2"}, hashes(transactions))
+
+	transactions, err = s.rpc.AsimovPendingTransactions(FromAddress("0xccc"), ToAddress("0xddd", "0xeee"))
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"0x3"}, hashes(transactions))
+}
+
 func (s *AsimovRPCTestSuite) TestAsimovGetCompilers() {
 	s.registerResponse(`["solidity", "some comp"]`, func(body []byte) {
 		s.methodEqual(body, "flow_getCompilers")
@@ -771,7 +997,7 @@ func (s *AsimovRPCTestSuite) TestAsimovGetBlockByHash() {
 
 func (s *AsimovRPCTestSuite) TestAsimovGetBlockByNumber() {
 	// Test with transactions
-	number := 3274863
+	number := Number(3274863)
 	s.registerResponse(`{}`, func(body []byte) {
 		s.methodEqual(body, "flow_getBlockByNumber")
 		s.paramsEqual(body, `["0x31f86f", true]`)
@@ -791,18 +1017,29 @@ func (s *AsimovRPCTestSuite) TestAsimovGetBlockByNumber() {
 
 	_, err = s.rpc.AsimovGetBlockByNumber(number, false)
 	s.Require().Nil(err)
+
+	httpmock.Reset()
+
+	// Test with a tag
+	s.registerResponse(`{}`, func(body []byte) {
+		s.methodEqual(body, "flow_getBlockByNumber")
+		s.paramsEqual(body, `["latest", false]`)
+	})
+
+	_, err = s.rpc.AsimovGetBlockByNumber(Latest(), false)
+	s.Require().Nil(err)
 }
 
 func (s *AsimovRPCTestSuite) TestAsimovCall() {
 	s.registerResponse(`"0x11"`, func(body []byte) {
 		s.methodEqual(body, "flow_call")
-		s.paramsEqual(body, `[{"from":"0x111","to":"0x222"}, "ttt"]`)
+		s.paramsEqual(body, `[{"from":"0x111","to":"0x222"}, "finalized"]`)
 	})
 
 	result, err := s.rpc.AsimovCall(T{
 		From: "0x111",
 		To:   "0x222",
-	}, "ttt")
+	}, Finalized())
 	s.Require().Nil(err)
 	s.Require().Equal("0x11", result)
 }
@@ -1092,14 +1329,12 @@ func (s *AsimovRPCTestSuite) TestAsimovGetFilterLogs() {
 
 func (s *AsimovRPCTestSuite) TestAsimovGetLogs() {
 	params := FilterParams{
-		FromBlock: "0x1",
-		ToBlock:   "0x10",
-		Address:   []string{"0x8888f1f195afa192cfee860698584c030f4c9db1"},
+		Address: []string{"0x8888f1f195afa192cfee860698584c030f4c9db1"},
 		Topics: [][]string{
 			{"0x111"},
 			nil,
 		},
-	}
+	}.Range(Number(1), Number(16))
 	result := `[{
 		"address": "0xaca0cc3a6bf9552f2866ccc67801d4e6aa6a70f2",
 		"blockHash": "0x9d9838090bb7f6194f62acea788688435b79cc44c62dcf1479abd9f2c72a7d5c",
@@ -1154,10 +1389,10 @@ func TestAsimovRPCTestSuite(t *testing.T) {
 
 func TestAsimovError(t *testing.T) {
 	var err error
-	err = AsimovError{-32555, "Messg"}
+	err = AsimovError{Code: -32555, Message: "Messg"}
 	require.Equal(t, "Error -32555 (Messg)", err.Error())
 
-	err = AsimovError{32847, "Kuku"}
+	err = AsimovError{Code: 32847, Message: "Kuku"}
 	require.Equal(t, "Error 32847 (Kuku)", err.Error())
 }
 
@@ -1175,3 +1410,86 @@ func newBigInt(s string) big.Int {
 	i, _ := new(big.Int).SetString(s, 10)
 	return *i
 }
+
+func (s *AsimovRPCTestSuite) TestUserAgent() {
+	var userAgent string
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		userAgent = request.Header.Get("User-Agent")
+		return httpmock.NewStringResponse(200, echoID(request, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`)), nil
+	})
+
+	_, err := s.rpc.Call("test")
+	s.Require().Nil(err)
+	s.Require().Equal(DefaultUserAgent(), userAgent)
+	s.Require().Equal("mist-asimov-rpc/devel", userAgent)
+
+	rpc := New(s.rpc.url, WithUserAgent("exchange/1.2"))
+	_, err = rpc.Call("test")
+	s.Require().Nil(err)
+	s.Require().Equal("mist-asimov-rpc/devel exchange/1.2", userAgent)
+
+	_, err = rpc.Agent("backfill/0.1").Call("test")
+	s.Require().Nil(err)
+	s.Require().Equal("mist-asimov-rpc/devel backfill/0.1", userAgent)
+
+	_, err = rpc.Call("test")
+	s.Require().Nil(err)
+	s.Require().Equal("mist-asimov-rpc/devel exchange/1.2", userAgent)
+}
+
+func TestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(echoID(r, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`)))
+	}))
+	defer server.Close()
+
+	_, err := New(server.URL, WithHttpClient(server.Client()), WithTimeout(10*time.Millisecond)).Call("test")
+	require.NotNil(t, err)
+
+	_, err = New(server.URL, WithHttpClient(server.Client()), WithTimeout(time.Second)).Call("test")
+	require.Nil(t, err)
+}
+
+func TestRequestIDs(t *testing.T) {
+	var mu sync.Mutex
+	ids := map[int64]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		ids[gjson.GetBytes(body, "id").Int()] = true
+		mu.Unlock()
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":` + requestID(body) + `, "result": "0x1"}`))
+	}))
+	defer server.Close()
+
+	rpc := New(server.URL)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := rpc.WithContext(context.Background()).AsimovBlockNumber()
+			require.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+	require.Len(t, ids, 20)
+}
+
+func (s *AsimovRPCTestSuite) TestResponseIDMismatch() {
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`), nil
+	})
+
+	_, err := s.rpc.Call("flow_blockNumber", WithCallID(2))
+	s.Require().Equal(ResponseIDError{Method: "flow_blockNumber", Expected: 2, Actual: 1}, err)
+
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":null, "error": {"code": -32700, "message": "parse error"}}`), nil
+	})
+	_, err = s.rpc.Call("flow_blockNumber")
+	s.Require().Equal(AsimovError{Code: -32700, Message: "parse error"}, err)
+}
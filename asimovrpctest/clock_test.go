@@ -0,0 +1,47 @@
+package asimovrpctest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	require.Equal(t, start, clock.Now())
+
+	immediate := clock.After(0)
+	require.Equal(t, start, <-immediate)
+
+	short := clock.After(time.Second)
+	long := clock.After(time.Minute)
+	require.Equal(t, 2, clock.Waiters())
+
+	clock.Advance(time.Second)
+	require.Equal(t, start.Add(time.Second), <-short)
+	require.Equal(t, 1, clock.Waiters())
+	select {
+	case <-long:
+		t.Fatal("timer fired early")
+	default:
+	}
+
+	clock.Advance(time.Hour)
+	require.Equal(t, start.Add(time.Hour+time.Second), <-long)
+	require.Equal(t, 0, clock.Waiters())
+}
+
+func TestFakeClockSleep(t *testing.T) {
+	clock := NewFakeClock(time.Time{})
+	done := make(chan struct{})
+	go func() {
+		clock.Sleep(time.Minute)
+		close(done)
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	<-done
+}
@@ -0,0 +1,263 @@
+package asimovrpctest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Error - JSON-RPC error returned by handlers of Node
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("Error %d (%s)", e.Code, e.Message)
+}
+
+// HandlerFunc answers a call with params of the request. Returned Error is sent as is,
+// other errors as internal error -32603.
+type HandlerFunc func(params []json.RawMessage) (interface{}, error)
+
+// Call - request received by Node
+type Call struct {
+	Method string
+	Params []json.RawMessage
+}
+
+// TestingT - subset of testing.TB used by assertions
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Node - fake node serving JSON-RPC over HTTP with canned responses:
+//
+//	node := asimovrpctest.NewNode()
+//	defer node.Close()
+//	node.Handle("flow_blockNumber", "0x10")
+//	client := asimovrpc.New(node.URL)
+//
+// Methods without a handler fail with -32601 like on a real node.
+type Node struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]HandlerFunc
+	latency  map[string]time.Duration
+	calls    []Call
+}
+
+// NewNode starts fake node, it must be closed with Close
+func NewNode() *Node {
+	n := &Node{handlers: map[string]HandlerFunc{}, latency: map[string]time.Duration{}}
+	n.Server = httptest.NewServer(http.HandlerFunc(n.serve))
+
+	return n
+}
+
+// Handle answers method with result, result is encoded to JSON unless it is json.RawMessage
+func (n *Node) Handle(method string, result interface{}) {
+	n.HandleFunc(method, func([]json.RawMessage) (interface{}, error) {
+		return result, nil
+	})
+}
+
+// HandleError answers method with JSON-RPC error
+func (n *Node) HandleError(method string, code int, message string) {
+	n.HandleFunc(method, func([]json.RawMessage) (interface{}, error) {
+		return nil, Error{Code: code, Message: message}
+	})
+}
+
+// HandleFunc answers method with handler
+func (n *Node) HandleFunc(method string, handler HandlerFunc) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.handlers[method] = handler
+}
+
+// SetLatency delays responses to method by d, method "" delays all responses.
+// A batch is delayed by the largest latency of its calls.
+func (n *Node) SetLatency(method string, d time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.latency[method] = d
+}
+
+// Calls returns received calls of method, or all calls if method is ""
+func (n *Node) Calls(method string) []Call {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var calls []Call
+	for _, call := range n.calls {
+		if method == "" || call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Reset removes handlers, latencies and received calls
+func (n *Node) Reset() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.handlers = map[string]HandlerFunc{}
+	n.latency = map[string]time.Duration{}
+	n.calls = nil
+}
+
+// AssertParams checks that the last call of method was made with params, compared as JSON
+func (n *Node) AssertParams(t TestingT, method string, params ...interface{}) bool {
+	t.Helper()
+
+	calls := n.Calls(method)
+	if len(calls) == 0 {
+		t.Errorf("asimovrpctest: %s was not called", method)
+		return false
+	}
+
+	expected, err := normalize(params)
+	if err != nil {
+		t.Errorf("asimovrpctest: cannot encode params: %v", err)
+		return false
+	}
+	actual, err := normalize(calls[len(calls)-1].Params)
+	if err != nil {
+		t.Errorf("asimovrpctest: cannot decode params: %v", err)
+		return false
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		e, _ := json.Marshal(expected)
+		a, _ := json.Marshal(actual)
+		t.Errorf("asimovrpctest: %s called with params %s, expected %s", method, a, e)
+		return false
+	}
+
+	return true
+}
+
+func normalize(params interface{}) (interface{}, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	err = json.Unmarshal(data, &value)
+	if list, ok := value.([]interface{}); ok && len(list) == 0 {
+		value = nil
+	}
+
+	return value, err
+}
+
+type request struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+func (n *Node) serve(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var requests []request
+	batch := len(bytes.TrimSpace(body)) > 0 && bytes.TrimSpace(body)[0] == '['
+	if batch {
+		err = json.Unmarshal(body, &requests)
+	} else {
+		requests = make([]request, 1)
+		err = json.Unmarshal(body, &requests[0])
+	}
+	if err != nil {
+		writeJSON(w, response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &Error{Code: -32700, Message: "parse error"}})
+		return
+	}
+
+	var delay time.Duration
+	n.mu.Lock()
+	for _, req := range requests {
+		n.calls = append(n.calls, Call{Method: req.Method, Params: req.Params})
+		if d, ok := n.latency[req.Method]; ok && d > delay {
+			delay = d
+		}
+	}
+	if d := n.latency[""]; d > delay {
+		delay = d
+	}
+	n.mu.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	responses := make([]response, len(requests))
+	for i, req := range requests {
+		responses[i] = n.respond(req)
+	}
+	if batch {
+		writeJSON(w, responses)
+	} else {
+		writeJSON(w, responses[0])
+	}
+}
+
+func (n *Node) respond(req request) response {
+	n.mu.Lock()
+	handler, ok := n.handlers[req.Method]
+	n.mu.Unlock()
+
+	resp := response{JSONRPC: "2.0", ID: req.ID}
+	if !ok {
+		resp.Error = &Error{Code: -32601, Message: fmt.Sprintf("the method %s does not exist/is not available", req.Method)}
+		return resp
+	}
+
+	result, err := handler(req.Params)
+	switch e := err.(type) {
+	case nil:
+		if result == nil {
+			result = json.RawMessage("null")
+		}
+		resp.Result = result
+	case Error:
+		resp.Error = &e
+	default:
+		resp.Error = &Error{Code: -32603, Message: err.Error()}
+	}
+
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,115 @@
+package asimovrpctest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	asimovrpc "github.com/mistdex/mist-asimov-rpc"
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestNode(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	node.Handle("flow_blockNumber", "0x10")
+	node.Handle("flow_getBalance", json.RawMessage(`"0x64"`))
+	client := asimovrpc.New(node.URL)
+
+	number, err := client.AsimovBlockNumber()
+	require.Nil(t, err)
+	require.Equal(t, 16, number)
+
+	balance, err := client.AsimovGetBalance("0x6247cf0412c6462da2a51d05139e2a3c6c630f0a", asimovrpc.Latest())
+	require.Nil(t, err)
+	require.Equal(t, int64(100), balance.Int64())
+	require.True(t, node.AssertParams(t, "flow_getBalance", "0x6247cf0412c6462da2a51d05139e2a3c6c630f0a", "latest"))
+	require.True(t, node.AssertParams(t, "flow_blockNumber"))
+	require.Len(t, node.Calls(""), 2)
+
+	r := new(recorder)
+	require.False(t, node.AssertParams(r, "flow_getBalance", "0x6247cf0412c6462da2a51d05139e2a3c6c630f0a", "pending"))
+	require.False(t, node.AssertParams(r, "flow_gasPrice"))
+	require.Equal(t, []string{
+		`asimovrpctest: flow_getBalance called with params ["0x6247cf0412c6462da2a51d05139e2a3c6c630f0a","latest"], expected ["0x6247cf0412c6462da2a51d05139e2a3c6c630f0a","pending"]`,
+		"asimovrpctest: flow_gasPrice was not called",
+	}, r.errors)
+
+	_, err = client.AsimovGasPrice()
+	require.True(t, errors.Is(err, asimovrpc.ErrMethodNotFound))
+
+	node.Reset()
+	require.Empty(t, node.Calls(""))
+	_, err = client.AsimovBlockNumber()
+	require.True(t, errors.Is(err, asimovrpc.ErrMethodNotFound))
+}
+
+func TestNodeErrors(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	node.HandleError("flow_sendRawTransaction", -32000, "nonce too low")
+	node.HandleFunc("flow_getCode", func(params []json.RawMessage) (interface{}, error) {
+		return nil, errors.New("state unavailable")
+	})
+	client := asimovrpc.New(node.URL)
+
+	_, err := client.AsimovSendRawTransaction("0x01")
+	require.True(t, errors.Is(err, asimovrpc.ErrNonceTooLow))
+
+	_, err = client.AsimovGetCode("0x6247cf0412c6462da2a51d05139e2a3c6c630f0a", asimovrpc.Latest())
+	var rpcErr asimovrpc.AsimovError
+	require.True(t, errors.As(err, &rpcErr))
+	require.Equal(t, -32603, rpcErr.Code)
+	require.Equal(t, "state unavailable", rpcErr.Message)
+}
+
+func TestNodeBatch(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	node.Handle("flow_blockNumber", "0x10")
+	client := asimovrpc.New(node.URL)
+
+	var number string
+	batch := client.NewBatch().Add("flow_blockNumber", &number).Add("flow_gasPrice", nil)
+	require.Nil(t, batch.Execute())
+	require.Nil(t, batch.Err(0))
+	require.Equal(t, "0x10", number)
+	require.True(t, errors.Is(batch.Err(1), asimovrpc.ErrMethodNotFound))
+	require.Len(t, node.Calls(""), 2)
+}
+
+func TestNodeLatency(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	node.Handle("flow_blockNumber", "0x10")
+	node.SetLatency("flow_blockNumber", time.Second)
+	client := asimovrpc.New(node.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.WithContext(ctx).AsimovBlockNumber()
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	node.SetLatency("flow_blockNumber", 0)
+	_, err = client.AsimovBlockNumber()
+	require.Nil(t, err)
+}
@@ -0,0 +1,61 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+)
+
+// WithBasicAuth send HTTP basic authentication credentials with every request
+func WithBasicAuth(user, password string) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if user == "" {
+			rpc.invalidOption("WithBasicAuth", "user is empty")
+			return
+		}
+		credentials := base64.StdEncoding.EncodeToString([]byte(user + ":" + password))
+		rpc.setHeader("Authorization", "Basic "+credentials)
+	}
+}
+
+// WithBearerToken send bearer token in the Authorization header of every request
+func WithBearerToken(token string) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if token == "" {
+			rpc.invalidOption("WithBearerToken", "token is empty")
+			return
+		}
+		rpc.setHeader("Authorization", "Bearer "+token)
+	}
+}
+
+// WithHTTPHeader send HTTP header with every request, including WebSocket handshakes.
+// Headers of the context (see WithHeader) replace headers of the client with the same name.
+func WithHTTPHeader(name, value string) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if name == "" {
+			rpc.invalidOption("WithHTTPHeader", "name is empty")
+			return
+		}
+		rpc.setHeader(name, value)
+	}
+}
+
+func (rpc *AsimovRPC) setHeader(name, value string) {
+	header := rpc.headers.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set(name, value)
+	rpc.headers = header
+}
+
+// applyHeaders sets headers of the client and of ctx on request header
+func (rpc *AsimovRPC) applyHeaders(ctx context.Context, header http.Header) {
+	for name, values := range rpc.headers {
+		header[name] = values
+	}
+	for name, values := range HeaderFromContext(ctx) {
+		header[name] = values
+	}
+}
@@ -0,0 +1,71 @@
+package asimovrpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *AsimovRPCTestSuite) TestAuthOptions() {
+	var header http.Header
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		header = request.Header
+		return httpmock.NewStringResponse(200, echoID(request, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`)), nil
+	})
+
+	rpc := New(s.rpc.url, WithBasicAuth("alice", "secret"), WithHTTPHeader("X-Api-Key", "key"))
+	_, err := rpc.AsimovBlockNumber()
+	s.Require().Nil(err)
+	user, password, ok := (&http.Request{Header: header}).BasicAuth()
+	s.Require().True(ok)
+	s.Require().Equal("alice", user)
+	s.Require().Equal("secret", password)
+	s.Require().Equal("key", header.Get("X-Api-Key"))
+
+	rpc = New(s.rpc.url, WithBasicAuth("alice", "secret"), WithBearerToken("token"))
+	_, err = rpc.AsimovBlockNumber()
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"Bearer token"}, header.Values("Authorization"))
+
+	_, err = rpc.CallContext(WithHeader(context.Background(), "Authorization", "Bearer tenant"), "flow_blockNumber")
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"Bearer tenant"}, header.Values("Authorization"))
+}
+
+func TestAuthOptionsInvalid(t *testing.T) {
+	_, err := NewClient("http://127.0.0.1:8545", WithBearerToken(""))
+	require.EqualError(t, err, "asimovrpc: invalid option WithBearerToken: token is empty")
+
+	_, err = NewClient("http://127.0.0.1:8545", WithBasicAuth("", "secret"))
+	require.EqualError(t, err, "asimovrpc: invalid option WithBasicAuth: user is empty")
+
+	_, err = NewClient("http://127.0.0.1:8545", WithHTTPHeader("", "value"))
+	require.EqualError(t, err, "asimovrpc: invalid option WithHTTPHeader: name is empty")
+}
+
+func TestAuthWebSocketHandshake(t *testing.T) {
+	authorization := make(chan string, 1)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization <- r.Header.Get("Authorization")
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conn.Close()
+	}))
+	defer server.Close()
+
+	rpc := New("ws"+strings.TrimPrefix(server.URL, "http"), WithBearerToken("token"))
+	defer rpc.Close()
+
+	rpc.SubscribeNewHeads(context.Background())
+	require.Equal(t, "Bearer token", <-authorization)
+}
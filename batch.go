@@ -0,0 +1,275 @@
+package asimovrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mistdex/mist-asimov-rpc/fetch"
+)
+
+// DefaultBatchSize - maximum number of requests sent in a single JSON-RPC batch
+const DefaultBatchSize = 100
+
+// BatchMethod - pseudo method under which batch payload sizes are recorded in Stats
+const BatchMethod = "rpc_batch"
+
+// fetchConcurrency - chunks of internal bulk fetches (RichList, Sweep, snapshots) exchanged at once
+const fetchConcurrency = 4
+
+// ErrMissingBatchResponse - node answered batch without response for a request
+var ErrMissingBatchResponse = errors.New("missing response in batch")
+
+type batchItem struct {
+	method string
+	params []interface{}
+	target interface{}
+	err    error
+}
+
+// Batch - JSON-RPC requests sent together, results are decoded into individual targets
+type Batch struct {
+	rpc   *AsimovRPC
+	items []batchItem
+}
+
+// WithBatchSize set maximum number of requests sent in a single batch, larger batches are split
+func WithBatchSize(size int) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if size <= 0 {
+			rpc.invalidOption("WithBatchSize", "size must be positive")
+			return
+		}
+		rpc.batchSize = size
+	}
+}
+
+// BatchObserver - receives every request of a batch once the batch was sent or the request was rejected
+// by the client (zero duration). Batches do not pass through interceptors, observers let them record
+// batched requests.
+type BatchObserver func(ctx context.Context, method string, duration time.Duration, err error)
+
+// WithBatchObserver add observers of batched requests
+func WithBatchObserver(observers ...BatchObserver) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		for _, observer := range observers {
+			if observer == nil {
+				rpc.invalidOption("WithBatchObserver", "observer is nil")
+				return
+			}
+		}
+		rpc.batchObservers = append(append([]BatchObserver{}, rpc.batchObservers...), observers...)
+	}
+}
+
+// NewBatch creates empty batch
+func (rpc *AsimovRPC) NewBatch() *Batch {
+	return &Batch{rpc: rpc}
+}
+
+// Add adds request whose result is decoded into target (nil - result is ignored)
+func (b *Batch) Add(method string, target interface{}, params ...interface{}) *Batch {
+	b.items = append(b.items, batchItem{method: method, params: params, target: target})
+	return b
+}
+
+// Len returns number of requests in batch
+func (b *Batch) Len() int {
+	return len(b.items)
+}
+
+// Err returns error of i-th request after Execute
+func (b *Batch) Err(i int) error {
+	return b.items[i].err
+}
+
+// Execute sends batch using client context, see ExecuteContext
+func (b *Batch) Execute() error {
+	return b.ExecuteContext(b.rpc.context())
+}
+
+// ExecuteContext sends batch in chunks of the client batch size. The returned error is set
+// when a chunk could not be exchanged at all, per-request errors are available from Err.
+func (b *Batch) ExecuteContext(ctx context.Context) error {
+	ctx = b.rpc.tagged(ctx)
+	size := b.rpc.batchSize
+	for start := 0; start < len(b.items); start += size {
+		end := start + size
+		if end > len(b.items) {
+			end = len(b.items)
+		}
+
+		if err := b.execute(ctx, b.items[start:end]); err != nil {
+			for i := start; i < len(b.items); i++ {
+				if b.items[i].err == nil {
+					b.items[i].err = err
+				}
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetch sends batch in chunks of the client batch size like ExecuteContext, exchanging up to
+// fetchConcurrency chunks at once in a fetch.Group. The first failed chunk cancels the rest, results
+// are valid only when no error is returned.
+func (b *Batch) fetch(ctx context.Context) error {
+	ctx = b.rpc.tagged(ctx)
+	size := b.rpc.batchSize
+	chunks := (len(b.items) + size - 1) / size
+
+	return fetch.Each(ctx, fetchConcurrency, chunks, func(ctx context.Context, i int) error {
+		start, end := i*size, (i+1)*size
+		if end > len(b.items) {
+			end = len(b.items)
+		}
+
+		err := b.execute(ctx, b.items[start:end])
+		for j := start; err != nil && j < end; j++ {
+			if b.items[j].err == nil {
+				b.items[j].err = err
+			}
+		}
+		return err
+	})
+}
+
+func (b *Batch) execute(ctx context.Context, items []batchItem) error {
+	rpc := b.rpc
+	if isWebSocket(rpc.endpoint()) {
+		// WebSocket requests are already multiplexed on one connection
+		for i := range items {
+			items[i].err = rpc.WithContext(ctx).call(items[i].method, items[i].target, items[i].params...)
+		}
+		return nil
+	}
+
+	changing := false
+	requests := make([]asimovRequest, 0, len(items))
+	for i := range items {
+		params, err := rpc.prepare(ctx, items[i].method, items[i].params)
+		if err != nil {
+			items[i].err = err
+			rpc.observeBatch(ctx, items[i].method, 0, err)
+			continue
+		}
+		requests = append(requests, asimovRequest{ID: i + 1, JSONRPC: "2.0", Method: items[i].method, Params: params})
+		changing = changing || StateChanging(items[i].method)
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(requests)
+	if err != nil {
+		return err
+	}
+
+	start := rpc.clock.Now()
+	var data json.RawMessage
+	// a batch with a state-changing request is state-changing as a whole
+	err = rpc.send(ctx, BatchMethod, changing, 0, body, func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(&data)
+	})
+	duration := rpc.clock.Now().Sub(start)
+	if err == nil {
+		err = b.decode(ctx, items, data)
+	}
+
+	for _, request := range requests {
+		item := &items[request.ID-1]
+		if err != nil {
+			item.err = err
+		}
+		rpc.observe(ctx, item.method, item.params, duration, item.err)
+		rpc.observeBatch(ctx, item.method, duration, item.err)
+	}
+
+	return err
+}
+
+// prepare checks batched request before it is sent and returns its encoded params
+func (rpc *AsimovRPC) prepare(ctx context.Context, method string, params []interface{}) ([]interface{}, error) {
+	if err := rpc.allowed(method); err != nil {
+		return nil, err
+	}
+	if err := rpc.screenCall(ctx, method, params); err != nil {
+		return nil, err
+	}
+	if err := rpc.capGas(method, params); err != nil {
+		return nil, err
+	}
+	if err := rpc.checkMetadataStore(ctx, method); err != nil {
+		return nil, err
+	}
+	encoded, err := rpc.encodeParams(params)
+	if err != nil {
+		return nil, err
+	}
+	if err := rpc.validate(ctx, method, encoded); err != nil {
+		return nil, err
+	}
+	if err := rpc.spend(ctx, method); err != nil {
+		return nil, err
+	}
+
+	return encoded, nil
+}
+
+func (rpc *AsimovRPC) observeBatch(ctx context.Context, method string, duration time.Duration, err error) {
+	for _, observer := range rpc.batchObservers {
+		observer(ctx, method, duration, err)
+	}
+}
+
+func (b *Batch) decode(ctx context.Context, items []batchItem, data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		// nodes without batch support answer with a single error object
+		response := new(asimovResponse)
+		if err := json.Unmarshal(data, response); err != nil {
+			return err
+		}
+		if response.Error != nil {
+			return *response.Error
+		}
+		return fmt.Errorf("unexpected batch response %s", truncate(data, DefaultDebugPayloadLimit))
+	}
+
+	var responses []asimovResponse
+	if err := json.Unmarshal(data, &responses); err != nil {
+		return err
+	}
+
+	answered := make(map[int]bool, len(responses))
+	for _, response := range responses {
+		if response.ID < 1 || response.ID > len(items) {
+			continue
+		}
+		answered[response.ID] = true
+
+		item := &items[response.ID-1]
+		switch {
+		case response.Error != nil:
+			item.err = *response.Error
+		case item.target != nil:
+			item.err = json.Unmarshal(response.Result, item.target)
+		}
+		if item.err == nil {
+			item.err = b.rpc.storeMetadata(ctx, item.method, response.Result)
+		}
+	}
+
+	for i := range items {
+		if items[i].err == nil && !answered[i+1] {
+			items[i].err = ErrMissingBatchResponse
+		}
+	}
+
+	return nil
+}
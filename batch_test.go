@@ -0,0 +1,94 @@
+package asimovrpc
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/tidwall/gjson"
+)
+
+func (s *AsimovRPCTestSuite) registerBatchResponder(requests *[]string) {
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := s.getBody(request)
+		*requests = append(*requests, string(body))
+
+		var responses []string
+		// answer in reverse order, the client must match responses by id
+		items := gjson.ParseBytes(body).Array()
+		for i := len(items) - 1; i >= 0; i-- {
+			id := items[i].Get("id").Int()
+			switch items[i].Get("method").String() {
+			case "flow_blockNumber":
+				responses = append(responses, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":"0x%x"}`, id, id))
+			case "flow_getBalance":
+				responses = append(responses, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":"0x64"}`, id))
+			case "flow_missing":
+			default:
+				responses = append(responses, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":-32601,"message":"method not found"}}`, id))
+			}
+		}
+		return httpmock.NewStringResponse(200, "["+strings.Join(responses, ",")+"]"), nil
+	})
+}
+
+func (s *AsimovRPCTestSuite) TestBatch() {
+	var requests []string
+	s.registerBatchResponder(&requests)
+
+	rpc := New(s.rpc.url)
+	var number, balance string
+	batch := rpc.NewBatch().
+		Add("flow_blockNumber", &number).
+		Add("flow_getBalance", &balance, "0x1", "latest").
+		Add("flow_unknown", nil).
+		Add("flow_missing", nil)
+
+	s.Require().Nil(batch.Execute())
+	s.Require().Len(requests, 1)
+	s.Require().Equal(`[{"id":1,"jsonrpc":"2.0","method":"flow_blockNumber","params":null},{"id":2,"jsonrpc":"2.0","method":"flow_getBalance","params":["0x1","latest"]},{"id":3,"jsonrpc":"2.0","method":"flow_unknown","params":null},{"id":4,"jsonrpc":"2.0","method":"flow_missing","params":null}]`, requests[0])
+
+	s.Require().Equal("0x1", number)
+	s.Require().Equal("0x64", balance)
+	s.Require().Nil(batch.Err(0))
+	s.Require().Nil(batch.Err(1))
+	s.Require().Equal(AsimovError{Code: -32601, Message: "method not found"}, batch.Err(2))
+	s.Require().Equal(ErrMissingBatchResponse, batch.Err(3))
+
+	stats := rpc.Stats()
+	s.Require().Equal(1, stats["flow_getBalance"].Calls)
+	s.Require().Equal(1, stats["flow_unknown"].Errors)
+	s.Require().Equal(int64(len(requests[0])), stats[BatchMethod].RequestBytes)
+}
+
+func (s *AsimovRPCTestSuite) TestBatchChunks() {
+	var requests []string
+	s.registerBatchResponder(&requests)
+
+	rpc := New(s.rpc.url, WithBatchSize(2))
+	numbers := make([]string, 5)
+	batch := rpc.NewBatch()
+	for i := range numbers {
+		batch.Add("flow_blockNumber", &numbers[i])
+	}
+
+	s.Require().Nil(batch.Execute())
+	s.Require().Len(requests, 3)
+	s.Require().Equal([]string{"0x1", "0x2", "0x1", "0x2", "0x1"}, numbers)
+}
+
+func (s *AsimovRPCTestSuite) TestBatchUnsupported() {
+	s.registerResponses(map[string]string{}, func([]byte) {})
+
+	var number string
+	batch := s.rpc.NewBatch().Add("flow_blockNumber", &number).Add("flow_blockNumber", &number)
+	err := batch.Execute()
+	s.Require().Equal(AsimovError{Code: -32601, Message: "method not found"}, err)
+	s.Require().Equal(err, batch.Err(0))
+	s.Require().Equal(err, batch.Err(1))
+
+	_, err = NewClient(s.rpc.url, WithBatchSize(0))
+	s.Require().EqualError(err, "asimovrpc: invalid option WithBatchSize: size must be positive")
+}
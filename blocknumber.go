@@ -0,0 +1,125 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BlockNumber - block parameter of RPC methods: a height or one of the symbolic tags, which are
+// negative so that heights convert with BlockNumber(n) or Number(n)
+type BlockNumber int64
+
+// Symbolic block tags
+const (
+	EarliestBlockNumber  BlockNumber = -5
+	SafeBlockNumber      BlockNumber = -4
+	FinalizedBlockNumber BlockNumber = -3
+	LatestBlockNumber    BlockNumber = -2
+	PendingBlockNumber   BlockNumber = -1
+)
+
+var blockTags = map[BlockNumber]string{
+	EarliestBlockNumber:  "earliest",
+	SafeBlockNumber:      "safe",
+	FinalizedBlockNumber: "finalized",
+	LatestBlockNumber:    "latest",
+	PendingBlockNumber:   "pending",
+}
+
+// Latest returns the most recent block
+func Latest() BlockNumber { return LatestBlockNumber }
+
+// Pending returns the pending block, built from the node's transaction pool
+func Pending() BlockNumber { return PendingBlockNumber }
+
+// Earliest returns the genesis block
+func Earliest() BlockNumber { return EarliestBlockNumber }
+
+// Finalized returns the most recent finalized block
+func Finalized() BlockNumber { return FinalizedBlockNumber }
+
+// Safe returns the most recent block safe from reorganisations
+func Safe() BlockNumber { return SafeBlockNumber }
+
+// Number returns block at height n
+func Number(n int) BlockNumber { return BlockNumber(n) }
+
+// ParseBlockNumber parses tag, 0x-prefixed hex or decimal height
+func ParseBlockNumber(s string) (BlockNumber, error) {
+	for number, tag := range blockTags {
+		if strings.EqualFold(s, tag) {
+			return number, nil
+		}
+	}
+
+	base, digits := 10, s
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		base, digits = 16, s[2:]
+	}
+	n, err := strconv.ParseInt(digits, base, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("Invalid block number %q", s)
+	}
+
+	return BlockNumber(n), nil
+}
+
+// IsTag reports whether the block is a symbolic tag rather than a height
+func (b BlockNumber) IsTag() bool {
+	return b < 0
+}
+
+// Int returns height of the block, tags have no height and return -1
+func (b BlockNumber) Int() int {
+	if b.IsTag() {
+		return -1
+	}
+
+	return int(b)
+}
+
+// String returns tag or 0x-prefixed hex height, as sent to the node
+func (b BlockNumber) String() string {
+	if tag, ok := blockTags[b]; ok {
+		return tag
+	}
+	if b < 0 {
+		return fmt.Sprintf("BlockNumber(%d)", int64(b))
+	}
+
+	return fmt.Sprintf("0x%x", int64(b))
+}
+
+// MarshalJSON implements json.Marshaler
+func (b BlockNumber) MarshalJSON() ([]byte, error) {
+	if b < 0 {
+		if _, ok := blockTags[b]; !ok {
+			return nil, fmt.Errorf("Invalid block number %d", int64(b))
+		}
+	}
+
+	return json.Marshal(b.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting tags, hex strings and JSON numbers
+func (b *BlockNumber) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n int64
+		if json.Unmarshal(data, &n) != nil || n < 0 {
+			return fmt.Errorf("Invalid block number %s", data)
+		}
+		*b = BlockNumber(n)
+		return nil
+	}
+
+	number, err := ParseBlockNumber(s)
+	if err != nil {
+		return err
+	}
+
+	*b = number
+	return nil
+}
@@ -0,0 +1,61 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockNumber(t *testing.T) {
+	for number, expected := range map[BlockNumber]string{
+		Latest():    `"latest"`,
+		Pending():   `"pending"`,
+		Earliest():  `"earliest"`,
+		Finalized(): `"finalized"`,
+		Safe():      `"safe"`,
+		Number(0):   `"0x0"`,
+		Number(255): `"0xff"`,
+	} {
+		data, err := json.Marshal(number)
+		require.Nil(t, err)
+		require.Equal(t, expected, string(data))
+
+		var decoded BlockNumber
+		require.Nil(t, json.Unmarshal(data, &decoded))
+		require.Equal(t, number, decoded)
+	}
+
+	require.True(t, Safe().IsTag())
+	require.Equal(t, -1, Latest().Int())
+	require.Equal(t, 42, Number(42).Int())
+
+	_, err := json.Marshal(BlockNumber(-9))
+	require.Error(t, err)
+	require.Equal(t, "BlockNumber(-9)", BlockNumber(-9).String())
+
+	var decoded BlockNumber
+	require.Nil(t, json.Unmarshal([]byte(`16`), &decoded))
+	require.Equal(t, Number(16), decoded)
+	require.EqualError(t, json.Unmarshal([]byte(`"next"`), &decoded), `Invalid block number "next"`)
+	require.EqualError(t, json.Unmarshal([]byte(`-1`), &decoded), "Invalid block number -1")
+}
+
+func TestParseBlockNumber(t *testing.T) {
+	for input, expected := range map[string]BlockNumber{
+		"latest":  Latest(),
+		"Pending": Pending(),
+		"0x10":    Number(16),
+		"0X10":    Number(16),
+		"10":      Number(10),
+	} {
+		number, err := ParseBlockNumber(input)
+		require.Nil(t, err)
+		require.Equal(t, expected, number)
+	}
+
+	for _, input := range []string{"", "0x", "-1", "12abc", "0xzz"} {
+		_, err := ParseBlockNumber(input)
+		require.Error(t, err, input)
+	}
+}
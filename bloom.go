@@ -0,0 +1,151 @@
+package asimovrpc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BloomLength - size of a logs bloom filter in bytes
+const BloomLength = 256
+
+// Bloom - 2048-bit logs bloom filter of a block or receipt
+type Bloom [BloomLength]byte
+
+// ParseBloom decodes hexadecimal logs bloom
+func ParseBloom(value string) (Bloom, error) {
+	var bloom Bloom
+
+	data, err := HexToBytes(value)
+	if err != nil {
+		return bloom, err
+	}
+	if len(data) != BloomLength {
+		return bloom, fmt.Errorf("invalid bloom length %d", len(data))
+	}
+
+	copy(bloom[:], data)
+	return bloom, nil
+}
+
+func bloomBits(data []byte) [3]uint {
+	hash := Keccak256(data)
+
+	var bits [3]uint
+	for i := range bits {
+		bits[i] = (uint(hash[2*i])<<8 | uint(hash[2*i+1])) & 2047
+	}
+
+	return bits
+}
+
+// Add adds address or topic bytes to the filter
+func (b *Bloom) Add(data []byte) {
+	for _, bit := range bloomBits(data) {
+		b[BloomLength-1-bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// Test returns false if data is definitely not in the filter
+func (b Bloom) Test(data []byte) bool {
+	for _, bit := range bloomBits(data) {
+		if b[BloomLength-1-bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatchFilter returns false if no log matching params can be in the filter
+func (b Bloom) MatchFilter(params FilterParams) bool {
+	if !b.testAny(params.Address) {
+		return false
+	}
+
+	for _, topics := range params.Topics {
+		if !b.testAny(topics) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b Bloom) testAny(values []string) bool {
+	if len(values) == 0 {
+		return true
+	}
+
+	for _, value := range values {
+		data, err := HexToBytes(value)
+		if err != nil || b.Test(data) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchLog returns true if log matches address and topics of params (block range is not checked)
+func MatchLog(log Log, params FilterParams) bool {
+	if len(params.Address) > 0 && !containsFold(params.Address, log.Address) {
+		return false
+	}
+
+	if len(params.Topics) > len(log.Topics) {
+		return false
+	}
+	for i, topics := range params.Topics {
+		if len(topics) > 0 && !containsFold(topics, log.Topics[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AsimovScanLogs returns logs matching params in [fromBlock, toBlock] without flow_getLogs.
+// It walks block headers, skips blocks whose logs bloom cannot match and filters receipts
+// of the remaining blocks locally, reading them with AsimovGetBlockReceipts. Use it with nodes that lack or limit flow_getLogs.
+func (rpc *AsimovRPC) AsimovScanLogs(params FilterParams, fromBlock, toBlock int) ([]Log, error) {
+	logs := []Log{}
+
+	for number := fromBlock; number <= toBlock; number++ {
+		block, err := rpc.AsimovGetBlockByNumber(Number(number), false)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			return nil, fmt.Errorf("block %d not found", number)
+		}
+
+		bloom, err := ParseBloom(block.LogsBloom)
+		if err == nil && !bloom.MatchFilter(params) || len(block.Transactions) == 0 {
+			continue
+		}
+
+		receipts, err := rpc.AsimovGetBlockReceipts(Number(number))
+		if err != nil {
+			return nil, err
+		}
+		for _, receipt := range receipts {
+			for _, log := range receipt.Logs {
+				if MatchLog(log, params) {
+					logs = append(logs, log)
+				}
+			}
+		}
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,116 @@
+package asimovrpc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestKeccak256(t *testing.T) {
+	require.Equal(t, "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470", hex.EncodeToString(Keccak256()))
+	require.Equal(t, hex.EncodeToString(Keccak256([]byte("hello world"))), hex.EncodeToString(Keccak256([]byte("hello"), []byte(" world"))))
+}
+
+func TestBloom(t *testing.T) {
+	var bloom Bloom
+	for _, data := range []string{"testtest", "test", "hallo", "other"} {
+		bloom.Add([]byte(data))
+	}
+
+	for _, data := range []string{"testtest", "test", "hallo", "other"} {
+		require.True(t, bloom.Test([]byte(data)), data)
+	}
+	for _, data := range []string{"tes", "lo"} {
+		require.False(t, bloom.Test([]byte(data)), data)
+	}
+
+	parsed, err := ParseBloom("0x" + hex.EncodeToString(bloom[:]))
+	require.Nil(t, err)
+	require.Equal(t, bloom, parsed)
+
+	_, err = ParseBloom("0x00")
+	require.NotNil(t, err)
+	_, err = ParseBloom("0xzz")
+	require.NotNil(t, err)
+}
+
+func TestBloomMatchFilter(t *testing.T) {
+	address := "0x8d12a197cb00d4747a1fe03395095ce2a5cc6819"
+	topic := "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+	var bloom Bloom
+	data, _ := HexToBytes(address)
+	bloom.Add(data)
+	data, _ = HexToBytes(topic)
+	bloom.Add(data)
+
+	require.True(t, bloom.MatchFilter(FilterParams{}))
+	require.True(t, bloom.MatchFilter(FilterParams{Address: []string{address}}))
+	require.True(t, bloom.MatchFilter(FilterParams{Address: []string{"0x111", address}, Topics: [][]string{{topic}}}))
+	require.True(t, bloom.MatchFilter(FilterParams{Topics: [][]string{nil, nil}}))
+	require.False(t, bloom.MatchFilter(FilterParams{Address: []string{"0x0f1b76410215ed963ea2c3d3eaddd4a56350b422"}}))
+	require.False(t, bloom.MatchFilter(FilterParams{Topics: [][]string{{topic}, {"0x00000000000000000000000000000000000000000000000000000000000000aa"}}}))
+}
+
+func TestMatchLog(t *testing.T) {
+	log := Log{Address: "0xAAA", Topics: []string{"0x01", "0x02"}}
+
+	require.True(t, MatchLog(log, FilterParams{}))
+	require.True(t, MatchLog(log, FilterParams{Address: []string{"0xaaa"}}))
+	require.True(t, MatchLog(log, FilterParams{Topics: [][]string{nil, {"0x03", "0x02"}}}))
+	require.False(t, MatchLog(log, FilterParams{Address: []string{"0xbbb"}}))
+	require.False(t, MatchLog(log, FilterParams{Topics: [][]string{{"0x02"}}}))
+	require.False(t, MatchLog(log, FilterParams{Topics: [][]string{nil, nil, nil}}))
+}
+
+func (s *AsimovRPCTestSuite) TestAsimovScanLogs() {
+	address := "0x8d12a197cb00d4747a1fe03395095ce2a5cc6819"
+	var bloom Bloom
+	data, _ := HexToBytes(address)
+	bloom.Add(data)
+
+	blocks := map[string]string{
+		"0x1": `{"number": "0x1", "logsBloom": "0x` + strings.Repeat("00", BloomLength) + `", "transactions": ["0xa"]}`,
+		"0x2": `{"number": "0x2", "logsBloom": "0x` + hex.EncodeToString(bloom[:]) + `", "transactions": ["0xb", "0xc"]}`,
+	}
+	receipts := map[string]string{
+		"0x2": `[{"transactionHash": "0xb", "logs": [{"address": "` + address + `", "logIndex": "0x0", "blockNumber": "0x2", "transactionHash": "0xb", "topics": []}]},
+			{"transactionHash": "0xc", "logs": [{"address": "0x111", "logIndex": "0x1", "blockNumber": "0x2", "transactionHash": "0xc", "topics": []}]}]`,
+	}
+
+	requested := []string{}
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := s.getBody(request)
+		method := gjson.GetBytes(body, "method").String()
+		param := gjson.GetBytes(body, "params.0").String()
+		requested = append(requested, method+" "+param)
+
+		result, ok := blocks[param]
+		if method == "flow_getBlockReceipts" {
+			result, ok = receipts[param]
+		}
+		if !ok {
+			result = "null"
+		}
+		return httpmock.NewStringResponse(200, echoID(request, fmt.Sprintf(`{"jsonrpc":"2.0", "id":1, "result": %s}`, result))), nil
+	})
+
+	logs, err := s.rpc.AsimovScanLogs(FilterParams{Address: []string{address}}, 1, 2)
+	s.Require().Nil(err)
+	s.Require().Len(logs, 1)
+	s.Require().Equal("0xb", logs[0].TransactionHash)
+	s.Require().Equal([]string{
+		"flow_getBlockByNumber 0x1",
+		"flow_getBlockByNumber 0x2",
+		"flow_getBlockReceipts 0x2",
+	}, requested)
+
+	_, err = s.rpc.AsimovScanLogs(FilterParams{}, 3, 3)
+	s.Require().EqualError(err, "block 3 not found")
+}
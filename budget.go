@@ -0,0 +1,242 @@
+package asimovrpc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Budget - limit of calls or provider credits spent per time window
+type Budget struct {
+	Window     time.Duration
+	MaxCalls   int         // 0 - unlimited
+	MaxCredits float64     // 0 - unlimited
+	Weights    CostWeights // credits per method, see CostReport
+	Block      bool        // wait for the next window instead of rejecting calls
+	Warnings   []float64   // fractions of budget (e.g. 0.8) logged once per window when reached
+}
+
+// BudgetExceededError - call rejected because budget of current window is spent
+type BudgetExceededError struct {
+	Method string
+	Reset  time.Time
+}
+
+func (err BudgetExceededError) Error() string {
+	return fmt.Sprintf("Budget exceeded calling %s, resets at %s", err.Method, err.Reset.Format(time.RFC3339))
+}
+
+// BudgetUsage - budget spent in current window
+type BudgetUsage struct {
+	Calls   int
+	Credits float64
+	Reset   time.Time
+}
+
+type budget struct {
+	Budget
+
+	mu      sync.Mutex
+	clock   Clock
+	start   time.Time
+	calls   int
+	credits float64
+	warned  int
+	waiting map[Priority]int
+}
+
+func newBudget(b Budget) *budget {
+	warnings := append([]float64(nil), b.Warnings...)
+	sort.Float64s(warnings)
+	b.Warnings = warnings
+
+	return &budget{Budget: b, clock: SystemClock, waiting: map[Priority]int{}}
+}
+
+// reloadBudget returns budget with limits of b replacing current, which keeps serving calls if the limits are unchanged.
+// The replacement keeps weights and usage of the current window, warnings already logged in it are not repeated.
+func reloadBudget(current *budget, b Budget, clock Clock) *budget {
+	if current != nil {
+		b.Weights = current.Weights
+	}
+	next := newBudget(b)
+	next.clock = clock
+	if current == nil {
+		return next
+	}
+	if reflect.DeepEqual(current.Budget, next.Budget) {
+		return current
+	}
+
+	current.mu.Lock()
+	next.start, next.calls, next.credits = current.start, current.calls, current.credits
+	current.mu.Unlock()
+	used := next.used()
+	for next.warned < len(next.Warnings) && used >= next.Warnings[next.warned] {
+		next.warned++
+	}
+
+	return next
+}
+
+func (b *budget) validate() string {
+	switch {
+	case b.Window <= 0:
+		return "window must be positive"
+	case b.MaxCalls < 0 || b.MaxCredits < 0:
+		return "limits must not be negative"
+	case b.MaxCalls == 0 && b.MaxCredits == 0:
+		return "either max calls or max credits required"
+	}
+
+	return ""
+}
+
+func (b *budget) reset(now time.Time) {
+	if now.Sub(b.start) >= b.Window {
+		b.start = now
+		b.calls = 0
+		b.credits = 0
+		b.warned = 0
+	}
+}
+
+func (b *budget) fits(weight float64) bool {
+	if b.MaxCalls > 0 && b.calls+1 > b.MaxCalls {
+		return false
+	}
+
+	return b.MaxCredits == 0 || b.credits+weight <= b.MaxCredits
+}
+
+func (b *budget) used() float64 {
+	used := 0.0
+	if b.MaxCalls > 0 {
+		used = float64(b.calls) / float64(b.MaxCalls)
+	}
+	if b.MaxCredits > 0 && b.credits/b.MaxCredits > used {
+		used = b.credits / b.MaxCredits
+	}
+
+	return used
+}
+
+// preempted returns true if a blocked call with higher priority is waiting for budget
+func (b *budget) preempted(priority Priority) bool {
+	for waiting, count := range b.waiting {
+		if waiting > priority && count > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// take reserves budget for a single call, returning thresholds crossed by it.
+// Blocked calls are served by priority once the window resets.
+func (b *budget) take(ctx context.Context, method string, priority Priority) ([]float64, error) {
+	weight := b.Weights.Weight(method)
+	if b.MaxCredits > 0 && weight > b.MaxCredits {
+		return nil, fmt.Errorf("Call of %s costs %g credits, more than the whole budget of %g", method, weight, b.MaxCredits)
+	}
+
+	waiting := false
+	for {
+		b.mu.Lock()
+		now := b.clock.Now()
+		b.reset(now)
+
+		if !b.preempted(priority) && b.fits(weight) {
+			if waiting {
+				b.waiting[priority]--
+			}
+			b.calls++
+			b.credits += weight
+
+			var crossed []float64
+			used := b.used()
+			for b.warned < len(b.Warnings) && used >= b.Warnings[b.warned] {
+				crossed = append(crossed, b.Warnings[b.warned])
+				b.warned++
+			}
+			b.mu.Unlock()
+
+			return crossed, nil
+		}
+
+		reset := b.start.Add(b.Window)
+		if !b.Block {
+			b.mu.Unlock()
+			return nil, BudgetExceededError{Method: method, Reset: reset}
+		}
+		if !waiting {
+			waiting = true
+			b.waiting[priority]++
+		}
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			b.mu.Lock()
+			b.waiting[priority]--
+			b.mu.Unlock()
+			return nil, ctx.Err()
+		case <-b.clock.After(reset.Sub(now)):
+		}
+	}
+}
+
+func (b *budget) usage() BudgetUsage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reset(b.clock.Now())
+
+	return BudgetUsage{Calls: b.calls, Credits: b.credits, Reset: b.start.Add(b.Window)}
+}
+
+// WithBudget limit calls or credits spent per time window
+func WithBudget(b Budget) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		rpc.budget = newBudget(b)
+		if message := rpc.budget.validate(); message != "" {
+			rpc.invalidOption("WithBudget", message)
+		}
+	}
+}
+
+// BudgetUsage returns budget spent in current window (false if no budget is configured)
+func (rpc *AsimovRPC) BudgetUsage() (BudgetUsage, bool) {
+	b := rpc.currentBudget()
+	if b == nil {
+		return BudgetUsage{}, false
+	}
+
+	return b.usage(), true
+}
+
+// currentBudget returns budget of the client, which UpdateConfig may replace
+func (rpc *AsimovRPC) currentBudget() *budget {
+	rpc.mu.RLock()
+	defer rpc.mu.RUnlock()
+
+	return rpc.budget
+}
+
+func (rpc *AsimovRPC) spend(ctx context.Context, method string) error {
+	b := rpc.currentBudget()
+	if b == nil {
+		return nil
+	}
+
+	crossed, err := b.take(ctx, method, rpc.priority)
+	for _, threshold := range crossed {
+		usage := b.usage()
+		rpc.warn(fmt.Sprintf("Budget warning: %g%% used (calls %d, credits %g, resets at %s)",
+			threshold*100, usage.Calls, usage.Credits, usage.Reset.Format(time.RFC3339)))
+	}
+
+	return err
+}
@@ -0,0 +1,113 @@
+package asimovrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetTake(t *testing.T) {
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	b := newBudget(Budget{
+		Window:     time.Minute,
+		MaxCredits: 10,
+		Weights:    CostWeights{Default: 1, Methods: map[string]float64{"flow_getLogs": 5}},
+		Warnings:   []float64{0.9, 0.5},
+	})
+	clock := asimovrpctest.NewFakeClock(now)
+	b.clock = clock
+
+	crossed, err := b.take(context.Background(), "flow_getLogs", PriorityNormal)
+	require.Nil(t, err)
+	require.Equal(t, []float64{0.5}, crossed)
+
+	for i := 0; i < 4; i++ {
+		crossed, err = b.take(context.Background(), "flow_blockNumber", PriorityNormal)
+		require.Nil(t, err)
+	}
+	require.Equal(t, []float64{0.9}, crossed)
+
+	_, err = b.take(context.Background(), "flow_getLogs", PriorityNormal)
+	require.Equal(t, BudgetExceededError{Method: "flow_getLogs", Reset: now.Add(time.Minute)}, err)
+
+	_, err = b.take(context.Background(), "flow_blockNumber", PriorityNormal)
+	require.Nil(t, err)
+	require.Equal(t, BudgetUsage{Calls: 6, Credits: 10, Reset: now.Add(time.Minute)}, b.usage())
+
+	clock.Advance(time.Minute)
+	now = now.Add(time.Minute)
+	_, err = b.take(context.Background(), "flow_getLogs", PriorityNormal)
+	require.Nil(t, err)
+	require.Equal(t, BudgetUsage{Calls: 1, Credits: 5, Reset: now.Add(time.Minute)}, b.usage())
+}
+
+func TestBudgetBlock(t *testing.T) {
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	b := newBudget(Budget{Window: time.Minute, MaxCalls: 1, Block: true})
+	clock := asimovrpctest.NewFakeClock(now)
+	b.clock = clock
+
+	_, err := b.take(context.Background(), "flow_blockNumber", PriorityNormal)
+	require.Nil(t, err)
+	clock.Advance(20 * time.Second)
+
+	done := make(chan error)
+	go func() {
+		_, err := b.take(context.Background(), "flow_blockNumber", PriorityNormal)
+		done <- err
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(39 * time.Second)
+	select {
+	case <-done:
+		t.Fatal("call was not blocked until the window reset")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.Advance(time.Second)
+	require.Nil(t, <-done)
+	require.Equal(t, now.Add(2*time.Minute), b.usage().Reset)
+}
+
+func TestBudgetInvalid(t *testing.T) {
+	_, err := NewClient("http://127.0.0.1:8545", WithBudget(Budget{MaxCalls: 1}))
+	require.EqualError(t, err, "asimovrpc: invalid option WithBudget: window must be positive")
+
+	_, err = NewClient("http://127.0.0.1:8545", WithBudget(Budget{Window: time.Second}))
+	require.EqualError(t, err, "asimovrpc: invalid option WithBudget: either max calls or max credits required")
+
+	_, err = NewClient("http://127.0.0.1:8545", WithLogger(nil), WithBudget(Budget{Window: time.Second, MaxCalls: 1, Warnings: []float64{0.5}}))
+	require.EqualError(t, err, "asimovrpc: invalid option WithLogger: logger is nil but budget warnings are enabled")
+
+	b := newBudget(Budget{Window: time.Second, MaxCredits: 1, Weights: CostWeights{Default: 2}})
+	_, err = b.take(context.Background(), "flow_getLogs", PriorityNormal)
+	require.EqualError(t, err, "Call of flow_getLogs costs 2 credits, more than the whole budget of 1")
+}
+
+func (s *AsimovRPCTestSuite) TestBudget() {
+	log := new(bufferLogger)
+	rpc := New(s.rpc.url, WithLogger(log), WithBudget(Budget{Window: time.Hour, MaxCalls: 2, Warnings: []float64{0.5}}))
+
+	calls := 0
+	s.registerResponse(`"0x1"`, func([]byte) { calls++ })
+	for i := 0; i < 2; i++ {
+		_, err := rpc.AsimovBlockNumber()
+		s.Require().Nil(err)
+	}
+	_, err := rpc.AsimovBlockNumber()
+	s.Require().IsType(BudgetExceededError{}, err)
+	s.Require().Equal(2, calls)
+
+	s.Require().Len(log.lines, 1)
+	s.Require().Contains(log.lines[0], "Budget warning: 50% used (calls 1, credits 0")
+
+	usage, ok := rpc.BudgetUsage()
+	s.Require().True(ok)
+	s.Require().Equal(2, usage.Calls)
+	_, ok = s.rpc.BudgetUsage()
+	s.Require().False(ok)
+}
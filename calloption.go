@@ -0,0 +1,97 @@
+package asimovrpc
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// CallOption - overrides client defaults for a single call, passed among params of Call, CallContext or RawCall:
+//
+//	result, err := client.Call("flow_getLogs", params, asimovrpc.WithCallTimeout(time.Minute))
+//
+// Options are removed from params before interceptors and the node see them.
+type CallOption func(options *callOptions)
+
+type callOptions struct {
+	timeout time.Duration
+	headers [][2]string
+	id      int
+}
+
+type callOptionsKey struct{}
+
+// WithCallTimeout set timeout of the request, replacing the client timeout set with WithTimeout
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(options *callOptions) {
+		options.timeout = timeout
+	}
+}
+
+// WithCallHeader add HTTP header to the request, see WithHeader
+func WithCallHeader(name, value string) CallOption {
+	return func(options *callOptions) {
+		options.headers = append(options.headers, [2]string{name, value})
+	}
+}
+
+// WithCallID set JSON-RPC id of the request, by default the next id of the client
+func WithCallID(id int) CallOption {
+	return func(options *callOptions) {
+		options.id = id
+	}
+}
+
+// withCallOptions moves call options out of params into ctx
+func withCallOptions(ctx context.Context, params []interface{}) (context.Context, []interface{}) {
+	var options *callOptions
+	var rest []interface{}
+	for i, param := range params {
+		option, ok := param.(CallOption)
+		if !ok {
+			if options != nil {
+				rest = append(rest, param)
+			}
+			continue
+		}
+		if options == nil {
+			options = new(callOptions)
+			*options = callOptionsFromContext(ctx)
+			rest = append(make([]interface{}, 0, len(params)), params[:i]...)
+		}
+		option(options)
+	}
+	if options == nil {
+		return ctx, params
+	}
+	if len(rest) == 0 {
+		rest = nil
+	}
+
+	for _, header := range options.headers {
+		ctx = WithHeader(ctx, header[0], header[1])
+	}
+	options.headers = nil
+
+	return context.WithValue(ctx, callOptionsKey{}, *options), rest
+}
+
+func callOptionsFromContext(ctx context.Context) callOptions {
+	options, _ := ctx.Value(callOptionsKey{}).(callOptions)
+	return options
+}
+
+// newRequest builds request of method call with the next id of the client, applying call options of ctx
+func (rpc *AsimovRPC) newRequest(ctx context.Context, method string, params []interface{}) asimovRequest {
+	request := asimovRequest{
+		ID:      callOptionsFromContext(ctx).id,
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	}
+	if request.ID == 0 {
+		request.ID = int(atomic.AddInt64(rpc.requestID, 1))
+	}
+
+	return request
+}
@@ -0,0 +1,69 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func (s *AsimovRPCTestSuite) TestCallOptions() {
+	var params []interface{}
+	record := func(next CallFunc) CallFunc {
+		return func(ctx context.Context, method string, p []interface{}) (json.RawMessage, error) {
+			params = p
+			return next(ctx, method, p)
+		}
+	}
+	rpc := New(s.rpc.url, WithInterceptor(record))
+
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := s.getBody(request)
+		s.Require().Equal(int64(7), gjson.GetBytes(body, "id").Int())
+		s.paramsEqual(body, `["0x111", "latest"]`)
+		s.Require().Equal([]string{"a", "b"}, request.Header.Values("X-Tenant"))
+		return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":7, "result": "0x10"}`), nil
+	})
+
+	result, err := rpc.Call("flow_getBalance", "0x111", WithCallID(7), WithCallHeader("X-Tenant", "a"), "latest", WithCallHeader("X-Tenant", "b"))
+	s.Require().Nil(err)
+	s.Require().Equal(`"0x10"`, string(result))
+	s.Require().Equal([]interface{}{"0x111", "latest"}, params)
+
+	s.registerResponse(`"0x1"`, func(body []byte) {
+		s.Require().Equal(int64(1), gjson.GetBytes(body, "id").Int())
+		s.paramsEqual(body, "null")
+	})
+	_, err = rpc.Call("flow_blockNumber", WithCallHeader("X-Tenant", "a"))
+	s.Require().Nil(err)
+	s.Require().Nil(params)
+}
+
+func TestCallTimeout(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	node.Handle("flow_getLogs", []interface{}{})
+	node.SetLatency("flow_getLogs", 100*time.Millisecond)
+	rpc := New(node.URL, WithTimeout(20*time.Millisecond))
+
+	_, err := rpc.Call("flow_getLogs", FilterParams{})
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	result, err := rpc.Call("flow_getLogs", FilterParams{}, WithCallTimeout(time.Second))
+	require.Nil(t, err)
+	require.Equal(t, "[]", string(result))
+
+	node.SetLatency("flow_getLogs", time.Second)
+	_, err = New(node.URL).CallContext(context.Background(), "flow_getLogs", FilterParams{}, WithCallTimeout(20*time.Millisecond))
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+}
@@ -0,0 +1,125 @@
+package asimovrpc
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Upgrades consulted when decoding responses and suggesting fees. List them in Network.Upgrades with
+// their activation heights; upgrades a network does not list are assumed active.
+const (
+	// UpgradeReceiptStatus - receipts report status instead of the intermediate state root
+	UpgradeReceiptStatus = "receipt-status"
+	// UpgradeTypedTransactions - transactions may have types other than TxLegacy
+	UpgradeTypedTransactions = "typed-tx"
+	// UpgradeFeeMarket - blocks have a base fee, flow_feeHistory is served and receipts report the effective gas price
+	UpgradeFeeMarket = "fee-market"
+)
+
+// ChainConfig - protocol upgrades of a network, used to select decoding and gas rules by block height
+type ChainConfig struct {
+	upgrades []Upgrade
+}
+
+// NewChainConfig creates chain config from upgrades in any order
+func NewChainConfig(upgrades ...Upgrade) (ChainConfig, error) {
+	sorted := make([]Upgrade, len(upgrades))
+	copy(sorted, upgrades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Height < sorted[j].Height
+	})
+
+	seen := make(map[string]bool, len(sorted))
+	for _, upgrade := range sorted {
+		if upgrade.Name == "" {
+			return ChainConfig{}, fmt.Errorf("upgrade at height %d has no name", upgrade.Height)
+		}
+		if upgrade.Height < 0 {
+			return ChainConfig{}, fmt.Errorf("upgrade %s has negative height", upgrade.Name)
+		}
+		if seen[upgrade.Name] {
+			return ChainConfig{}, fmt.Errorf("upgrade %s is defined twice", upgrade.Name)
+		}
+		seen[upgrade.Name] = true
+	}
+
+	return ChainConfig{upgrades: sorted}, nil
+}
+
+// Upgrades returns upgrades ordered by activation height
+func (c ChainConfig) Upgrades() []Upgrade {
+	return append([]Upgrade(nil), c.upgrades...)
+}
+
+// defines returns true if the chain config lists upgrade
+func (c ChainConfig) defines(upgrade string) bool {
+	for _, u := range c.upgrades {
+		if u.Name == upgrade {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Rules returns protocol rules in effect at block height
+func (c ChainConfig) Rules(height int) Rules {
+	rules := Rules{Height: height, active: make(map[string]bool, len(c.upgrades)), known: make(map[string]bool, len(c.upgrades))}
+	for _, upgrade := range c.upgrades {
+		rules.known[upgrade.Name] = true
+		if upgrade.Height <= height {
+			rules.active[upgrade.Name] = true
+		}
+	}
+
+	return rules
+}
+
+// Rules - protocol upgrades active at block height
+type Rules struct {
+	Height int
+	active map[string]bool
+	known  map[string]bool
+}
+
+// Active returns true if upgrade is active at rules height.
+// Upgrades unknown to the chain config are assumed active, so clients without
+// network configuration decode everything with the latest rules.
+func (r Rules) Active(upgrade string) bool {
+	if !r.known[upgrade] {
+		return true
+	}
+
+	return r.active[upgrade]
+}
+
+// applyTransaction makes transaction of a block at rules height legacy before UpgradeTypedTransactions,
+// ignoring the type some nodes report for such transactions
+func (r Rules) applyTransaction(transaction *Transaction) {
+	if !r.Active(UpgradeTypedTransactions) {
+		transaction.Type = TxLegacy
+	}
+}
+
+// applyReceipt drops fields of receipt of a block at rules height which the protocol did not define yet,
+// so status and fees of old receipts are not read from values back-filled by the node: Status before
+// UpgradeReceiptStatus, and EffectiveGasPrice before UpgradeFeeMarket, when the fee is priced with the
+// transaction gas price (see TransactionReceipt.FeeAt).
+func (r Rules) applyReceipt(receipt *TransactionReceipt) {
+	if !r.Active(UpgradeReceiptStatus) {
+		receipt.Status = ""
+	}
+	if !r.Active(UpgradeFeeMarket) {
+		receipt.EffectiveGasPrice = nil
+	}
+}
+
+// ChainConfig returns chain config of configured network (empty if no network is configured)
+func (rpc *AsimovRPC) ChainConfig() ChainConfig {
+	return rpc.chainConfig
+}
+
+// Rules returns protocol rules in effect at block height for configured network
+func (rpc *AsimovRPC) Rules(height int) Rules {
+	return rpc.chainConfig.Rules(height)
+}
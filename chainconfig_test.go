@@ -0,0 +1,102 @@
+package asimovrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func TestChainConfigRules(t *testing.T) {
+	config, err := NewChainConfig(Upgrade{Name: "typed-tx", Height: 200}, Upgrade{Name: "receipt-status", Height: 100})
+	require.Nil(t, err)
+	require.Equal(t, []Upgrade{{Name: "receipt-status", Height: 100}, {Name: "typed-tx", Height: 200}}, config.Upgrades())
+
+	rules := config.Rules(99)
+	require.False(t, rules.Active("receipt-status"))
+	require.False(t, rules.Active("typed-tx"))
+
+	rules = config.Rules(150)
+	require.True(t, rules.Active("receipt-status"))
+	require.False(t, rules.Active("typed-tx"))
+
+	rules = config.Rules(200)
+	require.True(t, rules.Active("typed-tx"))
+	require.True(t, rules.Active("unknown"))
+}
+
+func TestChainConfigInvalid(t *testing.T) {
+	_, err := NewChainConfig(Upgrade{Height: 1})
+	require.EqualError(t, err, "upgrade at height 1 has no name")
+
+	_, err = NewChainConfig(Upgrade{Name: "a", Height: -1})
+	require.EqualError(t, err, "upgrade a has negative height")
+
+	_, err = NewChainConfig(Upgrade{Name: "a", Height: 1}, Upgrade{Name: "a", Height: 2})
+	require.EqualError(t, err, "upgrade a is defined twice")
+
+	err = RegisterNetwork(Network{Name: "broken", GenesisHash: "0x1", Upgrades: []Upgrade{{Height: 1}}})
+	require.EqualError(t, err, "network broken: upgrade at height 1 has no name")
+}
+
+func TestRulesFromNetwork(t *testing.T) {
+	rpc := NewAsimovRPC("http://127.0.0.1:8545", WithNetwork(Network{
+		Name:        "devnet",
+		GenesisHash: "0xabc",
+		Upgrades:    []Upgrade{{Name: "typed-tx", Height: 10}},
+	}))
+	require.False(t, rpc.Rules(9).Active("typed-tx"))
+	require.True(t, rpc.Rules(10).Active("typed-tx"))
+
+	rpc = NewAsimovRPC("http://127.0.0.1:8545")
+	require.True(t, rpc.Rules(0).Active("typed-tx"))
+}
+
+func TestRulesDecoding(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_getTransactionReceipt", map[string]interface{}{
+		"blockHash": "0xb1", "blockNumber": "0x5", "gasUsed": "0x2", "status": "0x1", "effectiveGasPrice": "0x3",
+	})
+	node.Handle("flow_getTransactionByHash", map[string]interface{}{"hash": "0xa1", "blockNumber": "0x5", "gasPrice": "0x7", "type": "0x2"})
+	node.Handle("flow_blockNumber", "0x5")
+	node.Handle("flow_getBlockByNumber", map[string]interface{}{"number": "0x5", "transactions": []interface{}{}})
+	node.Handle("flow_gasPrice", "0x9")
+
+	rpc := New(node.URL, WithNetwork(Network{
+		Name:        "devnet",
+		GenesisHash: "0xabc",
+		Upgrades: []Upgrade{
+			{Name: UpgradeReceiptStatus, Height: 5},
+			{Name: UpgradeTypedTransactions, Height: 10},
+			{Name: UpgradeFeeMarket, Height: 10},
+		},
+	}))
+
+	receipt, err := rpc.AsimovGetTransactionReceipt("0xa1")
+	require.Nil(t, err)
+	require.Equal(t, "0x1", receipt.Status)
+	require.Nil(t, receipt.EffectiveGasPrice)
+
+	transaction, err := rpc.AsimovGetTransactionByHash("0xa1")
+	require.Nil(t, err)
+	require.Equal(t, TxLegacy, transaction.Type)
+
+	// receipts before the fee market are priced with the transaction gas price
+	fee, err := rpc.TransactionFee("0xa1")
+	require.Nil(t, err)
+	require.Equal(t, "14", fee.Value.String())
+
+	// fees before the fee market are suggested from gas prices
+	suggestion, err := rpc.SuggestFees(context.Background(), FeeStandard)
+	require.Nil(t, err)
+	require.Equal(t, "9", suggestion.GasPrice.String())
+	require.Empty(t, node.Calls("flow_feeHistory"))
+
+	// without network configuration everything is decoded with the latest rules
+	receipt, err = New(node.URL).AsimovGetTransactionReceipt("0xa1")
+	require.Nil(t, err)
+	require.Equal(t, "3", receipt.EffectiveGasPrice.String())
+}
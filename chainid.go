@@ -0,0 +1,74 @@
+package asimovrpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+)
+
+type chainIDCache struct {
+	mu sync.Mutex
+	id *big.Int
+}
+
+// WithChainIDCache fetches chain ID with flow_chainId when the client is created and caches it for
+// AsimovChainID and signing. The fetch is bounded by WithTimeout, or DefaultChainIDPrefetchTimeout
+// without it; if the node cannot be reached then, the chain ID is fetched on first use.
+func WithChainIDCache(enabled bool) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		rpc.chainID = nil
+		if enabled {
+			rpc.chainID = new(chainIDCache)
+		}
+	}
+}
+
+// DefaultChainIDPrefetchTimeout - bound of fetching chain ID when the client is created, unless WithTimeout is set
+const DefaultChainIDPrefetchTimeout = 5 * time.Second
+
+func (c *chainIDCache) prefetch(rpc *AsimovRPC) {
+	timeout := rpc.timeout
+	if timeout <= 0 {
+		timeout = DefaultChainIDPrefetchTimeout
+	}
+	ctx, cancel := context.WithTimeout(rpc.context(), timeout)
+	defer cancel()
+
+	if _, err := c.get(rpc.WithContext(ctx)); err != nil {
+		rpc.warn(fmt.Sprintf("Chain ID is not cached, fetching on first use: %v", err))
+	}
+}
+
+func (c *chainIDCache) get(rpc *AsimovRPC) (*big.Int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.id == nil {
+		id, err := rpc.fetchChainID()
+		if err != nil {
+			return nil, err
+		}
+		c.id = id
+	}
+
+	return new(big.Int).Set(c.id), nil
+}
+
+func (rpc *AsimovRPC) fetchChainID() (*big.Int, error) {
+	var response string
+	if err := rpc.call("flow_chainId", &response); err != nil {
+		return nil, err
+	}
+
+	id, err := ParseBigInt(response)
+	if err != nil {
+		return nil, err
+	}
+	if id.Sign() <= 0 {
+		return nil, ErrChainIDUnknown
+	}
+
+	return &id, nil
+}
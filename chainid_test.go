@@ -0,0 +1,102 @@
+package asimovrpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func (s *AsimovRPCTestSuite) TestAsimovChainID() {
+	s.registerResponseError(errors.New("Error"))
+	_, err := s.rpc.AsimovChainID()
+	s.Require().NotNil(err)
+
+	s.registerResponse(`"0x2a"`, func(body []byte) {
+		s.methodEqual(body, "flow_chainId")
+		s.paramsEqual(body, "null")
+	})
+	chainID, err := s.rpc.AsimovChainID()
+	s.Require().Nil(err)
+	s.Require().Equal(big.NewInt(42), chainID)
+
+	chainID, err = s.rpc.Flow().ChainID()
+	s.Require().Nil(err)
+	s.Require().Equal(int64(42), chainID.Int64())
+
+	s.registerResponse(`"0x0"`, func([]byte) {})
+	_, err = s.rpc.AsimovChainID()
+	s.Require().Equal(ErrChainIDUnknown, err)
+}
+
+func TestChainIDCache(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	node.Handle("flow_chainId", "0x2a")
+	rpc := New(node.URL, WithChainIDCache(true))
+	require.Len(t, node.Calls("flow_chainId"), 1)
+
+	for i := 0; i < 3; i++ {
+		chainID, err := rpc.AsimovChainID()
+		require.Nil(t, err)
+		require.Equal(t, int64(42), chainID.Int64())
+		chainID.SetInt64(1) // the cached value is not shared
+	}
+	id, err := rpc.WithContext(context.Background()).ChainID()
+	require.Nil(t, err)
+	require.Equal(t, int64(42), id)
+	require.Len(t, node.Calls("flow_chainId"), 1)
+	require.Empty(t, node.Calls("net_version"))
+}
+
+func TestChainIDCacheUnreachable(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	var logs bytes.Buffer
+	node.HandleError("flow_chainId", -32000, "not ready")
+	rpc := New(node.URL, WithChainIDCache(true), WithLogger(log.New(&logs, "", 0)))
+	require.Contains(t, logs.String(), "Chain ID is not cached, fetching on first use: Error -32000 (not ready)")
+
+	node.Handle("flow_chainId", "0x2a")
+	id, err := rpc.ChainID()
+	require.Nil(t, err)
+	require.Equal(t, int64(42), id)
+	_, err = rpc.ChainID()
+	require.Nil(t, err)
+	require.Len(t, node.Calls("flow_chainId"), 2)
+}
+
+func TestChainIDCachePrefetch(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_chainId", "0x2a")
+
+	// invalid options are reported without calling the node
+	_, err := NewClient(node.URL, WithChainIDCache(true), WithTimeout(-time.Second))
+	require.NotNil(t, err)
+	require.Empty(t, node.Calls("flow_chainId"))
+
+	release := make(chan struct{})
+	hanging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer hanging.Close()
+	defer close(release)
+
+	var logs bytes.Buffer
+	start := time.Now()
+	New(hanging.URL, WithChainIDCache(true), WithTimeout(50*time.Millisecond), WithLogger(log.New(&logs, "", 0)))
+	require.True(t, time.Since(start) < time.Second)
+	require.Contains(t, logs.String(), "Chain ID is not cached")
+}
@@ -0,0 +1,132 @@
+package asimovrpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// TotalSupplySelector - selector of totalSupply() read from asset issuing contracts
+const TotalSupplySelector = "0x18160ddd"
+
+// ChainStats - activity of a range of blocks
+type ChainStats struct {
+	FromBlock     int
+	ToBlock       int
+	Blocks        int
+	Transactions  int
+	GasUsed       int
+	Duration      time.Duration // between timestamps of the first and the last block
+	BlockInterval time.Duration // average time between blocks
+}
+
+// TPS returns average number of transactions per second
+func (s ChainStats) TPS() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+
+	return float64(s.Transactions) / s.Duration.Seconds()
+}
+
+// ChainStats returns stats of the last blocks up to the latest block
+func (rpc *AsimovRPC) ChainStats(ctx context.Context, blocks int) (*ChainStats, error) {
+	if blocks <= 0 {
+		return nil, fmt.Errorf("Number of blocks must be positive, got %d", blocks)
+	}
+
+	head, err := rpc.WithContext(ctx).AsimovBlockNumber()
+	if err != nil {
+		return nil, err
+	}
+	from := head - blocks + 1
+	if from < 0 {
+		from = 0
+	}
+
+	return rpc.ChainStatsRange(ctx, from, head)
+}
+
+// ChainStatsRange returns stats of blocks from fromBlock to toBlock inclusive, fetched in batches
+func (rpc *AsimovRPC) ChainStatsRange(ctx context.Context, fromBlock, toBlock int) (*ChainStats, error) {
+	if fromBlock < 0 || toBlock < fromBlock {
+		return nil, fmt.Errorf("Invalid block range %d-%d", fromBlock, toBlock)
+	}
+
+	blocks := make([]*proxyBlockWithoutTransactions, toBlock-fromBlock+1)
+	batch := rpc.NewBatch()
+	for i := range blocks {
+		batch.Add("flow_getBlockByNumber", &blocks[i], IntToHex(fromBlock+i), false)
+	}
+	if err := batch.ExecuteContext(ctx); err != nil {
+		return nil, err
+	}
+
+	stats := &ChainStats{FromBlock: fromBlock, ToBlock: toBlock, Blocks: len(blocks)}
+	for i, block := range blocks {
+		if err := batch.Err(i); err != nil {
+			return nil, err
+		}
+		if block == nil {
+			return nil, fmt.Errorf("Block %d not found", fromBlock+i)
+		}
+		stats.Transactions += len(block.Transactions)
+		stats.GasUsed += int(block.GasUsed)
+	}
+
+	first, last := blocks[0], blocks[len(blocks)-1]
+	stats.Duration = time.Duration(last.Timestamp-first.Timestamp) * time.Second
+	if len(blocks) > 1 {
+		stats.BlockInterval = stats.Duration / time.Duration(len(blocks)-1)
+	}
+
+	return stats, nil
+}
+
+// TotalSupply reads totalSupply() of asset issuing contract at block
+func (rpc *AsimovRPC) TotalSupply(ctx context.Context, contract string, block BlockNumber) (big.Int, error) {
+	supply, err := rpc.Supply(ctx, map[string]string{"": contract}, block)
+	if err != nil {
+		return big.Int{}, err
+	}
+
+	return supply[0].Value, nil
+}
+
+// Supply reads total supply of assets at block, contracts maps asset to its issuing contract.
+// Amounts are sorted by asset.
+func (rpc *AsimovRPC) Supply(ctx context.Context, contracts map[string]string, block BlockNumber) ([]Amount, error) {
+	assets := make([]string, 0, len(contracts))
+	for asset := range contracts {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+
+	results := make([]string, len(assets))
+	batch := rpc.NewBatch()
+	for i, asset := range assets {
+		batch.Add("flow_call", &results[i], T{To: contracts[asset], Data: TotalSupplySelector}, block)
+	}
+	if err := batch.ExecuteContext(ctx); err != nil {
+		return nil, err
+	}
+
+	supply := make([]Amount, len(assets))
+	for i, asset := range assets {
+		if err := batch.Err(i); err != nil {
+			return nil, err
+		}
+		if len(results[i]) <= 2 {
+			return nil, fmt.Errorf("Contract %s returned no total supply", contracts[asset])
+		}
+		value, err := ParseBigInt(results[i])
+		if err != nil {
+			return nil, fmt.Errorf("Contract %s returned invalid total supply %s: %v", contracts[asset], results[i], err)
+		}
+		supply[i] = Amount{Asset: asset, Value: value}
+	}
+
+	return supply, nil
+}
@@ -0,0 +1,109 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func TestChainStats(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	node.Handle("flow_blockNumber", "0xc")
+	node.HandleFunc("flow_getBlockByNumber", func(params []json.RawMessage) (interface{}, error) {
+		var number string
+		json.Unmarshal(params[0], &number)
+		n, _ := ParseInt(number)
+		if n > 12 {
+			return nil, nil
+		}
+		transactions := make([]string, n%3)
+		for i := range transactions {
+			transactions[i] = fmt.Sprintf("0x%x%x", n, i)
+		}
+		return map[string]interface{}{
+			"number":       number,
+			"gasUsed":      IntToHex(21000 * len(transactions)),
+			"timestamp":    IntToHex(1000 + 5*n),
+			"transactions": transactions,
+		}, nil
+	})
+	rpc := New(node.URL, WithBatchSize(2))
+
+	stats, err := rpc.ChainStats(context.Background(), 4)
+	require.Nil(t, err)
+	// blocks 9-12 hold 0, 1, 2 and 0 transactions
+	require.Equal(t, ChainStats{
+		FromBlock:     9,
+		ToBlock:       12,
+		Blocks:        4,
+		Transactions:  3,
+		GasUsed:       63000,
+		Duration:      15 * time.Second,
+		BlockInterval: 5 * time.Second,
+	}, *stats)
+	require.Equal(t, 0.2, stats.TPS())
+	require.Len(t, node.Calls("flow_getBlockByNumber"), 4)
+
+	stats, err = rpc.ChainStats(context.Background(), 100)
+	require.Nil(t, err)
+	require.Equal(t, 0, stats.FromBlock)
+	require.Equal(t, 13, stats.Blocks)
+
+	_, err = rpc.ChainStatsRange(context.Background(), 12, 13)
+	require.EqualError(t, err, "Block 13 not found")
+
+	_, err = rpc.ChainStatsRange(context.Background(), 5, 4)
+	require.EqualError(t, err, "Invalid block range 5-4")
+
+	_, err = rpc.ChainStats(context.Background(), 0)
+	require.EqualError(t, err, "Number of blocks must be positive, got 0")
+}
+
+func TestSupply(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	supplies := map[string]string{
+		"0x631f62ca646771cd0c78e80e4eaf1d2ddf8fe414bf": "0x00000000000000000000000000000000000000000000000000000000000003e8",
+		"0x63b1a3d3a4e7f9a2e2f86b2b2d0a8b4c2a2f25e2e1": "0x0000000000000000000000000000000000000000000000000de0b6b3a7640000",
+	}
+	node.HandleFunc("flow_call", func(params []json.RawMessage) (interface{}, error) {
+		var call struct {
+			To   string `json:"to"`
+			Data string `json:"data"`
+		}
+		json.Unmarshal(params[0], &call)
+		if call.Data != TotalSupplySelector {
+			return nil, asimovrpctest.Error{Code: 3, Message: "execution reverted"}
+		}
+		if supply, ok := supplies[call.To]; ok {
+			return supply, nil
+		}
+		return "0x", nil
+	})
+	rpc := New(node.URL)
+
+	supply, err := rpc.Supply(context.Background(), map[string]string{
+		"000000000000000200000001": "0x63b1a3d3a4e7f9a2e2f86b2b2d0a8b4c2a2f25e2e1",
+		"000000000000000100000001": "0x631f62ca646771cd0c78e80e4eaf1d2ddf8fe414bf",
+	}, Latest())
+	require.Nil(t, err)
+	require.Equal(t, "1000 000000000000000100000001", supply[0].String())
+	require.Equal(t, "1000000000000000000 000000000000000200000001", supply[1].String())
+
+	total, err := rpc.TotalSupply(context.Background(), "0x631f62ca646771cd0c78e80e4eaf1d2ddf8fe414bf", Number(16))
+	require.Nil(t, err)
+	require.Equal(t, int64(1000), total.Int64())
+	node.AssertParams(t, "flow_call", map[string]string{"from": "", "to": "0x631f62ca646771cd0c78e80e4eaf1d2ddf8fe414bf", "data": TotalSupplySelector}, "0x10")
+
+	_, err = rpc.TotalSupply(context.Background(), "0x6600000000000000000000000000000000000000000", Latest())
+	require.EqualError(t, err, "Contract 0x6600000000000000000000000000000000000000000 returned no total supply")
+}
@@ -0,0 +1,57 @@
+package asimovrpc
+
+import "time"
+
+// Clock - source of time used by polling, budgets, watchers, endpoint caches, call timings,
+// debug records, HMACSigner timestamps and transaction lifecycle events.
+// asimovrpctest.FakeClock implements it for tests without real sleeps.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SystemClock - clock backed by the time package
+var SystemClock Clock = systemClock{}
+
+// WithClock set clock used by polling, budgets, watchers, endpoint caches, call timings, debug records,
+// timestamps of HMACSigner signers without own clock and lifecycle events of sent and awaited transactions
+func WithClock(clock Clock) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if clock == nil {
+			rpc.invalidOption("WithClock", "clock is nil")
+			return
+		}
+		rpc.clock = clock
+	}
+}
+
+// useClock hands client clock to components created by options, whatever the options order
+func (rpc *AsimovRPC) useClock() {
+	if rpc.budget != nil {
+		rpc.budget.clock = rpc.clock
+	}
+	if rpc.endpoints != nil {
+		rpc.endpoints.clock = rpc.clock
+	}
+	if rpc.failover != nil {
+		rpc.failover.clock = rpc.clock
+	}
+	if rpc.resolver != nil {
+		rpc.resolver.clock = rpc.clock
+	}
+	for _, signer := range rpc.signers {
+		if signer, ok := signer.(*HMACSigner); ok && signer.clock == nil {
+			signer.clock = rpc.clock
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package asimovrpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func (s *AsimovRPCTestSuite) TestWithClock() {
+	clock := asimovrpctest.NewFakeClock(time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC))
+	rpc := New(s.rpc.url, WithClock(clock), WithPollInterval(time.Hour))
+
+	number := 0
+	s.registerResponseFunc(func() string {
+		number++
+		return fmt.Sprintf(`"0x%x"`, number)
+	})
+
+	done := make(chan error)
+	go func() {
+		_, err := rpc.WaitForBlock(context.Background(), 2)
+		done <- err
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(2 * time.Hour)
+	s.Require().Nil(<-done)
+	s.Require().Equal(2, number)
+
+	_, err := NewClient("http://127.0.0.1:8545", WithClock(nil))
+	s.Require().EqualError(err, "asimovrpc: invalid option WithClock: clock is nil")
+}
+
+func (s *AsimovRPCTestSuite) TestClockOptionOrder() {
+	clock := asimovrpctest.NewFakeClock(time.Time{})
+	rpc := New(s.rpc.url, WithClock(clock), WithBudget(Budget{Window: time.Minute, MaxCalls: 1}), WithEndpointProvider(StaticEndpoints{s.rpc.url}, 0))
+
+	s.Require().Equal(clock, rpc.budget.clock)
+	s.Require().Equal(clock, rpc.endpoints.clock)
+}
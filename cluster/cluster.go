@@ -0,0 +1,177 @@
+// Package cluster groups addresses likely controlled by the same owner, applying heuristics to
+// transactions of a scanned dataset in chain order:
+//
+//	clusterer := cluster.New(cluster.CommonInputOwnership, cluster.OneTimeChange)
+//	for _, tx := range transactions {
+//		clusterer.Add(tx)
+//	}
+//	id := clusterer.ID("0x66...")
+//
+// Heuristics are probabilistic, clusters are leads for compliance and analytics, not proof of ownership.
+package cluster
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Output - value sent to an address
+type Output struct {
+	Address string
+	Value   *big.Int
+}
+
+// Transaction - spending transaction, Inputs are addresses of the spent outputs
+type Transaction struct {
+	Hash    string
+	Inputs  []string
+	Outputs []Output
+}
+
+// Heuristic returns addresses of transaction controlled by one owner. Seen reports whether
+// an address appeared in an earlier transaction.
+type Heuristic func(transaction Transaction, seen func(address string) bool) []string
+
+// CommonInputOwnership - inputs of a transaction are signed by one owner
+func CommonInputOwnership(transaction Transaction, seen func(address string) bool) []string {
+	return transaction.Inputs
+}
+
+// OneTimeChange - when exactly one output goes to a fresh address that is not an input, while the
+// other outputs go to known addresses, the fresh output is change returned to the sender
+func OneTimeChange(transaction Transaction, seen func(address string) bool) []string {
+	if len(transaction.Inputs) == 0 || len(transaction.Outputs) < 2 {
+		return nil
+	}
+
+	inputs := map[string]bool{}
+	for _, input := range transaction.Inputs {
+		inputs[normalize(input)] = true
+	}
+
+	change := ""
+	for _, output := range transaction.Outputs {
+		address := normalize(output.Address)
+		if inputs[address] {
+			return nil // change returned to an input address needs no detection
+		}
+		if seen(address) {
+			continue
+		}
+		if change != "" && change != address {
+			return nil
+		}
+		change = address
+	}
+	if change == "" {
+		return nil
+	}
+
+	return []string{transaction.Inputs[0], change}
+}
+
+// Clusterer - links addresses of transactions into clusters with union-find
+type Clusterer struct {
+	heuristics []Heuristic
+	parent     map[string]string
+	id         map[string]string // smallest address of the cluster, by root
+	seen       map[string]bool
+}
+
+// New returns clusterer applying heuristics
+func New(heuristics ...Heuristic) *Clusterer {
+	return &Clusterer{
+		heuristics: heuristics,
+		parent:     map[string]string{},
+		id:         map[string]string{},
+		seen:       map[string]bool{},
+	}
+}
+
+// Add applies heuristics to transaction, transactions must be added in chain order
+func (c *Clusterer) Add(transaction Transaction) {
+	seen := func(address string) bool {
+		return c.seen[normalize(address)]
+	}
+	for _, heuristic := range c.heuristics {
+		addresses := heuristic(transaction, seen)
+		for i := 1; i < len(addresses); i++ {
+			c.union(normalize(addresses[0]), normalize(addresses[i]))
+		}
+	}
+
+	for _, input := range transaction.Inputs {
+		c.add(normalize(input))
+	}
+	for _, output := range transaction.Outputs {
+		c.add(normalize(output.Address))
+	}
+}
+
+// ID returns cluster ID of address: the smallest address of its cluster, "" for unknown addresses
+func (c *Clusterer) ID(address string) string {
+	address = normalize(address)
+	if _, ok := c.parent[address]; !ok {
+		return ""
+	}
+
+	return c.id[c.find(address)]
+}
+
+// Clusters returns sorted addresses of every cluster by cluster ID
+func (c *Clusterer) Clusters() map[string][]string {
+	clusters := map[string][]string{}
+	for address := range c.parent {
+		id := c.id[c.find(address)]
+		clusters[id] = append(clusters[id], address)
+	}
+	for _, addresses := range clusters {
+		sort.Strings(addresses)
+	}
+
+	return clusters
+}
+
+func (c *Clusterer) add(address string) {
+	c.seen[address] = true
+	c.node(address)
+}
+
+func (c *Clusterer) node(address string) {
+	if _, ok := c.parent[address]; !ok {
+		c.parent[address] = address
+		c.id[address] = address
+	}
+}
+
+func (c *Clusterer) find(address string) string {
+	root := address
+	for c.parent[root] != root {
+		root = c.parent[root]
+	}
+	for address != root {
+		address, c.parent[address] = c.parent[address], root
+	}
+
+	return root
+}
+
+func (c *Clusterer) union(a, b string) {
+	c.node(a)
+	c.node(b)
+
+	a, b = c.find(a), c.find(b)
+	if a == b {
+		return
+	}
+	c.parent[b] = a
+	if c.id[b] < c.id[a] {
+		c.id[a] = c.id[b]
+	}
+	delete(c.id, b)
+}
+
+func normalize(address string) string {
+	return strings.ToLower(address)
+}
@@ -0,0 +1,54 @@
+package cluster
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func outputs(addresses ...string) []Output {
+	result := make([]Output, len(addresses))
+	for i, address := range addresses {
+		result[i] = Output{Address: address, Value: big.NewInt(int64(i + 1))}
+	}
+	return result
+}
+
+func TestCommonInputOwnership(t *testing.T) {
+	c := New(CommonInputOwnership)
+	c.Add(Transaction{Hash: "0x1", Inputs: []string{"0xC", "0xa"}, Outputs: outputs("0xd")})
+	c.Add(Transaction{Hash: "0x2", Inputs: []string{"0xb", "0xc"}, Outputs: outputs("0xe")})
+	c.Add(Transaction{Hash: "0x3", Inputs: []string{"0xf"}, Outputs: outputs("0xd")})
+
+	require.Equal(t, "0xa", c.ID("0xB"))
+	require.Equal(t, "0xa", c.ID("0xc"))
+	require.Equal(t, "0xd", c.ID("0xd"))
+	require.Equal(t, "", c.ID("0x9"))
+	require.Equal(t, map[string][]string{
+		"0xa": {"0xa", "0xb", "0xc"},
+		"0xd": {"0xd"},
+		"0xe": {"0xe"},
+		"0xf": {"0xf"},
+	}, c.Clusters())
+}
+
+func TestOneTimeChange(t *testing.T) {
+	c := New(CommonInputOwnership, OneTimeChange)
+	c.Add(Transaction{Hash: "0x1", Inputs: []string{"0xa"}, Outputs: outputs("0xshop")})
+	// 0xshop is known, 0xb is fresh change of 0xa
+	c.Add(Transaction{Hash: "0x2", Inputs: []string{"0xa"}, Outputs: outputs("0xshop", "0xb")})
+	// two fresh outputs are ambiguous
+	c.Add(Transaction{Hash: "0x3", Inputs: []string{"0xb"}, Outputs: outputs("0xc", "0xd")})
+	// change to an input address
+	c.Add(Transaction{Hash: "0x4", Inputs: []string{"0xd"}, Outputs: outputs("0xd", "0xe")})
+
+	require.Equal(t, "0xa", c.ID("0xb"))
+	require.Equal(t, "0xshop", c.ID("0xshop"))
+	require.Equal(t, "0xc", c.ID("0xc"))
+	require.Equal(t, "0xd", c.ID("0xd"))
+	require.Equal(t, "0xe", c.ID("0xe"))
+
+	require.Nil(t, OneTimeChange(Transaction{Outputs: outputs("0xa", "0xb")}, func(string) bool { return false }))
+	require.Nil(t, OneTimeChange(Transaction{Inputs: []string{"0xa"}, Outputs: outputs("0xb")}, func(string) bool { return false }))
+}
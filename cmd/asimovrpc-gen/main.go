@@ -0,0 +1,59 @@
+// Command asimovrpc-gen generates typed namespace wrappers from an OpenRPC document,
+// read from a file or fetched from the node with rpc.discover.
+//
+//	asimovrpc-gen [-url http://127.0.0.1:8545 | -schema openrpc.json] [-package nodeapi] [-o nodeapi.go]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	asimovrpc "github.com/mistdex/mist-asimov-rpc"
+	"github.com/mistdex/mist-asimov-rpc/openrpc"
+)
+
+func main() {
+	url := flag.String("url", "http://127.0.0.1:8545", "node RPC url queried with rpc.discover")
+	schema := flag.String("schema", "", "OpenRPC document file, used instead of the node")
+	pkg := flag.String("package", "nodeapi", "package of generated file")
+	output := flag.String("o", "", "output file, stdout if empty")
+	flag.Parse()
+
+	if err := run(*url, *schema, *pkg, *output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(url, schema, pkg, output string) error {
+	var document *openrpc.Document
+	var err error
+	if schema != "" {
+		var data []byte
+		if data, err = ioutil.ReadFile(schema); err == nil {
+			document, err = openrpc.Parse(data)
+		}
+	} else {
+		var client *asimovrpc.AsimovRPC
+		if client, err = asimovrpc.NewClient(url); err == nil {
+			document, err = client.Discover()
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	source, err := openrpc.Generate(document, openrpc.Options{Package: pkg})
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(source)
+		return err
+	}
+
+	return ioutil.WriteFile(output, source, 0644)
+}
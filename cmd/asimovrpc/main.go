@@ -0,0 +1,57 @@
+// Command asimovrpc is a small command line client for Asimov nodes.
+//
+//	asimovrpc -url http://127.0.0.1:8545 [-labels labels.json] tx explain <hash>
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	asimovrpc "github.com/mistdex/mist-asimov-rpc"
+	"github.com/mistdex/mist-asimov-rpc/annotations"
+)
+
+func main() {
+	url := flag.String("url", "http://127.0.0.1:8545", "node RPC url")
+	labels := flag.String("labels", "", "JSON file with address annotations")
+	flag.Parse()
+
+	if err := run(*url, *labels, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(url, labels string, args []string) error {
+	if len(args) != 3 || args[0] != "tx" || args[1] != "explain" {
+		return fmt.Errorf("usage: asimovrpc [-url url] [-labels file] tx explain <hash>")
+	}
+
+	client, err := asimovrpc.NewClient(url)
+	if err != nil {
+		return err
+	}
+
+	store := annotations.NewMemoryStore()
+	if labels != "" {
+		file, err := os.Open(labels)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		if err := annotations.Import(store, file); err != nil {
+			return err
+		}
+	}
+
+	explanation, err := asimovrpc.Explainer{RPC: client, Labels: store}.Explain(context.Background(), args[2])
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(explanation)
+	return nil
+}
@@ -0,0 +1,32 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrAlreadyStarted - component was already started
+var ErrAlreadyStarted = errors.New("component already started")
+
+// ErrStopped - component was stopped and cannot be started again
+var ErrStopped = errors.New("component stopped")
+
+// Component - background component with a uniform lifecycle, so supervisors can manage
+// config watchers, subscriptions and other long running parts alike
+type Component interface {
+	// Start starts the component, which runs until Stop is called or ctx is done
+	Start(ctx context.Context) error
+	// Stop stops the component and waits until it is done
+	Stop()
+	// Errors returns channel of errors reported while running
+	Errors() <-chan error
+	// Done returns channel closed once the component has stopped
+	Done() <-chan struct{}
+}
+
+var (
+	_ Component = (*ConfigWatcher)(nil)
+	_ Component = (*Subscription)(nil)
+	_ Component = (*Supervisor)(nil)
+	_ Component = (*FilterManager)(nil)
+)
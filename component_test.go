@@ -0,0 +1,56 @@
+package asimovrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func waitDone(t *testing.T, component Component) {
+	select {
+	case <-component.Done():
+	case <-time.After(time.Second):
+		t.Fatal("component did not stop")
+	}
+}
+
+func TestConfigWatcherLifecycle(t *testing.T) {
+	path, cleanup := watchedConfig(t)
+	defer cleanup()
+	rpc := New("http://a:8545")
+
+	watcher := rpc.NewConfigWatcher(path, time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	require.Nil(t, watcher.Start(ctx))
+	require.Equal(t, ErrAlreadyStarted, watcher.Start(ctx))
+	cancel()
+	waitDone(t, watcher)
+	watcher.Stop()
+
+	watcher = rpc.NewConfigWatcher(path, time.Millisecond)
+	watcher.Stop()
+	waitDone(t, watcher)
+	require.Equal(t, ErrStopped, watcher.Start(context.Background()))
+}
+
+func TestSubscriptionLifecycle(t *testing.T) {
+	server, url := wsNode(t)
+	defer server.Close()
+
+	rpc := NewAsimovRPC(url)
+	defer rpc.Close()
+
+	subscription, err := rpc.SubscribeNewHeads(context.Background())
+	require.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.Nil(t, subscription.Start(ctx))
+	cancel()
+	waitDone(t, subscription)
+	subscription.Stop()
+
+	_, open := <-subscription.Errors()
+	require.False(t, open)
+}
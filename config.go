@@ -0,0 +1,490 @@
+package asimovrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// EnvPrefix - prefix of environment variables read by FromEnv
+const EnvPrefix = "ASIMOV_RPC_"
+
+// Config - declarative client configuration
+type Config struct {
+	URL                string        `json:"url" yaml:"url"`
+	Endpoints          []string      `json:"endpoints" yaml:"endpoints"`
+	EndpointRefresh    time.Duration `json:"endpointRefresh" yaml:"endpointRefresh"`
+	DNSRefresh         time.Duration `json:"dnsRefresh" yaml:"dnsRefresh"`
+	Timeout            time.Duration `json:"timeout" yaml:"timeout"`
+	UserAgent          string        `json:"userAgent" yaml:"userAgent"`
+	Debug              bool          `json:"debug" yaml:"debug"`
+	DebugFormat        string        `json:"debugFormat" yaml:"debugFormat"`
+	DebugPayloadLimit  int           `json:"debugPayloadLimit" yaml:"debugPayloadLimit"`
+	SlowQueryThreshold time.Duration `json:"slowQueryThreshold" yaml:"slowQueryThreshold"`
+	Diagnostics        bool          `json:"diagnostics" yaml:"diagnostics"`
+
+	Retries           int               `json:"retries" yaml:"retries"`
+	BasicAuthUser     string            `json:"basicAuthUser" yaml:"basicAuthUser"`
+	BasicAuthPassword string            `json:"basicAuthPassword" yaml:"basicAuthPassword"`
+	BearerToken       string            `json:"bearerToken" yaml:"bearerToken"`
+	Headers           map[string]string `json:"headers" yaml:"headers"`
+	ChainIDCache      bool              `json:"chainIdCache" yaml:"chainIdCache"`
+	MaxResponseSize   int64             `json:"maxResponseSize" yaml:"maxResponseSize"`
+	BatchSize         int               `json:"batchSize" yaml:"batchSize"`
+	LogsChunkSize     int               `json:"logsChunkSize" yaml:"logsChunkSize"`
+	Budget            *BudgetConfig     `json:"budget" yaml:"budget"`
+	MaxGas            int               `json:"maxGas" yaml:"maxGas"`
+	MaxGasPrice       int64             `json:"maxGasPrice" yaml:"maxGasPrice"` // wei
+	Failover          *FailoverConfig   `json:"failover" yaml:"failover"`
+	ReadOnly          bool              `json:"readOnly" yaml:"readOnly"`
+	AllowedMethods    []string          `json:"allowedMethods" yaml:"allowedMethods"`
+	DeniedMethods     []string          `json:"deniedMethods" yaml:"deniedMethods"`
+}
+
+// FailoverConfig - backup endpoints of Config, see WithFailoverPolicy. Zero MaxFailures and Cooldown
+// are taken from DefaultFailoverPolicy.
+type FailoverConfig struct {
+	Backups     []string      `json:"backups" yaml:"backups"`
+	MaxFailures int           `json:"maxFailures" yaml:"maxFailures"`
+	Cooldown    time.Duration `json:"cooldown" yaml:"cooldown"`
+}
+
+// BudgetConfig - rate limit and budget of Config, see Budget
+type BudgetConfig struct {
+	Window     time.Duration `json:"window" yaml:"window"`
+	MaxCalls   int           `json:"maxCalls" yaml:"maxCalls"`
+	MaxCredits float64       `json:"maxCredits" yaml:"maxCredits"`
+	Block      bool          `json:"block" yaml:"block"`
+	Warnings   []float64     `json:"warnings" yaml:"warnings"`
+}
+
+type proxyConfig struct {
+	URL                string   `json:"url"`
+	Endpoints          []string `json:"endpoints"`
+	EndpointRefresh    duration `json:"endpointRefresh"`
+	DNSRefresh         duration `json:"dnsRefresh"`
+	Timeout            duration `json:"timeout"`
+	UserAgent          string   `json:"userAgent"`
+	Debug              bool     `json:"debug"`
+	DebugFormat        string   `json:"debugFormat"`
+	DebugPayloadLimit  int      `json:"debugPayloadLimit"`
+	SlowQueryThreshold duration `json:"slowQueryThreshold"`
+	Diagnostics        bool     `json:"diagnostics"`
+
+	Retries           int                  `json:"retries"`
+	BasicAuthUser     string               `json:"basicAuthUser"`
+	BasicAuthPassword string               `json:"basicAuthPassword"`
+	BearerToken       string               `json:"bearerToken"`
+	Headers           map[string]string    `json:"headers"`
+	ChainIDCache      bool                 `json:"chainIdCache"`
+	MaxResponseSize   int64                `json:"maxResponseSize"`
+	BatchSize         int                  `json:"batchSize"`
+	LogsChunkSize     int                  `json:"logsChunkSize"`
+	Budget            *proxyBudgetConfig   `json:"budget"`
+	MaxGas            int                  `json:"maxGas"`
+	MaxGasPrice       int64                `json:"maxGasPrice"`
+	Failover          *proxyFailoverConfig `json:"failover"`
+	ReadOnly          bool                 `json:"readOnly"`
+	AllowedMethods    []string             `json:"allowedMethods"`
+	DeniedMethods     []string             `json:"deniedMethods"`
+}
+
+type proxyBudgetConfig struct {
+	Window     duration  `json:"window"`
+	MaxCalls   int       `json:"maxCalls"`
+	MaxCredits float64   `json:"maxCredits"`
+	Block      bool      `json:"block"`
+	Warnings   []float64 `json:"warnings"`
+}
+
+type proxyFailoverConfig struct {
+	Backups     []string `json:"backups"`
+	MaxFailures int      `json:"maxFailures"`
+	Cooldown    duration `json:"cooldown"`
+}
+
+// duration accepts both Go duration strings ("5s") and nanoseconds
+type duration time.Duration
+
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case float64:
+		*d = duration(v)
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*d = duration(parsed)
+	default:
+		return fmt.Errorf("invalid duration %s", data)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyConfig)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return err
+	}
+
+	*c = proxy.config()
+	return nil
+}
+
+func (proxy *proxyConfig) config() Config {
+	c := Config{
+		URL:                proxy.URL,
+		Endpoints:          proxy.Endpoints,
+		EndpointRefresh:    time.Duration(proxy.EndpointRefresh),
+		DNSRefresh:         time.Duration(proxy.DNSRefresh),
+		Timeout:            time.Duration(proxy.Timeout),
+		UserAgent:          proxy.UserAgent,
+		Debug:              proxy.Debug,
+		DebugFormat:        proxy.DebugFormat,
+		DebugPayloadLimit:  proxy.DebugPayloadLimit,
+		SlowQueryThreshold: time.Duration(proxy.SlowQueryThreshold),
+		Diagnostics:        proxy.Diagnostics,
+		Retries:            proxy.Retries,
+		BasicAuthUser:      proxy.BasicAuthUser,
+		BasicAuthPassword:  proxy.BasicAuthPassword,
+		BearerToken:        proxy.BearerToken,
+		Headers:            proxy.Headers,
+		ChainIDCache:       proxy.ChainIDCache,
+		MaxResponseSize:    proxy.MaxResponseSize,
+		BatchSize:          proxy.BatchSize,
+		LogsChunkSize:      proxy.LogsChunkSize,
+		MaxGas:             proxy.MaxGas,
+		MaxGasPrice:        proxy.MaxGasPrice,
+		ReadOnly:           proxy.ReadOnly,
+		AllowedMethods:     proxy.AllowedMethods,
+		DeniedMethods:      proxy.DeniedMethods,
+	}
+	if b := proxy.Budget; b != nil {
+		c.Budget = &BudgetConfig{Window: time.Duration(b.Window), MaxCalls: b.MaxCalls, MaxCredits: b.MaxCredits, Block: b.Block, Warnings: b.Warnings}
+	}
+	if f := proxy.Failover; f != nil {
+		c.Failover = &FailoverConfig{Backups: f.Backups, MaxFailures: f.MaxFailures, Cooldown: time.Duration(f.Cooldown)}
+	}
+
+	return c
+}
+
+// FromFile loads config from YAML (.yaml, .yml) or JSON file, unknown fields are rejected
+func FromFile(path string) (Config, error) {
+	var cfg Config
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(data, &cfg)
+	default:
+		proxy := new(proxyConfig)
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err = decoder.Decode(proxy); err == nil {
+			cfg = proxy.config()
+		}
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("config %s: %v", path, err)
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// FromEnv loads config from ASIMOV_RPC_* environment variables
+// (URL, ENDPOINTS and other lists as comma separated lists, TIMEOUT, DEBUG, RETRIES, BEARER_TOKEN, BUDGET_WINDOW,
+// MAX_GAS, FAILOVER, ...). Headers are only read from files.
+func FromEnv() (Config, error) {
+	var cfg Config
+	var err error
+
+	env := func(name string) (string, bool) {
+		return os.LookupEnv(EnvPrefix + name)
+	}
+	parseDuration := func(name string, target *time.Duration) {
+		if value, ok := env(name); ok && err == nil {
+			*target, err = time.ParseDuration(value)
+		}
+	}
+	parseBool := func(name string, target *bool) {
+		if value, ok := env(name); ok && err == nil {
+			*target, err = strconv.ParseBool(value)
+		}
+	}
+	parseInt := func(name string, target *int) {
+		if value, ok := env(name); ok && err == nil {
+			*target, err = strconv.Atoi(value)
+		}
+	}
+	parseList := func(name string, target *[]string) {
+		if value, ok := env(name); ok {
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					*target = append(*target, item)
+				}
+			}
+		}
+	}
+
+	cfg.URL, _ = env("URL")
+	parseList("ENDPOINTS", &cfg.Endpoints)
+	parseDuration("ENDPOINT_REFRESH", &cfg.EndpointRefresh)
+	parseDuration("DNS_REFRESH", &cfg.DNSRefresh)
+	parseDuration("TIMEOUT", &cfg.Timeout)
+	cfg.UserAgent, _ = env("USER_AGENT")
+	parseBool("DEBUG", &cfg.Debug)
+	cfg.DebugFormat, _ = env("DEBUG_FORMAT")
+	parseInt("DEBUG_PAYLOAD_LIMIT", &cfg.DebugPayloadLimit)
+	parseDuration("SLOW_QUERY_THRESHOLD", &cfg.SlowQueryThreshold)
+	parseBool("DIAGNOSTICS", &cfg.Diagnostics)
+	parseInt("RETRIES", &cfg.Retries)
+	cfg.BasicAuthUser, _ = env("BASIC_AUTH_USER")
+	cfg.BasicAuthPassword, _ = env("BASIC_AUTH_PASSWORD")
+	cfg.BearerToken, _ = env("BEARER_TOKEN")
+	parseBool("CHAIN_ID_CACHE", &cfg.ChainIDCache)
+	if value, ok := env("MAX_RESPONSE_SIZE"); ok && err == nil {
+		cfg.MaxResponseSize, err = strconv.ParseInt(value, 10, 64)
+	}
+	parseInt("BATCH_SIZE", &cfg.BatchSize)
+	parseInt("LOGS_CHUNK_SIZE", &cfg.LogsChunkSize)
+	if _, ok := env("BUDGET_WINDOW"); ok {
+		cfg.Budget = new(BudgetConfig)
+		parseDuration("BUDGET_WINDOW", &cfg.Budget.Window)
+		parseInt("BUDGET_MAX_CALLS", &cfg.Budget.MaxCalls)
+		parseBool("BUDGET_BLOCK", &cfg.Budget.Block)
+	}
+	parseInt("MAX_GAS", &cfg.MaxGas)
+	if value, ok := env("MAX_GAS_PRICE"); ok && err == nil {
+		cfg.MaxGasPrice, err = strconv.ParseInt(value, 10, 64)
+	}
+	if _, ok := env("FAILOVER"); ok {
+		cfg.Failover = new(FailoverConfig)
+		parseList("FAILOVER", &cfg.Failover.Backups)
+		parseInt("FAILOVER_MAX_FAILURES", &cfg.Failover.MaxFailures)
+		parseDuration("FAILOVER_COOLDOWN", &cfg.Failover.Cooldown)
+	}
+	parseBool("READ_ONLY", &cfg.ReadOnly)
+	parseList("ALLOWED_METHODS", &cfg.AllowedMethods)
+	parseList("DENIED_METHODS", &cfg.DeniedMethods)
+
+	if err != nil {
+		return cfg, fmt.Errorf("config from environment: %v", err)
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// Validate checks config for missing or invalid values
+func (c Config) Validate() error {
+	if c.URL == "" && len(c.Endpoints) == 0 {
+		return errors.New("config: url or endpoints required")
+	}
+
+	endpoints := append([]string{c.URL}, c.Endpoints...)
+	if c.Failover != nil {
+		endpoints = append(endpoints, c.Failover.Backups...)
+	}
+	for _, endpoint := range endpoints {
+		if endpoint == "" {
+			continue
+		}
+		if u, err := url.Parse(endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("config: invalid endpoint url %q", endpoint)
+		}
+	}
+
+	durations := map[string]time.Duration{
+		"endpointRefresh":    c.EndpointRefresh,
+		"dnsRefresh":         c.DNSRefresh,
+		"timeout":            c.Timeout,
+		"slowQueryThreshold": c.SlowQueryThreshold,
+	}
+	for name, d := range durations {
+		if d < 0 {
+			return fmt.Errorf("config: %s must not be negative", name)
+		}
+	}
+
+	counts := map[string]int64{
+		"debugPayloadLimit": int64(c.DebugPayloadLimit),
+		"retries":           int64(c.Retries),
+		"maxResponseSize":   c.MaxResponseSize,
+		"batchSize":         int64(c.BatchSize),
+		"logsChunkSize":     int64(c.LogsChunkSize),
+		"maxGas":            int64(c.MaxGas),
+		"maxGasPrice":       c.MaxGasPrice,
+	}
+	for name, n := range counts {
+		if n < 0 {
+			return fmt.Errorf("config: %s must not be negative", name)
+		}
+	}
+
+	if c.BasicAuthUser != "" && c.BearerToken != "" {
+		return errors.New("config: basicAuthUser and bearerToken are exclusive")
+	}
+	if c.Budget != nil && c.Budget.Window <= 0 {
+		return errors.New("config: budget window must be positive")
+	}
+	if f := c.Failover; f != nil {
+		switch {
+		case len(f.Backups) == 0:
+			return errors.New("config: failover backups required")
+		case len(c.Endpoints) > 0:
+			return errors.New("config: failover and endpoints are exclusive")
+		case f.MaxFailures < 0 || f.Cooldown < 0:
+			return errors.New("config: failover maxFailures and cooldown must not be negative")
+		}
+	}
+	for _, pattern := range append(append([]string{}, c.AllowedMethods...), c.DeniedMethods...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("config: malformed method pattern %q", pattern)
+		}
+	}
+
+	if _, err := parseDebugFormat(c.DebugFormat); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Options converts config to client options
+func (c Config) Options() []func(rpc *AsimovRPC) {
+	options := []func(rpc *AsimovRPC){
+		WithDebug(c.Debug),
+		WithDiagnostics(c.Diagnostics),
+	}
+
+	if c.DNSRefresh > 0 {
+		options = append(options, WithDNSRoundRobin(c.DNSRefresh))
+	}
+	if len(c.Endpoints) > 0 {
+		options = append(options, WithEndpointProvider(StaticEndpoints(c.Endpoints), c.EndpointRefresh))
+	}
+	if c.Timeout > 0 {
+		options = append(options, WithTimeout(c.Timeout))
+	}
+	if c.UserAgent != "" {
+		options = append(options, WithUserAgent(c.UserAgent))
+	}
+	if format, err := parseDebugFormat(c.DebugFormat); err == nil {
+		options = append(options, WithDebugFormat(format))
+	}
+	if c.DebugPayloadLimit > 0 {
+		options = append(options, WithDebugPayloadLimit(c.DebugPayloadLimit))
+	}
+	if c.SlowQueryThreshold > 0 {
+		options = append(options, WithSlowQueryThreshold(c.SlowQueryThreshold))
+	}
+	if c.Retries > 0 {
+		options = append(options, WithRetries(c.Retries))
+	}
+	if c.BasicAuthUser != "" {
+		options = append(options, WithBasicAuth(c.BasicAuthUser, c.BasicAuthPassword))
+	}
+	if c.BearerToken != "" {
+		options = append(options, WithBearerToken(c.BearerToken))
+	}
+	for name, value := range c.Headers {
+		options = append(options, WithHTTPHeader(name, value))
+	}
+	if c.ChainIDCache {
+		options = append(options, WithChainIDCache(true))
+	}
+	if c.MaxResponseSize > 0 {
+		options = append(options, WithMaxResponseSize(c.MaxResponseSize))
+	}
+	if c.BatchSize > 0 {
+		options = append(options, WithBatchSize(c.BatchSize))
+	}
+	if c.LogsChunkSize > 0 {
+		options = append(options, WithLogsChunkSize(c.LogsChunkSize))
+	}
+	if b := c.Budget; b != nil {
+		options = append(options, WithBudget(Budget{Window: b.Window, MaxCalls: b.MaxCalls, MaxCredits: b.MaxCredits, Block: b.Block, Warnings: b.Warnings}))
+	}
+	if caps := c.gasCaps(); !caps.empty() {
+		options = append(options, WithGasCaps(caps))
+	}
+	if f := c.Failover; f != nil {
+		policy := DefaultFailoverPolicy
+		if f.MaxFailures > 0 {
+			policy.MaxFailures = f.MaxFailures
+		}
+		if f.Cooldown > 0 {
+			policy.Cooldown = f.Cooldown
+		}
+		options = append(options, WithFailoverPolicy(policy, f.Backups...))
+	}
+	if c.ReadOnly {
+		options = append(options, WithReadOnly(true))
+	}
+	if len(c.AllowedMethods) > 0 {
+		options = append(options, WithAllowedMethods(c.AllowedMethods...))
+	}
+	if len(c.DeniedMethods) > 0 {
+		options = append(options, WithDeniedMethods(c.DeniedMethods...))
+	}
+
+	return options
+}
+
+// NewFromConfig validates config and creates client, extra options are applied after config ones
+func NewFromConfig(cfg Config, options ...func(rpc *AsimovRPC)) (*AsimovRPC, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	url := cfg.URL
+	if url == "" {
+		url = cfg.Endpoints[0]
+	}
+
+	return NewClient(url, append(cfg.Options(), options...)...)
+}
+
+func (c Config) gasCaps() GasCaps {
+	caps := GasCaps{MaxGas: c.MaxGas}
+	if c.MaxGasPrice > 0 {
+		caps.MaxGasPrice = big.NewInt(c.MaxGasPrice)
+	}
+
+	return caps
+}
+
+func parseDebugFormat(format string) (DebugFormat, error) {
+	switch strings.ToLower(format) {
+	case "", "text":
+		return DebugText, nil
+	case "json":
+		return DebugJSON, nil
+	}
+
+	return DebugText, fmt.Errorf("config: unknown debug format %q", format)
+}
@@ -0,0 +1,242 @@
+package asimovrpc
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	dir, err := ioutil.TempDir("", "config")
+	require.Nil(t, err)
+
+	path := filepath.Join(dir, name)
+	require.Nil(t, ioutil.WriteFile(path, []byte(content), 0644))
+
+	return path
+}
+
+func TestConfigFromFile(t *testing.T) {
+	expected := Config{
+		URL:                "http://127.0.0.1:8545",
+		Endpoints:          []string{"http://a:8545", "http://b:8545"},
+		Timeout:            5 * time.Second,
+		Debug:              true,
+		DebugFormat:        "json",
+		SlowQueryThreshold: 500 * time.Millisecond,
+	}
+
+	path := writeConfig(t, "client.yaml", `
+url: http://127.0.0.1:8545
+endpoints:
+  - http://a:8545
+  - http://b:8545
+timeout: 5s
+debug: true
+debugFormat: json
+slowQueryThreshold: 500ms
+`)
+	defer os.RemoveAll(filepath.Dir(path))
+	cfg, err := FromFile(path)
+	require.Nil(t, err)
+	require.Equal(t, expected, cfg)
+
+	path = writeConfig(t, "client.json", `{
+		"url": "http://127.0.0.1:8545",
+		"endpoints": ["http://a:8545", "http://b:8545"],
+		"timeout": "5s",
+		"debug": true,
+		"debugFormat": "json",
+		"slowQueryThreshold": 500000000
+	}`)
+	defer os.RemoveAll(filepath.Dir(path))
+	cfg, err = FromFile(path)
+	require.Nil(t, err)
+	require.Equal(t, expected, cfg)
+
+	path = writeConfig(t, "client.yml", "url: http://a\nunknown: 1\n")
+	defer os.RemoveAll(filepath.Dir(path))
+	_, err = FromFile(path)
+	require.NotNil(t, err)
+
+	path = writeConfig(t, "client.json", `{"url": "http://a", "timout": "5s"}`)
+	defer os.RemoveAll(filepath.Dir(path))
+	_, err = FromFile(path)
+	require.EqualError(t, err, "config "+path+`: json: unknown field "timout"`)
+
+	path = writeConfig(t, "client.json", `{"url": "http://a", "timeout": "soon"}`)
+	defer os.RemoveAll(filepath.Dir(path))
+	_, err = FromFile(path)
+	require.NotNil(t, err)
+
+	_, err = FromFile("missing.json")
+	require.NotNil(t, err)
+}
+
+func TestConfigFromFileOptions(t *testing.T) {
+	expected := Config{
+		URL:             "http://127.0.0.1:8545",
+		Retries:         2,
+		BearerToken:     "secret",
+		Headers:         map[string]string{"X-Api-Key": "key"},
+		ChainIDCache:    true,
+		MaxResponseSize: 1 << 20,
+		BatchSize:       50,
+		LogsChunkSize:   500,
+		Budget:          &BudgetConfig{Window: time.Minute, MaxCalls: 600, Block: true},
+		MaxGas:          1000000,
+		MaxGasPrice:     100000000000,
+		Failover:        &FailoverConfig{Backups: []string{"http://b:8545"}, Cooldown: time.Minute},
+		ReadOnly:        true,
+		DeniedMethods:   []string{"personal_*"},
+	}
+
+	path := writeConfig(t, "client.yaml", `
+url: http://127.0.0.1:8545
+retries: 2
+bearerToken: secret
+headers:
+  X-Api-Key: key
+chainIdCache: true
+maxResponseSize: 1048576
+batchSize: 50
+logsChunkSize: 500
+budget:
+  window: 1m
+  maxCalls: 600
+  block: true
+maxGas: 1000000
+maxGasPrice: 100000000000
+failover:
+  backups: [http://b:8545]
+  cooldown: 1m
+readOnly: true
+deniedMethods: ["personal_*"]
+`)
+	defer os.RemoveAll(filepath.Dir(path))
+	cfg, err := FromFile(path)
+	require.Nil(t, err)
+	require.Equal(t, expected, cfg)
+
+	path = writeConfig(t, "client.json", `{
+		"url": "http://127.0.0.1:8545",
+		"retries": 2,
+		"bearerToken": "secret",
+		"headers": {"X-Api-Key": "key"},
+		"chainIdCache": true,
+		"maxResponseSize": 1048576,
+		"batchSize": 50,
+		"logsChunkSize": 500,
+		"budget": {"window": "1m", "maxCalls": 600, "block": true},
+		"maxGas": 1000000,
+		"maxGasPrice": 100000000000,
+		"failover": {"backups": ["http://b:8545"], "cooldown": "1m"},
+		"readOnly": true,
+		"deniedMethods": ["personal_*"]
+	}`)
+	defer os.RemoveAll(filepath.Dir(path))
+	cfg, err = FromFile(path)
+	require.Nil(t, err)
+	require.Equal(t, expected, cfg)
+
+	path = writeConfig(t, "client.json", `{"url": "http://a", "budget": {"window": "1m", "calls": 1}}`)
+	defer os.RemoveAll(filepath.Dir(path))
+	_, err = FromFile(path)
+	require.NotNil(t, err)
+
+	rpc := New(cfg.URL, append(cfg.Options(), WithPollInterval(time.Millisecond))...)
+	require.Equal(t, 2, rpc.retries)
+	require.Equal(t, "Bearer secret", rpc.headers.Get("Authorization"))
+	require.Equal(t, "key", rpc.headers.Get("X-Api-Key"))
+	require.NotNil(t, rpc.chainID)
+	require.Equal(t, int64(1<<20), rpc.maxResponseSize)
+	require.Equal(t, 50, rpc.batchSize)
+	require.Equal(t, 500, rpc.logsChunkSize)
+	require.Equal(t, 600, rpc.budget.MaxCalls)
+	require.Equal(t, GasCaps{MaxGas: 1000000, MaxGasPrice: big.NewInt(100000000000)}, rpc.gasCaps)
+	require.Equal(t, FailoverPolicy{MaxFailures: DefaultFailoverPolicy.MaxFailures, Cooldown: time.Minute}, rpc.failover.policy)
+	require.True(t, rpc.readOnly)
+	require.Equal(t, []string{"personal_*"}, rpc.deniedMethods)
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	os.Setenv("ASIMOV_RPC_URL", "http://127.0.0.1:8545")
+	os.Setenv("ASIMOV_RPC_ENDPOINTS", "http://a:8545, http://b:8545")
+	os.Setenv("ASIMOV_RPC_TIMEOUT", "3s")
+	os.Setenv("ASIMOV_RPC_DEBUG", "true")
+	os.Setenv("ASIMOV_RPC_DEBUG_PAYLOAD_LIMIT", "100")
+	os.Setenv("ASIMOV_RPC_RETRIES", "2")
+	os.Setenv("ASIMOV_RPC_BUDGET_WINDOW", "1m")
+	os.Setenv("ASIMOV_RPC_BUDGET_MAX_CALLS", "600")
+	os.Setenv("ASIMOV_RPC_DENIED_METHODS", "personal_*, admin_*")
+	defer func() {
+		for _, name := range []string{"URL", "ENDPOINTS", "TIMEOUT", "DEBUG", "DEBUG_PAYLOAD_LIMIT", "RETRIES", "BUDGET_WINDOW", "BUDGET_MAX_CALLS", "DENIED_METHODS"} {
+			os.Unsetenv(EnvPrefix + name)
+		}
+	}()
+
+	cfg, err := FromEnv()
+	require.Nil(t, err)
+	require.Equal(t, Config{
+		URL:               "http://127.0.0.1:8545",
+		Endpoints:         []string{"http://a:8545", "http://b:8545"},
+		Timeout:           3 * time.Second,
+		Debug:             true,
+		DebugPayloadLimit: 100,
+		Retries:           2,
+		Budget:            &BudgetConfig{Window: time.Minute, MaxCalls: 600},
+		DeniedMethods:     []string{"personal_*", "admin_*"},
+	}, cfg)
+
+	os.Setenv("ASIMOV_RPC_DEBUG", "maybe")
+	_, err = FromEnv()
+	require.NotNil(t, err)
+}
+
+func TestConfigValidate(t *testing.T) {
+	require.NotNil(t, Config{}.Validate())
+	require.NotNil(t, Config{URL: "127.0.0.1:8545"}.Validate())
+	require.NotNil(t, Config{URL: "http://a", Timeout: -time.Second}.Validate())
+	require.NotNil(t, Config{URL: "http://a", DebugPayloadLimit: -1}.Validate())
+	require.NotNil(t, Config{URL: "http://a", DebugFormat: "xml"}.Validate())
+	require.NotNil(t, Config{URL: "http://a", Retries: -1}.Validate())
+	require.NotNil(t, Config{URL: "http://a", BasicAuthUser: "user", BearerToken: "secret"}.Validate())
+	require.NotNil(t, Config{URL: "http://a", Budget: &BudgetConfig{MaxCalls: 1}}.Validate())
+	require.NotNil(t, Config{URL: "http://a", Failover: &FailoverConfig{}}.Validate())
+	require.NotNil(t, Config{URL: "http://a", Endpoints: []string{"http://b"}, Failover: &FailoverConfig{Backups: []string{"http://c"}}}.Validate())
+	require.NotNil(t, Config{URL: "http://a", Failover: &FailoverConfig{Backups: []string{"c:8545"}}}.Validate())
+	require.NotNil(t, Config{URL: "http://a", DeniedMethods: []string{"personal_["}}.Validate())
+	require.Nil(t, Config{Endpoints: []string{"https://a"}}.Validate())
+}
+
+func TestNewFromConfig(t *testing.T) {
+	_, err := NewFromConfig(Config{})
+	require.NotNil(t, err)
+
+	rpc, err := NewFromConfig(Config{
+		Endpoints:          []string{"http://a:8545"},
+		Timeout:            time.Second,
+		UserAgent:          "app",
+		Debug:              true,
+		DebugFormat:        "JSON",
+		DebugPayloadLimit:  10,
+		SlowQueryThreshold: time.Minute,
+		Diagnostics:        true,
+		DNSRefresh:         time.Minute,
+	}, WithDebug(false))
+	require.Nil(t, err)
+	require.Equal(t, "http://a:8545", rpc.URL())
+	require.Equal(t, time.Second, rpc.timeout)
+	require.Equal(t, DefaultUserAgent()+" app", rpc.userAgent)
+	require.False(t, rpc.debugEnabled)
+	require.Equal(t, DebugJSON, rpc.debugFormat)
+	require.Equal(t, 10, rpc.debugPayloadLimit)
+	require.Equal(t, time.Minute, rpc.slowQueryThreshold)
+	require.NotNil(t, rpc.diagnostics)
+	require.NotNil(t, rpc.endpoints)
+}
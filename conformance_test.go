@@ -0,0 +1,123 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// conformanceVectors - cross-implementation test vectors in testdata/conformance/vectors.json,
+// see the README for their format
+type conformanceVectors struct {
+	Version   int `json:"version"`
+	Keccak256 []struct {
+		Input string `json:"input"`
+		Hash  string `json:"hash"`
+	} `json:"keccak256"`
+	MessageHash []struct {
+		Message string `json:"message"`
+		Hash    string `json:"hash"`
+	} `json:"messageHash"`
+	Addresses []struct {
+		PrivateKey string `json:"privateKey"`
+		Address    string `json:"address"`
+	} `json:"addresses"`
+	Signatures []struct {
+		PrivateKey string `json:"privateKey"`
+		Hash       string `json:"hash"`
+		Signature  string `json:"signature"`
+	} `json:"signatures"`
+	Transactions []struct {
+		Name        string `json:"name"`
+		PrivateKey  string `json:"privateKey"`
+		ChainID     int64  `json:"chainId"`
+		Transaction T      `json:"transaction"`
+		SigningHash string `json:"signingHash"`
+		Raw         string `json:"raw"`
+		Hash        string `json:"hash"`
+	} `json:"transactions"`
+}
+
+func loadConformanceVectors(t *testing.T) conformanceVectors {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "conformance", "vectors.json"))
+	require.Nil(t, err)
+
+	var vectors conformanceVectors
+	require.Nil(t, json.Unmarshal(data, &vectors))
+	require.Equal(t, 1, vectors.Version)
+
+	return vectors
+}
+
+func TestConformanceHashing(t *testing.T) {
+	vectors := loadConformanceVectors(t)
+	require.NotEmpty(t, vectors.Keccak256)
+	require.NotEmpty(t, vectors.MessageHash)
+
+	for _, vector := range vectors.Keccak256 {
+		input, err := HexToBytes(vector.Input)
+		require.Nil(t, err)
+		require.Equal(t, vector.Hash, fmt.Sprintf("0x%x", Keccak256(input)), vector.Input)
+	}
+	for _, vector := range vectors.MessageHash {
+		message, err := HexToBytes(vector.Message)
+		require.Nil(t, err)
+		require.Equal(t, vector.Hash, fmt.Sprintf("0x%x", SignedMessageHash(message)), vector.Message)
+	}
+}
+
+func TestConformanceAddresses(t *testing.T) {
+	vectors := loadConformanceVectors(t)
+	require.NotEmpty(t, vectors.Addresses)
+
+	for _, vector := range vectors.Addresses {
+		signer, err := NewPrivateKeySigner(vector.PrivateKey)
+		require.Nil(t, err)
+		require.Equal(t, vector.Address, signer.Address(), vector.PrivateKey)
+	}
+}
+
+func TestConformanceSignatures(t *testing.T) {
+	vectors := loadConformanceVectors(t)
+	require.NotEmpty(t, vectors.Signatures)
+
+	for _, vector := range vectors.Signatures {
+		signer, err := NewPrivateKeySigner(vector.PrivateKey)
+		require.Nil(t, err)
+		hash, err := HexToBytes(vector.Hash)
+		require.Nil(t, err)
+
+		signature, err := signer.SignHash(hash)
+		require.Nil(t, err)
+		require.Equal(t, vector.Signature, fmt.Sprintf("0x%x", signature), vector.Hash)
+
+		address, err := recoverAddress(hash, signature)
+		require.Nil(t, err)
+		require.Equal(t, signer.Address(), address)
+	}
+}
+
+func TestConformanceTransactions(t *testing.T) {
+	vectors := loadConformanceVectors(t)
+	require.NotEmpty(t, vectors.Transactions)
+
+	for _, vector := range vectors.Transactions {
+		t.Run(vector.Name, func(t *testing.T) {
+			signer, err := NewPrivateKeySigner(vector.PrivateKey)
+			require.Nil(t, err)
+
+			hash, err := SigningHash(vector.Transaction, vector.ChainID)
+			require.Nil(t, err)
+			require.Equal(t, vector.SigningHash, fmt.Sprintf("0x%x", hash))
+
+			signed, err := SignTransaction(vector.Transaction, vector.ChainID, signer)
+			require.Nil(t, err)
+			require.Equal(t, vector.Raw, signed.Raw)
+			require.Equal(t, vector.Hash, signed.Tx.Hash)
+		})
+	}
+}
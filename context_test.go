@@ -0,0 +1,51 @@
+package asimovrpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.Write([]byte(echoID(r, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`)))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	rpc := NewAsimovRPC(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := rpc.CallContext(ctx, "flow_blockNumber")
+	require.Error(t, err)
+	require.Equal(t, context.DeadlineExceeded, ctx.Err())
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = rpc.WithContext(ctx).AsimovBlockNumber()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "context canceled")
+	require.Nil(t, rpc.ctx)
+}
+
+func TestBudgetContext(t *testing.T) {
+	b := newBudget(Budget{Window: time.Hour, MaxCalls: 1, Block: true})
+	_, err := b.take(context.Background(), "flow_blockNumber", PriorityNormal)
+	require.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = b.take(ctx, "flow_blockNumber", PriorityUser)
+	require.Equal(t, context.DeadlineExceeded, err)
+	require.Equal(t, 0, b.waiting[PriorityUser])
+}
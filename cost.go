@@ -0,0 +1,80 @@
+package asimovrpc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CostWeights - provider credits charged per call of each method
+type CostWeights struct {
+	Default float64
+	Methods map[string]float64
+}
+
+// Weight returns credits charged for a single call of method
+func (w CostWeights) Weight(method string) float64 {
+	if weight, ok := w.Methods[method]; ok {
+		return weight
+	}
+
+	return w.Default
+}
+
+// MethodCost - cost accounting of a single method
+type MethodCost struct {
+	Method        string
+	Calls         int
+	Weight        float64
+	Credits       float64
+	RequestBytes  int64
+	ResponseBytes int64
+}
+
+// CostReport - provider credits spent since client creation, most expensive methods first
+type CostReport struct {
+	Methods       []MethodCost
+	Credits       float64
+	RequestBytes  int64
+	ResponseBytes int64
+}
+
+// CostReport returns cost accounting of all calls made by client using given weights
+func (rpc *AsimovRPC) CostReport(weights CostWeights) CostReport {
+	var report CostReport
+	for method, stats := range rpc.Stats() {
+		cost := MethodCost{
+			Method:        method,
+			Calls:         stats.Calls,
+			Weight:        weights.Weight(method),
+			RequestBytes:  stats.RequestBytes,
+			ResponseBytes: stats.ResponseBytes,
+		}
+		cost.Credits = float64(cost.Calls) * cost.Weight
+
+		report.Methods = append(report.Methods, cost)
+		report.Credits += cost.Credits
+		report.RequestBytes += cost.RequestBytes
+		report.ResponseBytes += cost.ResponseBytes
+	}
+
+	sort.Slice(report.Methods, func(i, j int) bool {
+		if report.Methods[i].Credits != report.Methods[j].Credits {
+			return report.Methods[i].Credits > report.Methods[j].Credits
+		}
+		return report.Methods[i].Method < report.Methods[j].Method
+	})
+
+	return report
+}
+
+func (r CostReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-40s %8s %8s %12s %12s %12s\n", "method", "calls", "weight", "credits", "sent", "received")
+	for _, m := range r.Methods {
+		fmt.Fprintf(&b, "%-40s %8d %8g %12g %12d %12d\n", m.Method, m.Calls, m.Weight, m.Credits, m.RequestBytes, m.ResponseBytes)
+	}
+	fmt.Fprintf(&b, "%-40s %8s %8s %12g %12d %12d\n", "total", "", "", r.Credits, r.RequestBytes, r.ResponseBytes)
+
+	return b.String()
+}
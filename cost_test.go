@@ -0,0 +1,47 @@
+package asimovrpc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCostWeights(t *testing.T) {
+	weights := CostWeights{Default: 1, Methods: map[string]float64{"flow_getLogs": 75}}
+	require.Equal(t, float64(75), weights.Weight("flow_getLogs"))
+	require.Equal(t, float64(1), weights.Weight("flow_blockNumber"))
+}
+
+func (s *AsimovRPCTestSuite) TestCostReport() {
+	rpc := New(s.rpc.url)
+
+	s.registerResponses(map[string]string{
+		"flow_blockNumber": `"0x1"`,
+		"flow_getLogs":     `[]`,
+	}, func([]byte) {})
+	for i := 0; i < 3; i++ {
+		_, err := rpc.AsimovBlockNumber()
+		s.Require().Nil(err)
+	}
+	_, err := rpc.AsimovGetLogs(FilterParams{})
+	s.Require().Nil(err)
+
+	stats := rpc.Stats()["flow_blockNumber"]
+	s.Require().Equal(int64(3*len(`{"id":1,"jsonrpc":"2.0","method":"flow_blockNumber","params":null}`)), stats.RequestBytes)
+	s.Require().Equal(int64(3*len(`{"jsonrpc":"2.0", "id":1, "result": "0x1"}`)), stats.ResponseBytes)
+
+	report := rpc.CostReport(CostWeights{Default: 1, Methods: map[string]float64{"flow_getLogs": 75}})
+	s.Require().Equal(float64(78), report.Credits)
+	s.Require().Len(report.Methods, 2)
+	s.Require().Equal("flow_getLogs", report.Methods[0].Method)
+	s.Require().Equal(float64(75), report.Methods[0].Credits)
+	s.Require().Equal("flow_blockNumber", report.Methods[1].Method)
+	s.Require().Equal(3, report.Methods[1].Calls)
+	s.Require().Equal(stats.RequestBytes+report.Methods[0].RequestBytes, report.RequestBytes)
+
+	lines := strings.Split(strings.TrimSpace(report.String()), "\n")
+	s.Require().Len(lines, 4)
+	s.Require().True(strings.HasPrefix(lines[1], "flow_getLogs "))
+	s.Require().True(strings.HasPrefix(lines[3], "total "))
+}
@@ -0,0 +1,71 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DebugFormat - format of debug request/response records
+type DebugFormat int
+
+// Debug record formats
+const (
+	DebugText DebugFormat = iota
+	DebugJSON
+)
+
+// DefaultDebugPayloadLimit - default maximum payload size included in JSON debug records
+const DefaultDebugPayloadLimit = 1024
+
+type debugRecord struct {
+	Time       string  `json:"time"`
+	Method     string  `json:"method"`
+	ID         int     `json:"id"`
+	Tags       Tags    `json:"tags,omitempty"`
+	DurationMs float64 `json:"duration_ms"`
+	Request    string  `json:"request"`
+	Response   string  `json:"response"`
+}
+
+func (rpc *AsimovRPC) debug(method string, id int, tags Tags, duration time.Duration, request, response []byte) {
+	rpc.mu.RLock()
+	format, limit := rpc.debugFormat, rpc.debugPayloadLimit
+	rpc.mu.RUnlock()
+
+	request, response = redact(request), redact(response)
+	if rpc.structured != nil {
+		rpc.logCall(method, id, tags, duration, request, response, limit)
+		return
+	}
+	if format != DebugJSON {
+		if len(tags) > 0 {
+			method = fmt.Sprintf("%s [%s]", method, tags)
+		}
+		rpc.log.Println(fmt.Sprintf("%s\nRequest: %s\nResponse: %s\n", method, request, response))
+		return
+	}
+
+	line, err := json.Marshal(debugRecord{
+		Time:       rpc.clock.Now().UTC().Format(time.RFC3339Nano),
+		Method:     method,
+		ID:         id,
+		Tags:       tags,
+		DurationMs: float64(duration) / float64(time.Millisecond),
+		Request:    truncate(request, limit),
+		Response:   truncate(response, limit),
+	})
+	if err != nil {
+		return
+	}
+
+	rpc.log.Println(string(line))
+}
+
+func truncate(data []byte, limit int) string {
+	if limit <= 0 || len(data) <= limit {
+		return string(data)
+	}
+
+	return fmt.Sprintf("%s...(%d bytes)", data[:limit], len(data))
+}
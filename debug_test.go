@@ -0,0 +1,58 @@
+package asimovrpc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+type bufferLogger struct {
+	lines []string
+}
+
+func (l *bufferLogger) Println(v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprint(v...))
+}
+
+func (s *AsimovRPCTestSuite) TestDebugText() {
+	log := new(bufferLogger)
+	rpc := New(s.rpc.url, WithLogger(log), WithDebug(true))
+
+	s.registerResponse(`"0x1"`, func([]byte) {})
+	_, err := rpc.Call("flow_blockNumber")
+	s.Require().Nil(err)
+
+	s.Require().Len(log.lines, 1)
+	s.Require().True(strings.HasPrefix(log.lines[0], "flow_blockNumber\nRequest: "))
+}
+
+func (s *AsimovRPCTestSuite) TestDebugJSON() {
+	log := new(bufferLogger)
+	rpc := New(s.rpc.url, WithLogger(log), WithDebug(true), WithDebugFormat(DebugJSON), WithDebugPayloadLimit(16))
+
+	s.registerResponse(`"0x1"`, func([]byte) {})
+	_, err := rpc.Call("flow_blockNumber")
+	s.Require().Nil(err)
+
+	s.Require().Len(log.lines, 1)
+	s.Require().False(strings.Contains(log.lines[0], "\n"))
+	record := gjson.Parse(log.lines[0])
+	s.Require().Equal("flow_blockNumber", record.Get("method").String())
+	s.Require().Equal(int64(1), record.Get("id").Int())
+	s.Require().True(record.Get("duration_ms").Exists())
+	s.Require().True(strings.HasPrefix(record.Get("request").String(), `{"id":1,"jsonrpc...(`))
+	s.Require().True(strings.HasSuffix(record.Get("response").String(), "bytes)"))
+
+	// records are stamped by the client clock
+	clock := asimovrpctest.NewFakeClock(time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC))
+	rpc = New(s.rpc.url, WithLogger(log), WithDebug(true), WithDebugFormat(DebugJSON), WithClock(clock))
+	_, err = rpc.Call("flow_blockNumber")
+	s.Require().Nil(err)
+	record = gjson.Parse(log.lines[1])
+	s.Require().Equal("2019-10-01T00:00:00Z", record.Get("time").String())
+	s.Require().Equal(float64(0), record.Get("duration_ms").Float())
+}
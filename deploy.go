@@ -0,0 +1,110 @@
+package asimovrpc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// MaxCodeSize is the maximum size of deployed contract code in bytes (EIP-170)
+	MaxCodeSize = 24576
+	// MaxInitCodeSize is the maximum size of contract creation code in bytes (EIP-3860)
+	MaxInitCodeSize = 2 * MaxCodeSize
+)
+
+// PreflightError - deployment rejected by a pre-flight check
+type PreflightError struct {
+	Check   string
+	Message string
+	Err     error
+}
+
+func (err PreflightError) Error() string {
+	if err.Err != nil {
+		return fmt.Sprintf("Preflight %s failed: %s: %s", err.Check, err.Message, err.Err)
+	}
+
+	return fmt.Sprintf("Preflight %s failed: %s", err.Check, err.Message)
+}
+
+// Unwrap returns the underlying error
+func (err PreflightError) Unwrap() error {
+	return err.Err
+}
+
+// Preflight - result of contract deployment pre-flight checks
+type Preflight struct {
+	InitCodeSize int
+	CodeSize     int
+	Gas          int
+}
+
+// PreflightDeploy checks a contract creation transaction without spending gas.
+// It validates the creation code size, simulates the constructor with flow_call to detect reverts
+// and oversized runtime code, and estimates the gas needed for the deployment. Only ErrExecutionReverted
+// fails the simulation check, other flow_call errors are returned as they are.
+func (rpc *AsimovRPC) PreflightDeploy(transaction T) (*Preflight, error) {
+	if transaction.To != "" {
+		return nil, PreflightError{Check: "transaction", Message: "contract creation must not have a recipient"}
+	}
+
+	preflight := new(Preflight)
+	preflight.InitCodeSize = hexSize(transaction.Data)
+	if preflight.InitCodeSize == 0 {
+		return nil, PreflightError{Check: "bytecode", Message: "transaction data is empty"}
+	}
+	if preflight.InitCodeSize > MaxInitCodeSize {
+		return nil, PreflightError{
+			Check:   "bytecode",
+			Message: fmt.Sprintf("creation code is %d bytes, limit is %d", preflight.InitCodeSize, MaxInitCodeSize),
+		}
+	}
+
+	code, err := rpc.AsimovCall(transaction, Pending())
+	if errors.Is(err, ErrExecutionReverted) {
+		return nil, PreflightError{Check: "simulation", Message: "constructor reverted", Err: err}
+	}
+	if err != nil {
+		return nil, err
+	}
+	preflight.CodeSize = hexSize(code)
+	if preflight.CodeSize > MaxCodeSize {
+		return nil, PreflightError{
+			Check:   "bytecode",
+			Message: fmt.Sprintf("deployed code is %d bytes, limit is %d", preflight.CodeSize, MaxCodeSize),
+		}
+	}
+
+	preflight.Gas, err = rpc.AsimovEstimateGas(transaction)
+	if err != nil {
+		return nil, PreflightError{Check: "gas", Message: "unable to estimate constructor gas", Err: err}
+	}
+	if transaction.Gas > 0 && transaction.Gas < preflight.Gas {
+		return nil, PreflightError{
+			Check:   "gas",
+			Message: fmt.Sprintf("gas limit %d is below estimated %d", transaction.Gas, preflight.Gas),
+		}
+	}
+
+	return preflight, nil
+}
+
+// DeployContract runs PreflightDeploy and sends the contract creation transaction.
+// If the transaction has no gas limit the estimated gas is used.
+func (rpc *AsimovRPC) DeployContract(transaction T) (string, error) {
+	preflight, err := rpc.PreflightDeploy(transaction)
+	if err != nil {
+		return "", err
+	}
+
+	if transaction.Gas == 0 {
+		transaction.Gas = preflight.Gas
+	}
+
+	return rpc.AsimovSendTransaction(transaction)
+}
+
+func hexSize(data string) int {
+	return len(strings.TrimPrefix(data, "0x")) / 2
+}
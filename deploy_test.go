@@ -0,0 +1,86 @@
+package asimovrpc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func (s *AsimovRPCTestSuite) TestPreflightDeploy() {
+	transaction := T{
+		From: "0x111",
+		Data: "0x6080604052",
+	}
+
+	_, err := s.rpc.PreflightDeploy(T{From: "0x111", To: "0x222", Data: "0x60"})
+	s.Require().IsType(PreflightError{}, err)
+
+	_, err = s.rpc.PreflightDeploy(T{From: "0x111"})
+	s.Require().Equal("bytecode", err.(PreflightError).Check)
+
+	_, err = s.rpc.PreflightDeploy(T{From: "0x111", Data: "0x" + strings.Repeat("00", MaxInitCodeSize+1)})
+	s.Require().Equal("bytecode", err.(PreflightError).Check)
+
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, fmt.Sprintf(`{"jsonrpc":"2.0", "id":%s, "error": {"code": 3, "message": "execution reverted: not owner"}}`,
+			requestID(s.getBody(request)))), nil
+	})
+	_, err = s.rpc.PreflightDeploy(transaction)
+	s.Require().Equal("simulation", err.(PreflightError).Check)
+	s.Require().True(errors.Is(err, ErrExecutionReverted))
+
+	// failures other than a revert say nothing about the constructor
+	s.registerResponses(map[string]string{"flow_estimateGas": `"0x5208"`}, func([]byte) {})
+	_, err = s.rpc.PreflightDeploy(transaction)
+	s.Require().IsType(AsimovError{}, err)
+
+	s.registerResponseError(errors.New("connection refused"))
+	_, err = s.rpc.PreflightDeploy(transaction)
+	s.Require().False(errors.As(err, &PreflightError{}))
+
+	s.registerResponses(map[string]string{
+		"flow_call":        `"0x` + strings.Repeat("00", MaxCodeSize+1) + `"`,
+		"flow_estimateGas": `"0x5208"`,
+	}, func([]byte) {})
+	_, err = s.rpc.PreflightDeploy(transaction)
+	s.Require().Equal("bytecode", err.(PreflightError).Check)
+
+	s.registerResponses(map[string]string{
+		"flow_call":        `"0x6080"`,
+		"flow_estimateGas": `"0x5208"`,
+	}, func(body []byte) {
+		if strings.Contains(string(body), "flow_call") {
+			s.paramsEqual(body, `[{"from":"0x111","data":"0x6080604052","gas":"0x64"}, "pending"]`)
+		}
+	})
+	_, err = s.rpc.PreflightDeploy(T{From: "0x111", Data: "0x6080604052", Gas: 100})
+	s.Require().Equal("gas", err.(PreflightError).Check)
+
+	s.registerResponses(map[string]string{
+		"flow_call":        `"0x6080"`,
+		"flow_estimateGas": `"0x5208"`,
+	}, func([]byte) {})
+	preflight, err := s.rpc.PreflightDeploy(transaction)
+	s.Require().Nil(err)
+	s.Require().Equal(&Preflight{InitCodeSize: 5, CodeSize: 2, Gas: 21000}, preflight)
+}
+
+func (s *AsimovRPCTestSuite) TestDeployContract() {
+	s.registerResponses(map[string]string{
+		"flow_call":            `"0x6080"`,
+		"flow_estimateGas":     `"0x5208"`,
+		"flow_sendTransaction": `"0x3333"`,
+	}, func(body []byte) {
+		if strings.Contains(string(body), "flow_sendTransaction") {
+			s.paramsEqual(body, `[{"from":"0x111","data":"0x6080604052","gas":"0x5208"}]`)
+		}
+	})
+
+	hash, err := s.rpc.DeployContract(T{From: "0x111", Data: "0x6080604052"})
+	s.Require().Nil(err)
+	s.Require().Equal("0x3333", hash)
+}
@@ -0,0 +1,128 @@
+package asimovrpc
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sort"
+	"sync"
+)
+
+// EndpointDiagnostics - connection statistics of a single endpoint
+type EndpointDiagnostics struct {
+	Endpoint      string
+	Requests      int
+	NewConns      int
+	ReusedConns   int
+	TLSHandshakes int
+	TLSResumed    int
+	Protocols     map[string]int
+}
+
+// ReuseRate returns share of requests sent over reused connections
+func (d EndpointDiagnostics) ReuseRate() float64 {
+	total := d.NewConns + d.ReusedConns
+	if total == 0 {
+		return 0
+	}
+
+	return float64(d.ReusedConns) / float64(total)
+}
+
+// ResumptionRate returns share of TLS handshakes that resumed a previous session
+func (d EndpointDiagnostics) ResumptionRate() float64 {
+	if d.TLSHandshakes == 0 {
+		return 0
+	}
+
+	return float64(d.TLSResumed) / float64(d.TLSHandshakes)
+}
+
+type diagnostics struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointDiagnostics
+}
+
+func newDiagnostics() *diagnostics {
+	return &diagnostics{endpoints: map[string]*EndpointDiagnostics{}}
+}
+
+func (d *diagnostics) endpoint(url string) *EndpointDiagnostics {
+	e, ok := d.endpoints[url]
+	if !ok {
+		e = &EndpointDiagnostics{Endpoint: url, Protocols: map[string]int{}}
+		d.endpoints[url] = e
+	}
+
+	return e
+}
+
+func (d *diagnostics) trace(req *http.Request) *http.Request {
+	url := req.URL.String()
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+
+			if info.Reused {
+				d.endpoint(url).ReusedConns++
+			} else {
+				d.endpoint(url).NewConns++
+			}
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			if err != nil {
+				return
+			}
+
+			d.mu.Lock()
+			defer d.mu.Unlock()
+
+			e := d.endpoint(url)
+			e.TLSHandshakes++
+			if state.DidResume {
+				e.TLSResumed++
+			}
+		},
+	}
+
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
+
+func (d *diagnostics) response(url string, response *http.Response) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	e := d.endpoint(url)
+	e.Requests++
+	if response != nil {
+		e.Protocols[response.Proto]++
+	}
+}
+
+func (d *diagnostics) snapshot() []EndpointDiagnostics {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	result := make([]EndpointDiagnostics, 0, len(d.endpoints))
+	for _, e := range d.endpoints {
+		copied := *e
+		copied.Protocols = make(map[string]int, len(e.Protocols))
+		for proto, count := range e.Protocols {
+			copied.Protocols[proto] = count
+		}
+		result = append(result, copied)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Endpoint < result[j].Endpoint })
+
+	return result
+}
+
+// Diagnostics returns per-endpoint connection statistics (nil unless WithDiagnostics is enabled)
+func (rpc *AsimovRPC) Diagnostics() []EndpointDiagnostics {
+	if rpc.diagnostics == nil {
+		return nil
+	}
+
+	return rpc.diagnostics.snapshot()
+}
@@ -0,0 +1,35 @@
+package asimovrpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnostics(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(echoID(r, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`)))
+	}))
+	defer server.Close()
+
+	rpc := New(server.URL, WithHttpClient(server.Client()), WithDiagnostics(true))
+	for i := 0; i < 3; i++ {
+		_, err := rpc.Call("flow_blockNumber")
+		require.Nil(t, err)
+	}
+
+	diagnostics := rpc.Diagnostics()
+	require.Len(t, diagnostics, 1)
+	require.Equal(t, server.URL, diagnostics[0].Endpoint)
+	require.Equal(t, 3, diagnostics[0].Requests)
+	require.Equal(t, 1, diagnostics[0].NewConns)
+	require.Equal(t, 2, diagnostics[0].ReusedConns)
+	require.Equal(t, 1, diagnostics[0].TLSHandshakes)
+	require.Equal(t, map[string]int{"HTTP/1.1": 3}, diagnostics[0].Protocols)
+	require.InDelta(t, 2.0/3.0, diagnostics[0].ReuseRate(), 0.001)
+	require.Equal(t, 0.0, diagnostics[0].ResumptionRate())
+
+	require.Nil(t, New(server.URL).Diagnostics())
+}
@@ -0,0 +1,79 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mistdex/mist-asimov-rpc/openrpc"
+)
+
+const discoverMethod = "rpc.discover"
+
+// Discover returns OpenRPC document describing methods of the node (rpc.discover).
+// Nodes without rpc.discover fail with an error matching ErrMethodNotFound.
+func (rpc *AsimovRPC) Discover() (*openrpc.Document, error) {
+	return rpc.DiscoverContext(rpc.context())
+}
+
+// DiscoverContext is Discover bound to ctx
+func (rpc *AsimovRPC) DiscoverContext(ctx context.Context) (*openrpc.Document, error) {
+	result, err := rpc.CallContext(ctx, discoverMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return openrpc.Parse(result)
+}
+
+type schema struct {
+	mu       sync.Mutex
+	document *openrpc.Document
+	disabled bool // node does not support rpc.discover
+}
+
+// WithSchemaValidation validate calls, batched requests included, against OpenRPC document before sending them, so wrong param
+// counts and types fail with openrpc.ValidationError without the network round trip. If document is nil,
+// it is fetched with rpc.discover on the first call; validation is turned off if the node does not
+// support rpc.discover and retried on the next call if discovery fails otherwise.
+func WithSchemaValidation(document *openrpc.Document) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		rpc.schema = &schema{document: document}
+	}
+}
+
+// validate checks call against the schema of the client
+func (rpc *AsimovRPC) validate(ctx context.Context, method string, params []interface{}) error {
+	if rpc.schema == nil || method == discoverMethod {
+		return nil
+	}
+
+	document := rpc.schemaDocument(ctx)
+	if document == nil {
+		return nil
+	}
+
+	return document.Validate(method, params)
+}
+
+func (rpc *AsimovRPC) schemaDocument(ctx context.Context) *openrpc.Document {
+	rpc.schema.mu.Lock()
+	defer rpc.schema.mu.Unlock()
+
+	if rpc.schema.document != nil || rpc.schema.disabled {
+		return rpc.schema.document
+	}
+
+	document, err := rpc.DiscoverContext(ctx)
+	switch {
+	case errors.Is(err, ErrMethodNotFound):
+		rpc.schema.disabled = true
+	case err != nil:
+		rpc.warn(fmt.Sprintf("Schema discovery failed: %v", err))
+	default:
+		rpc.schema.document = document
+	}
+
+	return rpc.schema.document
+}
@@ -0,0 +1,74 @@
+package asimovrpc
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/mistdex/mist-asimov-rpc/openrpc"
+	"github.com/tidwall/gjson"
+)
+
+func (s *AsimovRPCTestSuite) TestDiscover() {
+	document, err := ioutil.ReadFile("openrpc/testdata/openrpc.json")
+	s.Require().Nil(err)
+	s.registerResponse(string(document), func(body []byte) {
+		s.methodEqual(body, "rpc.discover")
+	})
+
+	result, err := s.rpc.Discover()
+	s.Require().Nil(err)
+	s.Require().Equal("Asimov node", result.Info.Title)
+	s.Require().Len(result.Methods, 6)
+}
+
+func (s *AsimovRPCTestSuite) TestSchemaValidation() {
+	data, err := ioutil.ReadFile("openrpc/testdata/openrpc.json")
+	s.Require().Nil(err)
+
+	var methods []string
+	s.registerResponses(map[string]string{"rpc.discover": string(data), "flow_getBalance": `"0x10"`}, func(body []byte) {
+		methods = append(methods, gjson.GetBytes(body, "method").String())
+	})
+
+	rpc := New(s.rpc.url, WithSchemaValidation(nil))
+	_, err = rpc.Call("flow_getBalance", "0x6247cf0412c6462da2a51d05139e2a3c6c630f0a")
+	s.Require().Equal(openrpc.ValidationError{Method: "flow_getBalance", Reason: "expected 2 params, got 1"}, err)
+	_, err = rpc.AsimovGetBalance("0x6247cf0412c6462da2a51d05139e2a3c6c630f0a", Latest())
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"rpc.discover", "flow_getBalance"}, methods)
+
+	document, err := openrpc.Parse(data)
+	s.Require().Nil(err)
+	methods = nil
+	rpc = New(s.rpc.url, WithSchemaValidation(document))
+	_, err = rpc.Call("flow_getBalance", "0x12", "latest")
+	s.Require().IsType(openrpc.ValidationError{}, err)
+	s.Require().Empty(methods)
+
+	// batched requests are validated too
+	var balance string
+	batch := rpc.NewBatch().Add("flow_getBalance", &balance, "0x6247cf0412c6462da2a51d05139e2a3c6c630f0a")
+	s.Require().Nil(batch.Execute())
+	s.Require().Equal(openrpc.ValidationError{Method: "flow_getBalance", Reason: "expected 2 params, got 1"}, batch.Err(0))
+	s.Require().Empty(methods)
+}
+
+func (s *AsimovRPCTestSuite) TestSchemaValidationUnsupported() {
+	calls := 0
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return httpmock.NewStringResponse(200, echoID(request, `{"jsonrpc":"2.0", "id":1, "error": {"code": -32601, "message": "the method rpc.discover does not exist"}}`)), nil
+		}
+		return httpmock.NewStringResponse(200, echoID(request, `{"jsonrpc":"2.0", "id":1, "result": "0x10"}`)), nil
+	})
+
+	rpc := New(s.rpc.url, WithSchemaValidation(nil))
+	for i := 0; i < 2; i++ {
+		_, err := rpc.Call("flow_getBalance", "0x12")
+		s.Require().Nil(err)
+	}
+	s.Require().Equal(3, calls)
+}
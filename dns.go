@@ -0,0 +1,156 @@
+package asimovrpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultDNSRefresh - default interval between DNS resolutions of round-robin endpoints.
+// Addresses resolved by a TTLResolver are re-resolved sooner when their records expire.
+const DefaultDNSRefresh = 30 * time.Second
+
+// TTLResolver - DNS resolver returning addresses of host together with the TTL of their records (0 - unknown).
+// The standard resolver does not expose TTLs, implement it with a DNS library for round-robin transports to honour them.
+type TTLResolver interface {
+	LookupIPAddrTTL(ctx context.Context, host string) ([]net.IPAddr, time.Duration, error)
+}
+
+type resolvedHost struct {
+	ips     []net.IPAddr
+	expires time.Time
+	next    int
+}
+
+// rotate returns addresses rotated so every call starts with the next address
+func (h *resolvedHost) rotate() []net.IPAddr {
+	start := h.next % len(h.ips)
+	h.next++
+
+	return append(append([]net.IPAddr{}, h.ips[start:]...), h.ips[:start]...)
+}
+
+type roundRobinResolver struct {
+	mu      sync.Mutex
+	refresh time.Duration
+	clock   Clock
+	lookup  func(ctx context.Context, host string) ([]net.IPAddr, time.Duration, error)
+	hosts   map[string]*resolvedHost
+}
+
+func newRoundRobinResolver(refresh time.Duration) *roundRobinResolver {
+	if refresh <= 0 {
+		refresh = DefaultDNSRefresh
+	}
+
+	return &roundRobinResolver{
+		refresh: refresh,
+		clock:   SystemClock,
+		lookup: func(ctx context.Context, host string) ([]net.IPAddr, time.Duration, error) {
+			ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+			return ips, 0, err
+		},
+		hosts: map[string]*resolvedHost{},
+	}
+}
+
+// resolve returns addresses of host rotated so every call starts with the next address.
+// Addresses are cached for the TTL of their records, at most for the refresh interval.
+func (r *roundRobinResolver) resolve(ctx context.Context, host string) ([]net.IPAddr, error) {
+	r.mu.Lock()
+	if h, ok := r.hosts[host]; ok && !r.clock.Now().After(h.expires) {
+		ips := h.rotate()
+		r.mu.Unlock()
+		return ips, nil
+	}
+	r.mu.Unlock()
+
+	// concurrent dials of an expired host may look it up more than once, the last lookup is kept
+	ips, ttl, err := r.lookup(ctx, host)
+	if err == nil && len(ips) == 0 {
+		err = &net.DNSError{Err: "no addresses", Name: host, IsNotFound: true}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, ok := r.hosts[host]
+	if err != nil {
+		if !ok {
+			return nil, err
+		}
+		// keep serving stale addresses while DNS is unavailable
+		ips, ttl = h.ips, 0
+	}
+	if ttl <= 0 || ttl > r.refresh {
+		ttl = r.refresh
+	}
+	next := 0
+	if ok {
+		next = h.next
+	}
+	h = &resolvedHost{ips: ips, expires: r.clock.Now().Add(ttl), next: next}
+	r.hosts[host] = h
+
+	return h.rotate(), nil
+}
+
+func (r *roundRobinResolver) dialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		if net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		ips, err := r.resolve(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ip := range ips {
+			var conn net.Conn
+			conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+		}
+
+		return nil, err
+	}
+}
+
+// NewRoundRobinTransport creates http transport spreading new connections across all addresses
+// the endpoint host resolves to. Addresses are re-resolved every refresh interval (DefaultDNSRefresh
+// if 0): the standard resolver does not expose record TTLs, use NewTTLRoundRobinTransport to honour them.
+func NewRoundRobinTransport(refresh time.Duration) *http.Transport {
+	return newRoundRobinTransport(newRoundRobinResolver(refresh))
+}
+
+// NewTTLRoundRobinTransport is NewRoundRobinTransport resolving hosts with resolver,
+// addresses are re-resolved when their records expire and at least every refresh interval
+func NewTTLRoundRobinTransport(resolver TTLResolver, refresh time.Duration) *http.Transport {
+	return newRoundRobinTransport(newTTLResolver(resolver, refresh))
+}
+
+func newTTLResolver(resolver TTLResolver, refresh time.Duration) *roundRobinResolver {
+	r := newRoundRobinResolver(refresh)
+	r.lookup = resolver.LookupIPAddrTTL
+
+	return r
+}
+
+func newRoundRobinTransport(resolver *roundRobinResolver) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = resolver.dialContext(&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	})
+	transport.MaxIdleConnsPerHost = 16
+
+	return transport
+}
@@ -0,0 +1,167 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func TestRoundRobinResolver(t *testing.T) {
+	lookups := 0
+	clock := asimovrpctest.NewFakeClock(time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC))
+	resolver := newRoundRobinResolver(time.Hour)
+	resolver.clock = clock
+	resolver.lookup = func(ctx context.Context, host string) ([]net.IPAddr, time.Duration, error) {
+		lookups++
+		return []net.IPAddr{{IP: net.IPv4(10, 0, 0, 1)}, {IP: net.IPv4(10, 0, 0, 2)}}, 0, nil
+	}
+
+	first, err := resolver.resolve(context.Background(), "node")
+	require.Nil(t, err)
+	second, err := resolver.resolve(context.Background(), "node")
+	require.Nil(t, err)
+	third, err := resolver.resolve(context.Background(), "node")
+	require.Nil(t, err)
+
+	require.Equal(t, 1, lookups)
+	require.Equal(t, "10.0.0.1", first[0].IP.String())
+	require.Equal(t, "10.0.0.2", second[0].IP.String())
+	require.Equal(t, "10.0.0.1", second[1].IP.String())
+	require.Equal(t, "10.0.0.1", third[0].IP.String())
+
+	clock.Advance(time.Hour + time.Second)
+	resolver.lookup = func(ctx context.Context, host string) ([]net.IPAddr, time.Duration, error) {
+		lookups++
+		return nil, 0, errors.New("dns error")
+	}
+	stale, err := resolver.resolve(context.Background(), "node")
+	require.Nil(t, err)
+	require.Len(t, stale, 2)
+	require.Equal(t, 2, lookups)
+
+	// stale addresses are kept until the next refresh
+	_, err = resolver.resolve(context.Background(), "node")
+	require.Nil(t, err)
+	require.Equal(t, 2, lookups)
+
+	_, err = resolver.resolve(context.Background(), "other")
+	require.NotNil(t, err)
+}
+
+type ttlResolver struct {
+	ips     []net.IPAddr
+	ttl     time.Duration
+	lookups int
+}
+
+func (r *ttlResolver) LookupIPAddrTTL(ctx context.Context, host string) ([]net.IPAddr, time.Duration, error) {
+	r.lookups++
+	return r.ips, r.ttl, nil
+}
+
+func TestRoundRobinResolverTTL(t *testing.T) {
+	clock := asimovrpctest.NewFakeClock(time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC))
+	lookup := &ttlResolver{ips: []net.IPAddr{{IP: net.IPv4(10, 0, 0, 1)}}, ttl: 10 * time.Second}
+	resolver := newTTLResolver(lookup, time.Minute)
+	resolver.clock = clock
+
+	_, err := resolver.resolve(context.Background(), "node")
+	require.Nil(t, err)
+	clock.Advance(10 * time.Second)
+	_, err = resolver.resolve(context.Background(), "node")
+	require.Nil(t, err)
+	require.Equal(t, 1, lookup.lookups)
+
+	// addresses expire with their records
+	clock.Advance(time.Second)
+	_, err = resolver.resolve(context.Background(), "node")
+	require.Nil(t, err)
+	require.Equal(t, 2, lookup.lookups)
+
+	// and at least every refresh interval
+	lookup.ttl = time.Hour
+	clock.Advance(11 * time.Second)
+	_, err = resolver.resolve(context.Background(), "node")
+	require.Nil(t, err)
+	require.Equal(t, 3, lookup.lookups)
+	clock.Advance(time.Minute)
+	_, err = resolver.resolve(context.Background(), "node")
+	require.Nil(t, err)
+	require.Equal(t, 3, lookup.lookups)
+	clock.Advance(time.Second)
+	_, err = resolver.resolve(context.Background(), "node")
+	require.Nil(t, err)
+	require.Equal(t, 4, lookup.lookups)
+
+	// hosts without addresses fail instead of being cached
+	lookup.ips = nil
+	_, err = resolver.resolve(context.Background(), "empty")
+	var dnsErr *net.DNSError
+	require.True(t, errors.As(err, &dnsErr))
+	require.True(t, dnsErr.IsNotFound)
+}
+
+func TestRoundRobinTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(echoID(r, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`)))
+	}))
+	defer server.Close()
+
+	resolver := newRoundRobinResolver(0)
+	resolver.lookup = func(ctx context.Context, host string) ([]net.IPAddr, time.Duration, error) {
+		require.Equal(t, "node.local", host)
+		return []net.IPAddr{{IP: net.IPv4(127, 0, 0, 1)}}, 0, nil
+	}
+	transport := NewRoundRobinTransport(0)
+	transport.DialContext = resolver.dialContext(&net.Dialer{})
+
+	url := strings.Replace(server.URL, "127.0.0.1", "node.local", 1)
+	rpc := New(url, WithHttpClient(&http.Client{Transport: transport}))
+	number, err := rpc.AsimovBlockNumber()
+	require.Nil(t, err)
+	require.Equal(t, 1, number)
+	require.Equal(t, DefaultDNSRefresh, resolver.refresh)
+}
+
+func TestRoundRobinTransportResolves(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(echoID(r, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`)))
+	}))
+	defer server.Close()
+
+	url := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	rpc := New(url, WithHttpClient(&http.Client{Transport: NewRoundRobinTransport(time.Minute)}))
+	number, err := rpc.AsimovBlockNumber()
+	require.Nil(t, err)
+	require.Equal(t, 1, number)
+}
+
+func TestDNSResolverOption(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(echoID(r, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`)))
+	}))
+	defer server.Close()
+
+	clock := asimovrpctest.NewFakeClock(time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC))
+	lookup := &ttlResolver{ips: []net.IPAddr{{IP: net.IPv4(127, 0, 0, 1)}}, ttl: time.Second}
+	url := strings.Replace(server.URL, "127.0.0.1", "node.local", 1)
+	rpc := New(url, WithDNSResolver(lookup, time.Minute), WithClock(clock))
+	require.Equal(t, clock, rpc.resolver.clock)
+	_, err := rpc.AsimovBlockNumber()
+	require.Nil(t, err)
+	require.Equal(t, 1, lookup.lookups)
+
+	_, err = NewClient(url, WithDNSResolver(nil, time.Minute))
+	require.EqualError(t, err, "asimovrpc: invalid option WithDNSResolver: resolver is nil")
+	_, err = NewClient(url, WithDNSRoundRobin(time.Minute), WithDNSResolver(lookup, time.Minute))
+	require.EqualError(t, err, "asimovrpc: invalid option WithDNSResolver: conflicts with WithDNSRoundRobin")
+}
@@ -0,0 +1,166 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultEndpointRefresh - default interval between EndpointProvider polls
+const DefaultEndpointRefresh = 30 * time.Second
+
+// EndpointProvider - source of node URLs (Consul, Kubernetes Endpoints, static file, ...)
+type EndpointProvider interface {
+	Endpoints() ([]string, error)
+}
+
+// StaticEndpoints - fixed list of node URLs
+type StaticEndpoints []string
+
+// Endpoints returns the list
+func (e StaticEndpoints) Endpoints() ([]string, error) {
+	return e, nil
+}
+
+// FileEndpoints - path of a file listing node URLs, either as JSON array or one per line
+type FileEndpoints string
+
+// Endpoints reads the file
+func (path FileEndpoints) Endpoints() ([]string, error) {
+	data, err := ioutil.ReadFile(string(path))
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []string
+	if json.Unmarshal(data, &endpoints) == nil {
+		return endpoints, nil
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			endpoints = append(endpoints, line)
+		}
+	}
+
+	return endpoints, nil
+}
+
+type endpointSet struct {
+	mu         sync.Mutex
+	provider   EndpointProvider
+	refresh    time.Duration
+	clock      Clock
+	updated    time.Time
+	refreshing bool
+	endpoints  []string
+	next       int
+	err        error
+}
+
+func newEndpointSet(provider EndpointProvider, refresh time.Duration) *endpointSet {
+	if refresh <= 0 {
+		refresh = DefaultEndpointRefresh
+	}
+
+	return &endpointSet{
+		provider: provider,
+		refresh:  refresh,
+		clock:    SystemClock,
+	}
+}
+
+// stale returns provider to poll if endpoint list is older than refresh interval and no poll is
+// running, marking the poll as running. s.mu must be held.
+func (s *endpointSet) stale() EndpointProvider {
+	if s.refreshing || !s.updated.IsZero() && s.clock.Now().Sub(s.updated) < s.refresh {
+		return nil
+	}
+	s.refreshing = true
+
+	return s.provider
+}
+
+// poll asks provider for endpoints without holding s.mu, so a slow provider does not block callers.
+// Provider errors and empty lists keep the previous endpoints.
+func (s *endpointSet) poll(provider EndpointProvider) {
+	endpoints, err := provider.Endpoints()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.refreshing = false
+	s.updated = s.clock.Now()
+	s.err = err
+	if err == nil && len(endpoints) > 0 {
+		s.endpoints = endpoints
+	}
+}
+
+// pick returns next endpoint in round-robin order or fallback if there are none. The first list is
+// polled before picking, later lists are refreshed in the background while the current one is used.
+func (s *endpointSet) pick(fallback string) string {
+	s.mu.Lock()
+	if provider := s.stale(); provider != nil {
+		if s.updated.IsZero() {
+			s.mu.Unlock()
+			s.poll(provider)
+			s.mu.Lock()
+		} else {
+			go s.poll(provider)
+		}
+	}
+	defer s.mu.Unlock()
+
+	if len(s.endpoints) == 0 {
+		return fallback
+	}
+
+	endpoint := s.endpoints[s.next%len(s.endpoints)]
+	s.next++
+
+	return endpoint
+}
+
+func (s *endpointSet) list() ([]string, error) {
+	s.mu.Lock()
+	provider := s.stale()
+	s.mu.Unlock()
+	if provider != nil {
+		s.poll(provider)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.endpoints...), s.err
+}
+
+// Endpoints returns current node list and the last provider error.
+// Without an EndpointProvider it returns the client url.
+func (rpc *AsimovRPC) Endpoints() ([]string, error) {
+	rpc.mu.RLock()
+	url, endpoints := rpc.url, rpc.endpoints
+	rpc.mu.RUnlock()
+
+	if endpoints == nil {
+		return []string{url}, nil
+	}
+
+	return endpoints.list()
+}
+
+func (rpc *AsimovRPC) endpoint() string {
+	rpc.mu.RLock()
+	url, endpoints := rpc.url, rpc.endpoints
+	rpc.mu.RUnlock()
+
+	if endpoints == nil {
+		return url
+	}
+
+	return endpoints.pick(url)
+}
@@ -0,0 +1,114 @@
+package asimovrpc
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+type endpointsFunc func() ([]string, error)
+
+func (f endpointsFunc) Endpoints() ([]string, error) {
+	return f()
+}
+
+func TestFileEndpoints(t *testing.T) {
+	dir, err := ioutil.TempDir("", "endpoints")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "nodes")
+	require.Nil(t, ioutil.WriteFile(path, []byte("# fleet\nhttp://a:8545\n\n http://b:8545 \n"), 0644))
+	endpoints, err := FileEndpoints(path).Endpoints()
+	require.Nil(t, err)
+	require.Equal(t, []string{"http://a:8545", "http://b:8545"}, endpoints)
+
+	require.Nil(t, ioutil.WriteFile(path, []byte(`["http://c:8545"]`), 0644))
+	endpoints, err = FileEndpoints(path).Endpoints()
+	require.Nil(t, err)
+	require.Equal(t, []string{"http://c:8545"}, endpoints)
+
+	_, err = FileEndpoints(filepath.Join(dir, "missing")).Endpoints()
+	require.NotNil(t, err)
+}
+
+func TestEndpointSet(t *testing.T) {
+	calls := 0
+	set := newEndpointSet(endpointsFunc(func() ([]string, error) {
+		calls++
+		if calls > 1 {
+			return nil, errors.New("provider error")
+		}
+		return []string{"http://a", "http://b"}, nil
+	}), 0)
+
+	require.Equal(t, "http://a", set.pick("http://fallback"))
+	require.Equal(t, "http://b", set.pick("http://fallback"))
+	require.Equal(t, "http://a", set.pick("http://fallback"))
+	require.Equal(t, 1, calls)
+
+	set.updated = set.updated.Add(-DefaultEndpointRefresh)
+	endpoints, err := set.list()
+	require.Equal(t, []string{"http://a", "http://b"}, endpoints)
+	require.EqualError(t, err, "provider error")
+	require.Equal(t, 2, calls)
+
+	empty := newEndpointSet(StaticEndpoints{}, 0)
+	require.Equal(t, "http://fallback", empty.pick("http://fallback"))
+}
+
+func TestEndpointSetBackgroundRefresh(t *testing.T) {
+	release := make(chan struct{})
+	var calls int32
+	set := newEndpointSet(endpointsFunc(func() ([]string, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return []string{"http://a"}, nil
+		}
+		<-release
+		return []string{"http://b"}, nil
+	}), 0)
+	require.Equal(t, "http://a", set.pick("http://fallback"))
+
+	// a slow provider does not block picks, the refresh runs once in the background
+	set.mu.Lock()
+	set.updated = set.updated.Add(-DefaultEndpointRefresh)
+	set.mu.Unlock()
+	for i := 0; i < 3; i++ {
+		require.Equal(t, "http://a", set.pick("http://fallback"))
+	}
+	close(release)
+	require.Eventually(t, func() bool { return set.pick("http://fallback") == "http://b" }, time.Second, time.Millisecond)
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func (s *AsimovRPCTestSuite) TestEndpointProvider() {
+	rpc := New(s.rpc.url, WithEndpointProvider(StaticEndpoints{"http://node-1", "http://node-2"}, 0))
+
+	called := []string{}
+	for _, url := range []string{"http://node-1", "http://node-2"} {
+		url := url
+		httpmock.RegisterResponder("POST", url, func(request *http.Request) (*http.Response, error) {
+			called = append(called, url)
+			return httpmock.NewStringResponse(200, echoID(request, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`)), nil
+		})
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := rpc.Call("test")
+		s.Require().Nil(err)
+	}
+
+	s.Require().Equal([]string{"http://node-1", "http://node-2", "http://node-1"}, called)
+
+	endpoints, err := s.rpc.Endpoints()
+	s.Require().Nil(err)
+	s.Require().Equal([]string{s.rpc.url}, endpoints)
+}
@@ -0,0 +1,153 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errors reported by nodes, match them with errors.Is:
+//
+//	if errors.Is(err, asimovrpc.ErrNonceTooLow) {
+//		...
+//	}
+var (
+	ErrMethodNotFound    = errors.New("method not found")
+	ErrInvalidParams     = errors.New("invalid params")
+	ErrFilterNotFound    = errors.New("filter not found")
+	ErrNonceTooLow       = errors.New("nonce too low")
+	ErrNonceTooHigh      = errors.New("nonce too high")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrExecutionReverted = errors.New("execution reverted")
+	ErrTooManyResults    = errors.New("too many results")
+	ErrRateLimited       = errors.New("rate limited")
+)
+
+// httpErrorBodyLimit - bytes of error page kept in HTTPError
+const httpErrorBodyLimit = 512
+
+// errorClass - node error matching sentinel by code or by message pattern
+type errorClass struct {
+	target  error
+	code    int    // 0 - any code
+	pattern string // lower case message substring, "" - any message
+}
+
+// errorClasses - a class matches if either its code or its pattern matches
+var errorClasses = []errorClass{
+	{target: ErrMethodNotFound, code: -32601},
+	{target: ErrInvalidParams, code: -32602},
+	{target: ErrFilterNotFound, pattern: "filter not found"},
+	{target: ErrNonceTooLow, pattern: "nonce too low"},
+	{target: ErrNonceTooHigh, pattern: "nonce too high"},
+	{target: ErrInsufficientFunds, pattern: "insufficient funds"},
+	{target: ErrExecutionReverted, code: 3, pattern: "execution reverted"},
+	{target: ErrTooManyResults, pattern: "query returned more than"},
+	{target: ErrTooManyResults, pattern: "block range"},
+	{target: ErrTooManyResults, pattern: "response size exceeded"},
+}
+
+// Is reports whether node error belongs to sentinel target
+func (err AsimovError) Is(target error) bool {
+	message := strings.ToLower(err.Message)
+	for _, class := range errorClasses {
+		if class.target != target {
+			continue
+		}
+		if class.code != 0 && class.code == err.Code {
+			return true
+		}
+		if class.pattern != "" && strings.Contains(message, class.pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DecodeData unmarshals Data of node error into v
+func (err AsimovError) DecodeData(v interface{}) error {
+	if len(err.Data) == 0 {
+		return errors.New("Node error has no data")
+	}
+
+	return json.Unmarshal(err.Data, v)
+}
+
+// RevertData returns true if err is execution reverted node error, together with revert data
+// ("0x..." ABI encoded reason) if node sent any
+func RevertData(err error) (string, bool) {
+	var asimovErr AsimovError
+	if !errors.As(err, &asimovErr) || !errors.Is(asimovErr, ErrExecutionReverted) {
+		return "", false
+	}
+
+	var data string
+	asimovErr.DecodeData(&data)
+
+	return data, true
+}
+
+// HTTPError - endpoint answered with non-2xx status, usually with an HTML or text error page instead of JSON.
+// Responses with status 429 match ErrRateLimited.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Body       string        // start of the response body
+	RetryAfter time.Duration // delay requested with Retry-After header, 0 - none
+}
+
+func (err HTTPError) Error() string {
+	if err.Body == "" {
+		return fmt.Sprintf("HTTP %s", err.Status)
+	}
+
+	return fmt.Sprintf("HTTP %s: %s", err.Status, err.Body)
+}
+
+// Is reports whether err is rate limiting matching ErrRateLimited
+func (err HTTPError) Is(target error) bool {
+	return target == ErrRateLimited && err.StatusCode == http.StatusTooManyRequests
+}
+
+// RetryAfter returns delay requested by a rate limited or unavailable endpoint with Retry-After header
+func RetryAfter(err error) (time.Duration, bool) {
+	var httpErr HTTPError
+	if !errors.As(err, &httpErr) || httpErr.RetryAfter <= 0 {
+		return 0, false
+	}
+
+	return httpErr.RetryAfter, true
+}
+
+func newHTTPError(response *http.Response, now time.Time) HTTPError {
+	body, _ := ioutil.ReadAll(io.LimitReader(response.Body, httpErrorBodyLimit))
+
+	return HTTPError{
+		StatusCode: response.StatusCode,
+		Status:     response.Status,
+		Body:       strings.TrimSpace(string(body)),
+		RetryAfter: parseRetryAfter(response.Header.Get("Retry-After"), now),
+	}
+}
+
+// parseRetryAfter parses Retry-After delay in seconds or HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
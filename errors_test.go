@@ -0,0 +1,112 @@
+package asimovrpc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsimovErrorIs(t *testing.T) {
+	tests := []struct {
+		err    AsimovError
+		target error
+	}{
+		{AsimovError{Code: -32601, Message: "the method flow_foo does not exist"}, ErrMethodNotFound},
+		{AsimovError{Code: -32602, Message: "missing value for required argument 0"}, ErrInvalidParams},
+		{AsimovError{Code: -32000, Message: "filter not found"}, ErrFilterNotFound},
+		{AsimovError{Code: -32000, Message: "Nonce too low"}, ErrNonceTooLow},
+		{AsimovError{Code: -32000, Message: "nonce too high"}, ErrNonceTooHigh},
+		{AsimovError{Code: -32000, Message: "insufficient funds for gas * price + value"}, ErrInsufficientFunds},
+		{AsimovError{Code: 3, Message: "reverted"}, ErrExecutionReverted},
+		{AsimovError{Code: -32000, Message: "execution reverted"}, ErrExecutionReverted},
+		{AsimovError{Code: -32005, Message: "query returned more than 10000 results"}, ErrTooManyResults},
+		{AsimovError{Code: -32000, Message: "exceed maximum block range: 5000"}, ErrTooManyResults},
+	}
+
+	for _, test := range tests {
+		require.True(t, errors.Is(test.err, test.target), test.err.Error())
+		require.True(t, errors.Is(fmt.Errorf("send: %w", test.err), test.target), test.err.Error())
+	}
+
+	require.False(t, errors.Is(AsimovError{Code: -32000, Message: "nonce too low"}, ErrNonceTooHigh))
+	require.False(t, errors.Is(AsimovError{Code: -32000, Message: "unknown block"}, ErrFilterNotFound))
+	require.False(t, errors.Is(errors.New("nonce too low"), ErrNonceTooLow))
+}
+
+func TestRevertData(t *testing.T) {
+	data, ok := RevertData(AsimovError{Code: 3, Message: "execution reverted", Data: []byte(`"0x08c379a0"`)})
+	require.True(t, ok)
+	require.Equal(t, "0x08c379a0", data)
+
+	data, ok = RevertData(AsimovError{Code: -32000, Message: "execution reverted"})
+	require.True(t, ok)
+	require.Equal(t, "", data)
+
+	_, ok = RevertData(AsimovError{Code: -32000, Message: "nonce too low"})
+	require.False(t, ok)
+	_, ok = RevertData(errors.New("execution reverted"))
+	require.False(t, ok)
+}
+
+func (s *AsimovRPCTestSuite) TestErrorData() {
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, echoID(request, `{"jsonrpc":"2.0", "id":1, "error": {"code": 3, "message": "execution reverted: not owner", "data": "0x08c379a0"}}`)), nil
+	})
+
+	_, err := s.rpc.Call("flow_call")
+	s.Require().True(errors.Is(err, ErrExecutionReverted))
+
+	var asimovErr AsimovError
+	s.Require().True(errors.As(err, &asimovErr))
+	var data string
+	s.Require().Nil(asimovErr.DecodeData(&data))
+	s.Require().Equal("0x08c379a0", data)
+	s.Require().NotNil(AsimovError{Code: 3}.DecodeData(&data))
+}
+
+func (s *AsimovRPCTestSuite) TestHTTPError() {
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		response := httpmock.NewStringResponse(502, "<html><body>Bad Gateway</body></html>\n")
+		response.Status = "502 Bad Gateway"
+		return response, nil
+	})
+
+	_, err := s.rpc.AsimovBlockNumber()
+	s.Require().Equal(HTTPError{StatusCode: 502, Status: "502 Bad Gateway", Body: "<html><body>Bad Gateway</body></html>"}, err)
+	s.Require().EqualError(err, "HTTP 502 Bad Gateway: <html><body>Bad Gateway</body></html>")
+	s.Require().False(errors.Is(err, ErrRateLimited))
+
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		response := httpmock.NewStringResponse(429, strings.Repeat("slow down ", 100))
+		response.Status = "429 Too Many Requests"
+		response.Header.Set("Retry-After", "7")
+		return response, nil
+	})
+
+	_, err = s.rpc.AsimovBlockNumber()
+	s.Require().True(errors.Is(err, ErrRateLimited))
+	delay, ok := RetryAfter(fmt.Errorf("wrapped: %w", err))
+	s.Require().True(ok)
+	s.Require().Equal(7*time.Second, delay)
+	s.Require().Len(err.(HTTPError).Body, httpErrorBodyLimit)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, 120*time.Second, parseRetryAfter("120", now))
+	require.Equal(t, 30*time.Second, parseRetryAfter("Wed, 01 Jan 2020 00:00:30 GMT", now))
+	require.Equal(t, time.Duration(0), parseRetryAfter("Tue, 31 Dec 2019 23:59:00 GMT", now))
+	require.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+	require.Equal(t, time.Duration(0), parseRetryAfter("", now))
+
+	_, ok := RetryAfter(errors.New("Error"))
+	require.False(t, ok)
+}
@@ -0,0 +1,169 @@
+package asimovrpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/mistdex/mist-asimov-rpc/annotations"
+)
+
+// TransferTopic - topic of ERC20/ERC721 Transfer(address,address,uint256) events
+const TransferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+// Explanation - human-readable description of what a transaction did
+type Explanation struct {
+	Transaction *Transaction
+	Receipt     *TransactionReceipt
+	Trace       *CallFrame
+	Narrative   []string
+}
+
+func (e Explanation) String() string {
+	return strings.Join(e.Narrative, "\n")
+}
+
+// Explainer - builds transaction explanations from transaction, receipt, logs and (when the node supports it) call trace
+type Explainer struct {
+	RPC     Client
+	Labels  annotations.Store
+	Methods MethodDecoder
+}
+
+// ExplainTransaction describes transaction with given hash using an Explainer without labels
+func (rpc *AsimovRPC) ExplainTransaction(ctx context.Context, hash string) (*Explanation, error) {
+	return Explainer{RPC: rpc}.Explain(ctx, hash)
+}
+
+// Explain describes transaction with given hash
+func (e Explainer) Explain(ctx context.Context, hash string) (*Explanation, error) {
+	rpc := bind(e.RPC, ctx)
+	transaction, err := rpc.AsimovGetTransactionByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if transaction.Hash == "" {
+		return nil, fmt.Errorf("transaction %s not found", hash)
+	}
+
+	explanation := &Explanation{Transaction: transaction}
+	e.describeTransaction(explanation)
+
+	if transaction.BlockNumber == nil {
+		explanation.Narrative = append(explanation.Narrative, "It is pending and has not been mined yet.")
+		return explanation, nil
+	}
+
+	explanation.Receipt, err = rpc.AsimovGetTransactionReceipt(hash)
+	if err != nil {
+		return nil, err
+	}
+	e.describeReceipt(explanation)
+
+	// call traces are optional, nodes without the debug namespace only get the receipt based explanation
+	trace, err := rpc.AsimovTraceTransaction(hash, &TraceConfig{Tracer: CallTracer})
+	if err == nil {
+		explanation.Trace, err = trace.CallFrame()
+	}
+	if err == nil {
+		e.describeCalls(explanation, *explanation.Trace, true)
+	} else if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	for _, log := range explanation.Receipt.Logs {
+		explanation.Narrative = append(explanation.Narrative, e.describeLog(log))
+	}
+
+	return explanation, nil
+}
+
+func (e Explainer) label(address string) string {
+	label := annotations.Label(e.Labels, address)
+	if label == address {
+		return address
+	}
+
+	return fmt.Sprintf("%s(%s)", label, address)
+}
+
+func (e Explainer) describeTransaction(explanation *Explanation) {
+	transaction := explanation.Transaction
+	sentence := fmt.Sprintf("Transaction %s from %s", transaction.Hash, e.label(transaction.From))
+	if transaction.To == "" {
+		sentence += " creates a contract"
+	} else {
+		sentence += " to " + e.label(transaction.To)
+		method := TraceFormatter{Methods: e.Methods}.method(CallFrame{Type: "CALL", To: transaction.To, Input: transaction.Input})
+		if method != "" {
+			sentence += " calls " + method
+		}
+	}
+	if transaction.Value.Sign() > 0 {
+		sentence += " sending " + transaction.Value.String()
+	}
+
+	explanation.Narrative = append(explanation.Narrative, sentence+".")
+}
+
+func (e Explainer) describeReceipt(explanation *Explanation) {
+	receipt := explanation.Receipt
+	status := "succeeded"
+	if receipt.Status == "0x0" {
+		status = "failed"
+	}
+
+	fee := new(big.Int).Mul(big.NewInt(int64(receipt.GasUsed)), &explanation.Transaction.GasPrice)
+	explanation.Narrative = append(explanation.Narrative, fmt.Sprintf(
+		"It %s in block %d at index %d, using %d of %d gas (fee %s).",
+		status, receipt.BlockNumber, receipt.TransactionIndex, receipt.GasUsed, explanation.Transaction.Gas, fee,
+	))
+
+	if receipt.ContractAddress != "" {
+		explanation.Narrative = append(explanation.Narrative, "It created contract "+e.label(receipt.ContractAddress)+".")
+	}
+}
+
+func (e Explainer) describeCalls(explanation *Explanation, frame CallFrame, root bool) {
+	if !root {
+		if frame.Value.Sign() > 0 {
+			explanation.Narrative = append(explanation.Narrative, fmt.Sprintf("%s sent %s to %s.", e.label(frame.From), frame.Value.String(), e.label(frame.To)))
+		}
+		if frame.Error != "" {
+			explanation.Narrative = append(explanation.Narrative, fmt.Sprintf("Internal call from %s to %s failed: %s.", e.label(frame.From), e.label(frame.To), frame.Error))
+		}
+	} else if frame.Error != "" {
+		explanation.Narrative = append(explanation.Narrative, "Execution failed: "+frame.Error+".")
+	}
+
+	for _, call := range frame.Calls {
+		e.describeCalls(explanation, call, false)
+	}
+}
+
+func (e Explainer) describeLog(log Log) string {
+	if len(log.Topics) == 3 && strings.EqualFold(log.Topics[0], TransferTopic) {
+		amount, err := ParseBigInt(log.Data)
+		if err == nil {
+			return fmt.Sprintf("Token %s transferred %s from %s to %s.",
+				e.label(log.Address), amount.String(), e.label(topicAddress(log.Topics[1])), e.label(topicAddress(log.Topics[2])))
+		}
+	}
+
+	topic := "anonymous event"
+	if len(log.Topics) > 0 {
+		topic = "event " + log.Topics[0]
+	}
+
+	return fmt.Sprintf("Contract %s emitted %s.", e.label(log.Address), topic)
+}
+
+func topicAddress(topic string) string {
+	topic = strings.TrimPrefix(topic, "0x")
+	if len(topic) < 40 {
+		return "0x" + topic
+	}
+
+	return "0x" + topic[len(topic)-40:]
+}
@@ -0,0 +1,121 @@
+package asimovrpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mistdex/mist-asimov-rpc/annotations"
+)
+
+const explainTransaction = `{
+	"hash": "0x01",
+	"nonce": "0x1",
+	"blockHash": "0xb1",
+	"blockNumber": "0x10",
+	"transactionIndex": "0x2",
+	"from": "0xaaa",
+	"to": "0xbbb",
+	"value": "0xde0b6b3a7640000",
+	"gas": "0x7a120",
+	"gasPrice": "0xa",
+	"input": "0x38ed1739000000"
+}`
+
+const explainReceipt = `{
+	"transactionHash": "0x01",
+	"transactionIndex": "0x2",
+	"blockHash": "0xb1",
+	"blockNumber": "0x10",
+	"cumulativeGasUsed": "0x5208",
+	"gasUsed": "0x5208",
+	"logs": [{
+		"logIndex": "0x0",
+		"transactionIndex": "0x2",
+		"transactionHash": "0x01",
+		"blockNumber": "0x10",
+		"blockHash": "0xb1",
+		"address": "0xccc",
+		"data": "0x64",
+		"topics": [
+			"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+			"0x000000000000000000000000000000000000000000000000000000000000000000000bbb",
+			"0x000000000000000000000000000000000000000000000000000000000000000000000ddd"
+		]
+	}, {
+		"logIndex": "0x1",
+		"transactionIndex": "0x2",
+		"transactionHash": "0x01",
+		"blockNumber": "0x10",
+		"blockHash": "0xb1",
+		"address": "0xbbb",
+		"data": "0x",
+		"topics": ["0x1c411e9a"]
+	}],
+	"status": "0x1"
+}`
+
+func (s *AsimovRPCTestSuite) TestExplainTransaction() {
+	s.registerResponses(map[string]string{
+		"flow_getTransactionByHash":  explainTransaction,
+		"flow_getTransactionReceipt": explainReceipt,
+		"debug_traceTransaction":     callTrace,
+	}, func(body []byte) {})
+
+	explainer := Explainer{
+		RPC: s.rpc,
+		Labels: annotations.NewMemoryStore(
+			annotations.Annotation{Address: "0xaaa", Label: "Trader"},
+			annotations.Annotation{Address: "0xbbb", Label: "Router"},
+			annotations.Annotation{Address: "0xccc", Label: "MIST"},
+		),
+		Methods: func(to, input string) (string, bool) {
+			if strings.HasPrefix(input, "0x38ed1739") {
+				return "swapExactTokensForTokens", true
+			}
+			return "", false
+		},
+	}
+
+	explanation, err := explainer.Explain(context.Background(), "0x01")
+	s.Require().Nil(err)
+	s.Require().NotNil(explanation.Trace)
+	s.Require().Equal([]string{
+		"Transaction 0x01 from Trader(0xaaa) to Router(0xbbb) calls swapExactTokensForTokens sending 1000000000000000000.",
+		"It succeeded in block 16 at index 2, using 21000 of 500000 gas (fee 210000).",
+		"Internal call from Router(0xbbb) to 0xddd failed: execution reverted.",
+		"Token MIST(0xccc) transferred 100 from 0x0000000000000000000000000000000000000bbb to 0x0000000000000000000000000000000000000ddd.",
+		"Contract Router(0xbbb) emitted event 0x1c411e9a.",
+	}, explanation.Narrative)
+}
+
+func (s *AsimovRPCTestSuite) TestExplainTransactionWithoutTrace() {
+	s.registerResponses(map[string]string{
+		"flow_getTransactionByHash":  explainTransaction,
+		"flow_getTransactionReceipt": strings.Replace(explainReceipt, `"status": "0x1"`, `"status": "0x0"`, 1),
+	}, func(body []byte) {})
+
+	explanation, err := s.rpc.ExplainTransaction(context.Background(), "0x01")
+	s.Require().Nil(err)
+	s.Require().Nil(explanation.Trace)
+	s.Require().Equal("It failed in block 16 at index 2, using 21000 of 500000 gas (fee 210000).", explanation.Narrative[1])
+	s.Require().Len(explanation.Narrative, 4)
+}
+
+func (s *AsimovRPCTestSuite) TestExplainPendingTransaction() {
+	s.registerResponses(map[string]string{
+		"flow_getTransactionByHash": `{"hash": "0x01", "from": "0xaaa", "to": "", "value": "0x0", "input": "0x6080"}`,
+	}, func(body []byte) {})
+
+	explanation, err := s.rpc.ExplainTransaction(context.Background(), "0x01")
+	s.Require().Nil(err)
+	s.Require().Equal("Transaction 0x01 from 0xaaa creates a contract.\nIt is pending and has not been mined yet.", explanation.String())
+}
+
+func (s *AsimovRPCTestSuite) TestExplainMissingTransaction() {
+	s.registerResponses(map[string]string{
+		"flow_getTransactionByHash": `null`,
+	}, func(body []byte) {})
+
+	_, err := s.rpc.ExplainTransaction(context.Background(), "0x01")
+	s.Require().EqualError(err, "transaction 0x01 not found")
+}
@@ -0,0 +1,90 @@
+package asimovrpc
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ExtensionConstructor creates typed wrapper of a namespace for the client it is given.
+// Wrappers are created on every Extension call, so they follow per-call clones such as WithContext.
+type ExtensionConstructor func(rpc Client) interface{}
+
+type extensions struct {
+	sync.RWMutex
+	constructors map[string]ExtensionConstructor
+}
+
+// UnknownExtensionError - no extension registered under the name
+type UnknownExtensionError struct {
+	Name string
+}
+
+func (err UnknownExtensionError) Error() string {
+	return fmt.Sprintf("Extension %s is not registered", err.Name)
+}
+
+// RegisterExtension attaches constructor of a vendor namespace wrapper to the client and its clones
+func (rpc *AsimovRPC) RegisterExtension(name string, constructor ExtensionConstructor) error {
+	if name == "" {
+		return errors.New("extension name is empty")
+	}
+	if constructor == nil {
+		return fmt.Errorf("extension %s: constructor is nil", name)
+	}
+
+	rpc.extensions.Lock()
+	defer rpc.extensions.Unlock()
+
+	if _, ok := rpc.extensions.constructors[name]; ok {
+		return fmt.Errorf("extension %s is already registered", name)
+	}
+	rpc.extensions.constructors[name] = constructor
+
+	return nil
+}
+
+// Extension returns wrapper created by constructor registered under name
+func (rpc *AsimovRPC) Extension(name string) (interface{}, error) {
+	rpc.extensions.RLock()
+	constructor, ok := rpc.extensions.constructors[name]
+	rpc.extensions.RUnlock()
+
+	if !ok {
+		return nil, UnknownExtensionError{Name: name}
+	}
+
+	return constructor(rpc), nil
+}
+
+// ExtensionAs returns extension registered under name as T
+func ExtensionAs[T any](rpc Client, name string) (T, error) {
+	var zero T
+	extension, err := rpc.Extension(name)
+	if err != nil {
+		return zero, err
+	}
+
+	typed, ok := extension.(T)
+	if !ok {
+		return zero, fmt.Errorf("extension %s is %T, not %T", name, extension, zero)
+	}
+
+	return typed, nil
+}
+
+// Namespace - base of extension wrappers, calling namespace methods through the client
+// so they share its transport, budget, stats and logging
+type Namespace struct {
+	namespace
+}
+
+// NewNamespace creates namespace of client, which may be a mock
+func NewNamespace(rpc Client, name string) Namespace {
+	return Namespace{namespace{rpc: rpc, name: name}}
+}
+
+// Call calls method of namespace, e.g. Call("peers", &peers) calls <name>_peers, and decodes result into target
+func (ns Namespace) Call(method string, target interface{}, params ...interface{}) error {
+	return ns.call(ns.name+"_"+method, target, params...)
+}
@@ -0,0 +1,42 @@
+package asimovrpc
+
+import (
+	"context"
+)
+
+type clusterAPI struct {
+	Namespace
+}
+
+func (api clusterAPI) Leader() (string, error) {
+	var leader string
+	err := api.Call("leader", &leader)
+	return leader, err
+}
+
+func (s *AsimovRPCTestSuite) TestExtension() {
+	rpc := New(s.rpc.url)
+	constructor := func(rpc Client) interface{} {
+		return clusterAPI{NewNamespace(rpc, "cluster")}
+	}
+	s.Require().Nil(rpc.RegisterExtension("cluster", constructor))
+	s.Require().EqualError(rpc.RegisterExtension("cluster", constructor), "extension cluster is already registered")
+	s.Require().NotNil(rpc.RegisterExtension("", constructor))
+
+	var method string
+	s.registerResponse(`"node-1"`, func(body []byte) { method = string(body) })
+
+	// registrations are shared with clones
+	cluster, err := ExtensionAs[clusterAPI](rpc.WithContext(context.Background()), "cluster")
+	s.Require().Nil(err)
+	leader, err := cluster.Leader()
+	s.Require().Nil(err)
+	s.Require().Equal("node-1", leader)
+	s.methodEqual([]byte(method), "cluster_leader")
+	s.Require().Equal(1, rpc.Stats()["cluster_leader"].Calls)
+
+	_, err = rpc.Extension("missing")
+	s.Require().Equal(UnknownExtensionError{Name: "missing"}, err)
+	_, err = ExtensionAs[Web3API](rpc, "cluster")
+	s.Require().EqualError(err, "extension cluster is asimovrpc.clusterAPI, not asimovrpc.Web3API")
+}
@@ -0,0 +1,211 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// FailoverPolicy - when endpoints of a failover client are considered down and retried
+type FailoverPolicy struct {
+	MaxFailures int           // consecutive failures marking endpoint down
+	Cooldown    time.Duration // time before a down endpoint is tried again
+}
+
+// DefaultFailoverPolicy - failover policy used by WithFailover
+var DefaultFailoverPolicy = FailoverPolicy{
+	MaxFailures: 3,
+	Cooldown:    30 * time.Second,
+}
+
+// EndpointHealth - health of a failover endpoint
+type EndpointHealth struct {
+	Endpoint  string
+	Healthy   bool
+	Failures  int // consecutive failures
+	LastError error
+	DownSince time.Time
+}
+
+// EndpointStatusError - endpoint of a failover client answered with a server error status
+type EndpointStatusError struct {
+	Endpoint string
+	Status   string
+	Err      HTTPError
+}
+
+func (err EndpointStatusError) Error() string {
+	return fmt.Sprintf("Endpoint %s responded %s", err.Endpoint, err.Status)
+}
+
+// Unwrap returns the HTTP error, so errors.As and RetryAfter work with and without failover
+func (err EndpointStatusError) Unwrap() error {
+	return err.Err
+}
+
+type failover struct {
+	mu        sync.Mutex
+	policy    FailoverPolicy
+	clock     Clock
+	endpoints []*EndpointHealth
+}
+
+func newFailover(urls []string, policy FailoverPolicy) *failover {
+	f := &failover{policy: policy, clock: SystemClock}
+	for _, url := range urls {
+		f.endpoints = append(f.endpoints, &EndpointHealth{Endpoint: url, Healthy: true})
+	}
+
+	return f
+}
+
+// order returns endpoints to try: healthy ones and those past cooldown in priority order,
+// then the remaining down endpoints as a last resort
+func (f *failover) order() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.clock.Now()
+	var available, down []string
+	for _, e := range f.endpoints {
+		if e.Healthy || now.Sub(e.DownSince) >= f.policy.Cooldown {
+			available = append(available, e.Endpoint)
+		} else {
+			down = append(down, e.Endpoint)
+		}
+	}
+
+	return append(available, down...)
+}
+
+func (f *failover) endpoint(url string) *EndpointHealth {
+	for _, e := range f.endpoints {
+		if e.Endpoint == url {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func (f *failover) success(url string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if e := f.endpoint(url); e != nil {
+		e.Healthy = true
+		e.Failures = 0
+		e.DownSince = time.Time{}
+	}
+}
+
+func (f *failover) failure(url string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	e := f.endpoint(url)
+	if e == nil {
+		return
+	}
+
+	e.Failures++
+	e.LastError = err
+	if e.Failures >= f.policy.MaxFailures || !e.Healthy {
+		// a failed retry after cooldown restarts the cooldown
+		e.Healthy = false
+		e.DownSince = f.clock.Now()
+	}
+}
+
+func (f *failover) health() []EndpointHealth {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result := make([]EndpointHealth, len(f.endpoints))
+	for i, e := range f.endpoints {
+		result[i] = *e
+	}
+
+	return result
+}
+
+// endpointFailure returns true if err means the endpoint is down: a connection error or a 5xx response
+func endpointFailure(err error) bool {
+	var statusErr EndpointStatusError
+	return connectionError(err) || errors.As(err, &statusErr)
+}
+
+// connectionError returns true if err happened before the request reached the endpoint,
+// i.e. its host could not be resolved or connected to
+func connectionError(err error) bool {
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	return errors.As(err, &dnsErr) || errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// WithFailover send calls to the client url and fail over to backups, in order, on connection
+// errors and 5xx responses. Endpoints failing DefaultFailoverPolicy.MaxFailures times in a row
+// are skipped until the cooldown passes, so the primary is used again once it recovers.
+// State-changing methods (see StateChanging), and batches containing one, fail over on connection
+// errors only, so a transaction the node may have accepted is never sent twice.
+func WithFailover(backups ...string) func(rpc *AsimovRPC) {
+	return WithFailoverPolicy(DefaultFailoverPolicy, backups...)
+}
+
+// WithFailoverPolicy is WithFailover with custom policy
+func WithFailoverPolicy(policy FailoverPolicy, backups ...string) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		switch {
+		case len(backups) == 0:
+			rpc.invalidOption("WithFailover", "no backup endpoints")
+			return
+		case policy.MaxFailures <= 0:
+			rpc.invalidOption("WithFailover", "max failures must be positive")
+			return
+		case policy.Cooldown < 0:
+			rpc.invalidOption("WithFailover", "cooldown is negative")
+			return
+		}
+		rpc.failover = newFailover(append([]string{rpc.url}, backups...), policy)
+	}
+}
+
+// EndpointHealth returns health of failover endpoints in priority order (nil unless WithFailover is used)
+func (rpc *AsimovRPC) EndpointHealth() []EndpointHealth {
+	if rpc.failover == nil {
+		return nil
+	}
+
+	return rpc.failover.health()
+}
+
+// CheckEndpoints probes every failover endpoint with web3_clientVersion, updating its health,
+// and returns the resulting health. Run it periodically to recover endpoints before the cooldown passes.
+func (rpc *AsimovRPC) CheckEndpoints(ctx context.Context) []EndpointHealth {
+	if rpc.failover == nil {
+		return nil
+	}
+
+	body, _ := json.Marshal(asimovRequest{ID: 1, JSONRPC: "2.0", Method: "web3_clientVersion", Params: []interface{}{}})
+	for _, e := range rpc.failover.health() {
+		response := new(asimovResponse)
+		err := rpc.sendTo(ctx, e.Endpoint, "web3_clientVersion", 1, body, func(r io.Reader) error {
+			return json.NewDecoder(r).Decode(response)
+		})
+		if err == nil && response.Error != nil {
+			err = *response.Error
+		}
+		if err != nil {
+			rpc.failover.failure(e.Endpoint, err)
+		} else {
+			rpc.failover.success(e.Endpoint)
+		}
+	}
+
+	return rpc.failover.health()
+}
@@ -0,0 +1,133 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+	"github.com/stretchr/testify/require"
+)
+
+func failoverNode(status *int32, hits *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		if code := atomic.LoadInt32(status); code != http.StatusOK {
+			w.WriteHeader(int(code))
+			return
+		}
+		w.Write([]byte(echoID(r, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`)))
+	}))
+}
+
+func TestFailover(t *testing.T) {
+	primaryStatus, backupStatus := int32(http.StatusBadGateway), int32(http.StatusOK)
+	var primaryHits, backupHits int32
+	primary := failoverNode(&primaryStatus, &primaryHits)
+	defer primary.Close()
+	backup := failoverNode(&backupStatus, &backupHits)
+	defer backup.Close()
+
+	clock := asimovrpctest.NewFakeClock(time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC))
+	rpc := New(primary.URL, WithFailoverPolicy(FailoverPolicy{MaxFailures: 2, Cooldown: time.Minute}, backup.URL), WithClock(clock))
+
+	for i := 0; i < 3; i++ {
+		number, err := rpc.AsimovBlockNumber()
+		require.Nil(t, err)
+		require.Equal(t, 1, number)
+	}
+	require.Equal(t, int32(2), atomic.LoadInt32(&primaryHits))
+	require.Equal(t, int32(3), atomic.LoadInt32(&backupHits))
+
+	health := rpc.EndpointHealth()
+	require.False(t, health[0].Healthy)
+	require.Equal(t, 2, health[0].Failures)
+	var statusErr EndpointStatusError
+	require.True(t, errors.As(health[0].LastError, &statusErr))
+	require.Equal(t, primary.URL, statusErr.Endpoint)
+	require.Equal(t, "502 Bad Gateway", statusErr.Status)
+	var httpErr HTTPError
+	require.True(t, errors.As(health[0].LastError, &httpErr))
+	require.Equal(t, http.StatusBadGateway, httpErr.StatusCode)
+	require.True(t, health[1].Healthy)
+
+	// primary recovers and is used again after cooldown
+	atomic.StoreInt32(&primaryStatus, http.StatusOK)
+	clock.Advance(time.Minute)
+	_, err := rpc.AsimovBlockNumber()
+	require.Nil(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(&primaryHits))
+	require.Equal(t, int32(3), atomic.LoadInt32(&backupHits))
+	require.True(t, rpc.EndpointHealth()[0].Healthy)
+
+	backup.Close()
+	health = rpc.CheckEndpoints(context.Background())
+	require.True(t, health[0].Healthy)
+	require.Equal(t, 1, health[1].Failures)
+
+	primary.Close()
+	_, err = rpc.AsimovBlockNumber()
+	require.NotNil(t, err)
+}
+
+func TestFailoverErrors(t *testing.T) {
+	primaryStatus, backupStatus := int32(http.StatusUnauthorized), int32(http.StatusOK)
+	var primaryHits, backupHits int32
+	primary := failoverNode(&primaryStatus, &primaryHits)
+	defer primary.Close()
+	backup := failoverNode(&backupStatus, &backupHits)
+	defer backup.Close()
+
+	rpc := New(primary.URL, WithFailover(backup.URL))
+
+	// the node answered, failing over would not help
+	_, err := rpc.AsimovBlockNumber()
+	require.True(t, errors.As(err, &HTTPError{}))
+	require.Equal(t, int32(0), atomic.LoadInt32(&backupHits))
+	require.True(t, rpc.EndpointHealth()[0].Healthy)
+
+	// the transaction may have been accepted before the server error
+	atomic.StoreInt32(&primaryStatus, http.StatusBadGateway)
+	_, err = rpc.AsimovSendRawTransaction("0x01")
+	var httpErr HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	require.Equal(t, http.StatusBadGateway, httpErr.StatusCode)
+	require.True(t, errors.As(err, &EndpointStatusError{}))
+	require.Equal(t, int32(0), atomic.LoadInt32(&backupHits))
+
+	_, err = rpc.AsimovBlockNumber()
+	require.Nil(t, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(&backupHits))
+
+	// batches sending a transaction are not sent twice either
+	atomic.StoreInt32(&backupHits, 0)
+	batch := rpc.NewBatch().Add("flow_blockNumber", nil).Add("flow_sendRawTransaction", nil, "0x01")
+	require.True(t, errors.As(batch.Execute(), &EndpointStatusError{}))
+	require.Equal(t, int32(0), atomic.LoadInt32(&backupHits))
+
+	// read-only batches fail over
+	rpc.NewBatch().Add("flow_blockNumber", nil).Execute()
+	require.Equal(t, int32(1), atomic.LoadInt32(&backupHits))
+	atomic.StoreInt32(&backupHits, 1)
+
+	// the transaction never reached the primary
+	primary.Close()
+	_, err = rpc.AsimovSendRawTransaction("0x01")
+	require.Nil(t, err)
+	require.Equal(t, int32(2), atomic.LoadInt32(&backupHits))
+}
+
+func TestFailoverOptions(t *testing.T) {
+	_, err := NewClient("http://a:8545", WithFailover())
+	require.EqualError(t, err, "asimovrpc: invalid option WithFailover: no backup endpoints")
+	_, err = NewClient("http://a:8545", WithFailoverPolicy(FailoverPolicy{}, "http://b:8545"))
+	require.EqualError(t, err, "asimovrpc: invalid option WithFailover: max failures must be positive")
+	_, err = NewClient("http://a:8545", WithFailover("http://b:8545"), WithEndpointProvider(StaticEndpoints{"http://c:8545"}, 0))
+	require.EqualError(t, err, "asimovrpc: invalid option WithFailover: conflicts with WithEndpointProvider")
+
+	require.Nil(t, New("http://a:8545").EndpointHealth())
+}
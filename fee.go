@@ -0,0 +1,69 @@
+package asimovrpc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// NativeAsset - ID of ASIM, the asset fees are paid in unless the receipt names another fee asset
+const NativeAsset = "000000000000000000000000"
+
+// ErrNoEffectiveGasPrice - receipt does not carry the effective gas price, use FeeAt with the transaction gas price
+var ErrNoEffectiveGasPrice = errors.New("receipt has no effective gas price")
+
+// Amount - value in the smallest unit of asset
+type Amount struct {
+	Asset string
+	Value big.Int
+}
+
+func (a Amount) String() string {
+	return fmt.Sprintf("%s %s", a.Value.String(), a.Asset)
+}
+
+// Fee returns fee paid for the transaction: gas used × effective gas price, in the fee asset
+func (t TransactionReceipt) Fee() (Amount, error) {
+	if t.EffectiveGasPrice == nil {
+		return Amount{}, ErrNoEffectiveGasPrice
+	}
+
+	return t.FeeAt(*t.EffectiveGasPrice), nil
+}
+
+// FeeAt returns fee of the transaction paid at gasPrice, for receipts without effective gas price
+func (t TransactionReceipt) FeeAt(gasPrice big.Int) Amount {
+	asset := t.FeeAsset
+	if asset == "" {
+		asset = NativeAsset
+	}
+
+	fee := Amount{Asset: asset}
+	fee.Value.Mul(big.NewInt(int64(t.GasUsed)), &gasPrice)
+
+	return fee
+}
+
+// TransactionFee returns fee paid for mined transaction, falling back to the gas price of the
+// transaction for nodes not reporting the effective gas price in receipts
+func (rpc *AsimovRPC) TransactionFee(hash string) (Amount, error) {
+	receipt, err := rpc.AsimovGetTransactionReceipt(hash)
+	if err != nil {
+		return Amount{}, err
+	}
+	if receipt.BlockHash == "" {
+		return Amount{}, fmt.Errorf("Transaction %s is not mined", hash)
+	}
+
+	fee, err := receipt.Fee()
+	if err != ErrNoEffectiveGasPrice {
+		return fee, err
+	}
+
+	transaction, err := rpc.AsimovGetTransactionByHash(hash)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	return receipt.FeeAt(transaction.GasPrice), nil
+}
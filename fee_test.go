@@ -0,0 +1,52 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiptFee(t *testing.T) {
+	var receipt TransactionReceipt
+	require.Nil(t, json.Unmarshal([]byte(`{"gasUsed": "0x5208", "effectiveGasPrice": "0x3b9aca00", "feeAsset": "000000000000000100000001"}`), &receipt))
+
+	fee, err := receipt.Fee()
+	require.Nil(t, err)
+	require.Equal(t, "000000000000000100000001", fee.Asset)
+	require.Equal(t, "21000000000000", fee.Value.String())
+	require.Equal(t, "21000000000000 000000000000000100000001", fee.String())
+
+	receipt = TransactionReceipt{}
+	require.Nil(t, json.Unmarshal([]byte(`{"gasUsed": "0x5208"}`), &receipt))
+	_, err = receipt.Fee()
+	require.Equal(t, ErrNoEffectiveGasPrice, err)
+
+	fee = receipt.FeeAt(*big.NewInt(2))
+	require.Equal(t, NativeAsset, fee.Asset)
+	require.Equal(t, int64(42000), fee.Value.Int64())
+}
+
+func (s *AsimovRPCTestSuite) TestTransactionFee() {
+	s.registerResponses(map[string]string{
+		"flow_getTransactionReceipt": `{"blockHash": "0x1", "gasUsed": "0x5208"}`,
+		"flow_getTransactionByHash":  `{"hash": "0xab", "gasPrice": "0x3"}`,
+	}, func([]byte) {})
+
+	fee, err := s.rpc.TransactionFee("0xab")
+	s.Require().Nil(err)
+	s.Require().Equal(NativeAsset, fee.Asset)
+	s.Require().Equal(int64(63000), fee.Value.Int64())
+
+	s.registerResponse(`{"blockHash": "0x1", "gasUsed": "0x5208", "effectiveGasPrice": "0x2"}`, func(body []byte) {
+		s.methodEqual(body, "flow_getTransactionReceipt")
+	})
+	fee, err = s.rpc.TransactionFee("0xab")
+	s.Require().Nil(err)
+	s.Require().Equal(int64(42000), fee.Value.Int64())
+
+	s.registerResponse(`null`, func([]byte) {})
+	_, err = s.rpc.TransactionFee("0xab")
+	s.Require().EqualError(err, "Transaction 0xab is not mined")
+}
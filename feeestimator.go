@@ -0,0 +1,227 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fee estimator defaults
+const (
+	DefaultFeeBlocks = 20
+	DefaultFeeTTL    = 15 * time.Second
+)
+
+// FeeTier - how fast a transaction priced with a fee suggestion is expected to be included
+type FeeTier int
+
+// Fee tiers, suggested at the 10th, 50th and 90th percentile of fees paid in recent blocks
+const (
+	FeeSlow FeeTier = iota
+	FeeStandard
+	FeeFast
+)
+
+// feePercentiles - percentiles of fees paid in sampled blocks, by tier
+var feePercentiles = []float64{10, 50, 90}
+
+func (t FeeTier) String() string {
+	switch t {
+	case FeeSlow:
+		return "slow"
+	case FeeStandard:
+		return "standard"
+	case FeeFast:
+		return "fast"
+	}
+
+	return fmt.Sprintf("FeeTier(%d)", int(t))
+}
+
+// FeeSuggestion - fees suggested for a tier. Nodes without flow_feeHistory get suggestions from gas prices
+// of transactions in recent blocks, with zero BaseFeePerGas and all fields equal to the gas price.
+type FeeSuggestion struct {
+	Tier                 FeeTier
+	BaseFeePerGas        big.Int // base fee of the next block
+	MaxPriorityFeePerGas big.Int
+	MaxFeePerGas         big.Int // 2 × base fee + priority fee, to stay valid while the base fee rises
+	GasPrice             big.Int // base fee + priority fee, for legacy transactions
+	Block                int     // newest sampled block
+}
+
+type feeEstimator struct {
+	mu          sync.Mutex
+	blocks      int
+	ttl         time.Duration
+	suggestions []FeeSuggestion // by tier
+	fetched     time.Time
+}
+
+// WithFeeEstimator sets number of recent blocks sampled by SuggestFees and for how long suggestions are
+// cached, DefaultFeeBlocks and DefaultFeeTTL by default. Zero ttl disables caching.
+func WithFeeEstimator(blocks int, ttl time.Duration) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if blocks <= 0 {
+			rpc.invalidOption("WithFeeEstimator", fmt.Sprintf("number of blocks must be positive, got %d", blocks))
+			return
+		}
+		if ttl < 0 {
+			rpc.invalidOption("WithFeeEstimator", fmt.Sprintf("ttl must not be negative, got %s", ttl))
+			return
+		}
+		rpc.feeEstimator = &feeEstimator{blocks: blocks, ttl: ttl}
+	}
+}
+
+// SuggestFees returns fees for tier, computed from fees paid in recent blocks with flow_feeHistory, or
+// from gas prices of their transactions on nodes without it and before UpgradeFeeMarket of the configured
+// network. Suggestions of all tiers are cached together.
+func (rpc *AsimovRPC) SuggestFees(ctx context.Context, tier FeeTier) (*FeeSuggestion, error) {
+	if tier < FeeSlow || tier > FeeFast {
+		return nil, fmt.Errorf("Unknown fee tier %s", tier)
+	}
+
+	e := rpc.feeEstimator
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := rpc.clock.Now()
+	if e.suggestions == nil || now.Sub(e.fetched) >= e.ttl {
+		suggestions, err := e.estimate(rpc.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		e.suggestions, e.fetched = suggestions, now
+	}
+
+	suggestion := e.suggestions[tier]
+	return &suggestion, nil
+}
+
+func (e *feeEstimator) estimate(rpc *AsimovRPC) ([]FeeSuggestion, error) {
+	if rpc.chainConfig.defines(UpgradeFeeMarket) {
+		head, err := rpc.AsimovBlockNumber()
+		if err != nil {
+			return nil, err
+		}
+		if !rpc.Rules(head + 1).Active(UpgradeFeeMarket) {
+			return e.scanBlocks(rpc)
+		}
+	}
+
+	history, err := rpc.AsimovFeeHistory(e.blocks, Latest(), feePercentiles)
+	if errors.Is(err, ErrMethodNotFound) {
+		return e.scanBlocks(rpc)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(history.BaseFeePerGas) == 0 {
+		return nil, fmt.Errorf("Fee history has no base fees")
+	}
+
+	// empty blocks pay no priority fees, so only blocks with transactions are sampled
+	rewards := make([][]big.Int, len(feePercentiles))
+	for i, reward := range history.Reward {
+		if i < len(history.GasUsedRatio) && history.GasUsedRatio[i] == 0 || len(reward) != len(feePercentiles) {
+			continue
+		}
+		for tier := range rewards {
+			rewards[tier] = append(rewards[tier], reward[tier])
+		}
+	}
+
+	baseFee := history.BaseFeePerGas[len(history.BaseFeePerGas)-1]
+	newest := history.OldestBlock + len(history.GasUsedRatio) - 1
+	suggestions := make([]FeeSuggestion, len(feePercentiles))
+	for tier := range suggestions {
+		// median over the blocks of priority fees paid at the percentile of the tier
+		tip, ok := percentile(rewards[tier], 50)
+		if !ok {
+			if tip, err = rpc.AsimovMaxPriorityFeePerGas(); err != nil {
+				return nil, err
+			}
+		}
+		suggestions[tier] = newFeeSuggestion(FeeTier(tier), baseFee, tip, newest)
+	}
+
+	return suggestions, nil
+}
+
+// scanBlocks suggests fees from gas prices of transactions in the sampled blocks, fetched in batches
+func (e *feeEstimator) scanBlocks(rpc *AsimovRPC) ([]FeeSuggestion, error) {
+	head, err := rpc.AsimovBlockNumber()
+	if err != nil {
+		return nil, err
+	}
+	from := head - e.blocks + 1
+	if from < 0 {
+		from = 0
+	}
+
+	blocks := make([]*proxyBlockWithTransactions, head-from+1)
+	batch := rpc.NewBatch()
+	for i := range blocks {
+		batch.Add("flow_getBlockByNumber", &blocks[i], IntToHex(from+i), true)
+	}
+	if err := batch.ExecuteContext(rpc.context()); err != nil {
+		return nil, err
+	}
+
+	var prices []big.Int
+	for i, block := range blocks {
+		if err := batch.Err(i); err != nil {
+			return nil, err
+		}
+		if block == nil {
+			return nil, fmt.Errorf("Block %d not found", from+i)
+		}
+		for _, transaction := range block.Transactions {
+			prices = append(prices, big.Int(transaction.GasPrice))
+		}
+	}
+
+	var gasPrice big.Int
+	if len(prices) == 0 {
+		if gasPrice, err = rpc.AsimovGasPrice(); err != nil {
+			return nil, err
+		}
+	}
+
+	suggestions := make([]FeeSuggestion, len(feePercentiles))
+	for tier := range suggestions {
+		price := gasPrice
+		if len(prices) > 0 {
+			price, _ = percentile(prices, feePercentiles[tier])
+		}
+		suggestions[tier] = newFeeSuggestion(FeeTier(tier), big.Int{}, price, head)
+	}
+
+	return suggestions, nil
+}
+
+func newFeeSuggestion(tier FeeTier, baseFee, tip big.Int, block int) FeeSuggestion {
+	suggestion := FeeSuggestion{Tier: tier, Block: block}
+	suggestion.BaseFeePerGas.Set(&baseFee)
+	suggestion.MaxPriorityFeePerGas.Set(&tip)
+	suggestion.GasPrice.Add(&baseFee, &tip)
+	suggestion.MaxFeePerGas.Add(&suggestion.GasPrice, &baseFee)
+
+	return suggestion
+}
+
+// percentile returns the p-th percentile of values using the nearest rank, false if there are no values
+func percentile(values []big.Int, p float64) (big.Int, bool) {
+	if len(values) == 0 {
+		return big.Int{}, false
+	}
+
+	sorted := append([]big.Int(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(&sorted[j]) < 0 })
+
+	return sorted[int(p/100*float64(len(sorted)-1)+0.5)], true
+}
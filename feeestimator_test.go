@@ -0,0 +1,125 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func TestSuggestFees(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	// the third block is empty, its zero rewards are not sampled
+	node.Handle("flow_feeHistory", map[string]interface{}{
+		"oldestBlock":   "0x10",
+		"baseFeePerGas": []string{"0x64", "0x64", "0x64", "0x6e"},
+		"gasUsedRatio":  []float64{0.5, 0.7, 0},
+		"reward":        [][]string{{"0x1", "0x5", "0xa"}, {"0x3", "0x7", "0x14"}, {"0x0", "0x0", "0x0"}},
+	})
+	clock := asimovrpctest.NewFakeClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	rpc := New(node.URL, WithClock(clock))
+
+	slow, err := rpc.SuggestFees(context.Background(), FeeSlow)
+	require.Nil(t, err)
+	require.Equal(t, FeeSlow, slow.Tier)
+	require.Equal(t, 18, slow.Block)
+	require.Equal(t, "110", slow.BaseFeePerGas.String())
+	require.Equal(t, "3", slow.MaxPriorityFeePerGas.String())
+	require.Equal(t, "113", slow.GasPrice.String())
+	require.Equal(t, "223", slow.MaxFeePerGas.String())
+
+	fast, err := rpc.SuggestFees(context.Background(), FeeFast)
+	require.Nil(t, err)
+	require.Equal(t, "20", fast.MaxPriorityFeePerGas.String())
+	require.Len(t, node.Calls("flow_feeHistory"), 1)
+	require.True(t, node.AssertParams(t, "flow_feeHistory", "0x14", "latest", []float64{10, 50, 90}))
+
+	clock.Advance(DefaultFeeTTL)
+	standard, err := rpc.SuggestFees(context.Background(), FeeStandard)
+	require.Nil(t, err)
+	require.Equal(t, "7", standard.MaxPriorityFeePerGas.String())
+	require.Len(t, node.Calls("flow_feeHistory"), 2)
+
+	_, err = rpc.SuggestFees(context.Background(), FeeTier(5))
+	require.EqualError(t, err, "Unknown fee tier FeeTier(5)")
+}
+
+func TestSuggestFeesEmptyBlocks(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	node.Handle("flow_feeHistory", map[string]interface{}{
+		"oldestBlock":   "0x10",
+		"baseFeePerGas": []string{"0x64", "0x64"},
+		"gasUsedRatio":  []float64{0},
+		"reward":        [][]string{{"0x0", "0x0", "0x0"}},
+	})
+	node.Handle("flow_maxPriorityFeePerGas", "0x2")
+	rpc := New(node.URL, WithFeeEstimator(1, 0))
+
+	for i := 0; i < 2; i++ {
+		suggestion, err := rpc.SuggestFees(context.Background(), FeeFast)
+		require.Nil(t, err)
+		require.Equal(t, "2", suggestion.MaxPriorityFeePerGas.String())
+		require.Equal(t, "202", suggestion.MaxFeePerGas.String())
+	}
+	// zero ttl disables caching
+	require.Len(t, node.Calls("flow_feeHistory"), 2)
+	require.True(t, node.AssertParams(t, "flow_feeHistory", "0x1", "latest", []float64{10, 50, 90}))
+}
+
+func TestSuggestFeesBlockScan(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	node.Handle("flow_blockNumber", "0x4")
+	node.HandleFunc("flow_getBlockByNumber", func(params []json.RawMessage) (interface{}, error) {
+		var number string
+		json.Unmarshal(params[0], &number)
+		n, _ := ParseInt(number)
+		// block n holds transactions priced 10n, 10n+1, ...
+		transactions := make([]map[string]interface{}, n)
+		for i := range transactions {
+			transactions[i] = map[string]interface{}{"hash": fmt.Sprintf("0x%x%x", n, i), "gasPrice": IntToHex(10*n + i)}
+		}
+		return map[string]interface{}{"number": number, "transactions": transactions}, nil
+	})
+	rpc := New(node.URL, WithFeeEstimator(3, time.Minute))
+
+	// blocks 2-4 hold prices 20, 21, 30, 31, 32, 40, 41, 42, 43
+	expected := map[FeeTier]int64{FeeSlow: 21, FeeStandard: 32, FeeFast: 42}
+	for tier, price := range expected {
+		suggestion, err := rpc.SuggestFees(context.Background(), tier)
+		require.Nil(t, err)
+		require.Equal(t, 4, suggestion.Block)
+		require.Equal(t, *big.NewInt(price), suggestion.GasPrice, "%s", tier)
+		require.Equal(t, suggestion.GasPrice, suggestion.MaxFeePerGas)
+		require.Equal(t, 0, suggestion.BaseFeePerGas.Sign())
+	}
+	require.Len(t, node.Calls("flow_getBlockByNumber"), 3)
+
+	// without transactions the node gas price is suggested for every tier
+	node.Handle("flow_blockNumber", "0x0")
+	node.Handle("flow_gasPrice", "0x9")
+	suggestion, err := New(node.URL).SuggestFees(context.Background(), FeeFast)
+	require.Nil(t, err)
+	require.Equal(t, "9", suggestion.GasPrice.String())
+}
+
+func TestFeeEstimatorOptions(t *testing.T) {
+	_, err := NewClient("http://a:8545", WithFeeEstimator(0, time.Second))
+	require.EqualError(t, err, "asimovrpc: invalid option WithFeeEstimator: number of blocks must be positive, got 0")
+
+	_, err = NewClient("http://a:8545", WithFeeEstimator(1, -time.Second))
+	require.EqualError(t, err, "asimovrpc: invalid option WithFeeEstimator: ttl must not be negative, got -1s")
+
+	require.Equal(t, "standard", FeeStandard.String())
+}
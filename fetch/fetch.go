@@ -0,0 +1,113 @@
+// Package fetch provides bounded, cancellable and panic-safe worker groups
+// for combining bulk requests made with asimovrpc.
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// PanicError - panic recovered from a task
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (err PanicError) Error() string {
+	return fmt.Sprintf("fetch: task panicked: %v\n%s", err.Value, err.Stack)
+}
+
+// Group runs tasks with bounded concurrency.
+// The first task error cancels the group context and is returned by Wait.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+}
+
+// WithContext creates group running at most limit tasks at once (unbounded if limit <= 0).
+// Returned context is cancelled when any task fails or Wait returns.
+func WithContext(ctx context.Context, limit int) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	g := &Group{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	if limit > 0 {
+		g.sem = make(chan struct{}, limit)
+	}
+
+	return g, ctx
+}
+
+// Go runs task in a new goroutine, blocking while the concurrency limit is reached.
+// Tasks scheduled after the group context is done are not started.
+func (g *Group) Go(task func(ctx context.Context) error) {
+	if err := g.ctx.Err(); err != nil {
+		g.fail(err)
+		return
+	}
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		case <-g.ctx.Done():
+			g.fail(g.ctx.Err())
+			return
+		}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+
+		if err := run(g.ctx, task); err != nil {
+			g.fail(err)
+		}
+	}()
+}
+
+// Wait blocks until all started tasks finish and returns the first error
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+
+	return g.err
+}
+
+func (g *Group) fail(err error) {
+	g.once.Do(func() {
+		g.err = err
+		g.cancel()
+	})
+}
+
+func run(ctx context.Context, task func(ctx context.Context) error) (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			err = PanicError{Value: value, Stack: debug.Stack()}
+		}
+	}()
+
+	return task(ctx)
+}
+
+// Each calls fn for every index in [0, n) using at most limit goroutines
+func Each(ctx context.Context, limit, n int, fn func(ctx context.Context, i int) error) error {
+	g, _ := WithContext(ctx, limit)
+	for i := 0; i < n; i++ {
+		i := i
+		g.Go(func(ctx context.Context) error {
+			return fn(ctx, i)
+		})
+	}
+
+	return g.Wait()
+}
@@ -0,0 +1,74 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupLimit(t *testing.T) {
+	var running, peak int32
+	err := Each(context.Background(), 3, 20, func(ctx context.Context, i int) error {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&peak)
+			if current <= max || atomic.CompareAndSwapInt32(&peak, max, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	})
+
+	require.Nil(t, err)
+	require.True(t, peak <= 3)
+}
+
+func TestGroupError(t *testing.T) {
+	expected := errors.New("error")
+	g, ctx := WithContext(context.Background(), 2)
+	g.Go(func(ctx context.Context) error {
+		return expected
+	})
+	g.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	require.Equal(t, expected, g.Wait())
+	require.NotNil(t, ctx.Err())
+}
+
+func TestGroupPanic(t *testing.T) {
+	err := Each(context.Background(), 0, 2, func(ctx context.Context, i int) error {
+		if i == 1 {
+			panic("boom")
+		}
+		return nil
+	})
+
+	require.IsType(t, PanicError{}, err)
+	require.Equal(t, "boom", err.(PanicError).Value)
+	require.Contains(t, err.Error(), "fetch: task panicked: boom")
+}
+
+func TestGroupCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, limit := range []int{0, 1} {
+		var calls int32
+		err := Each(ctx, limit, 5, func(ctx context.Context, i int) error {
+			atomic.AddInt32(&calls, 1)
+			return ctx.Err()
+		})
+
+		require.Equal(t, context.Canceled, err)
+		require.Equal(t, int32(0), calls)
+	}
+}
@@ -0,0 +1,246 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+// FilterKind - kind of node filter
+type FilterKind int
+
+// Filter kinds
+const (
+	LogFilter                FilterKind = iota // flow_newFilter, changes are logs
+	BlockFilter                                // flow_newBlockFilter, changes are block hashes
+	PendingTransactionFilter                   // flow_newPendingTransactionFilter, changes are transaction hashes
+)
+
+// FilterChange - log of a log filter, or block / transaction hash of other filters
+type FilterChange struct {
+	Log  Log
+	Hash string
+}
+
+// FilterManager keeps a node filter installed and polls flow_getFilterChanges every poll interval
+// (see WithPollInterval), delivering changes on Changes(). Filters expired by the node (ErrFilterNotFound)
+// are installed again, changes made while the filter was missing are not delivered.
+type FilterManager struct {
+	rpc     *AsimovRPC
+	kind    FilterKind
+	params  FilterParams
+	changes chan FilterChange
+	errors  chan error
+	stop    chan struct{}
+	done    chan struct{}
+
+	mu       sync.Mutex
+	id       string
+	started  bool
+	installs int
+	stopOnce sync.Once
+}
+
+// NewLogFilter creates manager of log filter, start it with Start
+func (rpc *AsimovRPC) NewLogFilter(params FilterParams) *FilterManager {
+	return rpc.newFilterManager(LogFilter, params)
+}
+
+// NewBlockFilter creates manager of new block filter, start it with Start
+func (rpc *AsimovRPC) NewBlockFilter() *FilterManager {
+	return rpc.newFilterManager(BlockFilter, FilterParams{})
+}
+
+// NewPendingTransactionFilter creates manager of pending transaction filter, start it with Start
+func (rpc *AsimovRPC) NewPendingTransactionFilter() *FilterManager {
+	return rpc.newFilterManager(PendingTransactionFilter, FilterParams{})
+}
+
+func (rpc *AsimovRPC) newFilterManager(kind FilterKind, params FilterParams) *FilterManager {
+	return &FilterManager{
+		rpc:     rpc,
+		kind:    kind,
+		params:  params,
+		changes: make(chan FilterChange),
+		errors:  make(chan error, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start installs the filter and starts polling it until Stop is called or ctx is done
+func (m *FilterManager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.started {
+		return ErrAlreadyStarted
+	}
+	select {
+	case <-m.stop:
+		return ErrStopped
+	default:
+	}
+
+	id, err := m.install(ctx)
+	if err != nil {
+		return err
+	}
+	m.id = id
+	m.installs++
+	m.started = true
+
+	go m.run(ctx)
+	return nil
+}
+
+// Stop stops polling, uninstalls the filter and waits for the polling goroutine to exit
+func (m *FilterManager) Stop() {
+	m.mu.Lock()
+	started := m.started
+	m.stopOnce.Do(func() {
+		close(m.stop)
+		if !started {
+			close(m.changes)
+			close(m.done)
+		}
+	})
+	m.mu.Unlock()
+
+	<-m.done
+}
+
+// Changes returns channel of filter changes, closed when the manager stops
+func (m *FilterManager) Changes() <-chan FilterChange {
+	return m.changes
+}
+
+// Errors returns channel of polling errors, errors are dropped while the channel is full
+func (m *FilterManager) Errors() <-chan error {
+	return m.errors
+}
+
+// Done returns channel closed when the polling goroutine exits
+func (m *FilterManager) Done() <-chan struct{} {
+	return m.done
+}
+
+// ID returns ID of the installed node filter, it changes when the filter is installed again
+func (m *FilterManager) ID() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.id
+}
+
+// Installs returns how many times the filter was installed
+func (m *FilterManager) Installs() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.installs
+}
+
+func (m *FilterManager) install(ctx context.Context) (string, error) {
+	rpc := m.rpc.WithContext(ctx)
+	switch m.kind {
+	case BlockFilter:
+		return rpc.AsimovNewBlockFilter()
+	case PendingTransactionFilter:
+		return rpc.AsimovNewPendingTransactionFilter()
+	}
+
+	return rpc.AsimovNewFilter(m.params)
+}
+
+func (m *FilterManager) run(ctx context.Context) {
+	defer close(m.done)
+	defer close(m.changes)
+	defer func() {
+		// best effort, the node expires the filter anyway
+		m.rpc.WithContext(context.Background()).AsimovUninstallFilter(m.ID())
+	}()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-m.rpc.clock.After(m.rpc.pollInterval):
+		}
+
+		changes, err := m.poll(ctx)
+		if errors.Is(err, ErrFilterNotFound) {
+			err = m.reinstall(ctx)
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				m.report(err)
+			}
+			continue
+		}
+
+		for _, change := range changes {
+			select {
+			case m.changes <- change:
+			case <-m.stop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func (m *FilterManager) poll(ctx context.Context) ([]FilterChange, error) {
+	result, err := m.rpc.CallContext(ctx, "flow_getFilterChanges", m.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	if m.kind == LogFilter {
+		var logs []Log
+		if err := json.Unmarshal(result, &logs); err != nil {
+			return nil, err
+		}
+		changes := make([]FilterChange, len(logs))
+		for i, log := range logs {
+			changes[i].Log = log
+		}
+		return changes, nil
+	}
+
+	var hashes []string
+	if err := json.Unmarshal(result, &hashes); err != nil {
+		return nil, err
+	}
+	changes := make([]FilterChange, len(hashes))
+	for i, hash := range hashes {
+		changes[i].Hash = hash
+	}
+
+	return changes, nil
+}
+
+func (m *FilterManager) reinstall(ctx context.Context) error {
+	id, err := m.install(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.id = id
+	m.installs++
+	m.mu.Unlock()
+
+	return nil
+}
+
+func (m *FilterManager) report(err error) {
+	select {
+	case m.errors <- err:
+	default:
+	}
+}
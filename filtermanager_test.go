@@ -0,0 +1,122 @@
+package asimovrpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func (s *AsimovRPCTestSuite) TestFilterManager() {
+	clock := asimovrpctest.NewFakeClock(time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC))
+	rpc := New(s.rpc.url, WithClock(clock), WithPollInterval(time.Second))
+
+	var mu sync.Mutex
+	var methods []string
+	installed := 0
+	polls := []string{
+		`{"result": [{"address": "0xaca0", "blockNumber": "0x1", "logIndex": "0x0", "topics": ["0x581d"]}]}`,
+		`{"error": {"code": -32000, "message": "filter not found"}}`,
+		`{"error": {"code": -32000, "message": "too many requests"}}`,
+		`{"result": [{"address": "0xaca0", "blockNumber": "0x2", "logIndex": "0x1", "topics": ["0x581d"]}]}`,
+	}
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := s.getBody(request)
+		method := gjson.GetBytes(body, "method").String()
+		mu.Lock()
+		defer mu.Unlock()
+		methods = append(methods, method+" "+gjson.GetBytes(body, "params.0").Raw)
+
+		response := `{"result": true}`
+		switch method {
+		case "flow_newFilter":
+			installed++
+			response = fmt.Sprintf(`{"result": "0x%d"}`, installed)
+		case "flow_getFilterChanges":
+			response, polls = polls[0], polls[1:]
+		}
+		return httpmock.NewStringResponse(200, echoID(request, `{"jsonrpc":"2.0", "id":1, `+response[1:])), nil
+	})
+
+	filter := rpc.NewLogFilter(FilterParams{Address: []string{"0xaca0"}})
+	s.Require().Nil(filter.Start(context.Background()))
+	s.Require().Equal(ErrAlreadyStarted, filter.Start(context.Background()))
+	s.Require().Equal("0x1", filter.ID())
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	change := <-filter.Changes()
+	s.Require().Equal(1, change.Log.BlockNumber)
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second) // filter not found, installed again
+	clock.BlockUntil(1)
+	s.Require().Equal("0x2", filter.ID())
+	s.Require().Equal(2, filter.Installs())
+
+	clock.Advance(time.Second)
+	err := <-filter.Errors()
+	s.Require().EqualError(err, "Error -32000 (too many requests)")
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	change = <-filter.Changes()
+	s.Require().Equal(2, change.Log.BlockNumber)
+
+	filter.Stop()
+	_, ok := <-filter.Changes()
+	s.Require().False(ok)
+
+	mu.Lock()
+	defer mu.Unlock()
+	s.Require().Equal([]string{
+		`flow_newFilter {"address":["0xaca0"]}`,
+		`flow_getFilterChanges "0x1"`,
+		`flow_getFilterChanges "0x1"`,
+		`flow_newFilter {"address":["0xaca0"]}`,
+		`flow_getFilterChanges "0x2"`,
+		`flow_getFilterChanges "0x2"`,
+		`flow_uninstallFilter "0x2"`,
+	}, methods)
+}
+
+func (s *AsimovRPCTestSuite) TestBlockFilter() {
+	clock := asimovrpctest.NewFakeClock(time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC))
+	rpc := New(s.rpc.url, WithClock(clock))
+	s.registerResponses(map[string]string{
+		"flow_newBlockFilter":   `"0xf"`,
+		"flow_getFilterChanges": `["0xb1", "0xb2"]`,
+		"flow_uninstallFilter":  `true`,
+	}, func([]byte) {})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	filter := rpc.NewBlockFilter()
+	s.Require().Nil(filter.Start(ctx))
+
+	clock.BlockUntil(1)
+	clock.Advance(DefaultPollInterval)
+	s.Require().Equal("0xb1", (<-filter.Changes()).Hash)
+	s.Require().Equal("0xb2", (<-filter.Changes()).Hash)
+
+	cancel()
+	waitDone(s.T(), filter)
+}
+
+func TestFilterManagerStartError(t *testing.T) {
+	rpc := New("http://127.0.0.1:1")
+	filter := rpc.NewPendingTransactionFilter()
+	require.NotNil(t, filter.Start(context.Background()))
+
+	filter.Stop()
+	waitDone(t, filter)
+	_, ok := <-filter.Changes()
+	require.False(t, ok)
+}
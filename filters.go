@@ -0,0 +1,51 @@
+package asimovrpc
+
+import (
+	"strings"
+)
+
+// TransactionFilter - client-side transaction predicate
+type TransactionFilter func(transaction Transaction) bool
+
+// FromAddress matches transactions sent by any of the addresses
+func FromAddress(addresses ...string) TransactionFilter {
+	set := addressSet(addresses)
+	return func(transaction Transaction) bool {
+		return set[strings.ToLower(transaction.From)]
+	}
+}
+
+// ToAddress matches transactions sent to any of the addresses
+func ToAddress(addresses ...string) TransactionFilter {
+	set := addressSet(addresses)
+	return func(transaction Transaction) bool {
+		return set[strings.ToLower(transaction.To)]
+	}
+}
+
+// InvolvingAddress matches transactions sent by or to any of the addresses
+func InvolvingAddress(addresses ...string) TransactionFilter {
+	set := addressSet(addresses)
+	return func(transaction Transaction) bool {
+		return set[strings.ToLower(transaction.From)] || set[strings.ToLower(transaction.To)]
+	}
+}
+
+func matchTransaction(transaction Transaction, filters []TransactionFilter) bool {
+	for _, filter := range filters {
+		if !filter(transaction) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func addressSet(addresses []string) map[string]bool {
+	set := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		set[strings.ToLower(address)] = true
+	}
+
+	return set
+}
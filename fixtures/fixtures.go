@@ -0,0 +1,172 @@
+// Package fixtures provides node responses to the methods wrapped by asimovrpc, grouped in
+// versioned sets, and an HTTP client answering calls with them:
+//
+//	client := asimovrpc.New("http://fixtures", asimovrpc.WithHttpClient(fixtures.HTTPClient(fixtures.Baseline)))
+//	block, err := client.AsimovGetBlockByNumber(4216277, true)
+//
+// Fixtures of a set live in data/<version>/<method>.json, each holding the JSON-RPC request
+// and the response of the node. The only set, Baseline, is written after the documented node
+// responses rather than recorded from a node release; sets recorded from releases go next to it.
+package fixtures
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Baseline - responses the client is tested against by default
+const Baseline = "baseline"
+
+//go:embed data
+var data embed.FS
+
+// Fixture - recorded call
+type Fixture struct {
+	Version  string          `json:"-"`
+	Method   string          `json:"-"`
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response"`
+}
+
+// Params returns params of the recorded request
+func (f Fixture) Params() ([]interface{}, error) {
+	var request struct {
+		Params []interface{} `json:"params"`
+	}
+	err := json.Unmarshal(f.Request, &request)
+
+	return request.Params, err
+}
+
+// Result returns result of the recorded response
+func (f Fixture) Result() (json.RawMessage, error) {
+	var response struct {
+		Result json.RawMessage `json:"result"`
+	}
+	err := json.Unmarshal(f.Response, &response)
+
+	return response.Result, err
+}
+
+// Versions returns sorted versions of fixture sets
+func Versions() []string {
+	entries, _ := fs.ReadDir(data, "data")
+	versions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			versions = append(versions, entry.Name())
+		}
+	}
+	sort.Strings(versions)
+
+	return versions
+}
+
+// Methods returns sorted methods with fixtures of version
+func Methods(version string) []string {
+	entries, _ := fs.ReadDir(data, path.Join("data", version))
+	methods := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if name := entry.Name(); strings.HasSuffix(name, ".json") {
+			methods = append(methods, strings.TrimSuffix(name, ".json"))
+		}
+	}
+	sort.Strings(methods)
+
+	return methods
+}
+
+// Load returns fixture of method from the set of version
+func Load(version, method string) (Fixture, error) {
+	content, err := data.ReadFile(path.Join("data", version, method+".json"))
+	if err != nil {
+		return Fixture{}, fmt.Errorf("fixtures: no fixture of %s for version %s", method, version)
+	}
+
+	fixture := Fixture{Version: version, Method: method}
+	if err := json.Unmarshal(content, &fixture); err != nil {
+		return Fixture{}, fmt.Errorf("fixtures: %s/%s: %v", version, method, err)
+	}
+
+	return fixture, nil
+}
+
+// HTTPClient returns client answering JSON-RPC calls and batches with fixtures of version.
+// Responses get the id of the request, methods without fixture fail with -32601 method not found.
+func HTTPClient(version string) *http.Client {
+	return &http.Client{Transport: transport{version: version}}
+}
+
+type transport struct {
+	version string
+}
+
+type call struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+}
+
+func (t transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	body, err := ioutil.ReadAll(request.Body)
+	request.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var content []byte
+	if body = bytes.TrimSpace(body); len(body) > 0 && body[0] == '[' {
+		var calls []call
+		if err := json.Unmarshal(body, &calls); err != nil {
+			return nil, err
+		}
+		responses := make([]json.RawMessage, len(calls))
+		for i, c := range calls {
+			responses[i] = t.respond(c)
+		}
+		content, err = json.Marshal(responses)
+	} else {
+		var c call
+		if err := json.Unmarshal(body, &c); err != nil {
+			return nil, err
+		}
+		content = t.respond(c)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(content)),
+		Request:    request,
+	}, nil
+}
+
+func (t transport) respond(c call) json.RawMessage {
+	response := map[string]json.RawMessage{}
+	fixture, err := Load(t.version, c.Method)
+	if err == nil {
+		err = json.Unmarshal(fixture.Response, &response)
+	}
+	if err != nil {
+		message, _ := json.Marshal(map[string]interface{}{
+			"code":    -32601,
+			"message": fmt.Sprintf("the method %s does not exist/is not available", c.Method),
+		})
+		response = map[string]json.RawMessage{"jsonrpc": json.RawMessage(`"2.0"`), "error": message}
+	}
+	response["id"] = c.ID
+
+	data, _ := json.Marshal(response)
+	return data
+}
@@ -0,0 +1,59 @@
+package fixtures
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	require.Contains(t, Versions(), Baseline)
+	require.Contains(t, Methods(Baseline), "flow_getBlockByHash")
+
+	for _, version := range Versions() {
+		for _, method := range Methods(version) {
+			fixture, err := Load(version, method)
+			require.Nil(t, err, method)
+			require.Equal(t, method, fixture.Method)
+
+			_, err = fixture.Params()
+			require.Nil(t, err, method)
+			result, err := fixture.Result()
+			require.Nil(t, err, method)
+			require.NotEmpty(t, result, method)
+		}
+	}
+
+	fixture, err := Load(Baseline, "flow_getBalance")
+	require.Nil(t, err)
+	params, err := fixture.Params()
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{"0x407d73d8a49eeb85d32cf465507dd71d507100c1", "latest"}, params)
+
+	_, err = Load(Baseline, "flow_missing")
+	require.EqualError(t, err, "fixtures: no fixture of flow_missing for version baseline")
+}
+
+func TestHTTPClient(t *testing.T) {
+	client := HTTPClient(Baseline)
+	post := func(body string) string {
+		response, err := client.Post("http://fixtures", "application/json", bytes.NewBufferString(body))
+		require.Nil(t, err)
+		defer response.Body.Close()
+		require.Equal(t, http.StatusOK, response.StatusCode)
+
+		data, err := ioutil.ReadAll(response.Body)
+		require.Nil(t, err)
+		return string(data)
+	}
+
+	require.JSONEq(t, `{"jsonrpc":"2.0","id":7,"result":"0x37eb38"}`,
+		post(`{"jsonrpc":"2.0","id":7,"method":"flow_blockNumber","params":[]}`))
+	require.JSONEq(t, `[
+		{"jsonrpc":"2.0","id":1,"result":"0x38a"},
+		{"jsonrpc":"2.0","id":2,"error":{"code":-32601,"message":"the method flow_missing does not exist/is not available"}}
+	]`, post(`[{"jsonrpc":"2.0","id":1,"method":"flow_hashrate"},{"jsonrpc":"2.0","id":2,"method":"flow_missing"}]`))
+}
@@ -0,0 +1,99 @@
+package asimovrpc
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// gasCappedMethods - methods whose transaction is checked against gas caps
+var gasCappedMethods = []string{
+	"flow_sendTransaction",
+	"flow_signTransaction",
+	"personal_sendTransaction",
+	"personal_signTransaction",
+}
+
+// GasCaps - upper bounds of transactions sent or signed by the client, zero values are unlimited
+type GasCaps struct {
+	MaxGas      int
+	MaxGasPrice *big.Int
+}
+
+// GasCapError - transaction exceeds a gas cap
+type GasCapError struct {
+	Method string
+	Field  string // gas or gasPrice
+	Value  *big.Int
+	Cap    *big.Int
+}
+
+func (err GasCapError) Error() string {
+	return fmt.Sprintf("Transaction of %s exceeds %s cap: %s > %s", err.Method, err.Field, err.Value, err.Cap)
+}
+
+// WithGasCaps reject transactions of send and sign transaction calls and SendTransactionLocal whose gas or gas price
+// exceeds caps with GasCapError before they reach the node. Transactions leaving gas or gas price to the node
+// and raw transactions are not checked. Caps are reloaded by UpdateConfig.
+func WithGasCaps(caps GasCaps) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if caps.MaxGas < 0 || caps.MaxGasPrice != nil && caps.MaxGasPrice.Sign() < 0 {
+			rpc.invalidOption("WithGasCaps", "caps must not be negative")
+		}
+		rpc.gasCaps = caps
+	}
+}
+
+// check returns GasCapError if transaction exceeds caps
+func (caps GasCaps) check(method string, transaction T) error {
+	if caps.MaxGas > 0 && transaction.Gas > caps.MaxGas {
+		return GasCapError{Method: method, Field: "gas", Value: big.NewInt(int64(transaction.Gas)), Cap: big.NewInt(int64(caps.MaxGas))}
+	}
+	if caps.MaxGasPrice != nil && caps.MaxGasPrice.Sign() > 0 && transaction.GasPrice != nil && transaction.GasPrice.Cmp(caps.MaxGasPrice) > 0 {
+		return GasCapError{Method: method, Field: "gasPrice", Value: transaction.GasPrice, Cap: caps.MaxGasPrice}
+	}
+
+	return nil
+}
+
+func gasCapped(method string) bool {
+	for _, m := range gasCappedMethods {
+		if method == m {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (caps GasCaps) empty() bool {
+	return caps.MaxGas == 0 && (caps.MaxGasPrice == nil || caps.MaxGasPrice.Sign() == 0)
+}
+
+// capGas checks transaction of a send or sign call against gas caps of the client
+func (rpc *AsimovRPC) capGas(method string, params []interface{}) error {
+	rpc.mu.RLock()
+	caps := rpc.gasCaps
+	rpc.mu.RUnlock()
+
+	if caps.empty() || len(params) == 0 || !gasCapped(method) {
+		return nil
+	}
+
+	switch transaction := params[0].(type) {
+	case T:
+		return caps.check(method, transaction)
+	case *T:
+		if transaction != nil {
+			return caps.check(method, *transaction)
+		}
+		return nil
+	}
+
+	// transactions given as maps or raw JSON are checked by their JSON encoding
+	transaction, err := decodeTransaction(params[0])
+	if err != nil {
+		return err
+	}
+
+	return caps.check(method, transaction)
+}
@@ -0,0 +1,51 @@
+package asimovrpc
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func TestGasCaps(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_sendTransaction", "0x1234")
+	node.Handle("personal_signTransaction", map[string]string{"raw": "0x01"})
+
+	rpc := New(node.URL, WithGasCaps(GasCaps{MaxGas: 100000, MaxGasPrice: big.NewInt(20)}))
+
+	_, err := rpc.AsimovSendTransaction(T{From: "0x1", To: "0x2", Gas: 100000, GasPrice: big.NewInt(20)})
+	require.Nil(t, err)
+	_, err = rpc.AsimovSendTransaction(T{From: "0x1", To: "0x2"})
+	require.Nil(t, err)
+
+	_, err = rpc.AsimovSendTransaction(T{From: "0x1", To: "0x2", Gas: 100001})
+	require.Equal(t, GasCapError{Method: "flow_sendTransaction", Field: "gas", Value: big.NewInt(100001), Cap: big.NewInt(100000)}, err)
+	require.EqualError(t, err, "Transaction of flow_sendTransaction exceeds gas cap: 100001 > 100000")
+
+	_, err = rpc.AsimovSendTransaction(T{From: "0x1", To: "0x2", GasPrice: big.NewInt(21)})
+	require.Equal(t, GasCapError{Method: "flow_sendTransaction", Field: "gasPrice", Value: big.NewInt(21), Cap: big.NewInt(20)}, err)
+	require.Len(t, node.Calls("flow_sendTransaction"), 2)
+
+	// transactions given as maps are checked by their JSON encoding
+	_, err = rpc.Call("personal_signTransaction", map[string]string{"from": "0x1", "gas": "0x186a1"}, "password")
+	require.Equal(t, "gas", err.(GasCapError).Field)
+	batch := rpc.NewBatch().Add("flow_sendTransaction", nil, T{From: "0x1", GasPrice: big.NewInt(21)})
+	require.Nil(t, batch.Execute())
+	require.Equal(t, "gasPrice", batch.Err(0).(GasCapError).Field)
+	require.Len(t, node.Calls("personal_signTransaction"), 0)
+	require.Len(t, node.Calls("flow_sendTransaction"), 2)
+
+	signer, err := NewPrivateKeySigner("0x" + strings.Repeat("46", 32))
+	require.Nil(t, err)
+	_, err = rpc.SendTransactionLocal(T{To: "0x2", Gas: 100001, GasPrice: big.NewInt(1)}, signer)
+	require.Equal(t, "gas", err.(GasCapError).Field)
+	require.Len(t, node.Calls("flow_sendRawTransaction"), 0)
+
+	_, err = NewClient(node.URL, WithGasCaps(GasCaps{MaxGas: -1}))
+	require.EqualError(t, err, "asimovrpc: invalid option WithGasCaps: caps must not be negative")
+}
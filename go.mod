@@ -1,9 +1,30 @@
 module github.com/mistdex/mist-asimov-rpc
 
-go 1.12
+go 1.18
 
 require (
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.2.0
+	github.com/gorilla/websocket v1.4.2
 	github.com/jarcoal/httpmock v1.0.4
+	github.com/prometheus/client_golang v1.14.0
 	github.com/stretchr/testify v1.4.0
 	github.com/tidwall/gjson v1.3.2
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
+	gopkg.in/yaml.v2 v2.4.0
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.1.2 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/matttproud/golang_protobuf_extensions v1.0.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/prometheus/client_model v0.3.0 // indirect
+	github.com/prometheus/common v0.37.0 // indirect
+	github.com/prometheus/procfs v0.8.0 // indirect
+	github.com/tidwall/match v1.0.1 // indirect
+	github.com/tidwall/pretty v1.0.0 // indirect
+	golang.org/x/sys v0.0.0-20220520151302-bc2c85ada10a // indirect
+	google.golang.org/protobuf v1.28.1 // indirect
 )
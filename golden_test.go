@@ -0,0 +1,176 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/mistdex/mist-asimov-rpc/fixtures"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files of TestGoldenDecode")
+
+// goldenCalls decode fixture of every wrapped method, arguments do not matter as fixtures are served by method
+var goldenCalls = map[string]func(rpc *AsimovRPC) (interface{}, error){
+	"web3_clientVersion":                    func(rpc *AsimovRPC) (interface{}, error) { return rpc.Web3ClientVersion() },
+	"web3_sha3":                             func(rpc *AsimovRPC) (interface{}, error) { return rpc.Web3Sha3([]byte("hello world")) },
+	"net_version":                           func(rpc *AsimovRPC) (interface{}, error) { return rpc.NetVersion() },
+	"net_listening":                         func(rpc *AsimovRPC) (interface{}, error) { return rpc.NetListening() },
+	"net_peerCount":                         func(rpc *AsimovRPC) (interface{}, error) { return rpc.NetPeerCount() },
+	"flow_protocolVersion":                  func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovProtocolVersion() },
+	"flow_syncing":                          func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovSyncing() },
+	"flow_coinbase":                         func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovCoinbase() },
+	"flow_mining":                           func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovMining() },
+	"flow_hashrate":                         func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovHashrate() },
+	"flow_gasPrice":                         func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGasPrice() },
+	"flow_maxPriorityFeePerGas":             func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovMaxPriorityFeePerGas() },
+	"flow_feeHistory":                       func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovFeeHistory(2, Latest(), []float64{25, 75}) },
+	"flow_chainId":                          func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovChainID() },
+	"flow_accounts":                         func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovAccounts() },
+	"flow_blockNumber":                      func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovBlockNumber() },
+	"flow_getBalance":                       func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetBalance("0x1", Latest()) },
+	"flow_getStorageAt":                     func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetStorageAt("0x1", 0, Latest()) },
+	"flow_getTransactionCount":              func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetTransactionCount("0x1", Latest()) },
+	"flow_getBlockTransactionCountByHash":   func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetBlockTransactionCountByHash("0x1") },
+	"flow_getBlockTransactionCountByNumber": func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetBlockTransactionCountByNumber(1) },
+	"flow_getUncleCountByBlockHash":         func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetUncleCountByBlockHash("0x1") },
+	"flow_getUncleCountByBlockNumber":       func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetUncleCountByBlockNumber(1) },
+	"flow_getUncleByBlockHashAndIndex": func(rpc *AsimovRPC) (interface{}, error) {
+		return rpc.AsimovGetUncleByBlockHashAndIndex("0x1", 0)
+	},
+	"flow_getUncleByBlockNumberAndIndex": func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetUncleByBlockNumberAndIndex(1, 0) },
+	"flow_getCode":                       func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetCode("0x1", Latest()) },
+	"flow_getProof":                      func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetProof("0x1", []string{"0x0"}, Latest()) },
+	"flow_sign":                          func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovSign("0x1", "0x2") },
+	"flow_sendTransaction":               func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovSendTransaction(T{From: "0x1"}) },
+	"flow_sendRawTransaction":            func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovSendRawTransaction("0x1") },
+	"flow_signTransaction":               func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovSignTransaction(T{From: "0x1"}) },
+	"flow_pendingTransactions":           func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovPendingTransactions() },
+	"flow_getCompilers":                  func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetCompilers() },
+	"flow_getBlockByHash":                func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetBlockByHash("0x1", true) },
+	"flow_getBlockByNumber":              func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetBlockByNumber(1, true) },
+	"flow_call":                          func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovCall(T{From: "0x1"}, Latest()) },
+	"flow_estimateGas":                   func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovEstimateGas(T{From: "0x1"}) },
+	"flow_getTransactionByHash":          func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetTransactionByHash("0x1") },
+	"flow_getTransactionByBlockHashAndIndex": func(rpc *AsimovRPC) (interface{}, error) {
+		return rpc.AsimovGetTransactionByBlockHashAndIndex("0x1", 0)
+	},
+	"flow_getTransactionByBlockNumberAndIndex": func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetTransactionByBlockNumberAndIndex(1, 0) },
+	"flow_getBlockReceipts":                    func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetBlockReceipts(Number(1)) },
+	"flow_getTransactionReceipt":               func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetTransactionReceipt("0x1") },
+	"flow_newFilter":                           func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovNewFilter(FilterParams{}) },
+	"flow_newBlockFilter":                      func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovNewBlockFilter() },
+	"flow_newPendingTransactionFilter":         func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovNewPendingTransactionFilter() },
+	"flow_getFilterChanges":                    func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetFilterChanges("0x1") },
+	"flow_getFilterLogs":                       func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetFilterLogs("0x1") },
+	"flow_getLogs":                             func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovGetLogs(FilterParams{}) },
+	"flow_uninstallFilter":                     func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovUninstallFilter("0x1") },
+	"personal_newAccount":                      func(rpc *AsimovRPC) (interface{}, error) { return rpc.PersonalNewAccount("password") },
+	"personal_unlockAccount":                   func(rpc *AsimovRPC) (interface{}, error) { return rpc.PersonalUnlockAccount("0x1", "password", 0) },
+	"personal_lockAccount":                     func(rpc *AsimovRPC) (interface{}, error) { return rpc.PersonalLockAccount("0x1") },
+	"personal_sign":                            func(rpc *AsimovRPC) (interface{}, error) { return rpc.PersonalSign("0x2", "0x1", "password") },
+	"personal_sendTransaction": func(rpc *AsimovRPC) (interface{}, error) {
+		return rpc.PersonalSendTransaction(T{From: "0x1"}, "password")
+	},
+	"admin_nodeInfo": func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovNodeInfo() },
+	"admin_peers":    func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovPeers() },
+	"admin_addPeer":  func(rpc *AsimovRPC) (interface{}, error) { return rpc.AsimovAddPeer("enode://1@127.0.0.1:8777") },
+	"debug_traceTransaction": func(rpc *AsimovRPC) (interface{}, error) {
+		return rpc.AsimovTraceTransaction("0x1", &TraceConfig{Tracer: CallTracer})
+	},
+	"debug_traceBlockByNumber": func(rpc *AsimovRPC) (interface{}, error) {
+		return rpc.AsimovTraceBlockByNumber(1, &TraceConfig{Tracer: CallTracer})
+	},
+	"debug_traceBlockByHash": func(rpc *AsimovRPC) (interface{}, error) {
+		return rpc.AsimovTraceBlockByHash("0x1", &TraceConfig{Tracer: CallTracer})
+	},
+	"rpc_modules":  func(rpc *AsimovRPC) (interface{}, error) { return rpc.Modules() },
+	"rpc.discover": func(rpc *AsimovRPC) (interface{}, error) { return rpc.Discover() },
+}
+
+// wrapperFuncs - helpers whose first argument names the method wrapped by the calling method
+var wrapperFuncs = map[string]bool{
+	"call": true, "RawCall": true, "CallContext": true, "getBlock": true, "getTransaction": true, "traceBlock": true,
+}
+
+// TestGoldenDecode decodes fixtures of every node version and compares the results with
+// testdata/golden/<version>/<method>.json, run with -update after changing types
+func TestGoldenDecode(t *testing.T) {
+	for _, version := range fixtures.Versions() {
+		rpc := New("http://fixtures", WithHttpClient(fixtures.HTTPClient(version)))
+
+		for _, method := range fixtures.Methods(version) {
+			call, ok := goldenCalls[method]
+			require.True(t, ok, "no golden call of %s", method)
+
+			value, err := call(rpc)
+			require.Nil(t, err, "%s/%s", version, method)
+			actual, err := json.MarshalIndent(value, "", "  ")
+			require.Nil(t, err)
+
+			golden := filepath.Join("testdata", "golden", version, method+".json")
+			if *updateGolden {
+				require.Nil(t, os.MkdirAll(filepath.Dir(golden), 0755))
+				require.Nil(t, ioutil.WriteFile(golden, append(actual, '\n'), 0644))
+				continue
+			}
+
+			expected, err := ioutil.ReadFile(golden)
+			require.Nil(t, err, "missing golden file, run go test -run TestGoldenDecode -update")
+			require.JSONEq(t, string(expected), string(actual), "%s/%s", version, method)
+		}
+	}
+}
+
+// TestGoldenCoverage checks that every method called by the wrappers of the package has a baseline fixture
+func TestGoldenCoverage(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	require.Nil(t, err)
+
+	wrapped := map[string]bool{discoverMethod: true}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		require.Nil(t, err)
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			var fn string
+			switch f := call.Fun.(type) {
+			case *ast.Ident:
+				fn = f.Name
+			case *ast.SelectorExpr:
+				fn = f.Sel.Name
+			}
+			if literal, ok := call.Args[0].(*ast.BasicLit); ok && wrapperFuncs[fn] && literal.Kind == token.STRING {
+				method, err := strconv.Unquote(literal.Value)
+				require.Nil(t, err)
+				wrapped[method] = true
+			}
+			return true
+		})
+	}
+
+	fixtured := map[string]bool{}
+	for _, method := range fixtures.Methods(fixtures.Baseline) {
+		fixtured[method] = true
+	}
+	for method := range wrapped {
+		require.True(t, fixtured[method], "no %s fixture of %s", fixtures.Baseline, method)
+	}
+}
@@ -0,0 +1,40 @@
+package asimovrpc
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicPolicy - what a streaming component does after one of its handlers panicked
+type PanicPolicy int
+
+// Panic policies
+const (
+	// PanicContinue reports the panic on Errors() and keeps the component running
+	PanicContinue PanicPolicy = iota
+	// PanicStop reports the panic on Errors() and stops the component
+	PanicStop
+)
+
+// PanicError - panic recovered from a user handler
+type PanicError struct {
+	Handler string
+	Value   interface{}
+	Stack   []byte
+}
+
+func (err PanicError) Error() string {
+	return fmt.Sprintf("Handler %s panicked: %v\n%s", err.Handler, err.Value, err.Stack)
+}
+
+// invoke calls handler, converting a panic into PanicError
+func invoke(name string, handler func()) (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			err = PanicError{Handler: name, Value: value, Stack: debug.Stack()}
+		}
+	}()
+
+	handler()
+	return nil
+}
@@ -0,0 +1,83 @@
+package asimovrpc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoke(t *testing.T) {
+	require.Nil(t, invoke("handler", func() {}))
+
+	err := invoke("handler", func() { panic("boom") })
+	panicErr, ok := err.(PanicError)
+	require.True(t, ok)
+	require.Equal(t, "handler", panicErr.Handler)
+	require.Equal(t, "boom", panicErr.Value)
+	require.True(t, strings.HasPrefix(err.Error(), "Handler handler panicked: boom\n"))
+	require.Contains(t, string(panicErr.Stack), "TestInvoke")
+}
+
+func watchedConfig(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "config")
+	require.Nil(t, err)
+
+	path := filepath.Join(dir, "client.json")
+	require.Nil(t, ioutil.WriteFile(path, []byte(`{"url": "http://a:8545"}`), 0644))
+	past := time.Now().Add(-time.Minute)
+	require.Nil(t, os.Chtimes(path, past, past))
+
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func touchConfig(t *testing.T, path, url string, age time.Duration) {
+	require.Nil(t, ioutil.WriteFile(path, []byte(`{"url": "`+url+`"}`), 0644))
+	modified := time.Now().Add(-age)
+	require.Nil(t, os.Chtimes(path, modified, modified))
+}
+
+func TestConfigWatcherHandlerPanic(t *testing.T) {
+	path, cleanup := watchedConfig(t)
+	defer cleanup()
+
+	rpc := New("http://a:8545")
+	watcher := rpc.WatchConfigFile(path, 5*time.Millisecond)
+	defer watcher.Stop()
+
+	reloaded := make(chan string, 2)
+	watcher.OnReload(func(cfg Config) { panic("bad handler") })
+	watcher.OnReload(func(cfg Config) { reloaded <- cfg.URL })
+
+	touchConfig(t, path, "http://b:8545", 30*time.Second)
+	err := <-watcher.Errors()
+	require.IsType(t, PanicError{}, err)
+	require.Equal(t, "http://b:8545", <-reloaded)
+
+	touchConfig(t, path, "http://c:8545", 0)
+	require.Equal(t, "http://c:8545", <-reloaded)
+}
+
+func TestConfigWatcherPanicStop(t *testing.T) {
+	path, cleanup := watchedConfig(t)
+	defer cleanup()
+
+	rpc := New("http://a:8545")
+	watcher := rpc.WatchConfigFile(path, 5*time.Millisecond)
+	watcher.SetPanicPolicy(PanicStop)
+	watcher.OnReload(func(cfg Config) { panic("bad handler") })
+
+	touchConfig(t, path, "http://b:8545", 0)
+	require.IsType(t, PanicError{}, <-watcher.Errors())
+
+	select {
+	case <-watcher.done:
+	case <-time.After(time.Second):
+		t.Fatal("watcher did not stop")
+	}
+	watcher.Stop()
+}
@@ -1,10 +1,13 @@
 package asimovrpc
 
 import (
+	"encoding/hex"
 	"fmt"
 	"math/big"
 	"strconv"
 	"strings"
+
+	"golang.org/x/crypto/sha3"
 )
 
 // ParseInt parse hex string value to int
@@ -21,6 +24,9 @@ func ParseInt(value string) (int, error) {
 func ParseBigInt(value string) (big.Int, error) {
 	i := big.Int{}
 	_, err := fmt.Sscan(value, &i)
+	if i.Sign() == 0 {
+		return big.Int{}, err
+	}
 
 	return i, err
 }
@@ -38,3 +44,23 @@ func BigToHex(bigInt big.Int) string {
 
 	return "0x" + strings.TrimPrefix(fmt.Sprintf("%x", bigInt.Bytes()), "0")
 }
+
+// Keccak256 returns Keccak-256 hash of concatenated data
+func Keccak256(data ...[]byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		hash.Write(d)
+	}
+
+	return hash.Sum(nil)
+}
+
+// HexToBytes decodes hexadecimal string with optional 0x prefix
+func HexToBytes(value string) ([]byte, error) {
+	value = strings.TrimPrefix(value, "0x")
+	if len(value)%2 == 1 {
+		value = "0" + value
+	}
+
+	return hex.DecodeString(value)
+}
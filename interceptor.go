@@ -0,0 +1,71 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// CallFunc - performs method call, the innermost CallFunc checks access and budget, sends the request
+// and records statistics
+type CallFunc func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error)
+
+// CallInterceptor - wraps calls with cross-cutting behavior such as caching, retries or request mutation.
+// Interceptors may change the context, method and params passed to next, skip it (serving cached
+// results) or call it many times (retrying).
+//
+//	func logCalls(next asimovrpc.CallFunc) asimovrpc.CallFunc {
+//		return func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+//			result, err := next(ctx, method, params)
+//			log.Println(method, err)
+//			return result, err
+//		}
+//	}
+type CallInterceptor func(next CallFunc) CallFunc
+
+// WithInterceptor add interceptors wrapping every Call. Interceptors added first are outermost,
+// batches are sent as a whole and do not pass through interceptors, see WithBatchObserver.
+func WithInterceptor(interceptors ...CallInterceptor) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		for _, interceptor := range interceptors {
+			if interceptor == nil {
+				rpc.invalidOption("WithInterceptor", "interceptor is nil")
+				return
+			}
+		}
+		rpc.interceptors = append(append([]CallInterceptor{}, rpc.interceptors...), interceptors...)
+	}
+}
+
+// chain returns invoke wrapped with retries and interceptors of the client
+func (rpc *AsimovRPC) chain() CallFunc {
+	call := rpc.invoke
+	if rpc.retries > 0 {
+		call = rpc.retry(call)
+	}
+	for i := len(rpc.interceptors) - 1; i >= 0; i-- {
+		call = rpc.interceptors[i](call)
+	}
+
+	return call
+}
+
+type headerKey struct{}
+
+// WithHeader returns context adding HTTP header to requests sent with it, for example
+// an authorization header set by an interceptor
+func WithHeader(ctx context.Context, name, value string) context.Context {
+	header := HeaderFromContext(ctx).Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Add(name, value)
+
+	return context.WithValue(ctx, headerKey{}, header)
+}
+
+// HeaderFromContext returns HTTP headers attached to ctx (nil - no headers)
+func HeaderFromContext(ctx context.Context) http.Header {
+	header, _ := ctx.Value(headerKey{}).(http.Header)
+	return header
+}
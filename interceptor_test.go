@@ -0,0 +1,125 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func recordCalls(name string, trace *[]string) CallInterceptor {
+	return func(next CallFunc) CallFunc {
+		return func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			*trace = append(*trace, name+" "+method)
+			return next(ctx, method, params)
+		}
+	}
+}
+
+func (s *AsimovRPCTestSuite) TestInterceptorOrder() {
+	var trace []string
+	rpc := New(s.rpc.url, WithInterceptor(recordCalls("outer", &trace)), WithInterceptor(recordCalls("inner", &trace)))
+	s.registerResponse(`"0x1"`, func(body []byte) {
+		trace = append(trace, "node")
+	})
+
+	_, err := rpc.AsimovBlockNumber()
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"outer flow_blockNumber", "inner flow_blockNumber", "node"}, trace)
+}
+
+func (s *AsimovRPCTestSuite) TestInterceptorCache() {
+	cache := map[string]json.RawMessage{}
+	caching := func(next CallFunc) CallFunc {
+		return func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			if result, ok := cache[method]; ok {
+				return result, nil
+			}
+			result, err := next(ctx, method, params)
+			if err == nil {
+				cache[method] = result
+			}
+			return result, err
+		}
+	}
+	rpc := New(s.rpc.url, WithInterceptor(caching))
+
+	calls := 0
+	s.registerResponse(`"0x1"`, func([]byte) { calls++ })
+
+	for i := 0; i < 3; i++ {
+		version, err := rpc.NetVersion()
+		s.Require().Nil(err)
+		s.Require().Equal("0x1", version)
+	}
+	s.Require().Equal(1, calls)
+	s.Require().Equal(1, rpc.Stats()["net_version"].Calls)
+}
+
+func (s *AsimovRPCTestSuite) TestInterceptorRetry() {
+	retry := func(next CallFunc) CallFunc {
+		return func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			result, err := next(ctx, method, params)
+			if errors.Is(err, ErrFilterNotFound) {
+				return next(ctx, method, params)
+			}
+			return result, err
+		}
+	}
+	rpc := New(s.rpc.url, WithInterceptor(retry))
+
+	calls := 0
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return httpmock.NewStringResponse(200, echoID(request, `{"jsonrpc":"2.0", "id":1, "error": {"code": -32000, "message": "filter not found"}}`)), nil
+		}
+		return httpmock.NewStringResponse(200, echoID(request, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`)), nil
+	})
+
+	_, err := rpc.AsimovBlockNumber()
+	s.Require().Nil(err)
+	s.Require().Equal(2, calls)
+}
+
+func (s *AsimovRPCTestSuite) TestInterceptorMutation() {
+	auth := func(next CallFunc) CallFunc {
+		return func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			if method == "flow_getBalance" && len(params) == 1 {
+				params = append(params, "latest")
+			}
+			return next(WithHeader(ctx, "Authorization", "Bearer token"), method, params)
+		}
+	}
+	rpc := New(s.rpc.url, WithInterceptor(auth))
+
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		s.Require().Equal("Bearer token", request.Header.Get("Authorization"))
+		s.Require().Equal("application/json", request.Header.Get("Content-Type"))
+		s.JSONEq(`{"jsonrpc":"2.0", "id":1, "method":"flow_getBalance", "params":["0x1", "latest"]}`, string(s.getBody(request)))
+		return httpmock.NewStringResponse(200, echoID(request, `{"jsonrpc":"2.0", "id":1, "result": "0x10"}`)), nil
+	})
+
+	_, err := rpc.Call("flow_getBalance", "0x1")
+	s.Require().Nil(err)
+}
+
+func TestInterceptorOptions(t *testing.T) {
+	_, err := NewClient("http://a:8545", WithInterceptor(nil))
+	require.EqualError(t, err, "asimovrpc: invalid option WithInterceptor: interceptor is nil")
+}
+
+func TestWithHeader(t *testing.T) {
+	ctx := WithHeader(context.Background(), "X-Key", "a")
+	derived := WithHeader(ctx, "X-Key", "b")
+
+	require.Equal(t, []string{"a"}, HeaderFromContext(ctx).Values("X-Key"))
+	require.Equal(t, []string{"a", "b"}, HeaderFromContext(derived).Values("X-Key"))
+	require.Nil(t, HeaderFromContext(context.Background()))
+}
@@ -1,7 +1,12 @@
 package asimovrpc
 
 import (
+	"context"
+	"encoding/json"
 	"math/big"
+	"time"
+
+	"github.com/mistdex/mist-asimov-rpc/openrpc"
 )
 
 type AsimovAPI interface {
@@ -16,27 +21,39 @@ type AsimovAPI interface {
 	AsimovMining() (bool, error)
 	AsimovHashrate() (int, error)
 	AsimovGasPrice() (big.Int, error)
+	AsimovMaxPriorityFeePerGas() (big.Int, error)
+	AsimovFeeHistory(blockCount int, newestBlock BlockNumber, rewardPercentiles []float64) (*FeeHistory, error)
+	AsimovChainID() (*big.Int, error)
 	AsimovAccounts() ([]string, error)
 	AsimovBlockNumber() (int, error)
-	AsimovGetBalance(address, block string) (big.Int, error)
-	AsimovGetStorageAt(data string, position int, tag string) (string, error)
-	AsimovGetTransactionCount(address, block string) (int, error)
+	AsimovGetBalance(address string, block BlockNumber) (big.Int, error)
+	AsimovGetStorageAt(data string, position int, block BlockNumber) (string, error)
+	AsimovGetTransactionCount(address string, block BlockNumber) (int, error)
 	AsimovGetBlockTransactionCountByHash(hash string) (int, error)
-	AsimovGetBlockTransactionCountByNumber(number int) (int, error)
+	AsimovGetBlockTransactionCountByNumber(number BlockNumber) (int, error)
 	AsimovGetUncleCountByBlockHash(hash string) (int, error)
-	AsimovGetUncleCountByBlockNumber(number int) (int, error)
-	AsimovGetCode(address, block string) (string, error)
+	AsimovGetUncleCountByBlockNumber(number BlockNumber) (int, error)
+	AsimovGetUncleByBlockHashAndIndex(hash string, index int) (*Block, error)
+	AsimovGetUncleByBlockNumberAndIndex(number BlockNumber, index int) (*Block, error)
+	AsimovGetCode(address string, block BlockNumber) (string, error)
+	AsimovGetProof(address string, storageKeys []string, block BlockNumber) (*AccountProof, error)
+	AsimovTraceTransaction(hash string, config *TraceConfig) (*TransactionTrace, error)
+	AsimovTraceBlockByNumber(number BlockNumber, config *TraceConfig) ([]TransactionTrace, error)
+	AsimovTraceBlockByHash(hash string, config *TraceConfig) ([]TransactionTrace, error)
 	AsimovSign(address, data string) (string, error)
 	AsimovSendTransaction(transaction T) (string, error)
 	AsimovSendRawTransaction(data string) (string, error)
-	AsimovCall(transaction T, tag string) (string, error)
+	AsimovSignTransaction(transaction T) (*SignedTransaction, error)
+	AsimovCall(transaction T, block BlockNumber, overrides ...StateOverride) (string, error)
 	AsimovEstimateGas(transaction T) (int, error)
 	AsimovGetBlockByHash(hash string, withTransactions bool) (*Block, error)
-	AsimovGetBlockByNumber(number int, withTransactions bool) (*Block, error)
+	AsimovGetBlockByNumber(number BlockNumber, withTransactions bool) (*Block, error)
 	AsimovGetTransactionByHash(hash string) (*Transaction, error)
 	AsimovGetTransactionByBlockHashAndIndex(blockHash string, transactionIndex int) (*Transaction, error)
-	AsimovGetTransactionByBlockNumberAndIndex(blockNumber, transactionIndex int) (*Transaction, error)
+	AsimovGetTransactionByBlockNumberAndIndex(blockNumber BlockNumber, transactionIndex int) (*Transaction, error)
+	AsimovGetBlockReceipts(block BlockReference) ([]TransactionReceipt, error)
 	AsimovGetTransactionReceipt(hash string) (*TransactionReceipt, error)
+	AsimovPendingTransactions(filters ...TransactionFilter) ([]Transaction, error)
 	AsimovGetCompilers() ([]string, error)
 	AsimovNewFilter(params FilterParams) (string, error)
 	AsimovNewBlockFilter() (string, error)
@@ -47,4 +64,119 @@ type AsimovAPI interface {
 	AsimovGetLogs(params FilterParams) ([]Log, error)
 }
 
+// Client - full method set of AsimovRPC, for mocks in unit tests of code built on the client.
+// TestClientMethodSet fails when a method of AsimovRPC is missing here.
+type Client interface {
+	AsimovAPI
+
+	// calls and client settings
+	Agent(app string) *AsimovRPC
+	URL() string
+	WithContext(ctx context.Context) *AsimovRPC
+	Priority(priority Priority) *AsimovRPC
+	Call(method string, params ...interface{}) (json.RawMessage, error)
+	CallContext(ctx context.Context, method string, params ...interface{}) (json.RawMessage, error)
+	RawCall(method string, params ...interface{}) (json.RawMessage, error)
+	NewBatch() *Batch
+	Asim1() *big.Int
+	UpdateConfig(cfg Config) error
+	WatchConfigFile(path string, interval time.Duration) *ConfigWatcher
+	NewConfigWatcher(path string, interval time.Duration) *ConfigWatcher
+	Close() error
+
+	// node-hosted accounts
+	PersonalNewAccount(password string) (string, error)
+	PersonalUnlockAccount(address, password string, duration time.Duration) (bool, error)
+	PersonalLockAccount(address string) (bool, error)
+	PersonalSign(data, address, password string) (string, error)
+	PersonalSendTransaction(transaction T, password string) (string, error)
+	ProveOwnership(proof *OwnershipProof) error
+
+	// node administration
+	AsimovNodeInfo() (*NodeInfo, error)
+	AsimovPeers() ([]PeerInfo, error)
+	AsimovAddPeer(enode string) (bool, error)
+
+	// namespaces and extensions
+	Modules() (map[string]string, error)
+	Web3() Web3API
+	Net() NetAPI
+	Flow() FlowAPI
+	Personal() PersonalAPI
+	Admin() AdminAPI
+	Debug() DebugAPI
+	RegisterExtension(name string, constructor ExtensionConstructor) error
+	Extension(name string) (interface{}, error)
+	Discover() (*openrpc.Document, error)
+	DiscoverContext(ctx context.Context) (*openrpc.Document, error)
+
+	// network and endpoints
+	ChainConfig() ChainConfig
+	Rules(height int) Rules
+	Network() (Network, bool)
+	ValidateConnection(ctx context.Context) error
+	Endpoints() ([]string, error)
+	EndpointHealth() []EndpointHealth
+	CheckEndpoints(ctx context.Context) []EndpointHealth
+	Diagnostics() []EndpointDiagnostics
+
+	// usage
+	BudgetUsage() (BudgetUsage, bool)
+	CostReport(weights CostWeights) CostReport
+	Stats() map[string]MethodStats
+	StatsByTag(key string) map[string]map[string]MethodStats
+
+	// logs and filters
+	AsimovScanLogs(params FilterParams, fromBlock, toBlock int) ([]Log, error)
+	AsimovGetLogsRange(ctx context.Context, params FilterParams, fromBlock, toBlock int) *PageIterator[Log]
+	NewLogFilter(params FilterParams) *FilterManager
+	NewBlockFilter() *FilterManager
+	NewPendingTransactionFilter() *FilterManager
+	Subscribe(ctx context.Context, kind string, params ...interface{}) (*Subscription, error)
+	SubscribeNewHeads(ctx context.Context) (*Subscription, error)
+	SubscribeLogs(ctx context.Context, params FilterParams) (*Subscription, error)
+	SubscribePendingTransactions(ctx context.Context) (*Subscription, error)
+
+	// transactions
+	ChainID() (int64, error)
+	SendTransactionLocal(transaction T, signer TransactionSigner) (string, error)
+	NewNonceManager() *NonceManager
+	PreflightDeploy(transaction T) (*Preflight, error)
+	DeployContract(transaction T) (string, error)
+	ExplainTransaction(ctx context.Context, hash string) (*Explanation, error)
+	TokenTransfers(ctx context.Context, hash string) ([]TokenTransfer, error)
+	TransactionFee(hash string) (Amount, error)
+	SuggestFees(ctx context.Context, tier FeeTier) (*FeeSuggestion, error)
+	Sweep(ctx context.Context, signers []TransactionSigner, sweep Sweep) (*SweepReport, error)
+	WatchOnly(addresses ...string) *WatchOnly
+	WaitForBlock(ctx context.Context, height int) (int, error)
+	WaitForSync(ctx context.Context) error
+	WaitForTransactionReceipt(ctx context.Context, hash string, confirmations int) (*TransactionReceipt, error)
+	TransactionMetadata(hash string) (*MetadataEnvelope, error)
+	ReceiptWithMetadata(ctx context.Context, hash string) (*ReceiptWithMetadata, error)
+
+	// chain statistics
+	BlockRewards(ctx context.Context, number int) (*BlockReward, error)
+	ChainStats(ctx context.Context, blocks int) (*ChainStats, error)
+	ChainStatsRange(ctx context.Context, fromBlock, toBlock int) (*ChainStats, error)
+	TotalSupply(ctx context.Context, contract string, block BlockNumber) (big.Int, error)
+	Supply(ctx context.Context, contracts map[string]string, block BlockNumber) ([]Amount, error)
+	TransferRecipients(token string, fromBlock, toBlock int) HolderSource
+	RichList(ctx context.Context, token string, source HolderSource, block BlockNumber) (*RichList, error)
+	Snapshot(ctx context.Context, token string, height int) (*Snapshot, error)
+	ResumeSnapshot(ctx context.Context, checkpoint *Snapshot, height int) (*Snapshot, error)
+	UpdateSnapshot(ctx context.Context, snapshot *Snapshot, height, sample int) ([]SnapshotChange, error)
+	VerifySnapshot(ctx context.Context, snapshot *Snapshot, sample int) error
+}
+
 var _ AsimovAPI = (*AsimovRPC)(nil)
+var _ Client = (*AsimovRPC)(nil)
+
+// bind returns client making calls with ctx, other Client implementations are returned as they are
+func bind(client Client, ctx context.Context) Client {
+	if rpc, ok := client.(*AsimovRPC); ok {
+		return rpc.WithContext(ctx)
+	}
+
+	return client
+}
@@ -0,0 +1,62 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientMethodSet(t *testing.T) {
+	client := reflect.TypeOf((*Client)(nil)).Elem()
+	rpc := reflect.TypeOf((*AsimovRPC)(nil))
+	for i := 0; i < rpc.NumMethod(); i++ {
+		if _, ok := client.MethodByName(rpc.Method(i).Name); !ok {
+			t.Errorf("Client is missing %s, add it to interface.go", rpc.Method(i).Name)
+		}
+	}
+}
+
+type mockClient struct {
+	Client
+	methods []string
+}
+
+func (m *mockClient) Call(method string, params ...interface{}) (json.RawMessage, error) {
+	m.methods = append(m.methods, method)
+	return json.RawMessage(`"node-1"`), nil
+}
+
+func (m *mockClient) AsimovGetTransactionReceipt(hash string) (*TransactionReceipt, error) {
+	m.methods = append(m.methods, "flow_getTransactionReceipt")
+	return &TransactionReceipt{Status: "0x1", Logs: []Log{{
+		Address: "0x66aa",
+		Topics:  []string{TransferTopic, "0x000000000000000000000000000000000000000000000000000000000000000b", "0x000000000000000000000000000000000000000000000000000000000000000c"},
+		Data:    "0x64",
+	}}}, nil
+}
+
+func TestClientHelpers(t *testing.T) {
+	// helpers built on Client work with mocks
+	mock := &mockClient{}
+	leader, err := CallAs[string](mock, "cluster_leader")
+	require.Nil(t, err)
+	require.Equal(t, "node-1", leader)
+
+	var node string
+	require.Nil(t, NewNamespace(mock, "cluster").Call("node", &node))
+	require.Equal(t, "node-1", node)
+
+	transfers, err := TransferExtractor{RPC: mock}.Transfers(context.Background(), "0x01")
+	require.Nil(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, "100", transfers[0].Amount.String())
+	require.Equal(t, []string{"cluster_leader", "cluster_node", "flow_getTransactionReceipt"}, mock.methods)
+
+	rpc := NewAsimovRPC("http://127.0.0.1:8545")
+	require.IsType(t, &AsimovRPC{}, rpc.WithContext(context.Background()))
+	require.IsType(t, &AsimovRPC{}, rpc.Agent("app"))
+	require.IsType(t, &AsimovRPC{}, rpc.Priority(PriorityUser))
+}
@@ -0,0 +1,231 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrTransactionDropped - transaction has no receipt and the node does not know it anymore
+var ErrTransactionDropped = errors.New("transaction dropped")
+
+// ErrTransactionReplaced - another transaction with the same nonce was mined
+var ErrTransactionReplaced = errors.New("transaction replaced")
+
+// TxState - transaction lifecycle state
+type TxState int
+
+// Transaction lifecycle states
+const (
+	TxBuilt TxState = iota
+	TxSigned
+	TxBroadcast
+	TxPending
+	TxMined
+	TxConfirmed
+	TxFailed
+	TxDropped
+	TxReplaced
+)
+
+var txStateNames = map[TxState]string{
+	TxBuilt:     "built",
+	TxSigned:    "signed",
+	TxBroadcast: "broadcast",
+	TxPending:   "pending",
+	TxMined:     "mined",
+	TxConfirmed: "confirmed",
+	TxFailed:    "failed",
+	TxDropped:   "dropped",
+	TxReplaced:  "replaced",
+}
+
+var txTransitions = map[TxState][]TxState{
+	TxBuilt:     {TxSigned, TxBroadcast},
+	TxSigned:    {TxBroadcast},
+	TxBroadcast: {TxPending, TxMined, TxDropped, TxReplaced},
+	TxPending:   {TxMined, TxDropped, TxReplaced},
+	TxMined:     {TxConfirmed, TxFailed, TxPending, TxDropped, TxReplaced}, // reorgs return mined transactions to the pool
+}
+
+func (state TxState) String() string {
+	if name, ok := txStateNames[state]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("TxState(%d)", int(state))
+}
+
+// Final returns true if no further transitions are possible from state
+func (state TxState) Final() bool {
+	return len(txTransitions[state]) == 0
+}
+
+// CanTransition returns true if the lifecycle allows moving from state to next
+func (state TxState) CanTransition(next TxState) bool {
+	for _, allowed := range txTransitions[state] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TransitionError - invalid lifecycle transition
+type TransitionError struct {
+	From TxState
+	To   TxState
+}
+
+func (err TransitionError) Error() string {
+	return fmt.Sprintf("Invalid transaction transition %s -> %s", err.From, err.To)
+}
+
+// TxEvent - transaction lifecycle transition
+type TxEvent struct {
+	Hash string
+	From TxState
+	To   TxState
+	Time time.Time // by the clock of the client driving the transition, see WithClock
+}
+
+// TxLifecycle - transaction lifecycle state machine
+type TxLifecycle struct {
+	mu        sync.Mutex
+	hash      string
+	state     TxState
+	history   []TxEvent
+	listeners []func(TxEvent)
+}
+
+// NewTxLifecycle creates lifecycle in the built state
+func NewTxLifecycle() *TxLifecycle {
+	return &TxLifecycle{state: TxBuilt}
+}
+
+// State returns current state
+func (l *TxLifecycle) State() TxState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.state
+}
+
+// Hash returns transaction hash once known
+func (l *TxLifecycle) Hash() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.hash
+}
+
+// SetHash sets transaction hash reported in transition events
+func (l *TxLifecycle) SetHash(hash string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.hash = hash
+}
+
+// History returns all transitions in order
+func (l *TxLifecycle) History() []TxEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]TxEvent(nil), l.history...)
+}
+
+// OnTransition registers listener called after every transition
+func (l *TxLifecycle) OnTransition(listener func(TxEvent)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.listeners = append(l.listeners, listener)
+}
+
+// Transition moves lifecycle to the next state and notifies listeners
+func (l *TxLifecycle) Transition(next TxState) error {
+	l.mu.Lock()
+	return l.transition(next, SystemClock.Now())
+}
+
+// transition moves locked lifecycle to next at time now, unlocks it and notifies listeners
+func (l *TxLifecycle) transition(next TxState, now time.Time) error {
+	if !l.state.CanTransition(next) {
+		err := TransitionError{From: l.state, To: next}
+		l.mu.Unlock()
+		return err
+	}
+
+	event := TxEvent{
+		Hash: l.hash,
+		From: l.state,
+		To:   next,
+		Time: now,
+	}
+	l.state = next
+	l.history = append(l.history, event)
+	listeners := make([]func(TxEvent), len(l.listeners))
+	copy(listeners, l.listeners)
+	l.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(event)
+	}
+
+	return nil
+}
+
+// advance sets hash unless it is known and moves lifecycle to next unless it is there already,
+// checking and moving under one lock. Transitions not allowed from the current state fail with TransitionError.
+func (l *TxLifecycle) advance(hash string, next TxState, now time.Time) error {
+	l.mu.Lock()
+	if l.hash == "" {
+		l.hash = hash
+	}
+	if l.state == next {
+		l.mu.Unlock()
+		return nil
+	}
+
+	return l.transition(next, now)
+}
+
+type txLifecycleKey struct{}
+
+// WithTxLifecycle attaches lifecycle of a transaction to ctx, for example:
+//
+//	lifecycle := asimovrpc.NewTxLifecycle()
+//	lifecycle.OnTransition(func(event asimovrpc.TxEvent) { ... })
+//	ctx = asimovrpc.WithTxLifecycle(ctx, lifecycle)
+//	tracked := client.WithContext(ctx)
+//	hash, err := tracked.SendTransactionLocal(transaction, signer)
+//	receipt, err := tracked.WaitForTransactionReceipt(ctx, hash, 12)
+//
+// SendTransactionLocal and NonceManager.SendContext move it to signed and broadcast.
+// WaitForTransactionReceipt moves it to pending while the transaction has no receipt, to mined once
+// it has one, and to confirmed or failed (by receipt status) once the receipt has the requested
+// confirmations. It moves it to dropped or replaced, and fails with ErrTransactionDropped or
+// ErrTransactionReplaced, if the node forgets the transaction or mines another one with its nonce.
+// Transitions not allowed from the current state fail the call with TransitionError.
+func WithTxLifecycle(ctx context.Context, lifecycle *TxLifecycle) context.Context {
+	return context.WithValue(ctx, txLifecycleKey{}, lifecycle)
+}
+
+// TxLifecycleFromContext returns transaction lifecycle attached to ctx
+func TxLifecycleFromContext(ctx context.Context) (*TxLifecycle, bool) {
+	lifecycle, ok := ctx.Value(txLifecycleKey{}).(*TxLifecycle)
+	return lifecycle, ok && lifecycle != nil
+}
+
+// advanceLifecycle advances transaction lifecycle attached to ctx, if any, at the time of clock
+func advanceLifecycle(ctx context.Context, clock Clock, hash string, next TxState) error {
+	if lifecycle, ok := TxLifecycleFromContext(ctx); ok {
+		return lifecycle.advance(hash, next, clock.Now())
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func TestTxLifecycle(t *testing.T) {
+	lifecycle := NewTxLifecycle()
+	require.Equal(t, TxBuilt, lifecycle.State())
+
+	events := []TxEvent{}
+	lifecycle.OnTransition(func(event TxEvent) {
+		events = append(events, event)
+	})
+
+	require.Nil(t, lifecycle.Transition(TxSigned))
+	lifecycle.SetHash("0x111")
+	require.Nil(t, lifecycle.Transition(TxBroadcast))
+	require.Nil(t, lifecycle.Transition(TxPending))
+	require.Nil(t, lifecycle.Transition(TxMined))
+	require.Nil(t, lifecycle.Transition(TxPending))
+	require.Nil(t, lifecycle.Transition(TxMined))
+	require.Nil(t, lifecycle.Transition(TxConfirmed))
+	require.True(t, lifecycle.State().Final())
+
+	err := lifecycle.Transition(TxFailed)
+	require.Equal(t, TransitionError{From: TxConfirmed, To: TxFailed}, err)
+	require.Equal(t, "Invalid transaction transition confirmed -> failed", err.Error())
+
+	require.Len(t, events, 7)
+	require.Equal(t, lifecycle.History(), events)
+	require.Equal(t, "", events[0].Hash)
+	require.Equal(t, "0x111", events[1].Hash)
+	require.Equal(t, TxSigned, events[1].From)
+	require.Equal(t, TxBroadcast, events[1].To)
+}
+
+func TestTxState(t *testing.T) {
+	require.Equal(t, "replaced", TxReplaced.String())
+	require.Equal(t, "TxState(42)", TxState(42).String())
+	require.True(t, TxBuilt.CanTransition(TxBroadcast))
+	require.False(t, TxSigned.CanTransition(TxMined))
+	require.True(t, TxDropped.Final())
+	require.False(t, TxMined.Final())
+}
+
+func TestTxLifecycleSendAndWait(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_chainId", "0x1")
+	node.Handle("flow_getTransactionCount", "0x9")
+	node.Handle("flow_sendRawTransaction", "0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788")
+	node.Handle("flow_blockNumber", "0x7")
+	node.Handle("flow_getTransactionByHash", map[string]interface{}{"hash": "0x33", "from": "0x1", "nonce": "0x9"})
+	polls := 0
+	node.HandleFunc("flow_getTransactionReceipt", func([]json.RawMessage) (interface{}, error) {
+		if polls++; polls < 2 {
+			return nil, nil
+		}
+		return map[string]interface{}{"blockHash": "0xb1", "blockNumber": "0x5", "status": "0x1"}, nil
+	})
+
+	signer, err := NewPrivateKeySigner("0x" + strings.Repeat("46", 32))
+	require.Nil(t, err)
+
+	lifecycle := NewTxLifecycle()
+	var states []TxState
+	lifecycle.OnTransition(func(event TxEvent) {
+		states = append(states, event.To)
+	})
+	ctx := WithTxLifecycle(context.Background(), lifecycle)
+	rpc := New(node.URL, WithPollInterval(time.Millisecond)).WithContext(ctx)
+
+	hash, err := rpc.NewNonceManager().SendContext(ctx, signer.Address(), func(nonce int) (string, error) {
+		transaction := eip155Transaction()
+		transaction.Nonce = nonce
+		return rpc.SendTransactionLocal(transaction, signer)
+	})
+	require.Nil(t, err)
+	require.Equal(t, hash, lifecycle.Hash())
+
+	_, err = rpc.WaitForTransactionReceipt(ctx, hash, 2)
+	require.Nil(t, err)
+	require.Equal(t, []TxState{TxSigned, TxBroadcast, TxPending, TxMined, TxConfirmed}, states)
+
+	// a failed transaction ends the lifecycle as failed
+	node.Handle("flow_getTransactionReceipt", map[string]interface{}{"blockHash": "0xb1", "blockNumber": "0x5", "status": "0x0"})
+	failed := NewTxLifecycle()
+	require.Nil(t, failed.Transition(TxBroadcast))
+	_, err = rpc.WaitForTransactionReceipt(WithTxLifecycle(context.Background(), failed), hash, 0)
+	require.Nil(t, err)
+	require.Equal(t, TxFailed, failed.State())
+	require.Equal(t, hash, failed.Hash())
+}
+
+func TestTxLifecycleDroppedAndReplaced(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_getTransactionReceipt", nil)
+	node.Handle("flow_getTransactionByHash", nil)
+	rpc := New(node.URL, WithPollInterval(time.Millisecond))
+
+	lifecycle := NewTxLifecycle()
+	require.Nil(t, lifecycle.Transition(TxBroadcast))
+	_, err := rpc.WaitForTransactionReceipt(WithTxLifecycle(context.Background(), lifecycle), "0x33", 0)
+	require.Equal(t, ErrTransactionDropped, err)
+	require.Equal(t, TxDropped, lifecycle.State())
+
+	// another transaction of the sender was mined with nonce 9
+	node.Handle("flow_getTransactionByHash", map[string]interface{}{"hash": "0x33", "from": "0x1", "nonce": "0x9"})
+	node.Handle("flow_getTransactionCount", "0xa")
+	lifecycle = NewTxLifecycle()
+	require.Nil(t, lifecycle.Transition(TxBroadcast))
+	_, err = rpc.WaitForTransactionReceipt(WithTxLifecycle(context.Background(), lifecycle), "0x33", 0)
+	require.Equal(t, ErrTransactionReplaced, err)
+	require.Equal(t, TxReplaced, lifecycle.State())
+	require.Len(t, node.Calls("flow_getTransactionReceipt"), 3) // asked again before reporting the replacement
+
+	// without lifecycle the wait goes on until ctx is done
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = rpc.WaitForTransactionReceipt(ctx, "0x33", 0)
+	require.Equal(t, context.DeadlineExceeded, err)
+	require.Len(t, node.Calls("flow_getTransactionCount"), 1)
+}
+
+func TestTxLifecycleAdvance(t *testing.T) {
+	now := time.Unix(1570000000, 0)
+	lifecycle := NewTxLifecycle()
+	require.Nil(t, lifecycle.advance("0x1", TxBroadcast, now))
+	require.Nil(t, lifecycle.advance("0x2", TxBroadcast, now))
+	require.Equal(t, "0x1", lifecycle.Hash())
+	require.Len(t, lifecycle.History(), 1)
+	require.Equal(t, now, lifecycle.History()[0].Time)
+
+	// concurrent advances move the lifecycle once
+	concurrent := NewTxLifecycle()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.Nil(t, concurrent.advance("0x1", TxBroadcast, now))
+		}()
+	}
+	wg.Wait()
+	require.Len(t, concurrent.History(), 1)
+
+	// transitions not allowed from the current state are reported
+	require.Equal(t, TransitionError{From: TxBroadcast, To: TxConfirmed}, lifecycle.advance("0x1", TxConfirmed, now))
+
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_getTransactionReceipt", map[string]interface{}{"blockHash": "0xb1", "blockNumber": "0x5", "status": "0x1"})
+	confirmed := NewTxLifecycle()
+	_, err := New(node.URL).WaitForTransactionReceipt(WithTxLifecycle(context.Background(), confirmed), "0x1", 0)
+	require.Equal(t, TransitionError{From: TxBuilt, To: TxMined}, err)
+}
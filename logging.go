@@ -0,0 +1,210 @@
+package asimovrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Redacted - replacement of secrets in logged requests and responses
+const Redacted = "[REDACTED]"
+
+type logLevel int
+
+const (
+	levelDebug logLevel = iota
+	levelWarn
+)
+
+// structuredLogger - receives records with key/value fields, set with WithSlog
+type structuredLogger interface {
+	logRecord(level logLevel, message string, fields ...interface{})
+}
+
+// secretParams - positions of passwords and keys among params of methods
+var secretParams = map[string][]int{
+	"personal_importRawKey":    {0, 1},
+	"personal_newAccount":      {0},
+	"personal_unlockAccount":   {1},
+	"personal_sendTransaction": {1},
+	"personal_signTransaction": {1},
+	"personal_sign":            {2},
+}
+
+// secretKeys - lower case substrings of object keys holding secrets
+var secretKeys = []string{"privatekey", "password", "passphrase", "secret", "mnemonic", "seed", "signature"}
+
+// warn logs message with the WithSlog logger at warning level, otherwise prints it with the client logger
+func (rpc *AsimovRPC) warn(message string, fields ...interface{}) {
+	if rpc.structured != nil {
+		rpc.structured.logRecord(levelWarn, message, fields...)
+		return
+	}
+	if rpc.log != nil {
+		rpc.log.Println(message)
+	}
+}
+
+// logCall logs request and response of a call with the WithSlog logger
+func (rpc *AsimovRPC) logCall(method string, id int, tags Tags, duration time.Duration, request, response []byte, limit int) {
+	fields := []interface{}{"method", method, "id", id, "duration", duration}
+	if len(tags) > 0 {
+		fields = append(fields, "tags", tags.String())
+	}
+	if code, ok := errorCode(response); ok {
+		fields = append(fields, "error_code", code)
+	}
+	fields = append(fields, "request", truncate(request, limit), "response", truncate(response, limit))
+
+	rpc.structured.logRecord(levelDebug, "asimovrpc call", fields...)
+}
+
+// errorCode returns code of JSON-RPC error response
+func errorCode(response []byte) (int, bool) {
+	var body struct {
+		Error *AsimovError `json:"error"`
+	}
+	if err := json.Unmarshal(response, &body); err != nil || body.Error == nil {
+		return 0, false
+	}
+
+	return body.Error.Code, true
+}
+
+// redact replaces secrets in JSON request or response body: passwords and keys of personal_* methods,
+// values of keys such as "privateKey" or "signature", private-key-like and signature-like strings.
+// Body is returned unchanged when it has no secrets.
+func redact(body []byte) []byte {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return body
+	}
+
+	if !redactMessage(value) {
+		return body
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+
+	return data
+}
+
+// redactParams returns params of method with secrets replaced, for logging
+func redactParams(method string, params []interface{}) []interface{} {
+	data, err := json.Marshal(asimovRequest{Method: method, Params: params})
+	if err != nil {
+		return params
+	}
+	redacted := redact(data)
+	if bytes.Equal(redacted, data) {
+		return params
+	}
+
+	var request struct {
+		Params []interface{} `json:"params"`
+	}
+	if err := json.Unmarshal(redacted, &request); err != nil {
+		return params
+	}
+
+	return request.Params
+}
+
+func redactMessage(value interface{}) bool {
+	switch message := value.(type) {
+	case []interface{}:
+		changed := false
+		for _, item := range message {
+			changed = redactMessage(item) || changed
+		}
+		return changed
+	case map[string]interface{}:
+		changed := false
+		method, _ := message["method"].(string)
+		if params, ok := message["params"].([]interface{}); ok {
+			for _, i := range secretParams[method] {
+				if i < len(params) && params[i] != nil {
+					params[i] = Redacted
+					changed = true
+				}
+			}
+		}
+		if _, c := redactValue(message); c {
+			changed = true
+		}
+		return changed
+	}
+
+	return false
+}
+
+func redactValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		if v != Redacted && secretLike(v) {
+			return Redacted, true
+		}
+	case []interface{}:
+		changed := false
+		for i, item := range v {
+			var c bool
+			v[i], c = redactValue(item)
+			changed = changed || c
+		}
+		return v, changed
+	case map[string]interface{}:
+		changed := false
+		for key, item := range v {
+			if secretKey(key) && item != nil && item != Redacted {
+				v[key] = Redacted
+				changed = true
+				continue
+			}
+			var c bool
+			v[key], c = redactValue(item)
+			changed = changed || c
+		}
+		return v, changed
+	}
+
+	return value, false
+}
+
+func secretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range secretKeys {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// secretLike reports whether s looks like a private key (64 hex digits without 0x prefix, hashes
+// always have one) or a 65-byte signature
+func secretLike(s string) bool {
+	switch {
+	case len(s) == 64:
+		return isHexDigits(s)
+	case len(s) == 132 && strings.HasPrefix(s, "0x"):
+		return isHexDigits(s[2:])
+	}
+
+	return false
+}
+
+func isHexDigits(s string) bool {
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+
+	return true
+}
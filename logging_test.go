@@ -0,0 +1,48 @@
+package asimovrpc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	key := strings.Repeat("4c", 32)
+	signature := "0x" + strings.Repeat("ab", 65)
+	hash := "0x" + strings.Repeat("cd", 32)
+
+	tests := []struct {
+		body     string
+		expected string
+	}{
+		{`{"id":1,"method":"flow_getTransactionByHash","params":["` + hash + `"]}`, `{"id":1,"method":"flow_getTransactionByHash","params":["` + hash + `"]}`},
+		{`{"id":1,"method":"personal_unlockAccount","params":["0x1","hunter2",300]}`, `{"id":1,"method":"personal_unlockAccount","params":["0x1","[REDACTED]",300]}`},
+		{`{"id":1,"method":"personal_importRawKey","params":["` + key + `","pw"]}`, `{"id":1,"method":"personal_importRawKey","params":["[REDACTED]","[REDACTED]"]}`},
+		{`{"id":1,"method":"flow_call","params":[{"to":"0x1","privateKey":"abc"}]}`, `{"id":1,"method":"flow_call","params":[{"privateKey":"[REDACTED]","to":"0x1"}]}`},
+		{`{"jsonrpc":"2.0","id":1,"result":"` + signature + `"}`, `{"id":1,"jsonrpc":"2.0","result":"[REDACTED]"}`},
+		{`[{"id":1,"method":"personal_newAccount","params":["pw"]},{"id":2,"method":"flow_blockNumber","params":null}]`, `[{"id":1,"method":"personal_newAccount","params":["[REDACTED]"]},{"id":2,"method":"flow_blockNumber","params":null}]`},
+		{`not json`, `not json`},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.expected, string(redact([]byte(test.body))), test.body)
+	}
+
+	require.Equal(t, []interface{}{"0x1", Redacted, float64(300)}, redactParams("personal_unlockAccount", []interface{}{"0x1", "hunter2", 300}))
+	params := []interface{}{"0x1", "latest"}
+	require.Equal(t, params, redactParams("flow_getBalance", params))
+}
+
+func (s *AsimovRPCTestSuite) TestDebugRedacted() {
+	log := new(bufferLogger)
+	rpc := New(s.rpc.url, WithLogger(log), WithDebug(true))
+
+	s.registerResponse(`true`, func([]byte) {})
+	_, err := rpc.Call("personal_unlockAccount", "0x1", "hunter2", 300)
+	s.Require().Nil(err)
+
+	s.Require().Len(log.lines, 1)
+	s.Require().Contains(log.lines[0], Redacted)
+	s.Require().NotContains(log.lines[0], "hunter2")
+}
@@ -0,0 +1,132 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// DefaultLogsChunkSize - blocks queried by one flow_getLogs call of AsimovGetLogsRange
+const DefaultLogsChunkSize = 2000
+
+// LogsRangeRetries - retries of a chunk failing with a transient error before AsimovGetLogsRange gives up
+const LogsRangeRetries = 3
+
+// WithLogsChunkSize set initial number of blocks queried by one flow_getLogs call of AsimovGetLogsRange
+func WithLogsChunkSize(blocks int) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if blocks <= 0 {
+			rpc.invalidOption("WithLogsChunkSize", "chunk size must be positive")
+			return
+		}
+		rpc.logsChunkSize = blocks
+	}
+}
+
+// AsimovGetLogsRange returns iterator over logs matching params between fromBlock and toBlock (inclusive),
+// ordered by block and log index without duplicates. Logs are fetched in chunks of blocks (see
+// WithLogsChunkSize) when the iterator needs them. Chunks the node refuses as too large (ErrTooManyResults)
+// are split. Network errors, 5xx and rate limited responses are retried LogsRangeRetries times with backoff
+// starting at the poll interval, or after the delay requested with Retry-After. Nodes without flow_getLogs
+// are scanned with AsimovScanLogs instead. FromBlock and ToBlock of params are ignored.
+func (rpc *AsimovRPC) AsimovGetLogsRange(ctx context.Context, params FilterParams, fromBlock, toBlock int) *PageIterator[Log] {
+	chunk := rpc.logsChunkSize
+	if chunk <= 0 {
+		chunk = DefaultLogsChunkSize
+	}
+	initial := chunk
+	client := rpc.WithContext(ctx)
+
+	return Paginate(ctx, func(ctx context.Context, cursor string) ([]Log, string, error) {
+		from := fromBlock
+		if cursor != "" {
+			from, _ = strconv.Atoi(cursor)
+		}
+		if from > toBlock {
+			return nil, "", nil
+		}
+
+		for retries := 0; ; {
+			to := from + chunk - 1
+			if to > toBlock {
+				to = toBlock
+			}
+			params = params.Range(Number(from), Number(to))
+
+			var logs []Log
+			var err error
+			if rpc.methods.supported("flow_getLogs") {
+				logs, err = client.AsimovGetLogs(params)
+				rpc.methods.check("flow_getLogs", err)
+			}
+			if !rpc.methods.supported("flow_getLogs") {
+				logs, err = client.AsimovScanLogs(params, from, to)
+			}
+			switch {
+			case err == nil:
+				if chunk < initial {
+					chunk *= 2 // the node may have refused a dense range only
+				}
+				next := ""
+				if to < toBlock {
+					next = strconv.Itoa(to + 1)
+				}
+				return orderLogs(logs), next, nil
+			case errors.Is(err, ErrTooManyResults) && chunk > 1:
+				chunk /= 2
+			case transient(ctx, err) && retries < LogsRangeRetries:
+				delay := rpc.backoff(retries)
+				if after, ok := RetryAfter(err); ok && after > delay {
+					delay = after
+				}
+				select {
+				case <-ctx.Done():
+					return nil, "", ctx.Err()
+				case <-rpc.clock.After(delay):
+				}
+				retries++
+			default:
+				return nil, "", err
+			}
+		}
+	})
+}
+
+// transient returns true if call may succeed when retried: it failed with a network error,
+// a 5xx response or rate limiting
+func transient(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var httpErr HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError || httpErr.StatusCode == http.StatusTooManyRequests
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// orderLogs sorts logs by block and log index and drops duplicates
+func orderLogs(logs []Log) []Log {
+	sort.SliceStable(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].LogIndex < logs[j].LogIndex
+	})
+
+	result := logs[:0]
+	for _, log := range logs {
+		if n := len(result); n > 0 && result[n-1].BlockNumber == log.BlockNumber &&
+			result[n-1].LogIndex == log.LogIndex && result[n-1].BlockHash == log.BlockHash {
+			continue
+		}
+		result = append(result, log)
+	}
+
+	return result
+}
@@ -0,0 +1,164 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func (s *AsimovRPCTestSuite) TestAsimovGetLogsRange() {
+	rpc := New(s.rpc.url, WithLogsChunkSize(10), WithPollInterval(time.Millisecond))
+
+	var ranges []string
+	failed := false
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := s.getBody(request)
+		s.methodEqual(body, "flow_getLogs")
+		s.Require().Equal(`["0xaca0"]`, gjson.GetBytes(body, "params.0.address").Raw)
+		from, _ := ParseInt(gjson.GetBytes(body, "params.0.fromBlock").String())
+		to, _ := ParseInt(gjson.GetBytes(body, "params.0.toBlock").String())
+		ranges = append(ranges, fmt.Sprintf("%d-%d", from, to))
+
+		if from > 12 && !failed {
+			failed = true
+			return nil, errors.New("connection reset by peer")
+		}
+		if from <= 12 && to >= 12 && to-from >= 3 {
+			return httpmock.NewStringResponse(200, echoID(request, `{"jsonrpc":"2.0", "id":1, "error": {"code": -32005, "message": "query returned more than 10000 results"}}`)), nil
+		}
+
+		// blocks with logs are returned in reverse order, block 12 twice
+		var logs []string
+		for block := to; block >= from; block-- {
+			if block%4 == 0 || block == 12 {
+				logs = append(logs, fmt.Sprintf(`{"blockNumber": "0x%x", "blockHash": "0x%x", "logIndex": "0x1"}`, block, block))
+				logs = append(logs, fmt.Sprintf(`{"blockNumber": "0x%x", "blockHash": "0x%x", "logIndex": "0x0"}`, block, block))
+			}
+			if block == 12 {
+				logs = append(logs, `{"blockNumber": "0xc", "blockHash": "0xc", "logIndex": "0x0"}`)
+			}
+		}
+		return httpmock.NewStringResponse(200, echoID(request, `{"jsonrpc":"2.0", "id":1, "result": [`+strings.Join(logs, ",")+`]}`)), nil
+	})
+
+	it := rpc.AsimovGetLogsRange(context.Background(), FilterParams{Address: []string{"0xaca0"}}.Range(Earliest(), Latest()), 3, 25)
+	var logs []string
+	for it.Next() {
+		logs = append(logs, fmt.Sprintf("%d/%d", it.Item().BlockNumber, it.Item().LogIndex))
+	}
+	s.Require().Nil(it.Err())
+	s.Require().Equal([]string{"4/0", "4/1", "8/0", "8/1", "12/0", "12/1", "16/0", "16/1", "20/0", "20/1", "24/0", "24/1"}, logs)
+	s.Require().Equal([]string{"3-12", "3-7", "8-17", "8-12", "8-9", "10-13", "10-11", "12-15", "12-13", "14-17", "14-17", "18-25"}, ranges)
+}
+
+func (s *AsimovRPCTestSuite) TestAsimovGetLogsRangeErrors() {
+	rpc := New(s.rpc.url, WithPollInterval(time.Millisecond))
+
+	calls := 0
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		calls++
+		return nil, errors.New("connection refused")
+	})
+	it := rpc.AsimovGetLogsRange(context.Background(), FilterParams{}, 0, 100)
+	s.Require().False(it.Next())
+	s.Require().NotNil(it.Err())
+	s.Require().Equal(LogsRangeRetries+1, calls)
+
+	calls = 0
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		calls++
+		return httpmock.NewStringResponse(200, echoID(request, `{"jsonrpc":"2.0", "id":1, "error": {"code": -32602, "message": "invalid topics"}}`)), nil
+	})
+	it = rpc.AsimovGetLogsRange(context.Background(), FilterParams{}, 0, 100)
+	s.Require().False(it.Next())
+	s.Require().True(errors.Is(it.Err(), ErrInvalidParams))
+	s.Require().Equal(1, calls)
+
+	it = rpc.AsimovGetLogsRange(context.Background(), FilterParams{}, 10, 5)
+	s.Require().False(it.Next())
+	s.Require().Nil(it.Err())
+}
+
+func TestAsimovGetLogsRangeScan(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.HandleFunc("flow_getBlockByNumber", func(params []json.RawMessage) (interface{}, error) {
+		var number string
+		json.Unmarshal(params[0], &number)
+		return map[string]interface{}{"number": number, "transactions": []string{"0xa" + strings.TrimPrefix(number, "0x")}}, nil
+	})
+	node.HandleFunc("flow_getTransactionReceipt", func(params []json.RawMessage) (interface{}, error) {
+		var hash string
+		json.Unmarshal(params[0], &hash)
+		number := "0x" + strings.TrimPrefix(hash, "0xa")
+		return map[string]interface{}{"transactionHash": hash, "blockHash": "0xb", "blockNumber": number,
+			"logs": []interface{}{map[string]interface{}{"address": "0xaca0", "blockNumber": number, "logIndex": "0x0", "topics": []string{}}}}, nil
+	})
+
+	rpc := New(node.URL, WithLogsChunkSize(2))
+	for i := 0; i < 2; i++ {
+		it := rpc.AsimovGetLogsRange(context.Background(), FilterParams{Address: []string{"0xaca0"}}, 1, 5)
+		var blocks []int
+		for it.Next() {
+			blocks = append(blocks, it.Item().BlockNumber)
+		}
+		require.Nil(t, it.Err())
+		require.Equal(t, []int{1, 2, 3, 4, 5}, blocks)
+	}
+	// the missing method is asked for once
+	require.Len(t, node.Calls("flow_getLogs"), 1)
+}
+
+func TestAsimovGetLogsRangeRetries(t *testing.T) {
+	status, retryAfter := http.StatusUnauthorized, ""
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 || status == http.StatusUnauthorized {
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(echoID(r, `{"jsonrpc":"2.0", "id":1, "result": []}`)))
+	}))
+	defer server.Close()
+
+	clock := asimovrpctest.NewFakeClock(time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC))
+	rpc := New(server.URL, WithPollInterval(time.Millisecond), WithClock(clock))
+
+	// the node refused the call, retrying would not help
+	it := rpc.AsimovGetLogsRange(context.Background(), FilterParams{}, 0, 10)
+	require.False(t, it.Next())
+	require.True(t, errors.As(it.Err(), &HTTPError{}))
+	require.Equal(t, 1, calls)
+
+	// rate limited calls are retried after the requested delay
+	status, retryAfter, calls = http.StatusTooManyRequests, "2", 0
+	done := make(chan error)
+	go func() {
+		it := rpc.AsimovGetLogsRange(context.Background(), FilterParams{}, 0, 10)
+		it.Next()
+		done <- it.Err()
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	require.Equal(t, 1, clock.Waiters())
+	clock.Advance(time.Second)
+	require.Nil(t, <-done)
+	require.Equal(t, 2, calls)
+}
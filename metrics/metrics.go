@@ -0,0 +1,160 @@
+// Package metrics records Prometheus metrics of asimovrpc calls: per-method request and error
+// counts, latency histograms and in-flight gauges, optionally labeled with call tags.
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	asimovrpc "github.com/mistdex/mist-asimov-rpc"
+)
+
+// Namespace - prefix of metric names
+const Namespace = "asimovrpc"
+
+// ClientErrorCode - code label of errors without JSON-RPC code: connection errors, timeouts and calls rejected by the client
+const ClientErrorCode = "client"
+
+// Collector - Prometheus metrics of calls, shared by any number of clients
+type Collector struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+	tags     []string
+}
+
+// New creates collector registered with registerer. Metrics already registered by another
+// collector are reused, so clients can share registerer.
+// Tags are keys of asimovrpc.Tags added as labels, for example asimovrpc.TagTenant, calls without
+// the tag have an empty label. Every distinct tag value is a separate series, so avoid tags like
+// asimovrpc.TagRequestID.
+func New(registerer prometheus.Registerer, tags ...string) (*Collector, error) {
+	c := &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "requests_total",
+			Help:      "Calls by method.",
+		}, labels(tags)),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "errors_total",
+			Help:      "Failed calls by method and JSON-RPC error code.",
+		}, append(labels(tags), "code")),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Call latency by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, labels(tags)),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "requests_in_flight",
+			Help:      "Calls in progress by method.",
+		}, labels(tags)),
+		tags: tags,
+	}
+
+	var err error
+	if c.requests, err = register(registerer, c.requests); err != nil {
+		return nil, err
+	}
+	if c.errors, err = register(registerer, c.errors); err != nil {
+		return nil, err
+	}
+	if c.latency, err = register(registerer, c.latency); err != nil {
+		return nil, err
+	}
+	if c.inFlight, err = register(registerer, c.inFlight); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func labels(tags []string) []string {
+	return append([]string{"method"}, tags...)
+}
+
+// values returns label values of method called with ctx
+func (c *Collector) values(ctx context.Context, method string) []string {
+	values := []string{method}
+	callTags := asimovrpc.TagsFromContext(ctx)
+	for _, tag := range c.tags {
+		values = append(values, callTags[tag])
+	}
+
+	return values
+}
+
+func register[T prometheus.Collector](registerer prometheus.Registerer, collector T) (T, error) {
+	err := registerer.Register(collector)
+	var registered prometheus.AlreadyRegisteredError
+	if errors.As(err, &registered) {
+		if existing, ok := registered.ExistingCollector.(T); ok {
+			return existing, nil
+		}
+	}
+
+	return collector, err
+}
+
+// Interceptor records metrics of calls passing through it
+func (c *Collector) Interceptor(next asimovrpc.CallFunc) asimovrpc.CallFunc {
+	return func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+		values := c.values(ctx, method)
+		inFlight := c.inFlight.WithLabelValues(values...)
+		inFlight.Inc()
+		start := time.Now()
+
+		result, err := next(ctx, method, params)
+
+		inFlight.Dec()
+		c.observe(values, time.Since(start), err)
+
+		return result, err
+	}
+}
+
+// Observe records metrics of a batched request, batches do not pass through interceptors
+func (c *Collector) Observe(ctx context.Context, method string, duration time.Duration, err error) {
+	c.observe(c.values(ctx, method), duration, err)
+}
+
+func (c *Collector) observe(values []string, duration time.Duration, err error) {
+	c.latency.WithLabelValues(values...).Observe(duration.Seconds())
+	c.requests.WithLabelValues(values...).Inc()
+	if err != nil {
+		c.errors.WithLabelValues(append(values, Code(err))...).Inc()
+	}
+}
+
+// Code returns code label of call error: JSON-RPC error code or ClientErrorCode
+func Code(err error) string {
+	var asimovErr asimovrpc.AsimovError
+	if errors.As(err, &asimovErr) {
+		return strconv.Itoa(asimovErr.Code)
+	}
+
+	return ClientErrorCode
+}
+
+// WithMetrics record metrics of client calls and batched requests with registerer, for example
+// prometheus.DefaultRegisterer, labeled with tags (see New). Metrics conflicting with other metrics
+// of registerer are reported by NewClient as an invalid option.
+func WithMetrics(registerer prometheus.Registerer, tags ...string) func(rpc *asimovrpc.AsimovRPC) {
+	c, err := New(registerer, tags...)
+	if err != nil {
+		return asimovrpc.InvalidOption("WithMetrics", err.Error())
+	}
+
+	return func(rpc *asimovrpc.AsimovRPC) {
+		asimovrpc.WithInterceptor(c.Interceptor)(rpc)
+		asimovrpc.WithBatchObserver(c.Observe)(rpc)
+	}
+}
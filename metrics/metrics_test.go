@@ -0,0 +1,157 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	asimovrpc "github.com/mistdex/mist-asimov-rpc"
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func TestInterceptor(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c, err := New(registry)
+	require.Nil(t, err)
+
+	var inFlight float64
+	responses := map[string]error{
+		"flow_blockNumber": nil,
+		"flow_call":        asimovrpc.AsimovError{Code: 3, Message: "execution reverted"},
+		"net_version":      errors.New("connection refused"),
+	}
+	call := c.Interceptor(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+		inFlight = testutil.ToFloat64(c.inFlight.WithLabelValues(method))
+		return json.RawMessage(`"0x1"`), responses[method]
+	})
+
+	for _, method := range []string{"flow_blockNumber", "flow_blockNumber", "flow_call", "net_version"} {
+		_, err := call(context.Background(), method, nil)
+		require.Equal(t, responses[method], err)
+	}
+
+	require.Equal(t, float64(1), inFlight)
+	require.Equal(t, float64(0), testutil.ToFloat64(c.inFlight.WithLabelValues("net_version")))
+	require.Equal(t, float64(2), testutil.ToFloat64(c.requests.WithLabelValues("flow_blockNumber")))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.errors.WithLabelValues("flow_call", "3")))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.errors.WithLabelValues("net_version", ClientErrorCode)))
+	require.Equal(t, 2, testutil.CollectAndCount(c.errors))
+	require.Equal(t, 3, testutil.CollectAndCount(c.latency))
+}
+
+func TestSharedRegisterer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	first, err := New(registry)
+	require.Nil(t, err)
+	second, err := New(registry)
+	require.Nil(t, err)
+	require.Same(t, first.requests, second.requests)
+
+	call := func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+		return nil, nil
+	}
+	first.Interceptor(call)(context.Background(), "net_version", nil)
+	second.Interceptor(call)(context.Background(), "net_version", nil)
+	require.Equal(t, float64(2), testutil.ToFloat64(first.requests.WithLabelValues("net_version")))
+
+	registry = prometheus.NewRegistry()
+	registry.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{Namespace: Namespace, Name: "requests_total", Help: "Other."}))
+	_, err = New(registry)
+	require.NotNil(t, err)
+	_, err = asimovrpc.NewClient("http://127.0.0.1:1", WithMetrics(registry))
+	require.Contains(t, err.Error(), "asimovrpc: invalid option WithMetrics: ")
+
+	// the label set of registered metrics can not change
+	registry = prometheus.NewRegistry()
+	_, err = New(registry)
+	require.Nil(t, err)
+	_, err = New(registry, asimovrpc.TagTenant)
+	require.NotNil(t, err)
+}
+
+func TestTags(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c, err := New(registry, asimovrpc.TagTenant)
+	require.Nil(t, err)
+
+	call := c.Interceptor(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+		return nil, errors.New("connection refused")
+	})
+	call(asimovrpc.WithTenant(context.Background(), "acme"), "net_version", nil)
+	call(asimovrpc.WithTenant(context.Background(), "acme"), "net_version", nil)
+	call(context.Background(), "net_version", nil)
+
+	require.Equal(t, float64(2), testutil.ToFloat64(c.requests.WithLabelValues("net_version", "acme")))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.requests.WithLabelValues("net_version", "")))
+	require.Equal(t, float64(2), testutil.ToFloat64(c.errors.WithLabelValues("net_version", "acme", ClientErrorCode)))
+}
+
+func TestBatch(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_blockNumber", "0x1")
+	node.HandleError("flow_call", 3, "execution reverted")
+
+	registry := prometheus.NewRegistry()
+	client, err := asimovrpc.NewClient(node.URL, WithMetrics(registry, asimovrpc.TagTenant), asimovrpc.WithDeniedMethods("personal_*"))
+	require.Nil(t, err)
+
+	var number string
+	err = client.NewBatch().
+		Add("flow_blockNumber", &number).
+		Add("flow_call", nil).
+		Add("personal_unlockAccount", nil).
+		ExecuteContext(asimovrpc.WithTenant(context.Background(), "acme"))
+	require.Nil(t, err)
+
+	c, err := New(registry, asimovrpc.TagTenant)
+	require.Nil(t, err)
+	require.Equal(t, float64(1), testutil.ToFloat64(c.requests.WithLabelValues("flow_blockNumber", "acme")))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.errors.WithLabelValues("flow_call", "acme", "3")))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.errors.WithLabelValues("personal_unlockAccount", "acme", ClientErrorCode)))
+}
+
+func TestWithMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client, err := asimovrpc.NewClient("http://127.0.0.1:1", WithMetrics(registry))
+	require.Nil(t, err)
+
+	_, err = client.NetVersion()
+	require.NotNil(t, err)
+
+	families, err := registry.Gather()
+	require.Nil(t, err)
+	names := map[string]bool{}
+	for _, family := range families {
+		names[family.GetName()] = true
+	}
+	require.True(t, names["asimovrpc_requests_total"])
+	require.True(t, names["asimovrpc_errors_total"])
+	require.True(t, names["asimovrpc_request_duration_seconds"])
+}
+
+func TestClientPool(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_blockNumber", "0x1")
+
+	registry := prometheus.NewRegistry()
+	pool := asimovrpc.NewClientPool(node.URL, WithMetrics(registry, asimovrpc.TagTenant))
+	defer pool.Close()
+	for _, tenant := range []string{"acme", "globex", "globex"} {
+		client, err := pool.Client(tenant)
+		require.Nil(t, err)
+		_, err = client.AsimovBlockNumber()
+		require.Nil(t, err)
+	}
+
+	c, err := New(registry, asimovrpc.TagTenant)
+	require.Nil(t, err)
+	require.Equal(t, float64(1), testutil.ToFloat64(c.requests.WithLabelValues("flow_blockNumber", "acme")))
+	require.Equal(t, float64(2), testutil.ToFloat64(c.requests.WithLabelValues("flow_blockNumber", "globex")))
+}
@@ -0,0 +1,381 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+)
+
+type namespace struct {
+	rpc  Client
+	name string
+}
+
+// Name returns namespace name
+func (ns namespace) Name() string {
+	return ns.name
+}
+
+// call calls method through the client and decodes result into target
+func (ns namespace) call(method string, target interface{}, params ...interface{}) error {
+	result, err := ns.rpc.Call(method, params...)
+	if err != nil || target == nil {
+		return err
+	}
+
+	return json.Unmarshal(result, target)
+}
+
+// Available returns true if the node reports the namespace in rpc_modules
+func (ns namespace) Available() (bool, error) {
+	modules, err := ns.rpc.Modules()
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := modules[ns.name]
+	return ok, nil
+}
+
+// Modules returns RPC namespaces enabled on the node with their versions.
+func (rpc *AsimovRPC) Modules() (map[string]string, error) {
+	modules := map[string]string{}
+
+	err := rpc.call("rpc_modules", &modules)
+	return modules, err
+}
+
+// Web3API - web3 namespace methods
+type Web3API struct {
+	namespace
+}
+
+// Web3 returns web3 namespace client
+func (rpc *AsimovRPC) Web3() Web3API {
+	return Web3API{namespace{rpc: rpc, name: "web3"}}
+}
+
+// ClientVersion returns the current client version.
+func (api Web3API) ClientVersion() (string, error) {
+	return api.rpc.Web3ClientVersion()
+}
+
+// Sha3 returns Keccak-256 (not the standardized SHA3-256) of the given data.
+func (api Web3API) Sha3(data []byte) (string, error) {
+	return api.rpc.Web3Sha3(data)
+}
+
+// NetAPI - net namespace methods
+type NetAPI struct {
+	namespace
+}
+
+// Net returns net namespace client
+func (rpc *AsimovRPC) Net() NetAPI {
+	return NetAPI{namespace{rpc: rpc, name: "net"}}
+}
+
+// Version returns the current network protocol version.
+func (api NetAPI) Version() (string, error) {
+	return api.rpc.NetVersion()
+}
+
+// Listening returns true if client is actively listening for network connections.
+func (api NetAPI) Listening() (bool, error) {
+	return api.rpc.NetListening()
+}
+
+// PeerCount returns number of peers currently connected to the client.
+func (api NetAPI) PeerCount() (int, error) {
+	return api.rpc.NetPeerCount()
+}
+
+// FlowAPI - flow namespace methods
+type FlowAPI struct {
+	namespace
+}
+
+// Flow returns flow namespace client
+func (rpc *AsimovRPC) Flow() FlowAPI {
+	return FlowAPI{namespace{rpc: rpc, name: "flow"}}
+}
+
+// ProtocolVersion returns the current ethereum protocol version.
+func (api FlowAPI) ProtocolVersion() (string, error) {
+	return api.rpc.AsimovProtocolVersion()
+}
+
+// Syncing returns an object with data about the sync status or false.
+func (api FlowAPI) Syncing() (*Syncing, error) {
+	return api.rpc.AsimovSyncing()
+}
+
+// Coinbase returns the client coinbase address
+func (api FlowAPI) Coinbase() (string, error) {
+	return api.rpc.AsimovCoinbase()
+}
+
+// Mining returns true if client is actively mining new blocks.
+func (api FlowAPI) Mining() (bool, error) {
+	return api.rpc.AsimovMining()
+}
+
+// Hashrate returns the number of hashes per second that the node is mining with.
+func (api FlowAPI) Hashrate() (int, error) {
+	return api.rpc.AsimovHashrate()
+}
+
+// GasPrice returns the current price per gas in wei.
+func (api FlowAPI) GasPrice() (big.Int, error) {
+	return api.rpc.AsimovGasPrice()
+}
+
+// MaxPriorityFeePerGas returns the suggested priority fee per gas in wei.
+func (api FlowAPI) MaxPriorityFeePerGas() (big.Int, error) {
+	return api.rpc.AsimovMaxPriorityFeePerGas()
+}
+
+// FeeHistory returns base fees, gas used ratios and priority fee percentiles of a range of blocks.
+func (api FlowAPI) FeeHistory(blockCount int, newestBlock BlockNumber, rewardPercentiles []float64) (*FeeHistory, error) {
+	return api.rpc.AsimovFeeHistory(blockCount, newestBlock, rewardPercentiles)
+}
+
+// ChainID returns the chain ID used for replay-protected signing.
+func (api FlowAPI) ChainID() (*big.Int, error) {
+	return api.rpc.AsimovChainID()
+}
+
+// Accounts returns a list of addresses owned by client.
+func (api FlowAPI) Accounts() ([]string, error) {
+	return api.rpc.AsimovAccounts()
+}
+
+// BlockNumber returns the number of most recent block.
+func (api FlowAPI) BlockNumber() (int, error) {
+	return api.rpc.AsimovBlockNumber()
+}
+
+// GetBalance returns the balance of the account of given address in wei.
+func (api FlowAPI) GetBalance(address string, block BlockNumber) (big.Int, error) {
+	return api.rpc.AsimovGetBalance(address, block)
+}
+
+// GetStorageAt returns the value from a storage position at a given address.
+func (api FlowAPI) GetStorageAt(data string, position int, block BlockNumber) (string, error) {
+	return api.rpc.AsimovGetStorageAt(data, position, block)
+}
+
+// GetTransactionCount returns the number of transactions sent from an address.
+func (api FlowAPI) GetTransactionCount(address string, block BlockNumber) (int, error) {
+	return api.rpc.AsimovGetTransactionCount(address, block)
+}
+
+// GetBlockTransactionCountByHash returns the number of transactions in a block from a block matching the given block hash.
+func (api FlowAPI) GetBlockTransactionCountByHash(hash string) (int, error) {
+	return api.rpc.AsimovGetBlockTransactionCountByHash(hash)
+}
+
+// GetBlockTransactionCountByNumber returns the number of transactions in a block from a block matching the given block
+func (api FlowAPI) GetBlockTransactionCountByNumber(number BlockNumber) (int, error) {
+	return api.rpc.AsimovGetBlockTransactionCountByNumber(number)
+}
+
+// GetUncleCountByBlockHash returns the number of uncles in a block from a block matching the given block hash.
+func (api FlowAPI) GetUncleCountByBlockHash(hash string) (int, error) {
+	return api.rpc.AsimovGetUncleCountByBlockHash(hash)
+}
+
+// GetUncleCountByBlockNumber returns the number of uncles in a block from a block matching the given block number.
+func (api FlowAPI) GetUncleCountByBlockNumber(number BlockNumber) (int, error) {
+	return api.rpc.AsimovGetUncleCountByBlockNumber(number)
+}
+
+// GetUncleByBlockHashAndIndex returns header of the uncle at index of the block with given hash.
+func (api FlowAPI) GetUncleByBlockHashAndIndex(hash string, index int) (*Block, error) {
+	return api.rpc.AsimovGetUncleByBlockHashAndIndex(hash, index)
+}
+
+// GetUncleByBlockNumberAndIndex returns header of the uncle at index of the block with given number.
+func (api FlowAPI) GetUncleByBlockNumberAndIndex(number BlockNumber, index int) (*Block, error) {
+	return api.rpc.AsimovGetUncleByBlockNumberAndIndex(number, index)
+}
+
+// GetCode returns code at a given address.
+func (api FlowAPI) GetCode(address string, block BlockNumber) (string, error) {
+	return api.rpc.AsimovGetCode(address, block)
+}
+
+// GetProof returns the account and storage values of address, including their Merkle proofs.
+func (api FlowAPI) GetProof(address string, storageKeys []string, block BlockNumber) (*AccountProof, error) {
+	return api.rpc.AsimovGetProof(address, storageKeys, block)
+}
+
+// Sign signs data with a given address.
+func (api FlowAPI) Sign(address, data string) (string, error) {
+	return api.rpc.AsimovSign(address, data)
+}
+
+// SendTransaction creates new message call transaction or a contract creation, if the data field contains code.
+func (api FlowAPI) SendTransaction(transaction T) (string, error) {
+	return api.rpc.AsimovSendTransaction(transaction)
+}
+
+// SendRawTransaction creates new message call transaction or a contract creation for signed transactions.
+func (api FlowAPI) SendRawTransaction(data string) (string, error) {
+	return api.rpc.AsimovSendRawTransaction(data)
+}
+
+// SignTransaction signs a transaction with a node-managed account without sending it.
+func (api FlowAPI) SignTransaction(transaction T) (*SignedTransaction, error) {
+	return api.rpc.AsimovSignTransaction(transaction)
+}
+
+// Call executes a new message call immediately without creating a transaction on the block chain.
+func (api FlowAPI) Call(transaction T, block BlockNumber, overrides ...StateOverride) (string, error) {
+	return api.rpc.AsimovCall(transaction, block, overrides...)
+}
+
+// EstimateGas makes a call or transaction, which won't be added to the blockchain and returns the used gas, which can be used for estimating the used gas.
+func (api FlowAPI) EstimateGas(transaction T) (int, error) {
+	return api.rpc.AsimovEstimateGas(transaction)
+}
+
+// GetBlockByHash returns information about a block by hash.
+func (api FlowAPI) GetBlockByHash(hash string, withTransactions bool) (*Block, error) {
+	return api.rpc.AsimovGetBlockByHash(hash, withTransactions)
+}
+
+// GetBlockByNumber returns information about a block by block number.
+func (api FlowAPI) GetBlockByNumber(number BlockNumber, withTransactions bool) (*Block, error) {
+	return api.rpc.AsimovGetBlockByNumber(number, withTransactions)
+}
+
+// GetTransactionByHash returns the information about a transaction requested by transaction hash.
+func (api FlowAPI) GetTransactionByHash(hash string) (*Transaction, error) {
+	return api.rpc.AsimovGetTransactionByHash(hash)
+}
+
+// GetTransactionByBlockHashAndIndex returns information about a transaction by block hash and transaction index position.
+func (api FlowAPI) GetTransactionByBlockHashAndIndex(blockHash string, transactionIndex int) (*Transaction, error) {
+	return api.rpc.AsimovGetTransactionByBlockHashAndIndex(blockHash, transactionIndex)
+}
+
+// GetTransactionByBlockNumberAndIndex returns information about a transaction by block number and transaction index position.
+func (api FlowAPI) GetTransactionByBlockNumberAndIndex(blockNumber BlockNumber, transactionIndex int) (*Transaction, error) {
+	return api.rpc.AsimovGetTransactionByBlockNumberAndIndex(blockNumber, transactionIndex)
+}
+
+// PendingTransactions returns full transaction objects from the node's pending pool.
+func (api FlowAPI) PendingTransactions(filters ...TransactionFilter) ([]Transaction, error) {
+	return api.rpc.AsimovPendingTransactions(filters...)
+}
+
+// GetBlockReceipts returns receipts of all transactions of a block.
+func (api FlowAPI) GetBlockReceipts(block BlockReference) ([]TransactionReceipt, error) {
+	return api.rpc.AsimovGetBlockReceipts(block)
+}
+
+// GetTransactionReceipt returns the receipt of a transaction by transaction hash.
+func (api FlowAPI) GetTransactionReceipt(hash string) (*TransactionReceipt, error) {
+	return api.rpc.AsimovGetTransactionReceipt(hash)
+}
+
+// GetCompilers returns a list of available compilers in the client.
+func (api FlowAPI) GetCompilers() ([]string, error) {
+	return api.rpc.AsimovGetCompilers()
+}
+
+// NewFilter creates a new filter object.
+func (api FlowAPI) NewFilter(params FilterParams) (string, error) {
+	return api.rpc.AsimovNewFilter(params)
+}
+
+// NewBlockFilter creates a filter in the node, to notify when a new block arrives.
+func (api FlowAPI) NewBlockFilter() (string, error) {
+	return api.rpc.AsimovNewBlockFilter()
+}
+
+// NewPendingTransactionFilter creates a filter in the node, to notify when new pending transactions arrive.
+func (api FlowAPI) NewPendingTransactionFilter() (string, error) {
+	return api.rpc.AsimovNewPendingTransactionFilter()
+}
+
+// UninstallFilter uninstalls a filter with given id.
+func (api FlowAPI) UninstallFilter(filterID string) (bool, error) {
+	return api.rpc.AsimovUninstallFilter(filterID)
+}
+
+// GetFilterChanges polling method for a filter, which returns an array of logs which occurred since last poll.
+func (api FlowAPI) GetFilterChanges(filterID string) ([]Log, error) {
+	return api.rpc.AsimovGetFilterChanges(filterID)
+}
+
+// GetFilterLogs returns an array of all logs matching filter with given id.
+func (api FlowAPI) GetFilterLogs(filterID string) ([]Log, error) {
+	return api.rpc.AsimovGetFilterLogs(filterID)
+}
+
+// GetLogs returns an array of all logs matching a given filter object.
+func (api FlowAPI) GetLogs(params FilterParams) ([]Log, error) {
+	return api.rpc.AsimovGetLogs(params)
+}
+
+// PersonalAPI - personal namespace methods
+type PersonalAPI struct {
+	namespace
+}
+
+// Personal returns personal namespace client
+func (rpc *AsimovRPC) Personal() PersonalAPI {
+	return PersonalAPI{namespace{rpc: rpc, name: "personal"}}
+}
+
+// NewAccount creates account on the node and returns its address.
+func (api PersonalAPI) NewAccount(password string) (string, error) {
+	return api.rpc.PersonalNewAccount(password)
+}
+
+// UnlockAccount decrypts key of address for duration, 0 - until the node exits.
+func (api PersonalAPI) UnlockAccount(address, password string, duration time.Duration) (bool, error) {
+	return api.rpc.PersonalUnlockAccount(address, password, duration)
+}
+
+// LockAccount removes decrypted key of address from the node memory.
+func (api PersonalAPI) LockAccount(address string) (bool, error) {
+	return api.rpc.PersonalLockAccount(address)
+}
+
+// Sign signs data with key of address decrypted with password.
+func (api PersonalAPI) Sign(data, address, password string) (string, error) {
+	return api.rpc.PersonalSign(data, address, password)
+}
+
+// SendTransaction sends transaction signed with key of transaction.From decrypted with password.
+func (api PersonalAPI) SendTransaction(transaction T, password string) (string, error) {
+	return api.rpc.PersonalSendTransaction(transaction, password)
+}
+
+// AdminAPI - admin namespace methods
+type AdminAPI struct {
+	namespace
+}
+
+// Admin returns admin namespace client
+func (rpc *AsimovRPC) Admin() AdminAPI {
+	return AdminAPI{namespace{rpc: rpc, name: "admin"}}
+}
+
+// NodeInfo returns information about the node: identity, listening ports and protocols.
+func (api AdminAPI) NodeInfo() (*NodeInfo, error) {
+	return api.rpc.AsimovNodeInfo()
+}
+
+// Peers returns peers connected to the node.
+func (api AdminAPI) Peers() ([]PeerInfo, error) {
+	return api.rpc.AsimovPeers()
+}
+
+// AddPeer asks the node to connect to the peer at enode URL.
+func (api AdminAPI) AddPeer(enode string) (bool, error) {
+	return api.rpc.AsimovAddPeer(enode)
+}
@@ -0,0 +1,54 @@
+package asimovrpc
+
+func (s *AsimovRPCTestSuite) TestNamespaces() {
+	s.registerResponse(`{"flow": "1.0", "net": "1.0", "rpc": "1.0"}`, func(body []byte) {
+		s.methodEqual(body, "rpc_modules")
+		s.paramsEqual(body, "null")
+	})
+
+	modules, err := s.rpc.Modules()
+	s.Require().Nil(err)
+	s.Require().Equal(map[string]string{"flow": "1.0", "net": "1.0", "rpc": "1.0"}, modules)
+
+	available, err := s.rpc.Flow().Available()
+	s.Require().Nil(err)
+	s.Require().True(available)
+
+	available, err = s.rpc.Web3().Available()
+	s.Require().Nil(err)
+	s.Require().False(available)
+	s.Require().Equal("web3", s.rpc.Web3().Name())
+
+	s.registerResponse(`"0x10"`, func(body []byte) {
+		s.methodEqual(body, "flow_getBalance")
+		s.paramsEqual(body, `["0x111", "latest"]`)
+	})
+	balance, err := s.rpc.Flow().GetBalance("0x111", Latest())
+	s.Require().Nil(err)
+	s.Require().Equal(newBigInt("16"), balance)
+
+	s.registerResponse(`"0x22"`, func(body []byte) {
+		s.methodEqual(body, "net_peerCount")
+	})
+	peers, err := s.rpc.Net().PeerCount()
+	s.Require().Nil(err)
+	s.Require().Equal(34, peers)
+
+	s.registerResponse(`"test client"`, func(body []byte) {
+		s.methodEqual(body, "web3_clientVersion")
+	})
+	version, err := s.rpc.Web3().ClientVersion()
+	s.Require().Nil(err)
+	s.Require().Equal("test client", version)
+
+	s.registerResponse(`[{"txHash": "0x1", "result": {"type": "CALL", "from": "0x2", "to": "0x3"}}]`, func(body []byte) {
+		s.methodEqual(body, "debug_traceBlockByNumber")
+		s.paramsEqual(body, `["0x5", {"tracer": "callTracer"}]`)
+	})
+	traces, err := s.rpc.Debug().TraceBlockByNumber(Number(5), &TraceConfig{Tracer: CallTracer})
+	s.Require().Nil(err)
+	frame, err := traces[0].CallFrame()
+	s.Require().Nil(err)
+	s.Require().Equal("0x3", frame.To)
+	s.Require().Equal("debug", s.rpc.Debug().Name())
+}
@@ -0,0 +1,144 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Upgrade - protocol upgrade activated at block height
+type Upgrade struct {
+	Name   string
+	Height int
+}
+
+// Network - well-known Asimov network description
+type Network struct {
+	Name        string
+	ChainID     int
+	GenesisHash string
+	Upgrades    []Upgrade
+}
+
+// NetworkMismatchError - connected node belongs to a different network
+type NetworkMismatchError struct {
+	Network string
+	Field   string
+	Want    string
+	Got     string
+}
+
+func (err NetworkMismatchError) Error() string {
+	return fmt.Sprintf("Node is not on network %s: %s is %s, want %s", err.Network, err.Field, err.Got, err.Want)
+}
+
+var networks = struct {
+	sync.RWMutex
+	byName map[string]Network
+}{byName: map[string]Network{}}
+
+// RegisterNetwork adds network to the registry used by LookupNetwork, replacing network with the same name
+func RegisterNetwork(network Network) error {
+	if network.Name == "" {
+		return errors.New("network name is empty")
+	}
+	if network.GenesisHash == "" {
+		return fmt.Errorf("network %s: genesis hash is empty", network.Name)
+	}
+	if _, err := NewChainConfig(network.Upgrades...); err != nil {
+		return fmt.Errorf("network %s: %v", network.Name, err)
+	}
+
+	networks.Lock()
+	defer networks.Unlock()
+	networks.byName[strings.ToLower(network.Name)] = network
+
+	return nil
+}
+
+// LookupNetwork returns registered network by name. No networks are built in, register them from
+// the network's published genesis data with RegisterNetwork.
+func LookupNetwork(name string) (Network, bool) {
+	networks.RLock()
+	defer networks.RUnlock()
+	network, ok := networks.byName[strings.ToLower(name)]
+
+	return network, ok
+}
+
+// WithNetwork set network the node is expected to belong to, checked by ValidateConnection
+func WithNetwork(network Network) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if network.GenesisHash == "" {
+			rpc.invalidOption("WithNetwork", "genesis hash is empty")
+			return
+		}
+		config, err := NewChainConfig(network.Upgrades...)
+		if err != nil {
+			rpc.invalidOption("WithNetwork", err.Error())
+			return
+		}
+		rpc.network = &network
+		rpc.chainConfig = config
+	}
+}
+
+// Network returns network configured with WithNetwork
+func (rpc *AsimovRPC) Network() (Network, bool) {
+	if rpc.network == nil {
+		return Network{}, false
+	}
+
+	return *rpc.network, true
+}
+
+// ValidateConnection checks that genesis hash (and chain id when known) of connected node match configured network.
+// The chain id is read with flow_chainId, or compared with the network id of net_version on nodes without it.
+func (rpc *AsimovRPC) ValidateConnection(ctx context.Context) error {
+	if rpc.network == nil {
+		return errors.New("no network configured")
+	}
+	network := *rpc.network
+
+	rpc = rpc.WithContext(ctx)
+	genesis, err := rpc.AsimovGetBlockByNumber(0, false)
+	if err != nil {
+		return err
+	}
+	if genesis == nil {
+		return errors.New("genesis block not found")
+	}
+	if !strings.EqualFold(genesis.Hash, network.GenesisHash) {
+		return NetworkMismatchError{Network: network.Name, Field: "genesis hash", Want: network.GenesisHash, Got: genesis.Hash}
+	}
+
+	if network.ChainID == 0 {
+		return nil
+	}
+
+	want := strconv.Itoa(network.ChainID)
+	id, err := rpc.AsimovChainID()
+	switch {
+	case err == nil:
+		if got := id.String(); got != want {
+			return NetworkMismatchError{Network: network.Name, Field: "chain id", Want: want, Got: got}
+		}
+		return nil
+	case !errors.Is(err, ErrMethodNotFound):
+		return err
+	}
+
+	// nodes without flow_chainId use the chain id as network id
+	version, err := rpc.NetVersion()
+	if err != nil {
+		return err
+	}
+	if version != want {
+		return NetworkMismatchError{Network: network.Name, Field: "network id", Want: want, Got: version}
+	}
+
+	return nil
+}
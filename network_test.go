@@ -0,0 +1,57 @@
+package asimovrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterNetwork(t *testing.T) {
+	require.EqualError(t, RegisterNetwork(Network{}), "network name is empty")
+	require.EqualError(t, RegisterNetwork(Network{Name: "devnet"}), "network devnet: genesis hash is empty")
+
+	require.Nil(t, RegisterNetwork(Network{Name: "DevNet", ChainID: 7, GenesisHash: "0xabc"}))
+	network, ok := LookupNetwork("devnet")
+	require.True(t, ok)
+	require.Equal(t, 7, network.ChainID)
+
+	_, ok = LookupNetwork("unknown")
+	require.False(t, ok)
+}
+
+func TestWithNetworkInvalid(t *testing.T) {
+	_, err := NewClient("http://127.0.0.1:8545", WithNetwork(Network{Name: "devnet"}))
+	require.EqualError(t, err, "asimovrpc: invalid option WithNetwork: genesis hash is empty")
+}
+
+func (s *AsimovRPCTestSuite) TestValidateConnection() {
+	s.registerResponses(map[string]string{
+		"flow_getBlockByNumber": `{"number": "0x0", "hash": "0xABC", "transactions": []}`,
+		"flow_chainId":          `"0x7"`,
+		"net_version":           `"1"`,
+	}, func(body []byte) {})
+
+	err := s.rpc.ValidateConnection(context.Background())
+	s.Require().EqualError(err, "no network configured")
+
+	rpc := NewAsimovRPC(s.rpc.url, WithNetwork(Network{Name: "devnet", ChainID: 7, GenesisHash: "0xabc"}))
+	s.Require().Nil(rpc.ValidateConnection(context.Background()))
+
+	rpc = NewAsimovRPC(s.rpc.url, WithNetwork(Network{Name: "devnet", ChainID: 8, GenesisHash: "0xabc"}))
+	s.Require().Equal(NetworkMismatchError{Network: "devnet", Field: "chain id", Want: "8", Got: "7"}, rpc.ValidateConnection(context.Background()))
+
+	// nodes without flow_chainId
+	s.registerResponses(map[string]string{
+		"flow_getBlockByNumber": `{"number": "0x0", "hash": "0xABC", "transactions": []}`,
+		"net_version":           `"7"`,
+	}, func(body []byte) {})
+	rpc = NewAsimovRPC(s.rpc.url, WithNetwork(Network{Name: "devnet", ChainID: 7, GenesisHash: "0xabc"}))
+	s.Require().Nil(rpc.ValidateConnection(context.Background()))
+	rpc = NewAsimovRPC(s.rpc.url, WithNetwork(Network{Name: "devnet", ChainID: 8, GenesisHash: "0xabc"}))
+	s.Require().Equal(NetworkMismatchError{Network: "devnet", Field: "network id", Want: "8", Got: "7"}, rpc.ValidateConnection(context.Background()))
+
+	rpc = NewAsimovRPC(s.rpc.url, WithNetwork(Network{Name: "testnet", GenesisHash: "0xdef"}))
+	err = rpc.ValidateConnection(context.Background())
+	s.Require().EqualError(err, "Node is not on network testnet: genesis hash is 0xABC, want 0xdef")
+}
@@ -0,0 +1,168 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// IsNonceError returns true if node rejected transaction because of its nonce ("nonce too low", "nonce too high")
+func IsNonceError(err error) bool {
+	return errors.Is(err, ErrNonceTooLow) || errors.Is(err, ErrNonceTooHigh)
+}
+
+type addressNonce struct {
+	mu     sync.Mutex
+	next   int
+	seeded bool
+}
+
+// NonceManager hands out increasing nonces per address to concurrent senders. Nonces are
+// seeded from flow_getTransactionCount with the "pending" tag and tracked locally afterwards.
+type NonceManager struct {
+	rpc *AsimovRPC
+
+	mu        sync.Mutex
+	addresses map[string]*addressNonce
+}
+
+// NewNonceManager creates nonce manager seeding nonces with rpc
+func (rpc *AsimovRPC) NewNonceManager() *NonceManager {
+	return &NonceManager{rpc: rpc, addresses: map[string]*addressNonce{}}
+}
+
+func (m *NonceManager) address(address string) *addressNonce {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := strings.ToLower(address)
+	a, ok := m.addresses[key]
+	if !ok {
+		a = new(addressNonce)
+		m.addresses[key] = a
+	}
+
+	return a
+}
+
+// seed fetches pending transaction count, a.mu must be held
+func (m *NonceManager) seed(address string, a *addressNonce) error {
+	count, err := m.rpc.AsimovGetTransactionCount(address, Pending())
+	if err != nil {
+		return err
+	}
+
+	a.next = count
+	a.seeded = true
+	return nil
+}
+
+// Next returns the next nonce of address
+func (m *NonceManager) Next(address string) (int, error) {
+	a := m.address(address)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.seeded {
+		if err := m.seed(address, a); err != nil {
+			return 0, err
+		}
+	}
+
+	nonce := a.next
+	a.next++
+	return nonce, nil
+}
+
+// Release returns nonce of a transaction which was not sent. Only the last handed out nonce
+// can be reused, release of an earlier one leaves a gap which is closed by Resync.
+func (m *NonceManager) Release(address string, nonce int) {
+	a := m.address(address)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.seeded && a.next == nonce+1 {
+		a.next = nonce
+	}
+}
+
+// Resync seeds nonce of address from the node again
+func (m *NonceManager) Resync(address string) error {
+	a := m.address(address)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return m.seed(address, a)
+}
+
+// Reset forgets nonce of address, it is seeded again on next use
+func (m *NonceManager) Reset(address string) {
+	a := m.address(address)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.seeded = false
+}
+
+// notBroadcast returns true if err shows that the transaction did not reach the pool of the node:
+// the node answered with an error, the node could not be connected to, or the client rejected the call.
+// Timeouts, broken connections and server errors are ambiguous, the transaction may have been accepted.
+func notBroadcast(err error) bool {
+	var asimovErr AsimovError
+	var httpErr HTTPError
+	switch {
+	case errors.As(err, &asimovErr), connectionError(err):
+		return true
+	case errors.As(err, &httpErr) && httpErr.StatusCode < http.StatusInternalServerError:
+		return true
+	case errors.As(err, &ReadOnlyError{}), errors.As(err, &MethodNotAllowedError{}), errors.As(err, &ScreeningDeniedError{}),
+		errors.As(err, &BudgetExceededError{}), errors.As(err, &ParamEncodingError{}), errors.As(err, &UnknownTxTypeError{}),
+		errors.Is(err, ErrChainIDUnknown), errors.Is(err, ErrNoMetadataStore):
+		return true
+	}
+
+	return false
+}
+
+// Send calls send with the next nonce of address. Nonce errors resync the address and send
+// is retried once with a fresh nonce. Errors showing that the transaction was not broadcast, such as
+// errors of the node, release the nonce. Other errors, such as timeouts, keep it used, because the
+// transaction may be in the pool; call Resync once the transaction is known to be lost.
+//
+//	hash, err := nonces.Send(signer.Address(), func(nonce int) (string, error) {
+//		transaction.Nonce = nonce
+//		return client.SendTransactionLocal(transaction, signer)
+//	})
+func (m *NonceManager) Send(address string, send func(nonce int) (string, error)) (string, error) {
+	return m.SendContext(m.rpc.context(), address, send)
+}
+
+// SendContext is Send moving the lifecycle attached to ctx with WithTxLifecycle to broadcast once send succeeds
+func (m *NonceManager) SendContext(ctx context.Context, address string, send func(nonce int) (string, error)) (string, error) {
+	for attempt := 0; ; attempt++ {
+		nonce, err := m.Next(address)
+		if err != nil {
+			return "", err
+		}
+
+		hash, err := send(nonce)
+		switch {
+		case err == nil:
+			return hash, advanceLifecycle(ctx, m.rpc.clock, hash, TxBroadcast)
+		case IsNonceError(err) && attempt == 0:
+			if err := m.Resync(address); err != nil {
+				return "", err
+			}
+		case IsNonceError(err):
+			m.Reset(address)
+			return "", err
+		case notBroadcast(err):
+			m.Release(address, nonce)
+			return "", err
+		default:
+			return "", err
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (s *AsimovRPCTestSuite) TestNonceManagerConcurrent() {
+	calls := 0
+	s.registerResponse(`"0x5"`, func([]byte) { calls++ })
+	nonces := s.rpc.NewNonceManager()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var handed []int
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonce, err := nonces.Next("0x6247cf0412c6462da2a51d05139e2a3c6c630f0a")
+			s.Require().Nil(err)
+			mu.Lock()
+			handed = append(handed, nonce)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	sort.Ints(handed)
+	for i, nonce := range handed {
+		s.Require().Equal(5+i, nonce)
+	}
+	s.Require().Equal(1, calls)
+
+	nonces.Release("0x6247CF0412C6462DA2A51D05139E2A3C6C630F0A", 54)
+	next, err := nonces.Next("0x6247cf0412c6462da2a51d05139e2a3c6c630f0a")
+	s.Require().Nil(err)
+	s.Require().Equal(54, next)
+	nonces.Release("0x6247cf0412c6462da2a51d05139e2a3c6c630f0a", 10)
+	next, err = nonces.Next("0x6247cf0412c6462da2a51d05139e2a3c6c630f0a")
+	s.Require().Nil(err)
+	s.Require().Equal(55, next)
+}
+
+func (s *AsimovRPCTestSuite) TestNonceManagerSend() {
+	count := 3
+	s.registerResponseFunc(func() string { return fmt.Sprintf(`"%s"`, IntToHex(count)) })
+	nonces := s.rpc.NewNonceManager()
+	address := "0x6247cf0412c6462da2a51d05139e2a3c6c630f0a"
+
+	nonce, err := nonces.Next(address)
+	s.Require().Nil(err)
+	s.Require().Equal(3, nonce)
+
+	// another sender used nonces 3 and 4, the stale nonce is rejected and resynced
+	count = 5
+	var sent []int
+	hash, err := nonces.Send(address, func(nonce int) (string, error) {
+		sent = append(sent, nonce)
+		if nonce < count {
+			return "", AsimovError{Code: -32000, Message: "nonce too low"}
+		}
+		return "0xab", nil
+	})
+	s.Require().Nil(err)
+	s.Require().Equal("0xab", hash)
+	s.Require().Equal([]int{4, 5}, sent)
+
+	// the node rejected the transaction, its nonce is reused
+	_, err = nonces.Send(address, func(nonce int) (string, error) {
+		return "", AsimovError{Code: -32000, Message: "insufficient funds"}
+	})
+	s.Require().True(errors.Is(err, ErrInsufficientFunds))
+	nonce, err = nonces.Next(address)
+	s.Require().Nil(err)
+	s.Require().Equal(6, nonce)
+
+	// the transaction may be in the pool after a timeout, its nonce stays used
+	_, err = nonces.Send(address, func(nonce int) (string, error) {
+		return "", context.DeadlineExceeded
+	})
+	s.Require().Equal(context.DeadlineExceeded, err)
+	nonce, err = nonces.Next(address)
+	s.Require().Nil(err)
+	s.Require().Equal(8, nonce)
+
+	_, err = nonces.Send(address, func(nonce int) (string, error) {
+		return "", AsimovError{Code: -32000, Message: "nonce too high"}
+	})
+	s.Require().True(IsNonceError(err))
+	nonce, err = nonces.Next(address)
+	s.Require().Nil(err)
+	s.Require().Equal(5, nonce)
+}
+
+func (s *AsimovRPCTestSuite) TestNonceManagerReset() {
+	count := 3
+	s.registerResponseFunc(func() string { return fmt.Sprintf(`"%s"`, IntToHex(count)) })
+	nonces := s.rpc.NewNonceManager()
+	address := "0x6247cf0412c6462da2a51d05139e2a3c6c630f0a"
+
+	nonce, err := nonces.Next(address)
+	s.Require().Nil(err)
+	s.Require().Equal(3, nonce)
+
+	// senders holding the address state see the reset instead of handing out nonces of a forgotten state
+	a := nonces.address(address)
+	nonces.Reset(address)
+	s.Require().True(a == nonces.address(address))
+	s.Require().False(a.seeded)
+
+	count = 5
+	nonce, err = nonces.Next(address)
+	s.Require().Nil(err)
+	s.Require().Equal(5, nonce)
+}
+
+func TestNotBroadcast(t *testing.T) {
+	require.True(t, notBroadcast(AsimovError{Code: -32000, Message: "insufficient funds"}))
+	require.True(t, notBroadcast(ReadOnlyError{Method: "flow_sendRawTransaction"}))
+	require.True(t, notBroadcast(&net.OpError{Op: "dial", Err: errors.New("connection refused")}))
+	require.True(t, notBroadcast(HTTPError{StatusCode: http.StatusUnauthorized}))
+	require.False(t, notBroadcast(HTTPError{StatusCode: http.StatusBadGateway}))
+	require.False(t, notBroadcast(context.DeadlineExceeded))
+	require.False(t, notBroadcast(errors.New("connection reset by peer")))
+}
+
+func TestIsNonceError(t *testing.T) {
+	require.True(t, IsNonceError(AsimovError{Code: -32000, Message: "Nonce too high"}))
+	require.False(t, IsNonceError(errors.New("insufficient funds")))
+	require.False(t, IsNonceError(nil))
+}
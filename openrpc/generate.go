@@ -0,0 +1,284 @@
+package openrpc
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultImport - import path of the asimovrpc package used by generated code
+const DefaultImport = "github.com/mistdex/mist-asimov-rpc"
+
+// Options - code generation settings
+type Options struct {
+	Package string // package of generated file, "" - nodeapi
+	Import  string // import path of asimovrpc, "" - DefaultImport
+}
+
+type generator struct {
+	document *Document
+	types    map[string]string
+	order    []string
+}
+
+// Generate emits Go source with a namespace API per method prefix (flow_getBalance -> FlowAPI.GetBalance)
+// built on asimovrpc.Namespace, and structs for object schemas:
+//
+//	flow := nodeapi.NewFlowAPI(client)
+//	balance, err := flow.GetBalance(address, "latest")
+//
+// Methods without a namespace prefix (such as rpc.discover) are skipped.
+func Generate(document *Document, options Options) ([]byte, error) {
+	if options.Package == "" {
+		options.Package = "nodeapi"
+	}
+	if options.Import == "" {
+		options.Import = DefaultImport
+	}
+
+	g := &generator{document: document, types: map[string]string{}}
+
+	namespaces := map[string][]Method{}
+	for _, method := range document.Methods {
+		i := strings.Index(method.Name, "_")
+		if i <= 0 || i == len(method.Name)-1 {
+			continue
+		}
+		namespaces[method.Name[:i]] = append(namespaces[method.Name[:i]], method)
+	}
+	names := make([]string, 0, len(namespaces))
+	for name := range namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var apis bytes.Buffer
+	for _, name := range names {
+		api := exported(name) + "API"
+		fmt.Fprintf(&apis, "// %s - %s namespace methods\ntype %s struct {\n\tasimovrpc.Namespace\n}\n\n", api, name, api)
+		fmt.Fprintf(&apis, "// New%s returns %s namespace client\nfunc New%s(rpc asimovrpc.Client) %s {\n\treturn %s{asimovrpc.NewNamespace(rpc, %q)}\n}\n\n", api, name, api, api, api, name)
+
+		for _, method := range namespaces[name] {
+			if err := g.method(&apis, api, name, method); err != nil {
+				return nil, fmt.Errorf("openrpc: %s: %v", method.Name, err)
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by asimovrpc-gen from %s %s. DO NOT EDIT.\n\n", document.Info.Title, document.Info.Version)
+	fmt.Fprintf(&out, "package %s\n\n", options.Package)
+
+	body := apis.String()
+	for _, name := range g.order {
+		body += g.types[name]
+	}
+	out.WriteString("import (\n")
+	if strings.Contains(body, "json.") {
+		out.WriteString("\t\"encoding/json\"\n\n")
+	}
+	fmt.Fprintf(&out, "\tasimovrpc %q\n)\n\n", options.Import)
+	out.WriteString(body)
+
+	return format.Source(out.Bytes())
+}
+
+func (g *generator) method(out *bytes.Buffer, api, namespace string, method Method) error {
+	name := exported(strings.TrimPrefix(method.Name, namespace+"_"))
+
+	var params, args []string
+	used := map[string]bool{}
+	for i, param := range method.Params {
+		t, err := g.goType(param.Schema, name+exported(param.Name))
+		if err != nil {
+			return err
+		}
+		arg := unexported(param.Name)
+		if arg == "" || used[arg] {
+			arg = fmt.Sprintf("param%d", i)
+		}
+		used[arg] = true
+		params = append(params, arg+" "+t)
+		args = append(args, arg)
+	}
+
+	comment := method.Summary
+	if comment == "" {
+		comment = "calls " + method.Name
+	}
+	fmt.Fprintf(out, "// %s %s\n", name, strings.TrimSpace(strings.ReplaceAll(comment, "\n", " ")))
+
+	short := fmt.Sprintf("%q", strings.TrimPrefix(method.Name, namespace+"_"))
+	if method.Result == nil {
+		call := strings.Join(append([]string{short, "nil"}, args...), ", ")
+		fmt.Fprintf(out, "func (api %s) %s(%s) error {\n\treturn api.Call(%s)\n}\n\n", api, name, strings.Join(params, ", "), call)
+		return nil
+	}
+
+	result, err := g.goType(method.Result.Schema, name+"Result")
+	if err != nil {
+		return err
+	}
+	call := strings.Join(append([]string{short, "&result"}, args...), ", ")
+	fmt.Fprintf(out, "func (api %s) %s(%s) (%s, error) {\n\tvar result %s\n\terr := api.Call(%s)\n\treturn result, err\n}\n\n",
+		api, name, strings.Join(params, ", "), result, result, call)
+
+	return nil
+}
+
+// goType returns Go type of schema, declaring structs under hint
+func (g *generator) goType(schema Schema, hint string) (string, error) {
+	if schema.Ref != "" {
+		resolved, err := g.document.resolve(schema)
+		if err != nil {
+			return "", err
+		}
+		name := exported(schema.Ref[strings.LastIndex(schema.Ref, "/")+1:])
+		if _, ok := g.types[name]; ok {
+			return name, nil
+		}
+		g.types[name] = "" // reserve against recursive references
+		g.order = append(g.order, name)
+
+		underlying, err := g.declaration(resolved, name)
+		if err != nil {
+			return "", err
+		}
+		g.types[name] = fmt.Sprintf("// %s %s\ntype %s %s\n\n", name, describe(resolved, "schema"), name, underlying)
+		return name, nil
+	}
+
+	if len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 {
+		return "json.RawMessage", nil
+	}
+
+	switch {
+	case schema.Type.Is("object"):
+		if len(schema.Properties) == 0 {
+			return "map[string]interface{}", nil
+		}
+		if _, ok := g.types[hint]; ok {
+			return hint, nil
+		}
+		g.types[hint] = ""
+		g.order = append(g.order, hint)
+		underlying, err := g.declaration(schema, hint)
+		if err != nil {
+			return "", err
+		}
+		g.types[hint] = fmt.Sprintf("// %s %s\ntype %s %s\n\n", hint, describe(schema, "object"), hint, underlying)
+		return hint, nil
+	case schema.Type.Is("array"):
+		if schema.Items == nil {
+			return "[]json.RawMessage", nil
+		}
+		item, err := g.goType(*schema.Items, hint+"Item")
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case schema.Type.Is("string"):
+		return "string", nil
+	case schema.Type.Is("integer"):
+		return "int64", nil
+	case schema.Type.Is("number"):
+		return "float64", nil
+	case schema.Type.Is("boolean"):
+		return "bool", nil
+	}
+
+	return "json.RawMessage", nil
+}
+
+// declaration returns struct definition of object schema or underlying type of other schemas
+func (g *generator) declaration(schema Schema, name string) (string, error) {
+	if !schema.Type.Is("object") || len(schema.Properties) == 0 || schema.Ref != "" {
+		if schema.Type.Is("object") && len(schema.Properties) == 0 {
+			return "map[string]interface{}", nil
+		}
+		return g.goType(schema, name+"Value")
+	}
+
+	properties := make([]string, 0, len(schema.Properties))
+	for property := range schema.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	required := map[string]bool{}
+	for _, property := range schema.Required {
+		required[property] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	for _, property := range properties {
+		field := exported(property)
+		t, err := g.goType(schema.Properties[property], name+field)
+		if err != nil {
+			return "", err
+		}
+		tag := property
+		if !required[property] {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&b, "\t%s %s `json:%q`\n", field, t, tag)
+	}
+	b.WriteString("}")
+
+	return b.String(), nil
+}
+
+func describe(schema Schema, fallback string) string {
+	for _, text := range []string{schema.Description, schema.Title} {
+		if text = strings.TrimSpace(strings.ReplaceAll(text, "\n", " ")); text != "" {
+			return "- " + text
+		}
+	}
+
+	return "- " + fallback
+}
+
+// exported converts name such as "block_number" or "getBalance" to "BlockNumber" / "GetBalance"
+func exported(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+
+	result := b.String()
+	if result == "" || unicode.IsDigit(rune(result[0])) {
+		result = "X" + result
+	}
+
+	return result
+}
+
+func unexported(name string) string {
+	name = exported(name)
+	if name == "X" {
+		return ""
+	}
+
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	result := string(runes)
+	if token.IsKeyword(result) || result == "api" || result == "result" || result == "err" {
+		result += "Param"
+	}
+
+	return result
+}
@@ -0,0 +1,150 @@
+// Package openrpc parses OpenRPC documents, as returned by the rpc.discover
+// method of a node, and generates typed asimovrpc namespace wrappers from them.
+package openrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Document - OpenRPC document
+type Document struct {
+	OpenRPC    string     `json:"openrpc"`
+	Info       Info       `json:"info"`
+	Methods    []Method   `json:"methods"`
+	Components Components `json:"components"`
+}
+
+// Info - document metadata
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Method - RPC method description
+type Method struct {
+	Name        string              `json:"name"`
+	Summary     string              `json:"summary,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Params      []ContentDescriptor `json:"params"`
+	Result      *ContentDescriptor  `json:"result,omitempty"`
+}
+
+// ContentDescriptor - named and typed parameter or result
+type ContentDescriptor struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Schema      Schema `json:"schema"`
+}
+
+// Schema - subset of JSON Schema used by OpenRPC documents
+type Schema struct {
+	Schema      string            `json:"$schema,omitempty"` // dialect of a top-level schema
+	ID          string            `json:"$id,omitempty"`
+	Ref         string            `json:"$ref,omitempty"`
+	Type        SchemaType        `json:"type,omitempty"`
+	Title       string            `json:"title,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Pattern     string            `json:"pattern,omitempty"`
+	Properties  map[string]Schema `json:"properties,omitempty"`
+	Required    []string          `json:"required,omitempty"`
+	Items       *Schema           `json:"items,omitempty"`
+	OneOf       []Schema          `json:"oneOf,omitempty"`
+	AnyOf       []Schema          `json:"anyOf,omitempty"`
+	Enum        []interface{}     `json:"enum,omitempty"`
+}
+
+// SchemaType - JSON Schema type, either a single name or a list of names
+type SchemaType []string
+
+// UnmarshalJSON accepts both "string" and ["string", "null"]
+func (t *SchemaType) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = SchemaType{name}
+		return nil
+	}
+
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	*t = names
+
+	return nil
+}
+
+// MarshalJSON encodes single type as a string
+func (t SchemaType) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+
+	return json.Marshal([]string(t))
+}
+
+// Is returns true if schema type includes name
+func (t SchemaType) Is(name string) bool {
+	for _, n := range t {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Components - reusable schemas referenced with $ref
+type Components struct {
+	Schemas map[string]Schema `json:"schemas,omitempty"`
+}
+
+// Parse parses OpenRPC document
+func Parse(data []byte) (*Document, error) {
+	document := new(Document)
+	if err := json.Unmarshal(data, document); err != nil {
+		return nil, err
+	}
+	if document.OpenRPC == "" {
+		return nil, fmt.Errorf("openrpc: missing openrpc version")
+	}
+
+	return document, nil
+}
+
+// Method returns method with given name
+func (d *Document) Method(name string) (Method, bool) {
+	for _, method := range d.Methods {
+		if method.Name == name {
+			return method, true
+		}
+	}
+
+	return Method{}, false
+}
+
+// Resolve returns schema referenced by $ref, or schema itself if it is not a reference
+func (d *Document) Resolve(schema Schema) (Schema, error) {
+	resolved, err := d.resolve(schema)
+	if err != nil {
+		return Schema{}, fmt.Errorf("openrpc: %v", err)
+	}
+
+	return resolved, nil
+}
+
+func (d *Document) resolve(schema Schema) (Schema, error) {
+	if schema.Ref == "" {
+		return schema, nil
+	}
+
+	name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+	resolved, ok := d.Components.Schemas[name]
+	if !ok || name == schema.Ref {
+		return Schema{}, fmt.Errorf("unresolved reference %s", schema.Ref)
+	}
+
+	return resolved, nil
+}
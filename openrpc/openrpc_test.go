@@ -0,0 +1,129 @@
+package openrpc
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func document(t *testing.T) *Document {
+	data, err := ioutil.ReadFile("testdata/openrpc.json")
+	require.Nil(t, err)
+
+	document, err := Parse(data)
+	require.Nil(t, err)
+	return document
+}
+
+func TestParse(t *testing.T) {
+	d := document(t)
+
+	require.Equal(t, "Asimov node", d.Info.Title)
+	require.Len(t, d.Methods, 6)
+
+	method, ok := d.Method("debug_traceCall")
+	require.True(t, ok)
+	require.Equal(t, SchemaType{"object", "null"}, method.Result.Schema.Type)
+	require.True(t, method.Result.Schema.Type.Is("null"))
+
+	method, _ = d.Method("flow_getBlockByNumber")
+	block, err := d.Resolve(method.Result.Schema)
+	require.Nil(t, err)
+	require.Equal(t, []string{"number"}, block.Required)
+
+	_, err = d.Resolve(Schema{Ref: "#/components/schemas/Missing"})
+	require.EqualError(t, err, "openrpc: unresolved reference #/components/schemas/Missing")
+	_, err = Parse([]byte(`{"methods": []}`))
+	require.NotNil(t, err)
+}
+
+func TestGenerate(t *testing.T) {
+	source, err := Generate(document(t), Options{Package: "nodeapi"})
+	require.Nil(t, err)
+	code := string(source)
+
+	for _, expected := range []string{
+		"package nodeapi",
+		`asimovrpc "github.com/mistdex/mist-asimov-rpc"`,
+		"func NewFlowAPI(rpc asimovrpc.Client) FlowAPI {",
+		"func (api FlowAPI) GetBalance(address string, block string) (string, error) {",
+		`err := api.Call("getBalance", &result, address, block)`,
+		"func (api FlowAPI) GetBlockByNumber(block string, full bool) (Block, error) {",
+		"func (api AdminAPI) Peers() ([]PeersResultItem, error) {",
+		`return api.Call("setHead", nil, number)`,
+		"func (api DebugAPI) TraceCall(typeParam json.RawMessage) (map[string]interface{}, error) {",
+		"// Block - block object",
+		"Number       string   `json:\"number\"`",
+		"Hash         string   `json:\"hash,omitempty\"`",
+	} {
+		require.Contains(t, code, expected)
+	}
+	require.NotContains(t, code, "discover")
+
+	_, err = Generate(&Document{Methods: []Method{{Name: "flow_get", Params: []ContentDescriptor{{Name: "x", Schema: Schema{Ref: "#/components/schemas/X"}}}}}}, Options{})
+	require.EqualError(t, err, "openrpc: flow_get: unresolved reference #/components/schemas/X")
+}
+
+func TestNames(t *testing.T) {
+	require.Equal(t, "BlockNumber", exported("block_number"))
+	require.Equal(t, "GetBalance", exported("getBalance"))
+	require.Equal(t, "X0x", exported("0x"))
+	require.Equal(t, "rangeParam", unexported("range"))
+	require.Equal(t, "fromBlock", unexported("from-block"))
+	require.True(t, strings.HasPrefix(exported("é"), "É"))
+}
+
+func TestValidate(t *testing.T) {
+	d := document(t)
+	address := "0x6247cf0412c6462da2a51d05139e2a3c6c630f0a"
+
+	require.Nil(t, d.Validate("flow_getBalance", []interface{}{address, "latest"}))
+	require.Nil(t, d.Validate("flow_getBlockByNumber", []interface{}{"0x1"}))
+	require.Nil(t, d.Validate("flow_getBlockByNumber", []interface{}{"0x1", true}))
+	require.Nil(t, d.Validate("debug_setHead", []interface{}{}))
+	require.Nil(t, d.Validate("debug_setHead", []interface{}{1e21}))
+	require.Nil(t, d.Validate("debug_traceCall", []interface{}{map[string]interface{}{"to": address}}))
+	require.Nil(t, d.Validate("flow_unknown", []interface{}{1, 2, 3}))
+
+	tests := []struct {
+		method string
+		params []interface{}
+		err    string
+	}{
+		{"flow_getBalance", []interface{}{address}, "openrpc: flow_getBalance: expected 2 params, got 1"},
+		{"flow_getBlockByNumber", []interface{}{}, "openrpc: flow_getBlockByNumber: expected 1 to 2 params, got 0"},
+		{"flow_getBalance", []interface{}{address, 1}, "openrpc: flow_getBalance: param block: expected string, got integer"},
+		{"flow_getBalance", []interface{}{"0x12", "latest"}, `openrpc: flow_getBalance: param address: "0x12" does not match ^0x[0-9a-fA-F]{40}$`},
+		{"flow_getBlockByNumber", []interface{}{"0x1", "true"}, "openrpc: flow_getBlockByNumber: param full: expected boolean, got string"},
+		{"debug_setHead", []interface{}{1.5}, "openrpc: debug_setHead: param number: expected integer, got number"},
+		{"debug_traceCall", []interface{}{1}, "openrpc: debug_traceCall: param type: matches none of the alternatives"},
+	}
+	for _, test := range tests {
+		err := d.Validate(test.method, test.params)
+		require.EqualError(t, err, test.err)
+		require.IsType(t, ValidationError{}, err)
+	}
+}
+
+func TestValidateObject(t *testing.T) {
+	d := &Document{
+		Methods: []Method{{
+			Name: "flow_sendBlock",
+			Params: []ContentDescriptor{
+				{Name: "block", Required: true, Schema: Schema{Ref: "#/components/schemas/Block"}},
+				{Name: "mode", Schema: Schema{Type: SchemaType{"string"}, Enum: []interface{}{"fast", "full"}}},
+			},
+		}},
+		Components: document(t).Components,
+	}
+
+	require.Nil(t, d.Validate("flow_sendBlock", []interface{}{map[string]interface{}{"number": "0x1", "gasUsed": 21000}, "fast"}))
+	require.EqualError(t, d.Validate("flow_sendBlock", []interface{}{map[string]interface{}{"hash": "0x1"}}),
+		"openrpc: flow_sendBlock: param block: missing property number")
+	require.EqualError(t, d.Validate("flow_sendBlock", []interface{}{map[string]interface{}{"number": "0x1", "transactions": []int{1}}}),
+		"openrpc: flow_sendBlock: param block: property transactions: item 0: expected string, got integer")
+	require.EqualError(t, d.Validate("flow_sendBlock", []interface{}{map[string]interface{}{"number": "0x1"}, "slow"}),
+		"openrpc: flow_sendBlock: param mode: slow is not one of [fast full]")
+}
@@ -0,0 +1,169 @@
+package openrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+)
+
+// ValidationError - call does not match the method description
+type ValidationError struct {
+	Method string
+	Param  string // "" - error of the whole call, such as wrong param count
+	Reason string
+}
+
+func (err ValidationError) Error() string {
+	if err.Param == "" {
+		return fmt.Sprintf("openrpc: %s: %s", err.Method, err.Reason)
+	}
+
+	return fmt.Sprintf("openrpc: %s: param %s: %s", err.Method, err.Param, err.Reason)
+}
+
+// Validate checks params of method call against the method description: param count, JSON types,
+// required object properties, enums and string patterns. Methods missing in the document are not checked.
+func (d *Document) Validate(method string, params []interface{}) error {
+	description, ok := d.Method(method)
+	if !ok {
+		return nil
+	}
+
+	required := 0
+	for i, param := range description.Params {
+		if param.Required {
+			required = i + 1
+		}
+	}
+	if len(params) < required || len(params) > len(description.Params) {
+		reason := fmt.Sprintf("expected %d params, got %d", len(description.Params), len(params))
+		if required < len(description.Params) {
+			reason = fmt.Sprintf("expected %d to %d params, got %d", required, len(description.Params), len(params))
+		}
+		return ValidationError{Method: method, Reason: reason}
+	}
+
+	for i, param := range params {
+		data, err := json.Marshal(param)
+		if err != nil {
+			return ValidationError{Method: method, Param: description.Params[i].Name, Reason: err.Error()}
+		}
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return ValidationError{Method: method, Param: description.Params[i].Name, Reason: err.Error()}
+		}
+		if reason := d.check(description.Params[i].Schema, value, 0); reason != "" {
+			return ValidationError{Method: method, Param: description.Params[i].Name, Reason: reason}
+		}
+	}
+
+	return nil
+}
+
+// check returns reason why JSON value does not match schema, "" if it matches
+func (d *Document) check(schema Schema, value interface{}, depth int) string {
+	if depth > 32 {
+		return "" // recursive schema
+	}
+	schema, err := d.resolve(schema)
+	if err != nil {
+		return err.Error()
+	}
+
+	for _, alternatives := range [][]Schema{schema.OneOf, schema.AnyOf} {
+		if len(alternatives) == 0 {
+			continue
+		}
+		matched := false
+		for _, alternative := range alternatives {
+			if d.check(alternative, value, depth+1) == "" {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return "matches none of the alternatives"
+		}
+	}
+
+	if len(schema.Type) > 0 && !schema.Type.Is(jsonType(value)) &&
+		!(jsonType(value) == "integer" && schema.Type.Is("number")) {
+		return fmt.Sprintf("expected %s, got %s", typeNames(schema.Type), jsonType(value))
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		return fmt.Sprintf("%v is not one of %v", value, schema.Enum)
+	}
+
+	switch value := value.(type) {
+	case string:
+		if schema.Pattern != "" {
+			if pattern, err := regexp.Compile(schema.Pattern); err == nil && !pattern.MatchString(value) {
+				return fmt.Sprintf("%q does not match %s", value, schema.Pattern)
+			}
+		}
+	case []interface{}:
+		if schema.Items != nil {
+			for i, item := range value {
+				if reason := d.check(*schema.Items, item, depth+1); reason != "" {
+					return fmt.Sprintf("item %d: %s", i, reason)
+				}
+			}
+		}
+	case map[string]interface{}:
+		for _, property := range schema.Required {
+			if _, ok := value[property]; !ok {
+				return fmt.Sprintf("missing property %s", property)
+			}
+		}
+		for property, item := range value {
+			if propertySchema, ok := schema.Properties[property]; ok {
+				if reason := d.check(propertySchema, item, depth+1); reason != "" {
+					return fmt.Sprintf("property %s: %s", property, reason)
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+func jsonType(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if value == math.Trunc(value) && !math.IsInf(value, 0) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	}
+
+	return "object"
+}
+
+func typeNames(t SchemaType) string {
+	if len(t) == 1 {
+		return t[0]
+	}
+
+	return fmt.Sprintf("one of %v", []string(t))
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	data, _ := json.Marshal(value)
+	for _, option := range enum {
+		if optionData, _ := json.Marshal(option); string(optionData) == string(data) {
+			return true
+		}
+	}
+
+	return false
+}
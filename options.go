@@ -1,12 +1,32 @@
 package asimovrpc
 
 import (
+	"fmt"
 	"io"
 	"net/http"
+	"time"
 )
 
 type httpClient interface {
-	Post(url string, contentType string, body io.Reader) (*http.Response, error)
+	Do(request *http.Request) (*http.Response, error)
+}
+
+// PostClient - HTTP client sending requests with Post only, as WithHttpClient accepted before it took Do
+type PostClient interface {
+	Post(url, contentType string, body io.Reader) (*http.Response, error)
+}
+
+// postClient adapts PostClient to httpClient
+type postClient struct {
+	client PostClient
+}
+
+func (c postClient) Do(request *http.Request) (*http.Response, error) {
+	if request.Method != http.MethodPost {
+		return nil, fmt.Errorf("Post client cannot send %s requests", request.Method)
+	}
+
+	return c.client.Post(request.URL.String(), request.Header.Get("Content-Type"), request.Body)
 }
 
 type logger interface {
@@ -16,7 +36,24 @@ type logger interface {
 // WithHttpClient set custom http client
 func WithHttpClient(client httpClient) func(rpc *AsimovRPC) {
 	return func(rpc *AsimovRPC) {
-		rpc.client = client
+		if client == nil {
+			rpc.invalidOption("WithHttpClient", "client is nil")
+			return
+		}
+		rpc.setTransport("WithHttpClient", client)
+	}
+}
+
+// WithPostClient set custom http client having Post only. Headers other than Content-Type, such as
+// User-Agent and signatures of WithRequestSigner, and the request context are not passed to it,
+// prefer WithHttpClient with a client having Do.
+func WithPostClient(client PostClient) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if client == nil {
+			rpc.invalidOption("WithPostClient", "client is nil")
+			return
+		}
+		rpc.setTransport("WithPostClient", postClient{client: client})
 	}
 }
 
@@ -30,6 +67,106 @@ func WithLogger(l logger) func(rpc *AsimovRPC) {
 // WithDebug set debug flag
 func WithDebug(enabled bool) func(rpc *AsimovRPC) {
 	return func(rpc *AsimovRPC) {
-		rpc.Debug = enabled
+		rpc.debugEnabled = enabled
+	}
+}
+
+// WithDebugFormat set format of debug records
+func WithDebugFormat(format DebugFormat) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		rpc.debugFormat = format
+	}
+}
+
+// WithDebugPayloadLimit set maximum request/response size included in JSON debug records (0 - unlimited)
+func WithDebugPayloadLimit(limit int) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		rpc.debugPayloadLimit = limit
+	}
+}
+
+// WithSlowQueryThreshold log calls taking longer than threshold
+func WithSlowQueryThreshold(threshold time.Duration) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		rpc.slowQueryThreshold = threshold
+	}
+}
+
+// WithLatencyBuckets set upper bounds of per-method latency histogram buckets
+func WithLatencyBuckets(buckets ...time.Duration) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		for _, bucket := range buckets {
+			if bucket <= 0 {
+				rpc.invalidOption("WithLatencyBuckets", "bucket bounds must be positive")
+				return
+			}
+		}
+		rpc.stats = newStats(buckets)
+	}
+}
+
+// WithUserAgent set application identifier appended to the default User-Agent header
+func WithUserAgent(app string) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		rpc.userAgent = DefaultUserAgent() + " " + app
+	}
+}
+
+// WithDiagnostics enable collection of negotiated protocol, connection reuse and TLS resumption statistics
+func WithDiagnostics(enabled bool) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if enabled {
+			rpc.diagnostics = newDiagnostics()
+		} else {
+			rpc.diagnostics = nil
+		}
+	}
+}
+
+// WithDNSRoundRobin use http client resolving endpoint host every refresh interval and spreading connections across its addresses.
+// Record TTLs are not honoured, see WithDNSResolver.
+func WithDNSRoundRobin(refresh time.Duration) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if refresh < 0 {
+			rpc.invalidOption("WithDNSRoundRobin", "refresh interval is negative")
+			return
+		}
+		rpc.resolver = newRoundRobinResolver(refresh)
+		rpc.setTransport("WithDNSRoundRobin", &http.Client{Transport: newRoundRobinTransport(rpc.resolver)})
+	}
+}
+
+// WithDNSResolver is WithDNSRoundRobin resolving endpoint host with resolver, re-resolving addresses when their records expire
+func WithDNSResolver(resolver TTLResolver, refresh time.Duration) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		switch {
+		case resolver == nil:
+			rpc.invalidOption("WithDNSResolver", "resolver is nil")
+			return
+		case refresh < 0:
+			rpc.invalidOption("WithDNSResolver", "refresh interval is negative")
+			return
+		}
+		rpc.resolver = newTTLResolver(resolver, refresh)
+		rpc.setTransport("WithDNSResolver", &http.Client{Transport: newRoundRobinTransport(rpc.resolver)})
+	}
+}
+
+// WithEndpointProvider spread calls across nodes returned by provider, polled every refresh interval.
+// The first list is polled on first use, later polls run in the background while calls use the current list.
+func WithEndpointProvider(provider EndpointProvider, refresh time.Duration) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if provider == nil {
+			rpc.invalidOption("WithEndpointProvider", "provider is nil")
+			return
+		}
+		rpc.endpoints = newEndpointSet(provider, refresh)
+	}
+}
+
+// WithTimeout set deadline for every call
+func WithTimeout(timeout time.Duration) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		rpc.timeout = timeout
 	}
 }
@@ -0,0 +1,75 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// StateOverride - temporary state of accounts keyed by address, applied by flow_call before executing
+// the call and discarded after it, e.g. to simulate a token transfer with a granted allowance
+type StateOverride map[string]AccountOverride
+
+// AccountOverride - replacement of an account's state, unset fields keep their value. State replaces the
+// whole storage of the account, StateDiff only the given slots; they can not be used together.
+type AccountOverride struct {
+	Balance   *big.Int
+	Nonce     *int
+	Code      string
+	State     map[string]string // storage slot - 32-byte value
+	StateDiff map[string]string // storage slot - 32-byte value
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (o AccountOverride) MarshalJSON() ([]byte, error) {
+	if o.State != nil && o.StateDiff != nil {
+		return nil, fmt.Errorf("Account override can not set both state and stateDiff")
+	}
+
+	params := map[string]interface{}{}
+	if o.Balance != nil {
+		params["balance"] = BigToHex(*o.Balance)
+	}
+	if o.Nonce != nil {
+		params["nonce"] = IntToHex(*o.Nonce)
+	}
+	if o.Code != "" {
+		params["code"] = o.Code
+	}
+	if o.State != nil {
+		params["state"] = o.State
+	}
+	if o.StateDiff != nil {
+		params["stateDiff"] = o.StateDiff
+	}
+
+	return json.Marshal(params)
+}
+
+// mergeOverrides returns overrides combined into one, fields of later overrides of an address win.
+// Addresses are matched case-insensitively.
+func mergeOverrides(overrides []StateOverride) StateOverride {
+	merged := StateOverride{}
+	for _, override := range overrides {
+		for address, account := range override {
+			address = strings.ToLower(address)
+			current := merged[address]
+			if account.Balance != nil {
+				current.Balance = account.Balance
+			}
+			if account.Nonce != nil {
+				current.Nonce = account.Nonce
+			}
+			if account.Code != "" {
+				current.Code = account.Code
+			}
+			if account.State != nil || account.StateDiff != nil {
+				current.State, current.StateDiff = account.State, account.StateDiff
+			}
+			merged[address] = current
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,49 @@
+package asimovrpc
+
+import (
+	"math/big"
+	"strings"
+)
+
+func (s *AsimovRPCTestSuite) TestAsimovCallStateOverride() {
+	nonce := 7
+	slot := "0x" + strings.Repeat("0", 63) + "1"
+	value := "0x" + strings.Repeat("0", 61) + "3e8"
+
+	s.registerResponse(`"0x01"`, func(body []byte) {
+		s.methodEqual(body, "flow_call")
+		s.paramsEqual(body, `[{"from":"0x111","to":"0x222"}, "latest", {
+			"0x111": {"balance": "0xde0b6b3a7640000", "nonce": "0x7"},
+			"0x222": {"code": "0x6080", "stateDiff": {"`+slot+`": "`+value+`"}}
+		}]`)
+	})
+
+	result, err := s.rpc.Flow().Call(T{From: "0x111", To: "0x222"}, Latest(),
+		StateOverride{
+			"0x111": {Balance: big.NewInt(1)},
+			"0x222": {Code: "0x6080", State: map[string]string{slot: "0x0"}},
+		},
+		StateOverride{
+			"0x111": {Balance: big.NewInt(1e18), Nonce: &nonce},
+			"0x222": {StateDiff: map[string]string{slot: value}},
+		},
+	)
+	s.Require().Nil(err)
+	s.Require().Equal("0x01", result)
+
+	_, err = s.rpc.AsimovCall(T{From: "0x111", To: "0x222"}, Latest(), StateOverride{
+		"0x222": {State: map[string]string{}, StateDiff: map[string]string{}},
+	})
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), "Account override can not set both state and stateDiff")
+}
+
+func (s *AsimovRPCTestSuite) TestMergeOverrides() {
+	merged := mergeOverrides([]StateOverride{
+		{"0xAB": {Code: "0x60"}},
+		{"0xab": {Balance: big.NewInt(5)}},
+	})
+	s.Require().Len(merged, 1)
+	s.Require().Equal("0x60", merged["0xab"].Code)
+	s.Require().Equal("5", merged["0xab"].Balance.String())
+}
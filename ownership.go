@@ -0,0 +1,110 @@
+package asimovrpc
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrOwnershipExpired - ownership proof verified after its expiry
+var ErrOwnershipExpired = errors.New("ownership proof expired")
+
+// OwnershipProof - proof that the holder of the key of Address signed a challenge chosen by the verifier,
+// e.g. an exchange linking a withdrawal address to a customer account. The verifier creates the challenge
+// with NewOwnershipChallenge and keeps it; the holder signs it with Sign or ProveOwnership and sends the
+// proof back; the verifier checks Verify and that the challenge is the one it issued.
+type OwnershipProof struct {
+	Address   string    `json:"address"`
+	Challenge string    `json:"challenge"`
+	Expiry    time.Time `json:"expiry"`
+	Signature string    `json:"signature,omitempty"` // 65-byte R || S || V of Hash()
+}
+
+// NewOwnershipChallenge creates unsigned proof for address with a random challenge valid until expiry
+func NewOwnershipChallenge(address string, expiry time.Time) (*OwnershipProof, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return &OwnershipProof{
+		Address:   address,
+		Challenge: fmt.Sprintf("0x%x", nonce),
+		Expiry:    expiry.UTC().Truncate(time.Second),
+	}, nil
+}
+
+// Message returns the text the holder signs, shown by wallets asking to confirm the signature
+func (p *OwnershipProof) Message() []byte {
+	return []byte(fmt.Sprintf("Prove ownership of Asimov address %s\nChallenge: %s\nExpires: %d",
+		strings.ToLower(p.Address), p.Challenge, p.Expiry.Unix()))
+}
+
+// Hash returns SignedMessageHash of Message, the hash signed by flow_sign
+func (p *OwnershipProof) Hash() []byte {
+	return SignedMessageHash(p.Message())
+}
+
+// Sign signs the proof with signer holding the key of Address
+func (p *OwnershipProof) Sign(signer TransactionSigner) error {
+	if !strings.EqualFold(p.Address, signer.Address()) {
+		return fmt.Errorf("Ownership proof of %s can not be signed by %s", p.Address, signer.Address())
+	}
+
+	signature, err := signer.SignHash(p.Hash())
+	if err != nil {
+		return err
+	}
+
+	p.Signature = fmt.Sprintf("0x%x", signature)
+	return nil
+}
+
+// ProveOwnership signs the proof with flow_sign, for addresses whose keys the node holds
+func (rpc *AsimovRPC) ProveOwnership(proof *OwnershipProof) error {
+	signature, err := rpc.AsimovSign(proof.Address, fmt.Sprintf("0x%x", proof.Message()))
+	if err != nil {
+		return err
+	}
+
+	proof.Signature = signature
+	return nil
+}
+
+// Verify checks that the proof has not expired at now and that Signature was made with the key of
+// Address. Nodes return V as 27 or 28, both forms are accepted.
+func (p *OwnershipProof) Verify(now time.Time) error {
+	if p.Signature == "" {
+		return fmt.Errorf("Ownership proof is not signed")
+	}
+	if !now.Before(p.Expiry) {
+		return ErrOwnershipExpired
+	}
+
+	signature, err := HexToBytes(p.Signature)
+	if err != nil {
+		return err
+	}
+	if len(signature) == 65 && signature[64] >= 27 {
+		signature = append(signature[:64:64], signature[64]-27)
+	}
+	address, err := recoverAddress(p.Hash(), signature)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(address, p.Address) {
+		return fmt.Errorf("Ownership proof of %s is signed by %s", p.Address, address)
+	}
+
+	return nil
+}
+
+// SignedMessageHash returns hash of data signed by flow_sign:
+// keccak256("\x19Ethereum Signed Message:\n" + len(data) + data)
+func SignedMessageHash(data []byte) []byte {
+	prefix := "\x19Ethereum Signed Message:\n" + strconv.Itoa(len(data))
+	return Keccak256([]byte(prefix), data)
+}
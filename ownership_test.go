@@ -0,0 +1,66 @@
+package asimovrpc
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignedMessageHash(t *testing.T) {
+	require.Equal(t, "0xa1de988600a42c4b4ab089b619297c17d53cffae5d5120d82d8a92d0bb3b78f2",
+		fmt.Sprintf("0x%x", SignedMessageHash([]byte("Hello World"))))
+}
+
+func TestOwnershipProof(t *testing.T) {
+	signer, err := NewPrivateKeySigner("0x" + strings.Repeat("46", 32))
+	require.Nil(t, err)
+	other, err := NewPrivateKeySigner("0x" + strings.Repeat("47", 32))
+	require.Nil(t, err)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	proof, err := NewOwnershipChallenge("0x"+strings.ToUpper(signer.Address()[2:]), now.Add(10*time.Minute+time.Millisecond))
+	require.Nil(t, err)
+	require.Len(t, proof.Challenge, 34)
+	require.Equal(t, now.Add(10*time.Minute), proof.Expiry)
+	require.Equal(t, fmt.Sprintf("Prove ownership of Asimov address %s\nChallenge: %s\nExpires: 1709295000", signer.Address(), proof.Challenge), string(proof.Message()))
+
+	second, err := NewOwnershipChallenge(signer.Address(), now)
+	require.Nil(t, err)
+	require.NotEqual(t, proof.Challenge, second.Challenge)
+
+	require.EqualError(t, proof.Verify(now), "Ownership proof is not signed")
+	require.EqualError(t, proof.Sign(other), fmt.Sprintf("Ownership proof of %s can not be signed by %s", proof.Address, other.Address()))
+	require.Nil(t, proof.Sign(signer))
+	require.Nil(t, proof.Verify(now))
+	require.Equal(t, ErrOwnershipExpired, proof.Verify(proof.Expiry))
+
+	forged := *proof
+	forged.Challenge = second.Challenge
+	require.Error(t, forged.Verify(now))
+
+	forged = *proof
+	forged.Address = other.Address()
+	require.Contains(t, forged.Verify(now).Error(), "Ownership proof of "+other.Address()+" is signed by 0x")
+}
+
+func (s *AsimovRPCTestSuite) TestProveOwnership() {
+	signer, err := NewPrivateKeySigner("0x" + strings.Repeat("46", 32))
+	s.Require().Nil(err)
+	proof := &OwnershipProof{Address: signer.Address(), Challenge: "0x01", Expiry: time.Unix(1709295000, 0)}
+
+	// nodes return V as 27 or 28
+	signature, err := signer.SignHash(proof.Hash())
+	s.Require().Nil(err)
+	signature[64] += 27
+
+	s.registerResponse(fmt.Sprintf(`"0x%x"`, signature), func(body []byte) {
+		s.methodEqual(body, "flow_sign")
+		s.paramsEqual(body, fmt.Sprintf(`["%s", "0x%x"]`, signer.Address(), proof.Message()))
+	})
+
+	s.Require().Nil(s.rpc.ProveOwnership(proof))
+	s.Require().Nil(proof.Verify(time.Unix(1709294000, 0)))
+}
@@ -0,0 +1,70 @@
+package asimovrpc
+
+import "context"
+
+// PageFetcher returns items of the page starting at cursor and cursor of the next page ("" - last page)
+type PageFetcher[T any] func(ctx context.Context, cursor string) (items []T, next string, err error)
+
+// PageIterator - lazily fetches pages and yields their items one by one
+type PageIterator[T any] struct {
+	ctx    context.Context
+	fetch  PageFetcher[T]
+	cursor string
+	items  []T
+	item   T
+	done   bool
+	err    error
+}
+
+// Paginate returns iterator over all items of paginated endpoint, starting with cursor "".
+// Pages are fetched only when the previous one is consumed.
+func Paginate[T any](ctx context.Context, fetch PageFetcher[T]) *PageIterator[T] {
+	return &PageIterator[T]{ctx: ctx, fetch: fetch}
+}
+
+// Next advances iterator to the next item, returning false when items are exhausted or on error
+func (it *PageIterator[T]) Next() bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		if it.err = it.ctx.Err(); it.err != nil {
+			return false
+		}
+
+		items, next, err := it.fetch(it.ctx, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items = items
+		it.cursor = next
+		it.done = next == ""
+	}
+
+	it.item = it.items[0]
+	it.items = it.items[1:]
+
+	return true
+}
+
+// Item returns current item
+func (it *PageIterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns error which stopped iteration
+func (it *PageIterator[T]) Err() error {
+	return it.err
+}
+
+// Collect fetches all pages and returns their items
+func Collect[T any](ctx context.Context, fetch PageFetcher[T]) ([]T, error) {
+	var result []T
+	it := Paginate(ctx, fetch)
+	for it.Next() {
+		result = append(result, it.Item())
+	}
+
+	return result, it.Err()
+}
@@ -0,0 +1,63 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func pages(fetched *[]string) PageFetcher[int] {
+	data := map[string][]int{"": {1, 2}, "b": {}, "c": {3}}
+	next := map[string]string{"": "b", "b": "c", "c": ""}
+
+	return func(ctx context.Context, cursor string) ([]int, string, error) {
+		*fetched = append(*fetched, cursor)
+		return data[cursor], next[cursor], nil
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	var fetched []string
+	it := Paginate(context.Background(), pages(&fetched))
+
+	require.True(t, it.Next())
+	require.Equal(t, 1, it.Item())
+	require.Equal(t, []string{""}, fetched)
+
+	require.True(t, it.Next())
+	require.True(t, it.Next())
+	require.Equal(t, 3, it.Item())
+	require.Equal(t, []string{"", "b", "c"}, fetched)
+
+	require.False(t, it.Next())
+	require.Nil(t, it.Err())
+}
+
+func TestPaginateErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var fetched []string
+	it := Paginate(ctx, pages(&fetched))
+	require.True(t, it.Next())
+	require.True(t, it.Next())
+	cancel()
+	require.False(t, it.Next())
+	require.Equal(t, context.Canceled, it.Err())
+
+	items, err := Collect(context.Background(), func(ctx context.Context, cursor string) ([]string, string, error) {
+		if cursor == "" {
+			return []string{"a"}, "next", nil
+		}
+		return nil, "", errors.New("page error")
+	})
+	require.EqualError(t, err, "page error")
+	require.Equal(t, []string{"a"}, items)
+}
+
+func TestCollect(t *testing.T) {
+	var fetched []string
+	items, err := Collect(context.Background(), pages(&fetched))
+	require.Nil(t, err)
+	require.Equal(t, []int{1, 2, 3}, items)
+}
@@ -0,0 +1,86 @@
+package asimovrpc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+// ParamEncodingError - call param cannot be encoded as a JSON-RPC value
+type ParamEncodingError struct {
+	Index  int
+	Reason string
+}
+
+func (err ParamEncodingError) Error() string {
+	return fmt.Sprintf("Param %d: %s", err.Index, err.Reason)
+}
+
+// WithRawParams pass call params to encoding/json as is. By default big.Int params are sent as
+// hex quantities ("0x1bc16d674ec80000") and []byte params as hex data ("0xa9059cbb"), also inside
+// []interface{} and map[string]interface{} params, instead of a JSON number and a base64 string.
+func WithRawParams(enabled bool) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		rpc.rawParams = enabled
+	}
+}
+
+// encodeParams returns params with Go values converted to JSON-RPC quantities and data
+func (rpc *AsimovRPC) encodeParams(params []interface{}) ([]interface{}, error) {
+	if rpc.rawParams || len(params) == 0 {
+		return params, nil
+	}
+
+	encoded := make([]interface{}, len(params))
+	for i, param := range params {
+		value, err := encodeParam(param)
+		if err != nil {
+			return nil, ParamEncodingError{Index: i, Reason: err.Error()}
+		}
+		encoded[i] = value
+	}
+
+	return encoded, nil
+}
+
+func encodeParam(param interface{}) (interface{}, error) {
+	switch value := param.(type) {
+	case *big.Int:
+		if value == nil {
+			return nil, nil
+		}
+		return encodeQuantity(*value)
+	case big.Int:
+		return encodeQuantity(value)
+	case []byte:
+		return "0x" + hex.EncodeToString(value), nil
+	case []interface{}:
+		encoded := make([]interface{}, len(value))
+		for i, item := range value {
+			var err error
+			if encoded[i], err = encodeParam(item); err != nil {
+				return nil, err
+			}
+		}
+		return encoded, nil
+	case map[string]interface{}:
+		encoded := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			var err error
+			if encoded[key], err = encodeParam(item); err != nil {
+				return nil, fmt.Errorf("%s: %v", key, err)
+			}
+		}
+		return encoded, nil
+	}
+
+	return param, nil
+}
+
+func encodeQuantity(value big.Int) (string, error) {
+	if value.Sign() < 0 {
+		return "", fmt.Errorf("negative quantity %s", value.String())
+	}
+
+	return BigToHex(value), nil
+}
@@ -0,0 +1,70 @@
+package asimovrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeParams(t *testing.T) {
+	rpc := New("http://a:8545")
+
+	params, err := rpc.encodeParams([]interface{}{
+		big.NewInt(2000000000000000000),
+		*big.NewInt(0),
+		[]byte{0xa9, 0x05, 0x9c, 0xbb},
+		[]interface{}{big.NewInt(16), "latest"},
+		map[string]interface{}{"value": big.NewInt(255), "data": []byte{}},
+		(*big.Int)(nil),
+		"0x1",
+		7,
+	})
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{
+		"0x1bc16d674ec80000",
+		"0x0",
+		"0xa9059cbb",
+		[]interface{}{"0x10", "latest"},
+		map[string]interface{}{"value": "0xff", "data": "0x"},
+		nil,
+		"0x1",
+		7,
+	}, params)
+
+	_, err = rpc.encodeParams([]interface{}{"0x1", map[string]interface{}{"value": big.NewInt(-1)}})
+	require.Equal(t, ParamEncodingError{Index: 1, Reason: "value: negative quantity -1"}, err)
+	require.Equal(t, "Param 1: value: negative quantity -1", err.Error())
+
+	raw := []interface{}{big.NewInt(1), []byte{1}}
+	params, err = New("http://a:8545", WithRawParams(true)).encodeParams(raw)
+	require.Nil(t, err)
+	require.Equal(t, raw, params)
+}
+
+func (s *AsimovRPCTestSuite) TestStrictParams() {
+	s.registerResponse(`"0x1"`, func(body []byte) {
+		s.JSONEq(`{"jsonrpc":"2.0", "id":`+requestID(body)+`, "method":"flow_call", "params":[{"value":"0x64", "data":"0x0102"}, "0x10"]}`, string(body))
+	})
+	_, err := s.rpc.Call("flow_call", map[string]interface{}{"value": big.NewInt(100), "data": []byte{1, 2}}, big.NewInt(16))
+	s.Require().Nil(err)
+
+	rpc := New(s.rpc.url, WithRawParams(true))
+	s.registerResponse(`"0x1"`, func(body []byte) {
+		s.JSONEq(`{"jsonrpc":"2.0", "id":1, "method":"flow_call", "params":[100, "AQI="]}`, string(body))
+	})
+	_, err = rpc.Call("flow_call", big.NewInt(100), []byte{1, 2})
+	s.Require().Nil(err)
+
+	var requests []string
+	s.registerBatchResponder(&requests)
+	var balance, number string
+	batch := s.rpc.NewBatch().
+		Add("flow_getBalance", &balance, "0x1", big.NewInt(-5)).
+		Add("flow_getBalance", &number, []byte{0x10}, "latest")
+	s.Require().Nil(batch.Execute())
+	s.Require().Equal(ParamEncodingError{Index: 1, Reason: "negative quantity -5"}, batch.Err(0))
+	s.Require().Nil(batch.Err(1))
+	s.Require().Len(requests, 1)
+	s.Require().JSONEq(`[{"jsonrpc":"2.0", "id":2, "method":"flow_getBalance", "params":["0x10", "latest"]}]`, requests[0])
+}
@@ -0,0 +1,159 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// PartialTransactionVersion - version of the PartialTransaction format written by this package
+const PartialTransactionVersion = 1
+
+// PartialTransaction - interchange format of a transaction waiting for its signature, passed as JSON between
+// the wallet preparing the transaction and an offline or hardware signer. The signer checks the transaction
+// it is shown, signs SigningHash with the key KeyHint points to, and returns the document with Signature
+// set; the wallet then broadcasts Finalize().Raw. Legacy transactions carry a single signature of From.
+type PartialTransaction struct {
+	Version     int    `json:"version"`
+	ChainID     int64  `json:"chainId"`
+	Transaction T      `json:"transaction"`
+	SigningHash string `json:"signingHash"`
+	KeyHint     string `json:"keyHint,omitempty"`   // derivation path or label of the signing key, for the signer
+	Signature   string `json:"signature,omitempty"` // 65-byte R || S || V, "" - not signed yet
+}
+
+// NewPartialTransaction creates unsigned partial transaction. Transaction must be complete: From, nonce,
+// gas and gas price are signed as they are.
+func NewPartialTransaction(transaction T, chainID int64, keyHint string) (*PartialTransaction, error) {
+	if transaction.From == "" {
+		return nil, fmt.Errorf("Partial transaction has no from address")
+	}
+
+	hash, err := SigningHash(transaction, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PartialTransaction{
+		Version:     PartialTransactionVersion,
+		ChainID:     chainID,
+		Transaction: transaction,
+		SigningHash: fmt.Sprintf("0x%x", hash),
+		KeyHint:     keyHint,
+	}, nil
+}
+
+// DecodePartialTransaction decodes partial transaction and checks that its signing hash and signature,
+// if any, match the transaction, so a tampered document is rejected before it is signed or sent
+func DecodePartialTransaction(data []byte) (*PartialTransaction, error) {
+	p := new(PartialTransaction)
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	if p.Version != PartialTransactionVersion {
+		return nil, fmt.Errorf("Unsupported partial transaction version %d", p.Version)
+	}
+
+	expected, err := NewPartialTransaction(p.Transaction, p.ChainID, p.KeyHint)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(p.SigningHash, expected.SigningHash) {
+		return nil, fmt.Errorf("Partial transaction signing hash %s does not match the transaction", p.SigningHash)
+	}
+	if p.Signature != "" {
+		signature := p.Signature
+		p.Signature = ""
+		if err := p.AddSignature(signature); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// Encode returns JSON of the partial transaction
+func (p *PartialTransaction) Encode() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// Signed reports whether the transaction has its signature
+func (p *PartialTransaction) Signed() bool {
+	return p.Signature != ""
+}
+
+// Sign signs the transaction with signer holding the key of From
+func (p *PartialTransaction) Sign(signer TransactionSigner) error {
+	if !strings.EqualFold(p.Transaction.From, signer.Address()) {
+		return fmt.Errorf("transaction from %s does not match signer %s", p.Transaction.From, signer.Address())
+	}
+
+	hash, err := HexToBytes(p.SigningHash)
+	if err != nil {
+		return err
+	}
+	signature, err := signer.SignHash(hash)
+	if err != nil {
+		return err
+	}
+
+	return p.AddSignature(fmt.Sprintf("0x%x", signature))
+}
+
+// AddSignature adds hex encoded 65-byte signature R || S || V produced by an external signer,
+// after checking that it signs SigningHash with the key of From
+func (p *PartialTransaction) AddSignature(signature string) error {
+	if p.Signed() {
+		return fmt.Errorf("Partial transaction is already signed")
+	}
+
+	data, err := HexToBytes(signature)
+	if err != nil {
+		return err
+	}
+	hash, err := HexToBytes(p.SigningHash)
+	if err != nil {
+		return err
+	}
+	address, err := recoverAddress(hash, data)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(address, p.Transaction.From) {
+		return fmt.Errorf("Signature of %s does not match transaction from %s", address, p.Transaction.From)
+	}
+
+	p.Signature = fmt.Sprintf("0x%x", data)
+	return nil
+}
+
+// Finalize returns signed transaction ready to be broadcast with AsimovSendRawTransaction
+func (p *PartialTransaction) Finalize() (*SignedTransaction, error) {
+	if !p.Signed() {
+		return nil, fmt.Errorf("Partial transaction is not signed")
+	}
+
+	signature, err := HexToBytes(p.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return assembleTransaction(p.Transaction, p.ChainID, p.Transaction.From, signature)
+}
+
+// recoverAddress returns 20-byte address of the key which produced 65-byte signature R || S || V of hash
+func recoverAddress(hash, signature []byte) (string, error) {
+	if len(signature) != 65 || signature[64] > 1 {
+		return "", fmt.Errorf("signature must be 65 bytes with recovery id 0 or 1")
+	}
+
+	compact := append([]byte{27 + signature[64]}, signature[:64]...)
+	key, _, err := ecdsa.RecoverCompact(compact, hash)
+	if err != nil {
+		return "", err
+	}
+
+	return keyAddress(key), nil
+}
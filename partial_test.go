@@ -0,0 +1,78 @@
+package asimovrpc
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartialTransaction(t *testing.T) {
+	signer, err := NewPrivateKeySigner("0x" + strings.Repeat("46", 32))
+	require.Nil(t, err)
+	transaction := eip155Transaction()
+	transaction.From = signer.Address()
+
+	partial, err := NewPartialTransaction(transaction, 1, "m/44'/10003'/0'/0/0")
+	require.Nil(t, err)
+	require.Equal(t, "0xdaf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53", partial.SigningHash)
+	require.False(t, partial.Signed())
+	_, err = partial.Finalize()
+	require.EqualError(t, err, "Partial transaction is not signed")
+
+	// the offline signer decodes the document, signs it and sends it back
+	data, err := partial.Encode()
+	require.Nil(t, err)
+	offline, err := DecodePartialTransaction(data)
+	require.Nil(t, err)
+	require.Equal(t, partial, offline)
+	require.Nil(t, offline.Sign(signer))
+	require.EqualError(t, offline.Sign(signer), "Partial transaction is already signed")
+
+	data, err = offline.Encode()
+	require.Nil(t, err)
+	signed, err := DecodePartialTransaction(data)
+	require.Nil(t, err)
+	require.True(t, signed.Signed())
+
+	final, err := signed.Finalize()
+	require.Nil(t, err)
+	expected, err := SignTransaction(eip155Transaction(), 1, signer)
+	require.Nil(t, err)
+	require.Equal(t, expected.Raw, final.Raw)
+	require.Equal(t, expected.Tx.Hash, final.Tx.Hash)
+}
+
+func TestPartialTransactionTampering(t *testing.T) {
+	signer, err := NewPrivateKeySigner("0x" + strings.Repeat("46", 32))
+	require.Nil(t, err)
+	other, err := NewPrivateKeySigner("0x" + strings.Repeat("47", 32))
+	require.Nil(t, err)
+	transaction := eip155Transaction()
+	transaction.From = signer.Address()
+
+	partial, err := NewPartialTransaction(transaction, 1, "")
+	require.Nil(t, err)
+	data, err := partial.Encode()
+	require.Nil(t, err)
+
+	tampered := strings.Replace(string(data), "0x3535353535353535353535353535353535353535", "0x3636363636363636363636363636363636363636", 1)
+	_, err = DecodePartialTransaction([]byte(tampered))
+	require.EqualError(t, err, "Partial transaction signing hash "+partial.SigningHash+" does not match the transaction")
+
+	_, err = DecodePartialTransaction([]byte(strings.Replace(string(data), `"version":1`, `"version":2`, 1)))
+	require.EqualError(t, err, "Unsupported partial transaction version 2")
+
+	require.NotNil(t, partial.Sign(other))
+
+	hash, err := HexToBytes(partial.SigningHash)
+	require.Nil(t, err)
+	signature, err := other.SignHash(hash)
+	require.Nil(t, err)
+	err = partial.AddSignature(fmt.Sprintf("0x%x", signature))
+	require.EqualError(t, err, "Signature of "+other.Address()+" does not match transaction from "+signer.Address())
+
+	_, err = NewPartialTransaction(eip155Transaction(), 1, "")
+	require.EqualError(t, err, "Partial transaction has no from address")
+}
@@ -0,0 +1,175 @@
+package asimovrpc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PaymentURIScheme - scheme of payment request URIs
+const PaymentURIScheme = "asimov"
+
+// PaymentRequest - payment request shown by point-of-sale terminals and wallets, e.g. as a QR code:
+//
+//	asimov:0x663f...?amount=1000000000000000000&asset=000000000000000100000001&label=Shop
+//
+// Amount is in the smallest unit of its asset. Parameters starting with "req-" are required ones the
+// payer must understand, ParsePaymentURI rejects URIs with such parameters.
+type PaymentRequest struct {
+	Address string
+	Amount  Amount // zero value - the payer chooses, "" asset - NativeAsset
+	Label   string // recipient name
+	Message string // purpose of the payment
+}
+
+// URI encodes payment request
+func (r PaymentRequest) URI() string {
+	query := url.Values{}
+	if r.Amount.Value.Sign() > 0 {
+		query.Set("amount", r.Amount.Value.String())
+	}
+	if r.Amount.Asset != "" && r.Amount.Asset != NativeAsset {
+		query.Set("asset", r.Amount.Asset)
+	}
+	if r.Label != "" {
+		query.Set("label", r.Label)
+	}
+	if r.Message != "" {
+		query.Set("message", r.Message)
+	}
+
+	uri := PaymentURIScheme + ":" + r.Address
+	if len(query) > 0 {
+		uri += "?" + strings.ReplaceAll(query.Encode(), "+", "%20")
+	}
+
+	return uri
+}
+
+// ParsePaymentURI decodes payment request URI, Amount.Asset is NativeAsset unless the URI names another asset
+func ParsePaymentURI(uri string) (*PaymentRequest, error) {
+	scheme, rest, ok := strings.Cut(uri, ":")
+	if !ok || !strings.EqualFold(scheme, PaymentURIScheme) {
+		return nil, fmt.Errorf("Payment URI must start with %s:", PaymentURIScheme)
+	}
+	address, rawQuery, _ := strings.Cut(rest, "?")
+	if !strings.HasPrefix(address, "0x") || len(address) <= 2 || !isHexDigits(address[2:]) {
+		return nil, fmt.Errorf("Invalid payment address %q", address)
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &PaymentRequest{
+		Address: address,
+		Amount:  Amount{Asset: NativeAsset},
+		Label:   query.Get("label"),
+		Message: query.Get("message"),
+	}
+	if amount := query.Get("amount"); amount != "" {
+		if _, ok := request.Amount.Value.SetString(amount, 10); !ok || request.Amount.Value.Sign() < 0 {
+			return nil, fmt.Errorf("Invalid payment amount %q", amount)
+		}
+	}
+	if asset := query.Get("asset"); asset != "" {
+		if len(asset) != len(NativeAsset) || !isHexDigits(asset) {
+			return nil, fmt.Errorf("Invalid payment asset %q", asset)
+		}
+		request.Amount.Asset = asset
+	}
+	for key := range query {
+		if strings.HasPrefix(key, "req-") {
+			return nil, fmt.Errorf("Unsupported required payment parameter %s", key)
+		}
+	}
+
+	return request, nil
+}
+
+// ChunkRawTransaction splits raw signed transaction into parts of at most size hex digits, for
+// animated or multiple QR codes moving a transaction off an offline signer. Parts look like
+// "2/3:1a2b3c4d:<hex>": index, total and a checksum of the whole transaction.
+func ChunkRawTransaction(raw string, size int) ([]string, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("Chunk size must be positive, got %d", size)
+	}
+	data, err := HexToBytes(raw)
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("Invalid raw transaction")
+	}
+
+	digits := hex.EncodeToString(data)
+	checksum := hex.EncodeToString(Keccak256(data)[:4])
+	total := (len(digits) + size - 1) / size
+
+	parts := make([]string, 0, total)
+	for i := 0; i < total; i++ {
+		end := (i + 1) * size
+		if end > len(digits) {
+			end = len(digits)
+		}
+		parts = append(parts, fmt.Sprintf("%d/%d:%s:%s", i+1, total, checksum, digits[i*size:end]))
+	}
+
+	return parts, nil
+}
+
+// JoinRawTransaction joins parts made by ChunkRawTransaction, in any order and with duplicates, back
+// into 0x-prefixed raw transaction. It fails when parts are missing or belong to different transactions.
+func JoinRawTransaction(parts []string) (string, error) {
+	if len(parts) == 0 {
+		return "", fmt.Errorf("No transaction parts")
+	}
+
+	chunks := map[int]string{}
+	total, checksum := 0, ""
+	for _, part := range parts {
+		fields := strings.SplitN(part, ":", 3)
+		if len(fields) != 3 {
+			return "", fmt.Errorf("Invalid transaction part %q", part)
+		}
+		position := strings.SplitN(fields[0], "/", 2)
+		if len(position) != 2 {
+			return "", fmt.Errorf("Invalid transaction part %q", part)
+		}
+		index, err1 := strconv.Atoi(position[0])
+		count, err2 := strconv.Atoi(position[1])
+		if err1 != nil || err2 != nil || index < 1 || index > count {
+			return "", fmt.Errorf("Invalid transaction part %q", part)
+		}
+		if total == 0 {
+			total, checksum = count, fields[1]
+		}
+		if count != total || fields[1] != checksum {
+			return "", fmt.Errorf("Transaction parts belong to different transactions")
+		}
+		chunks[index] = fields[2]
+	}
+
+	if len(chunks) != total {
+		var missing []int
+		for i := 1; i <= total; i++ {
+			if _, ok := chunks[i]; !ok {
+				missing = append(missing, i)
+			}
+		}
+		return "", fmt.Errorf("Transaction parts %v of %d are missing", missing, total)
+	}
+
+	var digits strings.Builder
+	for i := 1; i <= total; i++ {
+		digits.WriteString(chunks[i])
+	}
+	data, err := hex.DecodeString(digits.String())
+	if err != nil {
+		return "", fmt.Errorf("Invalid transaction part: %v", err)
+	}
+	if hex.EncodeToString(Keccak256(data)[:4]) != checksum {
+		return "", fmt.Errorf("Transaction checksum does not match")
+	}
+
+	return "0x" + hex.EncodeToString(data), nil
+}
@@ -0,0 +1,80 @@
+package asimovrpc
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentURI(t *testing.T) {
+	request := PaymentRequest{
+		Address: "0x66a1b2c3d4e5f60718293a4b5c6d7e8f9012345678",
+		Amount:  Amount{Asset: "000000000000000100000001", Value: *big.NewInt(1500)},
+		Label:   "Coffee & Co",
+		Message: "order 42",
+	}
+	uri := request.URI()
+	require.Equal(t, "asimov:0x66a1b2c3d4e5f60718293a4b5c6d7e8f9012345678?amount=1500&asset=000000000000000100000001&label=Coffee%20%26%20Co&message=order%2042", uri)
+
+	parsed, err := ParsePaymentURI(uri)
+	require.Nil(t, err)
+	require.Equal(t, request.Address, parsed.Address)
+	require.Equal(t, request.Amount.Asset, parsed.Amount.Asset)
+	require.Equal(t, "1500", parsed.Amount.Value.String())
+	require.Equal(t, request.Label, parsed.Label)
+	require.Equal(t, request.Message, parsed.Message)
+
+	require.Equal(t, "asimov:0x66", PaymentRequest{Address: "0x66", Amount: Amount{Asset: NativeAsset}}.URI())
+	parsed, err = ParsePaymentURI("ASIMOV:0x66?label=Shop+One&future=1")
+	require.Nil(t, err)
+	require.Equal(t, NativeAsset, parsed.Amount.Asset)
+	require.Equal(t, 0, parsed.Amount.Value.Sign())
+	require.Equal(t, "Shop One", parsed.Label)
+
+	_, err = ParsePaymentURI("bitcoin:0x66")
+	require.EqualError(t, err, "Payment URI must start with asimov:")
+	_, err = ParsePaymentURI("asimov:66")
+	require.EqualError(t, err, `Invalid payment address "66"`)
+	_, err = ParsePaymentURI("asimov:0x66?amount=1.5")
+	require.EqualError(t, err, `Invalid payment amount "1.5"`)
+	_, err = ParsePaymentURI("asimov:0x66?asset=01")
+	require.EqualError(t, err, `Invalid payment asset "01"`)
+	_, err = ParsePaymentURI("asimov:0x66?req-expires=100")
+	require.EqualError(t, err, "Unsupported required payment parameter req-expires")
+}
+
+func TestChunkRawTransaction(t *testing.T) {
+	raw := "0x" + strings.Repeat("f86c0a85", 10)
+
+	parts, err := ChunkRawTransaction(raw, 30)
+	require.Nil(t, err)
+	require.Len(t, parts, 3)
+	require.True(t, strings.HasPrefix(parts[0], "1/3:"))
+	require.True(t, strings.HasPrefix(parts[2], "3/3:"))
+
+	joined, err := JoinRawTransaction([]string{parts[2], parts[0], parts[2], parts[1]})
+	require.Nil(t, err)
+	require.Equal(t, raw, joined)
+
+	_, err = JoinRawTransaction(parts[:2])
+	require.EqualError(t, err, "Transaction parts [3] of 3 are missing")
+	_, err = JoinRawTransaction(nil)
+	require.EqualError(t, err, "No transaction parts")
+
+	other, err := ChunkRawTransaction("0x01020304", 2)
+	require.Nil(t, err)
+	_, err = JoinRawTransaction([]string{parts[0], other[1]})
+	require.EqualError(t, err, "Transaction parts belong to different transactions")
+
+	tampered := append([]string(nil), parts...)
+	tampered[1] = tampered[1][:len(tampered[1])-1] + "0"
+	_, err = JoinRawTransaction(tampered)
+	require.EqualError(t, err, "Transaction checksum does not match")
+
+	_, err = JoinRawTransaction([]string{"garbage"})
+	require.EqualError(t, err, `Invalid transaction part "garbage"`)
+	_, err = ChunkRawTransaction(raw, 0)
+	require.EqualError(t, err, "Chunk size must be positive, got 0")
+}
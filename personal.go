@@ -0,0 +1,46 @@
+package asimovrpc
+
+import "time"
+
+// Node-hosted account management of the personal namespace, meant for development nodes.
+// Passwords are never logged: see WithDebug and WithSlog. WithReadOnly rejects all of these methods.
+
+// PersonalNewAccount creates account on the node, its key encrypted with password, and returns its address.
+func (rpc *AsimovRPC) PersonalNewAccount(password string) (string, error) {
+	var address string
+
+	err := rpc.call("personal_newAccount", &address, password)
+	return address, err
+}
+
+// PersonalUnlockAccount decrypts key of address for duration, 0 - until the node exits.
+func (rpc *AsimovRPC) PersonalUnlockAccount(address, password string, duration time.Duration) (bool, error) {
+	var unlocked bool
+
+	err := rpc.call("personal_unlockAccount", &unlocked, address, password, int(duration/time.Second))
+	return unlocked, err
+}
+
+// PersonalLockAccount removes decrypted key of address from the node memory.
+func (rpc *AsimovRPC) PersonalLockAccount(address string) (bool, error) {
+	var locked bool
+
+	err := rpc.call("personal_lockAccount", &locked, address)
+	return locked, err
+}
+
+// PersonalSign signs data with key of address decrypted with password, like AsimovSign without unlocking the account.
+func (rpc *AsimovRPC) PersonalSign(data, address, password string) (string, error) {
+	var signature string
+
+	err := rpc.call("personal_sign", &signature, data, address, password)
+	return signature, err
+}
+
+// PersonalSendTransaction sends transaction signed with key of transaction.From decrypted with password.
+func (rpc *AsimovRPC) PersonalSendTransaction(transaction T, password string) (string, error) {
+	var hash string
+
+	err := rpc.call("personal_sendTransaction", &hash, transaction, password)
+	return hash, err
+}
@@ -0,0 +1,57 @@
+package asimovrpc
+
+import (
+	"math/big"
+	"time"
+)
+
+func (s *AsimovRPCTestSuite) TestPersonalAccounts() {
+	address := "0x6247cf0412c6462da2a51d05139e2a3c6c630f0a"
+
+	s.registerResponse(`"`+address+`"`, func(body []byte) {
+		s.methodEqual(body, "personal_newAccount")
+		s.paramsEqual(body, `["secret"]`)
+	})
+	created, err := s.rpc.PersonalNewAccount("secret")
+	s.Require().Nil(err)
+	s.Require().Equal(address, created)
+
+	s.registerResponse(`true`, func(body []byte) {
+		s.methodEqual(body, "personal_unlockAccount")
+		s.paramsEqual(body, `["`+address+`", "secret", 300]`)
+	})
+	unlocked, err := s.rpc.Personal().UnlockAccount(address, "secret", 5*time.Minute)
+	s.Require().Nil(err)
+	s.Require().True(unlocked)
+
+	s.registerResponse(`true`, func(body []byte) {
+		s.methodEqual(body, "personal_lockAccount")
+		s.paramsEqual(body, `["`+address+`"]`)
+	})
+	locked, err := s.rpc.PersonalLockAccount(address)
+	s.Require().Nil(err)
+	s.Require().True(locked)
+
+	_, err = New(s.rpc.url, WithReadOnly(true)).PersonalNewAccount("secret")
+	s.Require().Equal(ReadOnlyError{Method: "personal_newAccount"}, err)
+}
+
+func (s *AsimovRPCTestSuite) TestPersonalSigning() {
+	address := "0x6247cf0412c6462da2a51d05139e2a3c6c630f0a"
+
+	s.registerResponse(`"0xabcd"`, func(body []byte) {
+		s.methodEqual(body, "personal_sign")
+		s.paramsEqual(body, `["0xdeadbeef", "`+address+`", "secret"]`)
+	})
+	signature, err := s.rpc.PersonalSign("0xdeadbeef", address, "secret")
+	s.Require().Nil(err)
+	s.Require().Equal("0xabcd", signature)
+
+	s.registerResponse(`"0x1234"`, func(body []byte) {
+		s.methodEqual(body, "personal_sendTransaction")
+		s.paramsEqual(body, `[{"from": "`+address+`", "to": "0x1", "value": "0xa"}, "secret"]`)
+	})
+	hash, err := s.rpc.Personal().SendTransaction(T{From: address, To: "0x1", Value: big.NewInt(10)}, "secret")
+	s.Require().Nil(err)
+	s.Require().Equal("0x1234", hash)
+}
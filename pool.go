@@ -0,0 +1,138 @@
+package asimovrpc
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrPoolClosed - client pool was closed
+var ErrPoolClosed = errors.New("client pool is closed")
+
+// TenantConfig - per-tenant client settings of a ClientPool
+type TenantConfig struct {
+	URL     string  // "" - URL of the pool
+	Budget  *Budget // rate limit and budget of the tenant, nil - unlimited
+	Options []func(rpc *AsimovRPC)
+}
+
+// ClientPool - clients of many tenants served from one process. Clients share the HTTP client
+// and WebSocket connections, but every tenant has its own budget and statistics, and its calls
+// are tagged with TagTenant. Shared connections are closed by Close of the pool, not of a client.
+type ClientPool struct {
+	url     string
+	options []func(rpc *AsimovRPC)
+	ws      *wsTransport
+
+	mu      sync.Mutex
+	configs map[string]TenantConfig
+	clients map[string]*AsimovRPC
+	closed  bool
+}
+
+// NewClientPool creates pool of clients of url, options are applied to every tenant client
+func NewClientPool(url string, options ...func(rpc *AsimovRPC)) *ClientPool {
+	ws := newWSTransport()
+	ws.shared = true
+
+	return &ClientPool{
+		url:     url,
+		options: options,
+		ws:      ws,
+		configs: map[string]TenantConfig{},
+		clients: map[string]*AsimovRPC{},
+	}
+}
+
+// Configure sets config of tenant, replacing client created with the previous config
+func (p *ClientPool) Configure(tenant string, config TenantConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.configs[tenant] = config
+	delete(p.clients, tenant)
+}
+
+// Client returns client of tenant, created on first use. Tenants without config get
+// a client with the pool options only.
+func (p *ClientPool) Client(tenant string) (*AsimovRPC, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+	if client, ok := p.clients[tenant]; ok {
+		return client, nil
+	}
+
+	config := p.configs[tenant]
+	url := config.URL
+	if url == "" {
+		url = p.url
+	}
+	options := append(append([]func(rpc *AsimovRPC){}, p.options...), config.Options...)
+	options = append(options, WithDefaultTags(Tags{TagTenant: tenant}))
+	if config.Budget != nil {
+		options = append(options, WithBudget(*config.Budget))
+	}
+
+	client, err := NewClient(url, options...)
+	if err != nil {
+		return nil, err
+	}
+	client.ws = p.ws
+
+	p.clients[tenant] = client
+	return client, nil
+}
+
+// Remove drops client of tenant together with its config
+func (p *ClientPool) Remove(tenant string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.configs, tenant)
+	delete(p.clients, tenant)
+}
+
+// Tenants returns sorted tenants with a client
+func (p *ClientPool) Tenants() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tenants := make([]string, 0, len(p.clients))
+	for tenant := range p.clients {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+
+	return tenants
+}
+
+// Stats returns per-method call statistics of every tenant
+func (p *ClientPool) Stats() map[string]map[string]MethodStats {
+	p.mu.Lock()
+	clients := make(map[string]*AsimovRPC, len(p.clients))
+	for tenant, client := range p.clients {
+		clients[tenant] = client
+	}
+	p.mu.Unlock()
+
+	result := make(map[string]map[string]MethodStats, len(clients))
+	for tenant, client := range clients {
+		result[tenant] = client.Stats()
+	}
+
+	return result
+}
+
+// Close closes shared WebSocket connections, Client fails afterwards
+func (p *ClientPool) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	p.clients = map[string]*AsimovRPC{}
+	p.mu.Unlock()
+
+	return p.ws.close()
+}
@@ -0,0 +1,49 @@
+package asimovrpc
+
+import (
+	"time"
+)
+
+func (s *AsimovRPCTestSuite) TestClientPool() {
+	pool := NewClientPool(s.rpc.url, WithSlowQueryThreshold(time.Hour))
+	pool.Configure("acme", TenantConfig{Budget: &Budget{Window: time.Hour, MaxCalls: 1}})
+
+	s.registerResponse(`"0x1"`, func([]byte) {})
+
+	acme, err := pool.Client("acme")
+	s.Require().Nil(err)
+	same, err := pool.Client("acme")
+	s.Require().Nil(err)
+	s.Require().True(acme == same)
+	globex, err := pool.Client("globex")
+	s.Require().Nil(err)
+	s.Require().True(acme.ws == globex.ws)
+
+	_, err = acme.AsimovBlockNumber()
+	s.Require().Nil(err)
+	_, err = acme.AsimovBlockNumber()
+	s.Require().IsType(BudgetExceededError{}, err)
+	for i := 0; i < 3; i++ {
+		_, err = globex.AsimovBlockNumber()
+		s.Require().Nil(err)
+	}
+
+	s.Require().Equal([]string{"acme", "globex"}, pool.Tenants())
+	stats := pool.Stats()
+	s.Require().Equal(1, stats["acme"]["flow_blockNumber"].Calls)
+	s.Require().Equal(3, stats["globex"]["flow_blockNumber"].Calls)
+	s.Require().Equal(3, globex.StatsByTag(TagTenant)["globex"]["flow_blockNumber"].Calls)
+
+	pool.Configure("acme", TenantConfig{})
+	acme, err = pool.Client("acme")
+	s.Require().Nil(err)
+	_, err = acme.AsimovBlockNumber()
+	s.Require().Nil(err)
+
+	pool.Remove("globex")
+	s.Require().Equal([]string{"acme"}, pool.Tenants())
+
+	s.Require().Nil(pool.Close())
+	_, err = pool.Client("acme")
+	s.Require().Equal(ErrPoolClosed, err)
+}
@@ -0,0 +1,20 @@
+package asimovrpc
+
+// Priority - priority of calls competing for a blocked budget, higher is served first
+type Priority int
+
+// Call priorities
+const (
+	PriorityBackground Priority = -10
+	PriorityNormal     Priority = 0
+	PriorityUser       Priority = 10
+)
+
+// Priority returns a copy of the client whose calls carry given priority.
+// The copy shares budget and all other settings with the original client.
+func (rpc *AsimovRPC) Priority(priority Priority) *AsimovRPC {
+	client := *rpc
+	client.priority = priority
+
+	return &client
+}
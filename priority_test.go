@@ -0,0 +1,49 @@
+package asimovrpc
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetPriority(t *testing.T) {
+	b := newBudget(Budget{Window: 100 * time.Millisecond, MaxCalls: 1, Block: true})
+	_, err := b.take(context.Background(), "flow_blockNumber", PriorityNormal)
+	require.Nil(t, err)
+
+	var mu sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+	for _, priority := range []Priority{PriorityBackground, PriorityUser} {
+		wg.Add(1)
+		go func(priority Priority) {
+			defer wg.Done()
+			_, err := b.take(context.Background(), "flow_blockNumber", priority)
+			require.Nil(t, err)
+			mu.Lock()
+			order = append(order, priority)
+			mu.Unlock()
+		}(priority)
+	}
+
+	require.Eventually(t, func() bool {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return b.waiting[PriorityBackground] == 1 && b.waiting[PriorityUser] == 1
+	}, time.Second, time.Millisecond)
+
+	wg.Wait()
+	require.Equal(t, []Priority{PriorityUser, PriorityBackground}, order)
+}
+
+func TestPriorityClient(t *testing.T) {
+	rpc := NewAsimovRPC("http://127.0.0.1:8545")
+	background := rpc.Priority(PriorityBackground)
+
+	require.Equal(t, PriorityNormal, rpc.priority)
+	require.Equal(t, PriorityBackground, background.priority)
+	require.Equal(t, rpc.stats, background.stats)
+}
@@ -0,0 +1,290 @@
+package asimovrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"unsafe"
+)
+
+// emptyTrieRoot - root hash of empty Merkle Patricia trie
+const emptyTrieRoot = "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"
+
+// AccountProof - account and storage Merkle proofs returned by flow_getProof
+type AccountProof struct {
+	Address      string
+	AccountProof []string
+	Balance      big.Int
+	CodeHash     string
+	Nonce        int
+	StorageHash  string
+	StorageProof []StorageProof
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *AccountProof) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyAccountProof)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return err
+	}
+
+	*p = *(*AccountProof)(unsafe.Pointer(proxy))
+
+	return nil
+}
+
+// StorageProof - Merkle proof of a storage slot
+type StorageProof struct {
+	Key   string
+	Value big.Int
+	Proof []string
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *StorageProof) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyStorageProof)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return err
+	}
+
+	*p = *(*StorageProof)(unsafe.Pointer(proxy))
+
+	return nil
+}
+
+type proxyAccountProof struct {
+	Address      string         `json:"address"`
+	AccountProof []string       `json:"accountProof"`
+	Balance      hexBig         `json:"balance"`
+	CodeHash     string         `json:"codeHash"`
+	Nonce        hexInt         `json:"nonce"`
+	StorageHash  string         `json:"storageHash"`
+	StorageProof []StorageProof `json:"storageProof"`
+}
+
+type proxyStorageProof struct {
+	Key   string   `json:"key"`
+	Value hexBig   `json:"value"`
+	Proof []string `json:"proof"`
+}
+
+// ProofError - Merkle proof does not match the expected root
+type ProofError struct {
+	Key    string // address of account proof or storage key
+	Reason string
+}
+
+func (err ProofError) Error() string {
+	return fmt.Sprintf("Invalid proof of %s: %s", err.Key, err.Reason)
+}
+
+// AsimovGetProof returns the account and storage values of address, including their Merkle proofs.
+// Use AccountProof.Verify to check the proofs against the state root of the block.
+func (rpc *AsimovRPC) AsimovGetProof(address string, storageKeys []string, block BlockNumber) (*AccountProof, error) {
+	if storageKeys == nil {
+		storageKeys = []string{}
+	}
+
+	var proof *AccountProof
+	err := rpc.call("flow_getProof", &proof, address, storageKeys, block)
+	return proof, err
+}
+
+// Verify checks the account proof against stateRoot of the block the proof was requested for, and each
+// storage proof against the storage hash of the account. Accounts are expected to be stored as
+// RLP([nonce, balance, storageHash, codeHash]) under keccak256(address), and storage values as
+// RLP(value) under keccak256(32-byte key), so the proof proves every field of p.
+func (p *AccountProof) Verify(stateRoot string) error {
+	root, err := HexToBytes(stateRoot)
+	if err != nil {
+		return err
+	}
+	address, err := HexToBytes(p.Address)
+	if err != nil {
+		return ProofError{Key: p.Address, Reason: err.Error()}
+	}
+
+	value, err := VerifyMerkleProof(root, Keccak256(address), p.AccountProof)
+	if err != nil {
+		return ProofError{Key: p.Address, Reason: err.Error()}
+	}
+	if value == nil {
+		return p.verifyMissing()
+	}
+
+	expected, err := p.accountRLP()
+	if err != nil {
+		return ProofError{Key: p.Address, Reason: err.Error()}
+	}
+	if !bytes.Equal(value, expected) {
+		return ProofError{Key: p.Address, Reason: "account does not match the proven value"}
+	}
+
+	storageRoot, err := HexToBytes(p.StorageHash)
+	if err != nil {
+		return ProofError{Key: p.Address, Reason: err.Error()}
+	}
+	for _, storage := range p.StorageProof {
+		if err := storage.Verify(storageRoot); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verifyMissing checks that the account absent from the state trie is reported empty
+func (p *AccountProof) verifyMissing() error {
+	if p.Nonce != 0 || p.Balance.Sign() != 0 {
+		return ProofError{Key: p.Address, Reason: "account is not in the state trie"}
+	}
+	for _, storage := range p.StorageProof {
+		if storage.Value.Sign() != 0 {
+			return ProofError{Key: storage.Key, Reason: "account is not in the state trie"}
+		}
+	}
+
+	return nil
+}
+
+func (p *AccountProof) accountRLP() ([]byte, error) {
+	storageHash, err := HexToBytes(p.StorageHash)
+	if err != nil {
+		return nil, err
+	}
+	codeHash, err := HexToBytes(p.CodeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return rlpEncode([]interface{}{p.Nonce, &p.Balance, storageHash, codeHash})
+}
+
+// Verify checks the storage proof against storageRoot, the storage hash of the account
+func (p StorageProof) Verify(storageRoot []byte) error {
+	key, err := HexToBytes(p.Key)
+	if err != nil || len(key) > 32 {
+		return ProofError{Key: p.Key, Reason: "invalid storage key"}
+	}
+	slot := make([]byte, 32)
+	copy(slot[32-len(key):], key)
+
+	value, err := VerifyMerkleProof(storageRoot, Keccak256(slot), p.Proof)
+	if err != nil {
+		return ProofError{Key: p.Key, Reason: err.Error()}
+	}
+	if value == nil {
+		if p.Value.Sign() != 0 {
+			return ProofError{Key: p.Key, Reason: "slot is not in the storage trie"}
+		}
+		return nil
+	}
+
+	expected, err := rlpEncode(&p.Value)
+	if err != nil {
+		return ProofError{Key: p.Key, Reason: err.Error()}
+	}
+	if !bytes.Equal(value, expected) {
+		return ProofError{Key: p.Key, Reason: "value does not match the proven value"}
+	}
+
+	return nil
+}
+
+// VerifyMerkleProof walks proof, hex encoded RLP nodes of a Merkle Patricia trie starting with the root node,
+// along key and returns the value stored under key, nil if the proof shows the key is not in the trie
+func VerifyMerkleProof(root, key []byte, proof []string) ([]byte, error) {
+	if len(proof) == 0 {
+		if empty, _ := HexToBytes(emptyTrieRoot); bytes.Equal(root, empty) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("proof is empty")
+	}
+
+	path := keyNibbles(key)
+	reference, next := interface{}(root), 0
+	for {
+		var node []interface{}
+		switch ref := reference.(type) {
+		case []interface{}:
+			// nodes shorter than 32 bytes are embedded in their parent
+			node = ref
+		case []byte:
+			if len(ref) == 0 {
+				return nil, nil
+			}
+			if next >= len(proof) {
+				return nil, fmt.Errorf("proof ends before the key")
+			}
+			encoded, err := HexToBytes(proof[next])
+			if err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(Keccak256(encoded), ref) {
+				return nil, fmt.Errorf("node %d does not match its hash", next)
+			}
+			next++
+			decoded, err := rlpDecode(encoded)
+			if err != nil {
+				return nil, err
+			}
+			var ok bool
+			if node, ok = decoded.([]interface{}); !ok {
+				return nil, fmt.Errorf("node %d is not a list", next-1)
+			}
+		}
+
+		switch len(node) {
+		case 17:
+			if len(path) == 0 {
+				value, _ := node[16].([]byte)
+				if len(value) == 0 {
+					return nil, nil
+				}
+				return value, nil
+			}
+			reference, path = node[path[0]], path[1:]
+		case 2:
+			compact, ok := node[0].([]byte)
+			if !ok || len(compact) == 0 {
+				return nil, fmt.Errorf("invalid node path")
+			}
+			prefix, leaf := compactNibbles(compact)
+			if len(path) < len(prefix) || !bytes.Equal(path[:len(prefix)], prefix) {
+				return nil, nil
+			}
+			path = path[len(prefix):]
+			if leaf {
+				value, ok := node[1].([]byte)
+				if len(path) != 0 || !ok {
+					return nil, nil
+				}
+				return value, nil
+			}
+			reference = node[1]
+		default:
+			return nil, fmt.Errorf("invalid node with %d items", len(node))
+		}
+	}
+}
+
+func keyNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, len(key)*2)
+	for _, b := range key {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+
+	return nibbles
+}
+
+// compactNibbles decodes hex-prefix encoded path of extension or leaf node
+func compactNibbles(compact []byte) ([]byte, bool) {
+	flag := compact[0] >> 4
+	nibbles := keyNibbles(compact)[2:]
+	if flag&1 == 1 {
+		nibbles = append([]byte{compact[0] & 0x0f}, nibbles...)
+	}
+
+	return nibbles, flag&2 == 2
+}
@@ -0,0 +1,212 @@
+package asimovrpc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// compactPath hex-prefix encodes nibbles of extension or leaf node
+func compactPath(nibbles []byte, leaf bool) []byte {
+	flag := byte(0)
+	if leaf {
+		flag = 2
+	}
+	if len(nibbles)%2 == 1 {
+		flag |= 1
+		nibbles = append([]byte{flag}, nibbles...)
+	} else {
+		nibbles = append([]byte{flag, 0}, nibbles...)
+	}
+
+	compact := make([]byte, len(nibbles)/2)
+	for i := range compact {
+		compact[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+	return compact
+}
+
+func trieLeaf(nibbles, value []byte) []interface{} {
+	return []interface{}{compactPath(nibbles, true), value}
+}
+
+func trieBranch(children map[byte]interface{}) []interface{} {
+	node := make([]interface{}, 17)
+	for i := range node {
+		node[i] = []byte{}
+	}
+	for nibble, child := range children {
+		node[nibble] = child
+	}
+	return node
+}
+
+// trieNode returns RLP of node as hex and its hash
+func trieNode(t *testing.T, node []interface{}) (string, []byte) {
+	encoded, err := rlpEncode(node)
+	require.Nil(t, err)
+	return "0x" + hex.EncodeToString(encoded), Keccak256(encoded)
+}
+
+func mustRLP(t *testing.T, item interface{}) []byte {
+	encoded, err := rlpEncode(item)
+	require.Nil(t, err)
+	return encoded
+}
+
+// testAccountProof builds state trie with two accounts in a branch and returns proof of the first one
+// with storage slot 0 set to 42, the state root, and an address missing from the trie
+func testAccountProof(t *testing.T) (AccountProof, string, string) {
+	slot := make([]byte, 32)
+	storageLeaf, storageRoot := trieNode(t, trieLeaf(keyNibbles(Keccak256(slot)), mustRLP(t, big.NewInt(42))))
+
+	address := "0x" + strings.Repeat("11", 20)
+	codeHash := Keccak256()
+	proof := AccountProof{
+		Address:     address,
+		Balance:     *big.NewInt(1000),
+		CodeHash:    "0x" + hex.EncodeToString(codeHash),
+		Nonce:       5,
+		StorageHash: "0x" + hex.EncodeToString(storageRoot),
+		StorageProof: []StorageProof{
+			{Key: "0x0", Value: *big.NewInt(42), Proof: []string{storageLeaf}},
+		},
+	}
+
+	// place the other account and leave the missing one under different first nibbles
+	path := keyNibbles(Keccak256(mustHex(t, address)))
+	var other, missing string
+	for i := 0x22; other == "" || missing == ""; i++ {
+		candidate := "0x" + strings.Repeat(fmt.Sprintf("%02x", i), 20)
+		nibble := keyNibbles(Keccak256(mustHex(t, candidate)))[0]
+		if nibble == path[0] {
+			continue
+		}
+		if other == "" {
+			other = candidate
+		} else if nibble != keyNibbles(Keccak256(mustHex(t, other)))[0] {
+			missing = candidate
+		}
+	}
+	otherPath := keyNibbles(Keccak256(mustHex(t, other)))
+
+	account := mustRLP(t, []interface{}{5, big.NewInt(1000), storageRoot, codeHash})
+	otherAccount := mustRLP(t, []interface{}{1, big.NewInt(7), storageRoot, codeHash})
+	accountLeaf, accountHash := trieNode(t, trieLeaf(path[1:], account))
+	_, otherHash := trieNode(t, trieLeaf(otherPath[1:], otherAccount))
+	branch, root := trieNode(t, trieBranch(map[byte]interface{}{path[0]: accountHash, otherPath[0]: otherHash}))
+
+	proof.AccountProof = []string{branch, accountLeaf}
+	return proof, "0x" + hex.EncodeToString(root), missing
+}
+
+func mustHex(t *testing.T, value string) []byte {
+	data, err := HexToBytes(value)
+	require.Nil(t, err)
+	return data
+}
+
+func TestAccountProofVerify(t *testing.T) {
+	proof, root, _ := testAccountProof(t)
+	require.Nil(t, proof.Verify(root))
+
+	tampered := proof
+	tampered.Balance = *big.NewInt(1001)
+	require.Equal(t, ProofError{Key: proof.Address, Reason: "account does not match the proven value"}, tampered.Verify(root))
+
+	tampered = proof
+	tampered.StorageProof = []StorageProof{{Key: "0x0", Value: *big.NewInt(43), Proof: proof.StorageProof[0].Proof}}
+	require.EqualError(t, tampered.Verify(root), "Invalid proof of 0x0: value does not match the proven value")
+
+	tampered = proof
+	tampered.AccountProof = []string{proof.AccountProof[0], proof.AccountProof[1][:len(proof.AccountProof[1])-2] + "00"}
+	require.EqualError(t, tampered.Verify(root), fmt.Sprintf("Invalid proof of %s: node 1 does not match its hash", proof.Address))
+
+	tampered = proof
+	tampered.AccountProof = proof.AccountProof[:1]
+	require.EqualError(t, tampered.Verify(root), fmt.Sprintf("Invalid proof of %s: proof ends before the key", proof.Address))
+
+	require.NotNil(t, proof.Verify("0x"+strings.Repeat("00", 32)))
+
+	// slot missing from the storage trie must be zero
+	absent := proof
+	absent.StorageProof = []StorageProof{{Key: "0x1", Proof: proof.StorageProof[0].Proof}}
+	require.Nil(t, absent.Verify(root))
+	absent.StorageProof[0].Value = *big.NewInt(1)
+	require.EqualError(t, absent.Verify(root), "Invalid proof of 0x1: slot is not in the storage trie")
+}
+
+func TestAccountProofMissing(t *testing.T) {
+	proof, root, missing := testAccountProof(t)
+
+	empty := AccountProof{Address: missing, AccountProof: proof.AccountProof[:1], StorageHash: emptyTrieRoot}
+	require.Nil(t, empty.Verify(root))
+
+	empty.Balance = *big.NewInt(1)
+	require.EqualError(t, empty.Verify(root), fmt.Sprintf("Invalid proof of %s: account is not in the state trie", missing))
+}
+
+func TestVerifyMerkleProof(t *testing.T) {
+	// leaf shorter than 32 bytes is embedded in the branch
+	branch, root := trieNode(t, trieBranch(map[byte]interface{}{1: trieLeaf([]byte{2}, []byte("v"))}))
+
+	value, err := VerifyMerkleProof(root, []byte{0x12}, []string{branch})
+	require.Nil(t, err)
+	require.Equal(t, []byte("v"), value)
+
+	value, err = VerifyMerkleProof(root, []byte{0x13}, []string{branch})
+	require.Nil(t, err)
+	require.Nil(t, value)
+
+	value, err = VerifyMerkleProof(mustHex(t, emptyTrieRoot), []byte{0x12}, nil)
+	require.Nil(t, err)
+	require.Nil(t, value)
+
+	_, err = VerifyMerkleProof(root, []byte{0x12}, nil)
+	require.EqualError(t, err, "proof is empty")
+}
+
+func TestRLPDecode(t *testing.T) {
+	items := []interface{}{
+		[]byte{},
+		[]byte{0x7f},
+		[]byte("dog"),
+		[]interface{}{},
+		[]interface{}{[]byte("cat"), []interface{}{[]byte("dog")}},
+		[]byte(strings.Repeat("a", 56)),
+	}
+
+	for _, item := range items {
+		decoded, err := rlpDecode(mustRLP(t, item))
+		require.Nil(t, err)
+		require.Equal(t, item, decoded)
+	}
+
+	_, err := rlpDecode([]byte{0x83, 'd', 'o'})
+	require.NotNil(t, err)
+	_, err = rlpDecode([]byte{0x01, 0x02})
+	require.NotNil(t, err)
+}
+
+func (s *AsimovRPCTestSuite) TestAsimovGetProof() {
+	s.registerResponse(`{"address": "0x1", "accountProof": ["0xf8"], "balance": "0x10", "codeHash": "0xc5", "nonce": "0x2", "storageHash": "0x56", "storageProof": [{"key": "0x0", "value": "0x2a", "proof": ["0xe2"]}]}`, func(body []byte) {
+		s.methodEqual(body, "flow_getProof")
+		s.paramsEqual(body, `["0x1", [], "latest"]`)
+	})
+
+	proof, err := s.rpc.AsimovGetProof("0x1", nil, Latest())
+	s.Require().Nil(err)
+	s.Require().Equal(&AccountProof{
+		Address:      "0x1",
+		AccountProof: []string{"0xf8"},
+		Balance:      *big.NewInt(16),
+		CodeHash:     "0xc5",
+		Nonce:        2,
+		StorageHash:  "0x56",
+		StorageProof: []StorageProof{{Key: "0x0", Value: *big.NewInt(42), Proof: []string{"0xe2"}}},
+	}, proof)
+}
@@ -0,0 +1,42 @@
+package asimovrpc
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// lockedRand - rand.Rand safe for concurrent use, shared by client copies
+type lockedRand struct {
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func newLockedRand(source rand.Source) *lockedRand {
+	return &lockedRand{rand: rand.New(source)}
+}
+
+func (r *lockedRand) Int63n(n int64) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.rand.Int63n(n)
+}
+
+var defaultRand = newLockedRand(rand.NewSource(time.Now().UnixNano()))
+
+// WithRandSource set source of randomness used for poll jitter and snapshot sampling, making it reproducible in tests
+func WithRandSource(source rand.Source) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if source == nil {
+			rpc.invalidOption("WithRandSource", "source is nil")
+			return
+		}
+		rpc.rand = newLockedRand(source)
+	}
+}
+
+// WithSeed seed source of randomness used for poll jitter and snapshot sampling
+func WithSeed(seed int64) func(rpc *AsimovRPC) {
+	return WithRandSource(rand.NewSource(seed))
+}
@@ -0,0 +1,26 @@
+package asimovrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSeed(t *testing.T) {
+	sequence := func(rpc *AsimovRPC) []time.Duration {
+		var result []time.Duration
+		for i := 0; i < 5; i++ {
+			result = append(result, jitter(rpc.rand, time.Second))
+		}
+		return result
+	}
+
+	first := sequence(NewAsimovRPC("http://127.0.0.1:8545", WithSeed(42)))
+	second := sequence(NewAsimovRPC("http://127.0.0.1:8545", WithSeed(42)))
+	require.Equal(t, first, second)
+	require.NotEqual(t, first, sequence(NewAsimovRPC("http://127.0.0.1:8545", WithSeed(43))))
+
+	_, err := NewClient("http://127.0.0.1:8545", WithRandSource(nil))
+	require.EqualError(t, err, "asimovrpc: invalid option WithRandSource: source is nil")
+}
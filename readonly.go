@@ -0,0 +1,43 @@
+package asimovrpc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// stateChangingMethods - methods rejected in read-only mode, entries ending with _ are namespaces
+var stateChangingMethods = []string{
+	"flow_sendTransaction",
+	"flow_sendRawTransaction",
+	"personal_",
+	"admin_addPeer",
+	"miner_",
+}
+
+// ReadOnlyError - state-changing method called on a read-only client
+type ReadOnlyError struct {
+	Method string
+}
+
+func (err ReadOnlyError) Error() string {
+	return fmt.Sprintf("Method %s is not allowed in read-only mode", err.Method)
+}
+
+// WithReadOnly reject state-changing methods (sendTransaction, sendRawTransaction, personal_*, miner_*, admin_addPeer)
+// with ReadOnlyError before they reach the node
+func WithReadOnly(enabled bool) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		rpc.readOnly = enabled
+	}
+}
+
+// StateChanging returns true if method modifies node or chain state
+func StateChanging(method string) bool {
+	for _, m := range stateChangingMethods {
+		if method == m || strings.HasSuffix(m, "_") && strings.HasPrefix(method, m) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,43 @@
+package asimovrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateChanging(t *testing.T) {
+	for _, method := range []string{"flow_sendTransaction", "flow_sendRawTransaction", "personal_unlockAccount", "miner_start"} {
+		require.True(t, StateChanging(method), method)
+	}
+	for _, method := range []string{"flow_call", "flow_getBalance", "flow_sendTransactionCount", "personal", "net_version"} {
+		require.False(t, StateChanging(method), method)
+	}
+}
+
+func (s *AsimovRPCTestSuite) TestReadOnly() {
+	rpc := New(s.rpc.url, WithReadOnly(true))
+
+	calls := 0
+	s.registerResponse(`"0x1"`, func([]byte) { calls++ })
+
+	_, err := rpc.AsimovSendRawTransaction("0x00")
+	s.Require().Equal(ReadOnlyError{Method: "flow_sendRawTransaction"}, err)
+	s.Require().Equal("Method flow_sendRawTransaction is not allowed in read-only mode", err.Error())
+	_, err = rpc.Call("personal_unlockAccount", "0x6247cf0412c6462da2a51d05139e2a3c6c630f0a", "secret", 60)
+	s.Require().IsType(ReadOnlyError{}, err)
+
+	_, err = rpc.AsimovBlockNumber()
+	s.Require().Nil(err)
+	s.Require().Equal(1, calls)
+
+	var requests []string
+	s.registerBatchResponder(&requests)
+	var number string
+	batch := rpc.NewBatch().Add("flow_blockNumber", &number).Add("miner_stop", nil)
+	s.Require().Nil(batch.Execute())
+	s.Require().Nil(batch.Err(0))
+	s.Require().Equal(ReadOnlyError{Method: "miner_stop"}, batch.Err(1))
+	s.Require().Len(requests, 1)
+	s.Require().NotContains(requests[0], "miner_stop")
+}
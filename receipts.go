@@ -0,0 +1,117 @@
+package asimovrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// BlockReference - block given by number or tag (BlockNumber) or by hash (BlockHash)
+type BlockReference interface {
+	blockReference()
+}
+
+// BlockHash - block given by its hash
+type BlockHash string
+
+func (BlockNumber) blockReference() {}
+func (BlockHash) blockReference()   {}
+
+// methodSupport - methods the node answered with ErrMethodNotFound, so fallbacks don't ask again
+type methodSupport struct {
+	missing sync.Map
+}
+
+func (s *methodSupport) supported(method string) bool {
+	_, missing := s.missing.Load(method)
+	return !missing
+}
+
+func (s *methodSupport) check(method string, err error) error {
+	if errors.Is(err, ErrMethodNotFound) {
+		s.missing.Store(method, true)
+	}
+
+	return err
+}
+
+// AsimovGetBlockReceipts returns receipts of all transactions of block in transaction order, nil if the
+// block is not found. Nodes without flow_getBlockReceipts get the block and its receipts in batches of
+// WithBatchSize flow_getTransactionReceipt calls instead; after the first miss the client goes straight
+// to the fallback.
+func (rpc *AsimovRPC) AsimovGetBlockReceipts(block BlockReference) ([]TransactionReceipt, error) {
+	if rpc.methods.supported("flow_getBlockReceipts") {
+		result, err := rpc.RawCall("flow_getBlockReceipts", block)
+		if err == nil {
+			if bytes.Equal(result, []byte("null")) {
+				return nil, nil
+			}
+			var found []*TransactionReceipt
+			if err := json.Unmarshal(result, &found); err != nil {
+				return nil, err
+			}
+			receipts := make([]TransactionReceipt, len(found))
+			for i, receipt := range found {
+				if receipt == nil {
+					return nil, fmt.Errorf("Receipt %d of block %v not found", i, block)
+				}
+				receipts[i] = *receipt
+			}
+			rpc.applyReceiptRules(receipts)
+			return receipts, nil
+		}
+		if !errors.Is(rpc.methods.check("flow_getBlockReceipts", err), ErrMethodNotFound) {
+			return nil, err
+		}
+	}
+
+	return rpc.batchBlockReceipts(block)
+}
+
+func (rpc *AsimovRPC) batchBlockReceipts(block BlockReference) ([]TransactionReceipt, error) {
+	var found *Block
+	var err error
+	switch block := block.(type) {
+	case BlockHash:
+		found, err = rpc.AsimovGetBlockByHash(string(block), false)
+	case BlockNumber:
+		found, err = rpc.AsimovGetBlockByNumber(block, false)
+	default:
+		return nil, fmt.Errorf("Unsupported block reference %T", block)
+	}
+	if err != nil || found == nil {
+		return nil, err
+	}
+
+	receipts := make([]*TransactionReceipt, len(found.Transactions))
+	batch := rpc.NewBatch()
+	for i, transaction := range found.Transactions {
+		batch.Add("flow_getTransactionReceipt", &receipts[i], transaction.Hash)
+	}
+	if err := batch.ExecuteContext(rpc.context()); err != nil {
+		return nil, err
+	}
+
+	result := make([]TransactionReceipt, len(receipts))
+	for i, receipt := range receipts {
+		if err := batch.Err(i); err != nil {
+			return nil, err
+		}
+		if receipt == nil {
+			return nil, fmt.Errorf("Receipt of transaction %s not found", found.Transactions[i].Hash)
+		}
+		result[i] = *receipt
+	}
+	rpc.applyReceiptRules(result)
+
+	return result, nil
+}
+
+// applyReceiptRules applies rules of the block of every receipt (see Rules.applyReceipt)
+func (rpc *AsimovRPC) applyReceiptRules(receipts []TransactionReceipt) {
+	for i := range receipts {
+		rpc.Rules(receipts[i].BlockNumber).applyReceipt(&receipts[i])
+	}
+}
@@ -0,0 +1,77 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func TestAsimovGetBlockReceipts(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	node.Handle("flow_getBlockReceipts", []map[string]interface{}{
+		{"transactionHash": "0xa1", "transactionIndex": "0x0", "blockNumber": "0x10", "gasUsed": "0x5208", "status": "0x1"},
+		{"transactionHash": "0xa2", "transactionIndex": "0x1", "blockNumber": "0x10", "gasUsed": "0x5208", "status": "0x0"},
+	})
+	rpc := New(node.URL)
+
+	receipts, err := rpc.AsimovGetBlockReceipts(Latest())
+	require.Nil(t, err)
+	require.Len(t, receipts, 2)
+	require.Equal(t, "0xa2", receipts[1].TransactionHash)
+	require.Equal(t, 16, receipts[1].BlockNumber)
+	require.True(t, node.AssertParams(t, "flow_getBlockReceipts", "latest"))
+
+	_, err = rpc.Flow().GetBlockReceipts(BlockHash("0xb1"))
+	require.Nil(t, err)
+	require.True(t, node.AssertParams(t, "flow_getBlockReceipts", "0xb1"))
+	require.Empty(t, node.Calls("flow_getTransactionReceipt"))
+}
+
+func TestAsimovGetBlockReceiptsFallback(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	node.HandleFunc("flow_getBlockByNumber", func(params []json.RawMessage) (interface{}, error) {
+		var number string
+		json.Unmarshal(params[0], &number)
+		if number != "0x10" {
+			return nil, nil
+		}
+		return map[string]interface{}{"number": "0x10", "transactions": []string{"0xa1", "0xa2", "0xa3"}}, nil
+	})
+	missingReceipt := false
+	node.HandleFunc("flow_getTransactionReceipt", func(params []json.RawMessage) (interface{}, error) {
+		var hash string
+		json.Unmarshal(params[0], &hash)
+		if hash == "0xa3" && missingReceipt {
+			return nil, nil
+		}
+		return map[string]interface{}{"transactionHash": hash, "blockNumber": "0x10"}, nil
+	})
+	rpc := New(node.URL, WithBatchSize(2))
+
+	receipts, err := rpc.AsimovGetBlockReceipts(Number(16))
+	require.Nil(t, err)
+	require.Len(t, receipts, 3)
+	for i, hash := range []string{"0xa1", "0xa2", "0xa3"} {
+		require.Equal(t, hash, receipts[i].TransactionHash)
+	}
+	require.Len(t, node.Calls("flow_getBlockReceipts"), 1)
+	require.Len(t, node.Calls("flow_getTransactionReceipt"), 3)
+
+	// the node is not asked for block receipts again
+	receipts, err = rpc.WithContext(context.Background()).AsimovGetBlockReceipts(Number(17))
+	require.Nil(t, err)
+	require.Nil(t, receipts)
+	require.Len(t, node.Calls("flow_getBlockReceipts"), 1)
+
+	missingReceipt = true
+	_, err = rpc.AsimovGetBlockReceipts(Number(16))
+	require.EqualError(t, err, "Receipt of transaction 0xa3 not found")
+}
@@ -0,0 +1,291 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultConfigWatchInterval - default interval between config file checks
+const DefaultConfigWatchInterval = 5 * time.Second
+
+// settings - runtime-reloadable client settings, shared by the client and its copies
+type settings struct {
+	url                string
+	endpoints          *endpointSet
+	timeout            time.Duration
+	debugEnabled       bool
+	debugFormat        DebugFormat
+	debugPayloadLimit  int
+	slowQueryThreshold time.Duration
+	budget             *budget
+	gasCaps            GasCaps
+}
+
+// UpdateConfig applies the runtime-reloadable part of cfg (url/endpoints, timeout, debug settings,
+// slow query threshold, budget and gas caps) without recreating the client. All of them are swapped at once,
+// and apply to copies made with WithContext, Agent and Priority too.
+// Endpoints of cfg replace static endpoints of an earlier config, endpoints of other providers set
+// with WithEndpointProvider are kept and conflict with endpoints of cfg. Failover clients accept
+// neither endpoints nor another url.
+// The budget of cfg replaces limits of the current budget keeping its weights and usage of the current window,
+// calls already waiting for the old limits are served by them. A config without budget or gas caps removes them.
+// Transport, user agent, diagnostics, auth, retries, response and batch sizes, failover and access settings
+// are fixed at construction.
+func (rpc *AsimovRPC) UpdateConfig(cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	format, _ := parseDebugFormat(cfg.DebugFormat)
+	limit := cfg.DebugPayloadLimit
+	if limit == 0 {
+		limit = DefaultDebugPayloadLimit
+	}
+
+	rpc.mu.Lock()
+	defer rpc.mu.Unlock()
+
+	next := *rpc.settings
+	if cfg.URL != "" {
+		next.url = cfg.URL
+	}
+	if _, static := endpointProvider(next.endpoints).(StaticEndpoints); next.endpoints != nil && !static {
+		if len(cfg.Endpoints) > 0 {
+			return errors.New("config: endpoints conflict with WithEndpointProvider")
+		}
+	} else if len(cfg.Endpoints) > 0 {
+		next.endpoints = newEndpointSet(StaticEndpoints(cfg.Endpoints), cfg.EndpointRefresh)
+		next.endpoints.clock = rpc.clock
+	} else {
+		next.endpoints = nil
+	}
+	if next.url == "" {
+		next.url = cfg.Endpoints[0]
+	}
+	if rpc.failover != nil && next.url != rpc.url {
+		return errors.New("config: url of a WithFailover client can not be reloaded")
+	}
+
+	next.timeout = cfg.Timeout
+	next.debugEnabled = cfg.Debug
+	next.debugFormat = format
+	next.debugPayloadLimit = limit
+	next.slowQueryThreshold = cfg.SlowQueryThreshold
+	next.gasCaps = cfg.gasCaps()
+	next.budget = nil
+	if b := cfg.Budget; b != nil {
+		next.budget = reloadBudget(rpc.budget, Budget{Window: b.Window, MaxCalls: b.MaxCalls, MaxCredits: b.MaxCredits, Block: b.Block, Warnings: b.Warnings}, rpc.clock)
+		if message := next.budget.validate(); message != "" {
+			return fmt.Errorf("config: budget %s", message)
+		}
+	}
+
+	candidate := *rpc
+	candidate.settings = &next
+	if err := candidate.validateOptions(); err != nil {
+		return err
+	}
+	*rpc.settings = next
+
+	return nil
+}
+
+// endpointProvider returns provider of s, nil if s is nil
+func endpointProvider(s *endpointSet) EndpointProvider {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.provider
+}
+
+// ConfigWatcher - reloads client config when the watched file changes
+type ConfigWatcher struct {
+	rpc      *AsimovRPC
+	path     string
+	interval time.Duration
+	modified time.Time
+	pending  *Config
+	errors   chan error
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+
+	mu       sync.Mutex
+	started  bool
+	handlers []func(Config)
+	policy   PanicPolicy
+}
+
+// WatchConfigFile creates and starts config watcher, see NewConfigWatcher
+func (rpc *AsimovRPC) WatchConfigFile(path string, interval time.Duration) *ConfigWatcher {
+	w := rpc.NewConfigWatcher(path, interval)
+	w.Start(context.Background())
+
+	return w
+}
+
+// NewConfigWatcher creates watcher checking config file every interval once started and applying it
+// with UpdateConfig when modified. Load and validation errors are delivered on Errors() and keep the current config.
+func (rpc *AsimovRPC) NewConfigWatcher(path string, interval time.Duration) *ConfigWatcher {
+	if interval <= 0 {
+		interval = DefaultConfigWatchInterval
+	}
+
+	w := &ConfigWatcher{
+		rpc:      rpc,
+		path:     path,
+		interval: interval,
+		errors:   make(chan error, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if info, err := os.Stat(path); err == nil {
+		w.modified = info.ModTime()
+	}
+
+	return w
+}
+
+// Start starts watching until Stop is called or ctx is done
+func (w *ConfigWatcher) Start(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.started {
+		return ErrAlreadyStarted
+	}
+	select {
+	case <-w.stop:
+		return ErrStopped
+	default:
+	}
+	w.started = true
+
+	go w.run(ctx)
+	return nil
+}
+
+// OnReload registers handler called with every successfully applied config.
+// Handler panics are recovered and reported on Errors() as PanicError.
+func (w *ConfigWatcher) OnReload(handler func(Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.handlers = append(w.handlers, handler)
+}
+
+// SetPanicPolicy sets whether the watcher keeps running after a handler panic (PanicContinue by default)
+func (w *ConfigWatcher) SetPanicPolicy(policy PanicPolicy) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.policy = policy
+}
+
+// Errors returns channel of reload errors, errors are dropped while the channel is full
+func (w *ConfigWatcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Done returns channel closed when the watcher goroutine exits
+func (w *ConfigWatcher) Done() <-chan struct{} {
+	return w.done
+}
+
+// Stop stops watching and waits for the watcher goroutine to exit
+func (w *ConfigWatcher) Stop() {
+	w.mu.Lock()
+	started := w.started
+	w.stopOnce.Do(func() {
+		close(w.stop)
+		if !started {
+			close(w.done)
+		}
+	})
+	w.mu.Unlock()
+
+	<-w.done
+}
+
+func (w *ConfigWatcher) run(ctx context.Context) {
+	defer close(w.done)
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-w.rpc.clock.After(w.interval):
+			if err := w.check(); err != nil {
+				w.report(err)
+			}
+			if !w.notify() {
+				return
+			}
+		}
+	}
+}
+
+func (w *ConfigWatcher) report(err error) {
+	select {
+	case w.errors <- err:
+	default:
+	}
+}
+
+// notify calls reload handlers with pending config, returning false if the watcher must stop
+func (w *ConfigWatcher) notify() bool {
+	if w.pending == nil {
+		return true
+	}
+	cfg := *w.pending
+	w.pending = nil
+
+	w.mu.Lock()
+	handlers := make([]func(Config), len(w.handlers))
+	copy(handlers, w.handlers)
+	policy := w.policy
+	w.mu.Unlock()
+
+	for _, handler := range handlers {
+		if err := invoke("OnReload", func() { handler(cfg) }); err != nil {
+			w.report(err)
+			if policy == PanicStop {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (w *ConfigWatcher) check() error {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return err
+	}
+	if !info.ModTime().After(w.modified) {
+		return nil
+	}
+	w.modified = info.ModTime()
+
+	cfg, err := FromFile(w.path)
+	if err != nil {
+		return err
+	}
+
+	if err := w.rpc.UpdateConfig(cfg); err != nil {
+		return err
+	}
+	w.pending = &cfg
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func TestUpdateConfig(t *testing.T) {
+	rpc := New("http://a:8545")
+
+	require.NotNil(t, rpc.UpdateConfig(Config{}))
+
+	require.Nil(t, rpc.UpdateConfig(Config{
+		Endpoints:          []string{"http://b:8545", "http://c:8545"},
+		Timeout:            time.Second,
+		Debug:              true,
+		DebugFormat:        "json",
+		SlowQueryThreshold: time.Minute,
+	}))
+	require.Equal(t, "http://a:8545", rpc.URL())
+	endpoints, err := rpc.Endpoints()
+	require.Nil(t, err)
+	require.Equal(t, []string{"http://b:8545", "http://c:8545"}, endpoints)
+	require.True(t, rpc.debugEnabled)
+	require.Equal(t, DebugJSON, rpc.debugFormat)
+	require.Equal(t, DefaultDebugPayloadLimit, rpc.debugPayloadLimit)
+	require.Equal(t, time.Second, rpc.timeout)
+	require.Equal(t, time.Minute, rpc.slowQueryThreshold)
+
+	require.Nil(t, rpc.UpdateConfig(Config{Endpoints: []string{"http://d:8545"}}))
+	endpoints, err = rpc.Endpoints()
+	require.Nil(t, err)
+	require.Equal(t, []string{"http://d:8545"}, endpoints)
+	require.False(t, rpc.debugEnabled)
+
+	require.Nil(t, rpc.UpdateConfig(Config{URL: "http://e:8545"}))
+	require.Equal(t, "http://e:8545", rpc.endpoint())
+}
+
+func TestUpdateConfigBudget(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_blockNumber", "0x1")
+
+	weights := CostWeights{Default: 1}
+	rpc := New(node.URL, WithBudget(Budget{Window: time.Minute, MaxCalls: 2, Weights: weights}))
+	copy := rpc.Priority(PriorityUser)
+	_, err := rpc.AsimovBlockNumber()
+	require.Nil(t, err)
+
+	// unchanged limits keep the budget
+	b := rpc.budget
+	require.Nil(t, rpc.UpdateConfig(Config{URL: node.URL, Budget: &BudgetConfig{Window: time.Minute, MaxCalls: 2}}))
+	require.Same(t, b, rpc.budget)
+
+	// usage and weights of the window are kept by new limits
+	require.Nil(t, rpc.UpdateConfig(Config{URL: node.URL, Budget: &BudgetConfig{Window: time.Minute, MaxCalls: 3}}))
+	require.False(t, b == rpc.budget)
+	require.Equal(t, weights, rpc.budget.Weights)
+	usage, _ := copy.BudgetUsage()
+	require.Equal(t, 1, usage.Calls)
+	for i := 0; i < 2; i++ {
+		_, err = copy.AsimovBlockNumber()
+		require.Nil(t, err)
+	}
+	_, err = copy.AsimovBlockNumber()
+	require.True(t, errors.As(err, &BudgetExceededError{}))
+
+	err = rpc.UpdateConfig(Config{URL: node.URL, Budget: &BudgetConfig{Window: time.Minute}})
+	require.EqualError(t, err, "config: budget either max calls or max credits required")
+	require.Equal(t, 3, rpc.budget.MaxCalls)
+
+	require.Nil(t, rpc.UpdateConfig(Config{URL: node.URL}))
+	_, ok := copy.BudgetUsage()
+	require.False(t, ok)
+	_, err = copy.AsimovBlockNumber()
+	require.Nil(t, err)
+}
+
+func TestUpdateConfigGasCaps(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_sendTransaction", "0x1234")
+
+	rpc := New(node.URL)
+	copy := rpc.WithContext(context.Background())
+	transaction := T{From: "0x1", To: "0x2", Gas: 50000, GasPrice: big.NewInt(30)}
+	_, err := copy.AsimovSendTransaction(transaction)
+	require.Nil(t, err)
+
+	require.Nil(t, rpc.UpdateConfig(Config{URL: node.URL, MaxGas: 40000}))
+	_, err = copy.AsimovSendTransaction(transaction)
+	require.Equal(t, "gas", err.(GasCapError).Field)
+
+	require.Nil(t, rpc.UpdateConfig(Config{URL: node.URL, MaxGasPrice: 20}))
+	_, err = copy.AsimovSendTransaction(transaction)
+	require.Equal(t, GasCapError{Method: "flow_sendTransaction", Field: "gasPrice", Value: big.NewInt(30), Cap: big.NewInt(20)}, err)
+
+	// a failing update keeps all settings
+	require.NotNil(t, rpc.UpdateConfig(Config{URL: node.URL, MaxGas: 60000, Budget: &BudgetConfig{Window: time.Minute}}))
+	require.Equal(t, GasCaps{MaxGasPrice: big.NewInt(20)}, rpc.gasCaps)
+
+	require.Nil(t, rpc.UpdateConfig(Config{URL: node.URL}))
+	_, err = copy.AsimovSendTransaction(transaction)
+	require.Nil(t, err)
+	require.Len(t, node.Calls("flow_sendTransaction"), 2)
+}
+
+func TestUpdateConfigShared(t *testing.T) {
+	// copies see updates of the original client
+	rpc := New("http://a:8545")
+	copies := []*AsimovRPC{rpc.WithContext(context.Background()), rpc.Agent("app"), rpc.Priority(PriorityUser)}
+	require.Nil(t, rpc.UpdateConfig(Config{URL: "http://b:8545", Timeout: time.Second, Debug: true}))
+	for _, client := range copies {
+		require.Equal(t, "http://b:8545", client.URL())
+		require.Equal(t, time.Second, client.timeout)
+		require.True(t, client.debugEnabled)
+	}
+
+	// static endpoints, such as those of NewFromConfig, are replaced by the config
+	rpc = New("http://a:8545", WithEndpointProvider(StaticEndpoints{"http://b:8545"}, 0))
+	require.Nil(t, rpc.UpdateConfig(Config{Endpoints: []string{"http://c:8545"}}))
+	require.Nil(t, rpc.UpdateConfig(Config{URL: "http://a:8545"}))
+	require.Equal(t, "http://a:8545", rpc.endpoint())
+
+	// endpoints of other providers are kept and conflict with endpoints of the config
+	rpc = New("http://a:8545", WithEndpointProvider(FileEndpoints("endpoints.txt"), 0))
+	require.Nil(t, rpc.UpdateConfig(Config{URL: "http://a:8545", Timeout: time.Second}))
+	require.NotNil(t, rpc.endpoints)
+	require.EqualError(t, rpc.UpdateConfig(Config{Endpoints: []string{"http://c:8545"}}), "config: endpoints conflict with WithEndpointProvider")
+
+	// failover clients can not get endpoints or another url
+	rpc = New("http://a:8545", WithFailover("http://b:8545"))
+	require.EqualError(t, rpc.UpdateConfig(Config{URL: "http://a:8545", Endpoints: []string{"http://c:8545"}}),
+		"asimovrpc: invalid option WithFailover: conflicts with WithEndpointProvider")
+	require.EqualError(t, rpc.UpdateConfig(Config{URL: "http://c:8545"}), "config: url of a WithFailover client can not be reloaded")
+	require.Nil(t, rpc.UpdateConfig(Config{URL: "http://a:8545", Timeout: time.Second}))
+	require.Nil(t, rpc.endpoints)
+	require.Equal(t, time.Second, rpc.timeout)
+}
+
+func TestWatchConfigFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "client.json")
+	require.Nil(t, ioutil.WriteFile(path, []byte(`{"url": "http://a:8545"}`), 0644))
+	past := time.Now().Add(-time.Minute)
+	require.Nil(t, os.Chtimes(path, past, past))
+
+	rpc := New("http://a:8545")
+	watcher := rpc.WatchConfigFile(path, 5*time.Millisecond)
+	defer watcher.Stop()
+
+	require.Nil(t, ioutil.WriteFile(path, []byte(`{"url": "http://b:8545", "debug": true}`), 0644))
+	require.Eventually(t, func() bool { return rpc.URL() == "http://b:8545" }, time.Second, 5*time.Millisecond)
+
+	require.Nil(t, ioutil.WriteFile(path, []byte(`{"url": "b:8545"}`), 0644))
+	future := time.Now().Add(time.Minute)
+	require.Nil(t, os.Chtimes(path, future, future))
+	select {
+	case err := <-watcher.Errors():
+		require.NotNil(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("expected reload error")
+	}
+	require.Equal(t, "http://b:8545", rpc.URL())
+
+	watcher.Stop()
+}
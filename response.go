@@ -0,0 +1,56 @@
+package asimovrpc
+
+import (
+	"fmt"
+	"io"
+)
+
+// ResponseTooLargeError - response body exceeds the limit set with WithMaxResponseSize
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (err ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("Response exceeds %d bytes", err.Limit)
+}
+
+// WithMaxResponseSize limit size of response bodies, larger responses fail with ResponseTooLargeError.
+// Responses are not limited by default. WebSocket connections are closed on messages above the limit,
+// connections shared by a ClientPool keep the limit of the client which dialed them.
+func WithMaxResponseSize(bytes int64) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if bytes <= 0 {
+			rpc.invalidOption("WithMaxResponseSize", "size must be positive")
+			return
+		}
+		rpc.maxResponseSize = bytes
+	}
+}
+
+// responseBody counts bytes read from response body and fails once more than limit bytes are read (0 - no limit)
+type responseBody struct {
+	r     io.Reader
+	n     int64
+	limit int64
+}
+
+func (b *responseBody) Read(p []byte) (int, error) {
+	if b.limit > 0 {
+		if b.n >= b.limit {
+			var probe [1]byte
+			n, err := b.r.Read(probe[:])
+			if n > 0 {
+				return 0, ResponseTooLargeError{Limit: b.limit}
+			}
+			return 0, err
+		}
+		if remaining := b.limit - b.n; int64(len(p)) > remaining {
+			p = p[:remaining]
+		}
+	}
+
+	n, err := b.r.Read(p)
+	b.n += int64(n)
+
+	return n, err
+}
@@ -0,0 +1,59 @@
+package asimovrpc
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *AsimovRPCTestSuite) TestMaxResponseSize() {
+	result := `"0x` + strings.Repeat("ab", 100) + `"`
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, echoID(request, `{"jsonrpc":"2.0", "id":1, "result": `+result+`}`)), nil
+	})
+
+	rpc := New(s.rpc.url, WithMaxResponseSize(1024))
+	code, err := rpc.AsimovGetCode("0x1", Latest())
+	s.Require().Nil(err)
+	s.Require().Equal(strings.Trim(result, `"`), code)
+
+	rpc = New(s.rpc.url, WithMaxResponseSize(100))
+	_, err = rpc.AsimovGetCode("0x1", Latest())
+	s.Require().Equal(ResponseTooLargeError{Limit: 100}, err)
+
+	rpc = New(s.rpc.url, WithMaxResponseSize(100), WithDebug(true))
+	_, err = rpc.AsimovGetCode("0x1", Latest())
+	s.Require().Equal(ResponseTooLargeError{Limit: 100}, err)
+
+	results := make([]string, 2)
+	batch := rpc.NewBatch()
+	batch.Add("flow_getCode", &results[0], "0x1", "latest")
+	batch.Add("flow_getCode", &results[1], "0x2", "latest")
+	s.Require().Equal(ResponseTooLargeError{Limit: 100}, batch.Execute())
+}
+
+func TestWithMaxResponseSizeInvalid(t *testing.T) {
+	_, err := NewClient("http://localhost", WithMaxResponseSize(0))
+	require.EqualError(t, err, "asimovrpc: invalid option WithMaxResponseSize: size must be positive")
+}
+
+func TestResponseBodyLimit(t *testing.T) {
+	body := &responseBody{r: strings.NewReader("12345"), limit: 5}
+	buf := make([]byte, 10)
+	n, err := body.Read(buf)
+	require.Nil(t, err)
+	require.Equal(t, 5, n)
+	_, err = body.Read(buf)
+	require.Equal(t, "EOF", err.Error())
+	require.Equal(t, int64(5), body.n)
+
+	body = &responseBody{r: strings.NewReader("123456"), limit: 5}
+	n, _ = body.Read(buf)
+	require.Equal(t, 5, n)
+	_, err = body.Read(buf)
+	require.Equal(t, ResponseTooLargeError{Limit: 5}, err)
+}
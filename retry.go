@@ -0,0 +1,55 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// maxBackoffShift - retries past it keep backing off by 1024 poll intervals
+const maxBackoffShift = 10
+
+// WithRetries retry calls failing with network errors, 5xx or rate limited responses up to retries times,
+// with jittered backoff doubling from the poll interval up to 1024 intervals or after the delay requested with Retry-After.
+// State-changing methods such as flow_sendRawTransaction are not retried, the node may have accepted them.
+func WithRetries(retries int) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if retries < 0 {
+			rpc.invalidOption("WithRetries", "retries must not be negative")
+			return
+		}
+		rpc.retries = retries
+	}
+}
+
+// retry wraps the innermost CallFunc retrying transient failures of calls
+func (rpc *AsimovRPC) retry(next CallFunc) CallFunc {
+	return func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+		for retries := 0; ; retries++ {
+			result, err := next(ctx, method, params)
+			if err == nil || retries >= rpc.retries || StateChanging(method) || !transient(ctx, err) {
+				return result, err
+			}
+
+			delay := rpc.backoff(retries)
+			if after, ok := RetryAfter(err); ok && after > delay {
+				delay = after
+			}
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-rpc.clock.After(delay):
+			}
+		}
+	}
+}
+
+// backoff returns jittered delay before retry number retries (from 0), doubling the poll interval
+// up to maxBackoffShift times
+func (rpc *AsimovRPC) backoff(retries int) time.Duration {
+	if retries > maxBackoffShift {
+		retries = maxBackoffShift
+	}
+
+	return jitter(rpc.rand, rpc.pollInterval<<retries)
+}
@@ -0,0 +1,61 @@
+package asimovrpc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRetries(t *testing.T) {
+	failures, calls := 2, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(echoID(r, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`)))
+	}))
+	defer server.Close()
+
+	rpc := New(server.URL, WithRetries(2), WithPollInterval(time.Millisecond))
+	number, err := rpc.AsimovBlockNumber()
+	require.Nil(t, err)
+	require.Equal(t, 1, number)
+	require.Equal(t, 3, calls)
+
+	// the transaction may have been accepted before the server error
+	failures, calls = 1, 0
+	_, err = rpc.AsimovSendRawTransaction("0x01")
+	require.True(t, errors.As(err, &HTTPError{}))
+	require.Equal(t, 1, calls)
+
+	failures, calls = 5, 0
+	_, err = rpc.AsimovBlockNumber()
+	require.True(t, errors.As(err, &HTTPError{}))
+	require.Equal(t, 3, calls)
+
+	_, err = NewClient(server.URL, WithRetries(-1))
+	require.EqualError(t, err, "asimovrpc: invalid option WithRetries: retries must not be negative")
+}
+
+func TestBackoff(t *testing.T) {
+	rpc := New("http://localhost", WithPollInterval(time.Second))
+
+	for retries, interval := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		delay := rpc.backoff(retries)
+		require.GreaterOrEqual(t, int64(delay), int64(interval-interval/5))
+		require.LessOrEqual(t, int64(delay), int64(interval+interval/5))
+	}
+
+	// the shift is capped, so late retries neither overflow nor grow
+	for _, retries := range []int{maxBackoffShift, 64, 1000} {
+		delay := rpc.backoff(retries)
+		require.GreaterOrEqual(t, int64(delay), int64(819*time.Second))
+		require.LessOrEqual(t, int64(delay), int64(1229*time.Second))
+	}
+}
@@ -0,0 +1,127 @@
+package asimovrpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// RewardPolicy - economics of a network: native asset issued per block and the part of fees burned
+type RewardPolicy interface {
+	// Subsidy returns native asset issued to the validator of block at height
+	Subsidy(height int) big.Int
+	// Burned returns part of fees (total fees of one asset in block at height) that is burned
+	Burned(height int, fees Amount) big.Int
+}
+
+// FeesOnlyPolicy - reward policy without subsidy and burning, validators receive all fees.
+// It is the default, configure the actual network economics with WithRewardPolicy.
+type FeesOnlyPolicy struct{}
+
+// Subsidy returns 0
+func (FeesOnlyPolicy) Subsidy(height int) big.Int {
+	return big.Int{}
+}
+
+// Burned returns 0
+func (FeesOnlyPolicy) Burned(height int, fees Amount) big.Int {
+	return big.Int{}
+}
+
+// WithRewardPolicy set network economics used by BlockRewards
+func WithRewardPolicy(policy RewardPolicy) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if policy == nil {
+			rpc.invalidOption("WithRewardPolicy", "policy is nil")
+			return
+		}
+		rpc.rewardPolicy = policy
+	}
+}
+
+// BlockReward - fees, burned amounts and reward of a block validator, amounts are sorted by asset
+type BlockReward struct {
+	Block     int
+	Validator string
+	Fees      []Amount // fees paid by transactions of the block
+	Burned    []Amount // part of fees burned
+	Subsidy   Amount   // native asset issued
+	Reward    []Amount // received by validator: fees - burned + subsidy
+}
+
+// BlockRewards computes reward of block validator from receipts of block transactions (see TransactionFee)
+// and reward policy of the client
+func (rpc *AsimovRPC) BlockRewards(ctx context.Context, number int) (*BlockReward, error) {
+	block, err := rpc.WithContext(ctx).AsimovGetBlockByNumber(Number(number), true)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("Block %d not found", number)
+	}
+
+	receipts, err := rpc.WithContext(ctx).AsimovGetBlockReceipts(BlockHash(block.Hash))
+	if err != nil {
+		return nil, err
+	}
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf("Block %d has %d transactions, got %d receipts", number, len(block.Transactions), len(receipts))
+	}
+
+	fees := map[string]*big.Int{}
+	for i, transaction := range block.Transactions {
+		if receipts[i].TransactionHash != transaction.Hash {
+			return nil, fmt.Errorf("Receipt of transaction %s not found", transaction.Hash)
+		}
+		fee, err := receipts[i].Fee()
+		switch {
+		case err == ErrNoEffectiveGasPrice:
+			fee = receipts[i].FeeAt(transaction.GasPrice)
+		case err != nil:
+			return nil, err
+		}
+		if fees[fee.Asset] == nil {
+			fees[fee.Asset] = new(big.Int)
+		}
+		fees[fee.Asset].Add(fees[fee.Asset], &fee.Value)
+	}
+
+	policy := rpc.rewardPolicy
+	if policy == nil {
+		policy = FeesOnlyPolicy{}
+	}
+
+	reward := &BlockReward{Block: block.Number, Validator: block.Miner, Subsidy: Amount{Asset: NativeAsset, Value: policy.Subsidy(block.Number)}}
+	rewards := map[string]*big.Int{NativeAsset: new(big.Int).Set(&reward.Subsidy.Value)}
+	for _, asset := range sortedAssets(fees) {
+		fee := Amount{Asset: asset, Value: *fees[asset]}
+		burned := Amount{Asset: asset, Value: policy.Burned(block.Number, fee)}
+		reward.Fees = append(reward.Fees, fee)
+		if burned.Value.Sign() != 0 {
+			reward.Burned = append(reward.Burned, burned)
+		}
+
+		if rewards[asset] == nil {
+			rewards[asset] = new(big.Int)
+		}
+		rewards[asset].Add(rewards[asset], new(big.Int).Sub(&fee.Value, &burned.Value))
+	}
+	for _, asset := range sortedAssets(rewards) {
+		if rewards[asset].Sign() != 0 {
+			reward.Reward = append(reward.Reward, Amount{Asset: asset, Value: *rewards[asset]})
+		}
+	}
+
+	return reward, nil
+}
+
+func sortedAssets(amounts map[string]*big.Int) []string {
+	assets := make([]string, 0, len(amounts))
+	for asset := range amounts {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+
+	return assets
+}
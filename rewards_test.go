@@ -0,0 +1,113 @@
+package asimovrpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/tidwall/gjson"
+)
+
+type halfBurned struct{}
+
+func (halfBurned) Subsidy(height int) big.Int {
+	return *big.NewInt(int64(1000 * height))
+}
+
+func (halfBurned) Burned(height int, fees Amount) big.Int {
+	if fees.Asset != NativeAsset {
+		return big.Int{}
+	}
+	return *new(big.Int).Div(&fees.Value, big.NewInt(2))
+}
+
+func (s *AsimovRPCTestSuite) registerBlockResponder(receipts string) {
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := gjson.ParseBytes(s.getBody(request))
+		result := receipts
+		switch body.Get("method").String() {
+		case "flow_getBlockByNumber":
+			s.Require().Equal(`["0x5",true]`, body.Get("params").Raw)
+			result = `{"number": "0x5", "hash": "0xb", "miner": "0x66b2aa0a4e8e7a8f8a5b3c8bdbe6d1b0cd5ab9b5c9", "transactions": [
+				{"hash": "0x1", "gasPrice": "0x2"}, {"hash": "0x2", "gasPrice": "0x3"}, {"hash": "0x3", "gasPrice": "0x4"}]}`
+		case "flow_getBlockReceipts":
+			s.Require().Equal(`["0xb"]`, body.Get("params").Raw)
+		default:
+			s.Failf("unexpected method", body.Get("method").String())
+		}
+		return httpmock.NewStringResponse(200, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":%s}`, body.Get("id").Int(), result)), nil
+	})
+}
+
+const blockReceipts = `[
+	{"transactionHash": "0x1", "blockHash": "0xb", "gasUsed": "0x64", "effectiveGasPrice": "0x2"},
+	{"transactionHash": "0x2", "blockHash": "0xb", "gasUsed": "0xa", "effectiveGasPrice": "0x3", "feeAsset": "000000000000000100000001"},
+	{"transactionHash": "0x3", "blockHash": "0xb", "gasUsed": "0x32"}]`
+
+func (s *AsimovRPCTestSuite) TestBlockRewards() {
+	s.registerBlockResponder(blockReceipts)
+
+	reward, err := s.rpc.BlockRewards(context.Background(), 5)
+	s.Require().Nil(err)
+	s.Require().Equal(5, reward.Block)
+	s.Require().Equal("0x66b2aa0a4e8e7a8f8a5b3c8bdbe6d1b0cd5ab9b5c9", reward.Validator)
+	// 100 * 2 + 50 * 4 (gas price of transaction) native, 10 * 3 in fee asset
+	s.Require().Equal("400 000000000000000000000000, 30 000000000000000100000001", amounts(reward.Fees))
+	s.Require().Empty(reward.Burned)
+	s.Require().Equal(int64(0), reward.Subsidy.Value.Int64())
+	s.Require().Equal(amounts(reward.Fees), amounts(reward.Reward))
+
+	rpc := New(s.rpc.url, WithRewardPolicy(halfBurned{}))
+	reward, err = rpc.BlockRewards(context.Background(), 5)
+	s.Require().Nil(err)
+	s.Require().Equal("200 000000000000000000000000", amounts(reward.Burned))
+	s.Require().Equal("5000 000000000000000000000000", reward.Subsidy.String())
+	s.Require().Equal("5200 000000000000000000000000, 30 000000000000000100000001", amounts(reward.Reward))
+}
+
+func (s *AsimovRPCTestSuite) TestBlockRewardsMissingReceipt() {
+	s.registerBlockResponder(`[
+		{"transactionHash": "0x1", "blockHash": "0xb", "gasUsed": "0x64", "effectiveGasPrice": "0x2"},
+		null,
+		{"transactionHash": "0x3", "blockHash": "0xb", "gasUsed": "0x32"}]`)
+
+	_, err := s.rpc.BlockRewards(context.Background(), 5)
+	s.Require().EqualError(err, "Receipt 1 of block 0xb not found")
+
+	s.registerBlockResponder(`[
+		{"transactionHash": "0x1", "blockHash": "0xb", "gasUsed": "0x64", "effectiveGasPrice": "0x2"},
+		{"transactionHash": "0x3", "blockHash": "0xb", "gasUsed": "0x32"}]`)
+
+	_, err = s.rpc.BlockRewards(context.Background(), 5)
+	s.Require().EqualError(err, "Block 5 has 3 transactions, got 2 receipts")
+
+	s.registerBlockResponder(`[
+		{"transactionHash": "0x1", "blockHash": "0xb", "gasUsed": "0x64", "effectiveGasPrice": "0x2"},
+		{"transactionHash": "0x3", "blockHash": "0xb", "gasUsed": "0x32"},
+		{"transactionHash": "0x2", "blockHash": "0xb", "gasUsed": "0xa", "effectiveGasPrice": "0x3"}]`)
+
+	_, err = s.rpc.BlockRewards(context.Background(), 5)
+	s.Require().EqualError(err, "Receipt of transaction 0x2 not found")
+}
+
+func (s *AsimovRPCTestSuite) TestBlockRewardsNotFound() {
+	s.registerResponse(`null`, func([]byte) {})
+
+	_, err := s.rpc.BlockRewards(context.Background(), 5)
+	s.Require().EqualError(err, "Block 5 not found")
+
+	_, err = NewClient(s.rpc.url, WithRewardPolicy(nil))
+	s.Require().EqualError(err, "asimovrpc: invalid option WithRewardPolicy: policy is nil")
+}
+
+func amounts(list []Amount) string {
+	var values []string
+	for _, amount := range list {
+		values = append(values, amount.String())
+	}
+	return strings.Join(values, ", ")
+}
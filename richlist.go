@@ -0,0 +1,168 @@
+package asimovrpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// BalanceOfSelector - selector of balanceOf(address) read from token contracts
+const BalanceOfSelector = "0x70a08231"
+
+// HolderSource returns addresses that may hold an asset, duplicates are ignored
+type HolderSource func(ctx context.Context) ([]string, error)
+
+// Holders returns holder source of a fixed list of addresses
+func Holders(addresses ...string) HolderSource {
+	return func(context.Context) ([]string, error) {
+		return addresses, nil
+	}
+}
+
+// TransferRecipients returns holder source of recipients of token Transfer events from fromBlock to toBlock,
+// scanned with AsimovGetLogsRange
+func (rpc *AsimovRPC) TransferRecipients(token string, fromBlock, toBlock int) HolderSource {
+	return func(ctx context.Context) ([]string, error) {
+		params := FilterParams{Address: []string{token}, Topics: [][]string{{TransferTopic}}}
+		it := rpc.AsimovGetLogsRange(ctx, params, fromBlock, toBlock)
+
+		var recipients []string
+		for it.Next() {
+			if topics := it.Item().Topics; len(topics) == 3 {
+				recipients = append(recipients, topicAddress(topics[2]))
+			}
+		}
+
+		return recipients, it.Err()
+	}
+}
+
+// Holding - balance of a rich list holder
+type Holding struct {
+	Rank    int // 1 - largest balance
+	Address string
+	Balance big.Int
+}
+
+// RichList - holders of an asset with non-zero balance, sorted by balance
+type RichList struct {
+	Token    string // "" - native asset
+	Block    BlockNumber
+	Holdings []Holding
+}
+
+// Pages returns number of pages of size
+func (l *RichList) Pages(size int) int {
+	if size <= 0 {
+		return 0
+	}
+
+	return (len(l.Holdings) + size - 1) / size
+}
+
+// Page returns holdings of page (starting at 0) of size, nil after the last page
+func (l *RichList) Page(page, size int) []Holding {
+	if page < 0 || size <= 0 || page*size >= len(l.Holdings) {
+		return nil
+	}
+
+	end := (page + 1) * size
+	if end > len(l.Holdings) {
+		end = len(l.Holdings)
+	}
+
+	return l.Holdings[page*size : end]
+}
+
+// RichList reads balances of holders from source at block in batches and ranks holders by balance.
+// Token "" ranks native asset balances (flow_getBalance), otherwise balanceOf(address) of the token contract.
+func (rpc *AsimovRPC) RichList(ctx context.Context, token string, source HolderSource, block BlockNumber) (*RichList, error) {
+	addresses, err := source(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	holders := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if key := strings.ToLower(address); !seen[key] {
+			seen[key] = true
+			holders = append(holders, address)
+		}
+	}
+
+	balances, err := rpc.balances(ctx, token, holders, block)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &RichList{Token: token, Block: block}
+	for i, holder := range holders {
+		if balances[i].Sign() > 0 {
+			list.Holdings = append(list.Holdings, Holding{Address: holder, Balance: balances[i]})
+		}
+	}
+
+	sort.SliceStable(list.Holdings, func(i, j int) bool {
+		if c := list.Holdings[i].Balance.Cmp(&list.Holdings[j].Balance); c != 0 {
+			return c > 0
+		}
+		return strings.ToLower(list.Holdings[i].Address) < strings.ToLower(list.Holdings[j].Address)
+	})
+	for i := range list.Holdings {
+		list.Holdings[i].Rank = i + 1
+	}
+
+	return list, nil
+}
+
+// balances reads balances of holders at block in a batch, token "" - native asset
+func (rpc *AsimovRPC) balances(ctx context.Context, token string, holders []string, block BlockNumber) ([]big.Int, error) {
+	results := make([]string, len(holders))
+	batch := rpc.NewBatch()
+	for i, holder := range holders {
+		if token == "" {
+			batch.Add("flow_getBalance", &results[i], holder, block)
+			continue
+		}
+		data, err := balanceOfData(holder)
+		if err != nil {
+			return nil, err
+		}
+		batch.Add("flow_call", &results[i], T{To: token, Data: data}, block)
+	}
+	if err := batch.fetch(ctx); err != nil {
+		return nil, err
+	}
+
+	balances := make([]big.Int, len(holders))
+	for i, holder := range holders {
+		if err := batch.Err(i); err != nil {
+			return nil, err
+		}
+		if len(results[i]) <= 2 {
+			if token != "" {
+				return nil, fmt.Errorf("Contract %s returned no balance of %s", token, holder)
+			}
+			continue
+		}
+		balance, err := ParseBigInt(results[i])
+		if err != nil {
+			return nil, fmt.Errorf("Balance of %s: invalid value %s", holder, results[i])
+		}
+		balances[i] = balance
+	}
+
+	return balances, nil
+}
+
+func balanceOfData(address string) (string, error) {
+	hex := strings.TrimPrefix(strings.ToLower(address), "0x")
+	if len(hex) == 0 || len(hex) > 64 {
+		return "", fmt.Errorf("Invalid holder address %q", address)
+	}
+
+	return BalanceOfSelector + strings.Repeat("0", 64-len(hex)) + hex, nil
+}
@@ -0,0 +1,99 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func TestRichList(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	balances := map[string]string{
+		"0x1111111111111111111111111111111111111111": "0x64",
+		"0x2222222222222222222222222222222222222222": "0x3e8",
+		"0x3333333333333333333333333333333333333333": "0x0",
+		"0x4444444444444444444444444444444444444444": "0x64",
+	}
+	node.HandleFunc("flow_getBalance", func(params []json.RawMessage) (interface{}, error) {
+		var address string
+		json.Unmarshal(params[0], &address)
+		return balances[address], nil
+	})
+	rpc := New(node.URL, WithBatchSize(2))
+
+	list, err := rpc.RichList(context.Background(), "", Holders(
+		"0x4444444444444444444444444444444444444444",
+		"0x1111111111111111111111111111111111111111",
+		"0x3333333333333333333333333333333333333333",
+		"0x2222222222222222222222222222222222222222",
+		"0x1111111111111111111111111111111111111111",
+	), Latest())
+	require.Nil(t, err)
+	require.Len(t, node.Calls("flow_getBalance"), 4)
+	require.Len(t, list.Holdings, 3)
+	require.Equal(t, Holding{Rank: 1, Address: "0x2222222222222222222222222222222222222222", Balance: newBigInt("1000")}, list.Holdings[0])
+	require.Equal(t, "0x1111111111111111111111111111111111111111", list.Holdings[1].Address)
+	require.Equal(t, "0x4444444444444444444444444444444444444444", list.Holdings[2].Address)
+	require.Equal(t, 3, list.Holdings[2].Rank)
+
+	require.Equal(t, 2, list.Pages(2))
+	require.Equal(t, list.Holdings[:2], list.Page(0, 2))
+	require.Equal(t, list.Holdings[2:], list.Page(1, 2))
+	require.Nil(t, list.Page(2, 2))
+	require.Nil(t, list.Page(0, 0))
+}
+
+func TestRichListToken(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	token := "0x631f62ca646771cd0c78e80e4eaf1d2ddf8fe414bf"
+	node.HandleFunc("flow_getLogs", func(params []json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[
+			{"address": "` + token + `", "blockNumber": "0x1", "logIndex": "0x0", "transactionHash": "0xa",
+			 "topics": ["` + TransferTopic + `", "0x0000000000000000000000000000000000000000000000000000000000000000", "0x000000000000000000000000aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"]},
+			{"address": "` + token + `", "blockNumber": "0x2", "logIndex": "0x0", "transactionHash": "0xb",
+			 "topics": ["` + TransferTopic + `", "0x000000000000000000000000aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "0x000000000000000000000000bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"]}
+		]`), nil
+	})
+	node.HandleFunc("flow_call", func(params []json.RawMessage) (interface{}, error) {
+		var data struct {
+			Data string `json:"data"`
+		}
+		json.Unmarshal(params[0], &data)
+		switch {
+		case strings.HasSuffix(data.Data, "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"):
+			return "0x000000000000000000000000000000000000000000000000000000000000000a", nil
+		case strings.HasSuffix(data.Data, "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"):
+			return "0x0000000000000000000000000000000000000000000000000000000000000014", nil
+		}
+		return "0x", nil
+	})
+	rpc := New(node.URL)
+
+	list, err := rpc.RichList(context.Background(), token, rpc.TransferRecipients(token, 0, 10), Number(10))
+	require.Nil(t, err)
+	require.Equal(t, token, list.Token)
+	require.Len(t, list.Holdings, 2)
+	require.Equal(t, "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", list.Holdings[0].Address)
+	require.Equal(t, int64(20), list.Holdings[0].Balance.Int64())
+	require.Equal(t, int64(10), list.Holdings[1].Balance.Int64())
+	node.AssertParams(t, "flow_call", map[string]string{
+		"from": "",
+		"to":   token,
+		"data": BalanceOfSelector + "000000000000000000000000bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+	}, "0xa")
+
+	_, err = rpc.RichList(context.Background(), token, Holders("0xcccccccccccccccccccccccccccccccccccccccc"), Latest())
+	require.EqualError(t, err, "Contract "+token+" returned no balance of 0xcccccccccccccccccccccccccccccccccccccccc")
+
+	_, err = rpc.RichList(context.Background(), token, Holders("0x"), Latest())
+	require.EqualError(t, err, `Invalid holder address "0x"`)
+}
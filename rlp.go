@@ -0,0 +1,116 @@
+package asimovrpc
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// rlpEncode encodes []byte, string (raw bytes), uint64, int, *big.Int and []interface{} items
+// using Recursive Length Prefix encoding
+func rlpEncode(item interface{}) ([]byte, error) {
+	switch value := item.(type) {
+	case []byte:
+		if len(value) == 1 && value[0] < 0x80 {
+			return value, nil
+		}
+		return append(rlpHeader(0x80, len(value)), value...), nil
+	case string:
+		return rlpEncode([]byte(value))
+	case uint64:
+		return rlpEncode(new(big.Int).SetUint64(value))
+	case int:
+		if value < 0 {
+			return nil, fmt.Errorf("rlp: negative integer %d", value)
+		}
+		return rlpEncode(uint64(value))
+	case *big.Int:
+		if value == nil {
+			return rlpEncode([]byte{})
+		}
+		if value.Sign() < 0 {
+			return nil, fmt.Errorf("rlp: negative integer %s", value)
+		}
+		return rlpEncode(value.Bytes())
+	case []interface{}:
+		var payload []byte
+		for _, element := range value {
+			encoded, err := rlpEncode(element)
+			if err != nil {
+				return nil, err
+			}
+			payload = append(payload, encoded...)
+		}
+		return append(rlpHeader(0xc0, len(payload)), payload...), nil
+	default:
+		return nil, fmt.Errorf("rlp: unsupported type %T", item)
+	}
+}
+
+func rlpHeader(offset byte, length int) []byte {
+	if length <= 55 {
+		return []byte{offset + byte(length)}
+	}
+
+	size := new(big.Int).SetInt64(int64(length)).Bytes()
+	return append([]byte{offset + 55 + byte(len(size))}, size...)
+}
+
+// rlpDecode decodes single RLP item into []byte or []interface{} of items
+func rlpDecode(data []byte) (interface{}, error) {
+	item, rest, err := rlpSplit(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("rlp: %d trailing bytes", len(rest))
+	}
+
+	return item, nil
+}
+
+func rlpSplit(data []byte) (interface{}, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("rlp: unexpected end of input")
+	}
+
+	prefix := data[0]
+	switch {
+	case prefix < 0x80:
+		return data[:1], data[1:], nil
+	case prefix < 0xc0:
+		content, rest, err := rlpContent(data, 0x80)
+		return content, rest, err
+	default:
+		content, rest, err := rlpContent(data, 0xc0)
+		if err != nil {
+			return nil, nil, err
+		}
+		list := []interface{}{}
+		for len(content) > 0 {
+			var element interface{}
+			if element, content, err = rlpSplit(content); err != nil {
+				return nil, nil, err
+			}
+			list = append(list, element)
+		}
+		return list, rest, nil
+	}
+}
+
+// rlpContent splits payload of string or list item starting with data from the remaining input
+func rlpContent(data []byte, offset byte) ([]byte, []byte, error) {
+	header, length := 1, int(data[0]-offset)
+	if length > 55 {
+		size := length - 55
+		if size > 8 || len(data) < 1+size {
+			return nil, nil, fmt.Errorf("rlp: unexpected end of input")
+		}
+		length = int(new(big.Int).SetBytes(data[1 : 1+size]).Int64())
+		header += size
+	}
+	if length < 0 || len(data)-header < length {
+		return nil, nil, fmt.Errorf("rlp: unexpected end of input")
+	}
+
+	return data[header : header+length], data[header+length:], nil
+}
@@ -0,0 +1,119 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/mistdex/mist-asimov-rpc/abi"
+	"github.com/mistdex/mist-asimov-rpc/openrpc"
+)
+
+// JSONSchemaDialect - JSON Schema dialect of the schemas returned by JSONSchema
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// schemaTypes - types published as JSON schemas by Schemas, by schema name
+var schemaTypes = map[string]interface{}{
+	"block":        Block{},
+	"transaction":  Transaction{},
+	"receipt":      TransactionReceipt{},
+	"log":          Log{},
+	"decodedEvent": abi.DecodedEvent{},
+}
+
+var (
+	bigIntType     = reflect.TypeOf(big.Int{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// Schemas returns JSON schemas of the JSON encoding of Block, Transaction, TransactionReceipt, Log and
+// abi.DecodedEvent keyed by block, transaction, receipt, log and decodedEvent, for consumers in other
+// languages to validate data exported by this package and generate types from it. The same schemas are
+// published in the schemas directory.
+func Schemas() map[string]openrpc.Schema {
+	schemas := map[string]openrpc.Schema{}
+	for name, value := range schemaTypes {
+		schemas[name] = JSONSchema(value)
+	}
+
+	return schemas
+}
+
+// JSONSchema returns JSON schema of the encoding/json encoding of value's type. Nested structs are
+// inlined; big integers are JSON numbers which may exceed the precision of float64 parsers.
+func JSONSchema(value interface{}) openrpc.Schema {
+	t := reflect.TypeOf(value)
+	schema := schemaOf(t, false)
+	schema.Schema = JSONSchemaDialect
+	schema.Title = t.Name()
+
+	return schema
+}
+
+func schemaOf(t reflect.Type, nullable bool) openrpc.Schema {
+	schema := openrpc.Schema{}
+
+	switch {
+	case t == rawMessageType:
+		return schema // any JSON value
+	case t.Kind() == reflect.Ptr:
+		return schemaOf(t.Elem(), true)
+	case t == bigIntType:
+		schema.Type = openrpc.SchemaType{"integer"}
+	case t.Kind() == reflect.Bool:
+		schema.Type = openrpc.SchemaType{"boolean"}
+	case t.Kind() == reflect.String:
+		schema.Type = openrpc.SchemaType{"string"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		schema.Type = openrpc.SchemaType{"integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		schema.Type = openrpc.SchemaType{"number"}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		items := schemaOf(t.Elem(), false)
+		schema.Type = openrpc.SchemaType{"array"}
+		schema.Items = &items
+		nullable = nullable || t.Kind() == reflect.Slice
+	case t.Kind() == reflect.Map:
+		schema.Type = openrpc.SchemaType{"object"}
+		nullable = true
+	case t.Kind() == reflect.Struct:
+		schema.Type = openrpc.SchemaType{"object"}
+		schema.Properties = map[string]openrpc.Schema{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, omitEmpty, ok := jsonField(field)
+			if !ok {
+				continue
+			}
+			schema.Properties[name] = schemaOf(field.Type, false)
+			if !omitEmpty {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+	}
+
+	if nullable && len(schema.Type) > 0 {
+		schema.Type = append(schema.Type, "null")
+	}
+
+	return schema
+}
+
+// jsonField returns name of struct field in encoding/json encoding, ok is false for skipped fields
+func jsonField(field reflect.StructField) (name string, omitEmpty, ok bool) {
+	if field.PkgPath != "" {
+		return "", false, false
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+
+	return name, strings.Contains(","+options+",", ",omitempty,"), true
+}
@@ -0,0 +1,74 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/abi"
+	"github.com/mistdex/mist-asimov-rpc/fixtures"
+	"github.com/mistdex/mist-asimov-rpc/openrpc"
+)
+
+// TestSchemas compares Schemas with the published schemas/<name>.json, run with -update after changing types
+func TestSchemas(t *testing.T) {
+	for name, schema := range Schemas() {
+		actual, err := json.MarshalIndent(schema, "", "  ")
+		require.Nil(t, err)
+
+		published := filepath.Join("schemas", name+".json")
+		if *updateGolden {
+			require.Nil(t, os.MkdirAll(filepath.Dir(published), 0755))
+			require.Nil(t, ioutil.WriteFile(published, append(actual, '\n'), 0644))
+			continue
+		}
+
+		expected, err := ioutil.ReadFile(published)
+		require.Nil(t, err, "missing schema file, run go test -run TestSchemas -update")
+		require.JSONEq(t, string(expected), string(actual), name)
+	}
+}
+
+func TestSchemasMatchEncoding(t *testing.T) {
+	rpc := New("http://fixtures", WithHttpClient(fixtures.HTTPClient(fixtures.Versions()[0])))
+	block, err := rpc.AsimovGetBlockByNumber(1, true)
+	require.Nil(t, err)
+	receipt, err := rpc.AsimovGetTransactionReceipt("0x1")
+	require.Nil(t, err)
+	require.NotEmpty(t, receipt.Logs)
+
+	erc20, err := abi.Parse([]byte(`[{"type":"event","name":"Transfer","inputs":[
+		{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256"}]}]`))
+	require.Nil(t, err)
+	event, err := erc20.DecodeEvent([]string{
+		TransferTopic,
+		"0x0000000000000000000000006247cf0412c6462da2a51d05139e2a3c6c630f0a",
+		"0x000000000000000000000000c1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4",
+	}, "0x00000000000000000000000000000000000000000000000000000000000003e8")
+	require.Nil(t, err)
+
+	schemas := Schemas()
+	for name, value := range map[string]interface{}{
+		"block":        block,
+		"transaction":  &block.Transactions[0],
+		"receipt":      receipt,
+		"log":          &receipt.Logs[0],
+		"decodedEvent": event,
+	} {
+		document := &openrpc.Document{Methods: []openrpc.Method{{
+			Name:   "check",
+			Params: []openrpc.ContentDescriptor{{Name: name, Required: true, Schema: schemas[name]}},
+		}}}
+		require.Nil(t, document.Validate("check", []interface{}{value}), name)
+	}
+
+	document := &openrpc.Document{Methods: []openrpc.Method{{
+		Name:   "check",
+		Params: []openrpc.ContentDescriptor{{Name: "log", Required: true, Schema: schemas["log"]}},
+	}}}
+	require.Error(t, document.Validate("check", []interface{}{map[string]interface{}{"LogIndex": "0x1"}}))
+}
@@ -0,0 +1,279 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Selectors of token calls whose address argument is screened as a counterparty
+const (
+	TransferSelector     = "0xa9059cbb" // transfer(address,uint256)
+	TransferFromSelector = "0x23b872dd" // transferFrom(address,address,uint256)
+	ApproveSelector      = "0x095ea7b3" // approve(address,uint256)
+)
+
+// ScreenResult - decision of a Screener about a transaction
+type ScreenResult int
+
+const (
+	// ScreenAllow - transaction is broadcast
+	ScreenAllow ScreenResult = iota
+	// ScreenFlag - transaction is broadcast and recorded for review
+	ScreenFlag
+	// ScreenDeny - transaction is rejected with ScreeningDeniedError
+	ScreenDeny
+)
+
+func (r ScreenResult) String() string {
+	switch r {
+	case ScreenAllow:
+		return "allow"
+	case ScreenFlag:
+		return "flag"
+	case ScreenDeny:
+		return "deny"
+	}
+
+	return fmt.Sprintf("ScreenResult(%d)", int(r))
+}
+
+// Screening - transaction about to be broadcast
+type Screening struct {
+	Method         string
+	From           string
+	To             string
+	Counterparties []string // token recipients and spenders decoded from transfer, transferFrom and approve calls
+	Transaction    T
+}
+
+// Addresses returns from, to and counterparties without empty and duplicate addresses
+func (s Screening) Addresses() []string {
+	seen := map[string]bool{}
+	var addresses []string
+	for _, address := range append([]string{s.From, s.To}, s.Counterparties...) {
+		if key := strings.ToLower(address); address != "" && !seen[key] {
+			seen[key] = true
+			addresses = append(addresses, address)
+		}
+	}
+
+	return addresses
+}
+
+// ScreenDecision - result of screening with the reason given by the screening provider
+type ScreenDecision struct {
+	Result ScreenResult
+	Reason string
+}
+
+// Screener checks addresses of transactions before they are broadcast, for example with a sanctions screening provider
+type Screener interface {
+	Screen(ctx context.Context, screening Screening) (ScreenDecision, error)
+}
+
+// ScreenerFunc - function implementing Screener
+type ScreenerFunc func(ctx context.Context, screening Screening) (ScreenDecision, error)
+
+// Screen calls f(ctx, screening)
+func (f ScreenerFunc) Screen(ctx context.Context, screening Screening) (ScreenDecision, error) {
+	return f(ctx, screening)
+}
+
+// AuditEntry - screening decision recorded in the audit log
+type AuditEntry struct {
+	Time      time.Time
+	Screening Screening
+	Decision  ScreenDecision
+	Err       error // screener failure or undecodable transaction, the transaction is denied
+	Tags      Tags  // tags of the call context, such as TagTenant and TagRequestID
+}
+
+func (e AuditEntry) String() string {
+	line := fmt.Sprintf("screening %s %s from=%s to=%s counterparties=%s",
+		e.Screening.Method, e.Decision.Result, e.Screening.From, e.Screening.To, strings.Join(e.Screening.Counterparties, ","))
+	if e.Decision.Reason != "" {
+		line += fmt.Sprintf(" reason=%q", e.Decision.Reason)
+	}
+	if e.Err != nil {
+		line += fmt.Sprintf(" error=%q", e.Err.Error())
+	}
+	if len(e.Tags) > 0 {
+		line += " " + e.Tags.String()
+	}
+
+	return line
+}
+
+// AuditLog - records every screening decision
+type AuditLog interface {
+	Record(entry AuditEntry)
+}
+
+// AuditLogFunc - function implementing AuditLog
+type AuditLogFunc func(entry AuditEntry)
+
+// Record calls f(entry)
+func (f AuditLogFunc) Record(entry AuditEntry) {
+	f(entry)
+}
+
+// ScreeningDeniedError - transaction rejected by the Screener, or not screened because the Screener failed
+type ScreeningDeniedError struct {
+	Screening Screening
+	Reason    string
+	Err       error
+}
+
+func (err ScreeningDeniedError) Error() string {
+	if err.Err != nil {
+		return fmt.Sprintf("Transaction from %s not screened: %v", err.Screening.From, err.Err)
+	}
+
+	return fmt.Sprintf("Transaction from %s denied by screening: %s", err.Screening.From, err.Reason)
+}
+
+// WithScreener screen flow_sendTransaction and personal_sendTransaction calls and transactions sent with
+// SendTransactionLocal before broadcasting.
+// Transactions given to Call as maps or raw JSON are screened by their JSON encoding and denied if they can not be
+// decoded. Raw transactions sent with AsimovSendRawTransaction are not decoded and not screened.
+// Decisions are recorded with WithAuditLog, otherwise flagged and denied transactions are logged.
+func WithScreener(screener Screener) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if screener == nil {
+			rpc.invalidOption("WithScreener", "screener is nil")
+			return
+		}
+		rpc.screener = screener
+	}
+}
+
+// WithAuditLog set audit log recording decisions of the screener set with WithScreener
+func WithAuditLog(log AuditLog) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if log == nil {
+			rpc.invalidOption("WithAuditLog", "audit log is nil")
+			return
+		}
+		rpc.auditLog = log
+	}
+}
+
+// screenCall screens transaction of flow_sendTransaction and personal_sendTransaction calls
+func (rpc *AsimovRPC) screenCall(ctx context.Context, method string, params []interface{}) error {
+	if rpc.screener == nil || method != "flow_sendTransaction" && method != "personal_sendTransaction" || len(params) == 0 {
+		return nil
+	}
+
+	switch transaction := params[0].(type) {
+	case T:
+		return rpc.screen(ctx, method, transaction)
+	case *T:
+		if transaction != nil {
+			return rpc.screen(ctx, method, *transaction)
+		}
+	}
+
+	// transactions given as maps or raw JSON are screened by their JSON encoding, undecodable ones are denied
+	transaction, err := decodeTransaction(params[0])
+	if err != nil {
+		screening := Screening{Method: method}
+		rpc.audit(AuditEntry{Time: rpc.clock.Now(), Screening: screening, Decision: ScreenDecision{Result: ScreenDeny}, Err: err,
+			Tags: TagsFromContext(rpc.tagged(ctx))})
+		return ScreeningDeniedError{Screening: screening, Err: err}
+	}
+
+	return rpc.screen(ctx, method, transaction)
+}
+
+// decodeTransaction returns transaction of a send call given as another type than T by its JSON encoding
+func decodeTransaction(param interface{}) (T, error) {
+	data, err := json.Marshal(param)
+	if err != nil {
+		return T{}, err
+	}
+
+	var transaction T
+	if err := json.Unmarshal(data, &transaction); err != nil {
+		return T{}, fmt.Errorf("Transaction of type %T can not be decoded: %v", param, err)
+	}
+	if transaction.Data == "" {
+		var input struct {
+			Input string `json:"input"`
+		}
+		json.Unmarshal(data, &input)
+		transaction.Data = input.Input
+	}
+
+	return transaction, nil
+}
+
+// screen asks the screener about transaction and records the decision, failing unless the transaction may be broadcast
+func (rpc *AsimovRPC) screen(ctx context.Context, method string, transaction T) error {
+	if rpc.screener == nil {
+		return nil
+	}
+
+	screening := Screening{
+		Method:         method,
+		From:           transaction.From,
+		To:             transaction.To,
+		Counterparties: counterparties(transaction.Data),
+		Transaction:    transaction,
+	}
+	decision, err := rpc.screener.Screen(ctx, screening)
+	if err != nil {
+		decision = ScreenDecision{Result: ScreenDeny}
+	}
+	rpc.audit(AuditEntry{Time: rpc.clock.Now(), Screening: screening, Decision: decision, Err: err, Tags: TagsFromContext(rpc.tagged(ctx))})
+
+	if err != nil {
+		return ScreeningDeniedError{Screening: screening, Err: err}
+	}
+	if decision.Result == ScreenDeny {
+		return ScreeningDeniedError{Screening: screening, Reason: decision.Reason}
+	}
+
+	return nil
+}
+
+func (rpc *AsimovRPC) audit(entry AuditEntry) {
+	if rpc.auditLog != nil {
+		rpc.auditLog.Record(entry)
+		return
+	}
+	if entry.Decision.Result != ScreenAllow || entry.Err != nil {
+		rpc.warn(entry.String())
+	}
+}
+
+// counterparties decodes address arguments of token calls in transaction data
+func counterparties(data string) []string {
+	data = strings.ToLower(data)
+	if len(data) < 10 {
+		return nil
+	}
+
+	var words int
+	switch data[:10] {
+	case TransferSelector, ApproveSelector:
+		words = 1
+	case TransferFromSelector:
+		words = 2
+	default:
+		return nil
+	}
+
+	args := data[10:]
+	var addresses []string
+	for i := 0; i < words; i++ {
+		if len(args) < (i+1)*64 {
+			break
+		}
+		addresses = append(addresses, topicAddress(args[i*64:(i+1)*64]))
+	}
+
+	return addresses
+}
@@ -0,0 +1,179 @@
+package asimovrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+const sanctioned = "0x00000000000000000000000000000000000000aa"
+
+func sanctionsScreener(ctx context.Context, screening Screening) (ScreenDecision, error) {
+	for _, address := range screening.Addresses() {
+		if strings.EqualFold(address, sanctioned) {
+			return ScreenDecision{Result: ScreenDeny, Reason: "sanctioned"}, nil
+		}
+	}
+	if screening.Transaction.Value != nil && screening.Transaction.Value.Sign() > 0 {
+		return ScreenDecision{Result: ScreenFlag, Reason: "value transfer"}, nil
+	}
+
+	return ScreenDecision{Result: ScreenAllow}, nil
+}
+
+func TestScreener(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_sendTransaction", "0x1234")
+
+	var entries []AuditEntry
+	rpc := New(node.URL, WithScreener(ScreenerFunc(sanctionsScreener)), WithAuditLog(AuditLogFunc(func(entry AuditEntry) {
+		entries = append(entries, entry)
+	})))
+
+	hash, err := rpc.AsimovSendTransaction(T{From: "0x1", To: "0x2"})
+	require.Nil(t, err)
+	require.Equal(t, "0x1234", hash)
+
+	transfer := TransferSelector + strings.Repeat("0", 24) + strings.TrimPrefix(sanctioned, "0x") + strings.Repeat("0", 63) + "1"
+	_, err = rpc.AsimovSendTransaction(T{From: "0x1", To: "0x3", Data: transfer})
+	require.Equal(t, ScreeningDeniedError{
+		Screening: Screening{Method: "flow_sendTransaction", From: "0x1", To: "0x3", Counterparties: []string{sanctioned},
+			Transaction: T{From: "0x1", To: "0x3", Data: transfer}},
+		Reason: "sanctioned",
+	}, err)
+	require.Len(t, node.Calls("flow_sendTransaction"), 1)
+
+	require.Len(t, entries, 2)
+	require.Equal(t, ScreenAllow, entries[0].Decision.Result)
+	require.Equal(t, ScreenDeny, entries[1].Decision.Result)
+	require.False(t, entries[1].Time.IsZero())
+
+	batch := rpc.NewBatch()
+	batch.Add("flow_sendTransaction", nil, T{From: sanctioned, To: "0x2"})
+	batch.Add("flow_sendTransaction", nil, T{From: "0x1", To: "0x2"})
+	require.Nil(t, batch.Execute())
+	require.IsType(t, ScreeningDeniedError{}, batch.Err(0))
+	require.Nil(t, batch.Err(1))
+	require.Len(t, node.Calls("flow_sendTransaction"), 2)
+}
+
+func TestScreenerPersonalSendTransaction(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("personal_sendTransaction", "0x1234")
+
+	var entries []AuditEntry
+	rpc := New(node.URL, WithScreener(ScreenerFunc(sanctionsScreener)), WithAuditLog(AuditLogFunc(func(entry AuditEntry) {
+		entries = append(entries, entry)
+	})))
+
+	hash, err := rpc.PersonalSendTransaction(T{From: "0x1", To: "0x2"}, "secret")
+	require.Nil(t, err)
+	require.Equal(t, "0x1234", hash)
+
+	_, err = rpc.PersonalSendTransaction(T{From: "0x1", To: sanctioned}, "secret")
+	require.IsType(t, ScreeningDeniedError{}, err)
+	require.Len(t, node.Calls("personal_sendTransaction"), 1)
+
+	require.Len(t, entries, 2)
+	require.Equal(t, "personal_sendTransaction", entries[1].Screening.Method)
+	require.Equal(t, ScreenDeny, entries[1].Decision.Result)
+}
+
+func TestScreenerUntypedTransaction(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_sendTransaction", "0x1234")
+
+	var entries []AuditEntry
+	rpc := New(node.URL, WithScreener(ScreenerFunc(sanctionsScreener)), WithAuditLog(AuditLogFunc(func(entry AuditEntry) {
+		entries = append(entries, entry)
+	})))
+	ctx := WithTenant(context.Background(), "acme")
+
+	_, err := rpc.CallContext(ctx, "flow_sendTransaction", map[string]interface{}{"from": "0x1", "to": sanctioned})
+	require.IsType(t, ScreeningDeniedError{}, err)
+
+	transfer := TransferSelector + strings.Repeat("0", 24) + strings.TrimPrefix(sanctioned, "0x") + strings.Repeat("0", 64)
+	_, err = rpc.Call("flow_sendTransaction", json.RawMessage(`{"from": "0x1", "to": "0x2", "input": "`+transfer+`"}`))
+	require.IsType(t, ScreeningDeniedError{}, err)
+
+	_, err = rpc.Call("flow_sendTransaction", "0x1")
+	require.IsType(t, ScreeningDeniedError{}, err)
+	require.Empty(t, node.Calls("flow_sendTransaction"))
+
+	_, err = rpc.Call("flow_sendTransaction", map[string]interface{}{"from": "0x1", "to": "0x2"})
+	require.Nil(t, err)
+	require.Len(t, node.Calls("flow_sendTransaction"), 1)
+
+	require.Len(t, entries, 4)
+	require.Equal(t, Tags{TagTenant: "acme"}, entries[0].Tags)
+	require.Equal(t, []string{sanctioned}, entries[1].Screening.Counterparties)
+	require.NotNil(t, entries[2].Err)
+	require.Equal(t, ScreenDeny, entries[2].Decision.Result)
+	require.Equal(t, ScreenAllow, entries[3].Decision.Result)
+	require.Contains(t, entries[0].String(), " tenant=acme")
+}
+
+func TestScreenerFailure(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	var buf bytes.Buffer
+	rpc := New(node.URL, WithLogger(log.New(&buf, "", 0)), WithScreener(ScreenerFunc(func(context.Context, Screening) (ScreenDecision, error) {
+		return ScreenDecision{}, errors.New("provider unavailable")
+	})))
+
+	_, err := rpc.AsimovSendTransaction(T{From: "0x1", To: "0x2"})
+	require.EqualError(t, err, "Transaction from 0x1 not screened: provider unavailable")
+	require.Empty(t, node.Calls(""))
+	require.Equal(t, "screening flow_sendTransaction deny from=0x1 to=0x2 counterparties= error=\"provider unavailable\"\n", buf.String())
+
+	_, err = NewClient(node.URL, WithScreener(nil))
+	require.EqualError(t, err, "asimovrpc: invalid option WithScreener: screener is nil")
+}
+
+func TestScreenerSendTransactionLocal(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_chainId", "0x1")
+	node.Handle("flow_sendRawTransaction", "0x1234")
+
+	signer, err := NewPrivateKeySigner("0x" + strings.Repeat("46", 32))
+	require.Nil(t, err)
+
+	var buf bytes.Buffer
+	rpc := New(node.URL, WithLogger(log.New(&buf, "", 0)), WithScreener(ScreenerFunc(sanctionsScreener)))
+	hash, err := rpc.SendTransactionLocal(eip155Transaction(), signer)
+	require.Nil(t, err)
+	require.Equal(t, "0x1234", hash)
+	require.Contains(t, buf.String(), "screening flow_sendRawTransaction flag from="+signer.Address())
+
+	transaction := eip155Transaction()
+	transaction.To = sanctioned
+	_, err = rpc.SendTransactionLocal(transaction, signer)
+	require.IsType(t, ScreeningDeniedError{}, err)
+	require.Len(t, node.Calls("flow_sendRawTransaction"), 1)
+}
+
+func TestCounterparties(t *testing.T) {
+	word := func(address string) string {
+		return strings.Repeat("0", 24) + strings.TrimPrefix(address, "0x")
+	}
+	from, to := "0x00000000000000000000000000000000000000b1", "0x00000000000000000000000000000000000000b2"
+
+	require.Equal(t, []string{to}, counterparties(TransferSelector+word(to)+strings.Repeat("0", 64)))
+	require.Equal(t, []string{from, to}, counterparties(TransferFromSelector+word(from)+word(to)+strings.Repeat("0", 64)))
+	require.Equal(t, []string{to}, counterparties(ApproveSelector+word(to)))
+	require.Nil(t, counterparties("0x12345678"+word(to)))
+	require.Nil(t, counterparties(TransferSelector))
+}
@@ -0,0 +1,105 @@
+package asimovrpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+)
+
+// Default headers set by HMACSigner
+const (
+	DefaultKeyHeader       = "X-Asimov-Key"
+	DefaultTimestampHeader = "X-Asimov-Timestamp"
+	DefaultSignatureHeader = "X-Asimov-Signature"
+)
+
+// RequestSigner - signs outgoing requests for gateways requiring authenticated calls
+type RequestSigner interface {
+	Sign(request *http.Request, body []byte) error
+}
+
+// RequestSignerFunc - function implementing RequestSigner
+type RequestSignerFunc func(request *http.Request, body []byte) error
+
+// Sign calls f(request, body)
+func (f RequestSignerFunc) Sign(request *http.Request, body []byte) error {
+	return f(request, body)
+}
+
+// HMACSigner - signs requests with hex encoded HMAC-SHA256 of "<unix timestamp>.<body>"
+type HMACSigner struct {
+	KeyID           string
+	Secret          []byte
+	KeyHeader       string
+	TimestampHeader string
+	SignatureHeader string
+
+	clock Clock // nil - the clock of the client, see WithClock
+}
+
+// NewHMACSigner creates HMAC signer using default headers
+func NewHMACSigner(keyID string, secret []byte) *HMACSigner {
+	return &HMACSigner{
+		KeyID:           keyID,
+		Secret:          secret,
+		KeyHeader:       DefaultKeyHeader,
+		TimestampHeader: DefaultTimestampHeader,
+		SignatureHeader: DefaultSignatureHeader,
+	}
+}
+
+// Signature returns signature of body sent at timestamp
+func (s *HMACSigner) Signature(timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, s.Secret)
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sign implements RequestSigner
+func (s *HMACSigner) Sign(request *http.Request, body []byte) error {
+	clock := s.clock
+	if clock == nil {
+		clock = SystemClock
+	}
+	timestamp := clock.Now().Unix()
+
+	if s.KeyID != "" {
+		request.Header.Set(s.KeyHeader, s.KeyID)
+	}
+	request.Header.Set(s.TimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(s.SignatureHeader, s.Signature(timestamp, body))
+
+	return nil
+}
+
+// WithRequestSigner sign requests to all endpoints without own signer
+func WithRequestSigner(signer RequestSigner) func(rpc *AsimovRPC) {
+	return WithEndpointSigner("", signer)
+}
+
+// WithEndpointSigner sign requests sent to given endpoint url
+func WithEndpointSigner(endpoint string, signer RequestSigner) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if signer == nil {
+			rpc.invalidOption("WithRequestSigner", "signer is nil")
+			return
+		}
+		if rpc.signers == nil {
+			rpc.signers = map[string]RequestSigner{}
+		}
+		rpc.signers[endpoint] = signer
+	}
+}
+
+func (rpc *AsimovRPC) signer(endpoint string) RequestSigner {
+	if signer, ok := rpc.signers[endpoint]; ok {
+		return signer
+	}
+
+	return rpc.signers[""]
+}
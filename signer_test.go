@@ -0,0 +1,64 @@
+package asimovrpc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func TestHMACSigner(t *testing.T) {
+	signer := NewHMACSigner("key-1", []byte("secret"))
+
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		w.Write([]byte(echoID(r, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`)))
+	}))
+	defer server.Close()
+
+	rpc := NewAsimovRPC(server.URL, WithRequestSigner(signer), WithClock(asimovrpctest.NewFakeClock(time.Unix(1570000000, 0))))
+	_, err := rpc.AsimovBlockNumber()
+	require.Nil(t, err)
+
+	require.Equal(t, "key-1", headers.Get("X-Asimov-Key"))
+	require.Equal(t, "1570000000", headers.Get("X-Asimov-Timestamp"))
+	require.Equal(t, "6aeba3f2d96618e370ca559d8d392630f20d5c0249e22449cb80cb81192c5959", headers.Get("X-Asimov-Signature"))
+}
+
+func TestEndpointSigner(t *testing.T) {
+	signed := RequestSignerFunc(func(request *http.Request, body []byte) error {
+		request.Header.Set("X-Signed", "endpoint")
+		return nil
+	})
+	fallback := RequestSignerFunc(func(request *http.Request, body []byte) error {
+		request.Header.Set("X-Signed", "default")
+		return nil
+	})
+
+	rpc := NewAsimovRPC("http://127.0.0.1:8545", WithEndpointSigner("http://127.0.0.1:8545", signed), WithRequestSigner(fallback))
+	request, _ := http.NewRequest(http.MethodPost, "http://127.0.0.1:8545", nil)
+	require.Nil(t, rpc.signer("http://127.0.0.1:8545").Sign(request, nil))
+	require.Equal(t, "endpoint", request.Header.Get("X-Signed"))
+	require.Nil(t, rpc.signer("http://127.0.0.1:8546").Sign(request, nil))
+	require.Equal(t, "default", request.Header.Get("X-Signed"))
+
+	require.Nil(t, NewAsimovRPC("http://127.0.0.1:8545").signer("http://127.0.0.1:8545"))
+}
+
+func TestRequestSignerError(t *testing.T) {
+	rpc := NewAsimovRPC("http://127.0.0.1:8545", WithRequestSigner(RequestSignerFunc(func(*http.Request, []byte) error {
+		return errors.New("no key")
+	})))
+
+	_, err := rpc.AsimovBlockNumber()
+	require.EqualError(t, err, "no key")
+
+	_, err = NewClient("http://127.0.0.1:8545", WithRequestSigner(nil))
+	require.EqualError(t, err, "asimovrpc: invalid option WithRequestSigner: signer is nil")
+}
@@ -0,0 +1,34 @@
+//go:build go1.21
+
+package asimovrpc
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithSlog log debug records, slow calls and warnings with structured fields such as method, id,
+// duration and error_code. Debug records are logged at debug level, other records at warning level.
+// It replaces the WithLogger logger.
+func WithSlog(logger *slog.Logger) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if logger == nil {
+			rpc.invalidOption("WithSlog", "logger is nil")
+			return
+		}
+		rpc.structured = slogLogger{logger}
+	}
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l slogLogger) logRecord(level logLevel, message string, fields ...interface{}) {
+	slogLevel := slog.LevelDebug
+	if level == levelWarn {
+		slogLevel = slog.LevelWarn
+	}
+
+	l.logger.Log(context.Background(), slogLevel, message, fields...)
+}
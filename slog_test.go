@@ -0,0 +1,44 @@
+//go:build go1.21
+
+package asimovrpc
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+func (s *AsimovRPCTestSuite) TestSlog() {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	rpc := New(s.rpc.url, WithLogger(nil), WithSlog(logger), WithDebug(true), WithSlowQueryThreshold(time.Nanosecond))
+
+	s.registerResponses(map[string]string{}, func([]byte) {})
+	_, err := rpc.Call("personal_unlockAccount", "0x1", "hunter2", 300)
+	s.Require().NotNil(err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	s.Require().Len(lines, 2)
+	s.Require().NotContains(buf.String(), "hunter2")
+
+	call := gjson.Parse(lines[0])
+	s.Require().Equal("DEBUG", call.Get("level").String())
+	s.Require().Equal("asimovrpc call", call.Get("msg").String())
+	s.Require().Equal("personal_unlockAccount", call.Get("method").String())
+	s.Require().Equal(int64(1), call.Get("id").Int())
+	s.Require().Equal(int64(-32601), call.Get("error_code").Int())
+	s.Require().True(call.Get("duration").Exists())
+	s.Require().Contains(call.Get("request").String(), Redacted)
+
+	slow := gjson.Parse(lines[1])
+	s.Require().Equal("WARN", slow.Get("level").String())
+	s.Require().Equal("asimovrpc slow call", slow.Get("msg").String())
+	s.Require().Equal(`["0x1","[REDACTED]",300]`, slow.Get("params").Raw)
+	s.Require().Equal(int64(-32601), slow.Get("error_code").Int())
+
+	_, err = NewClient(s.rpc.url, WithSlog(nil))
+	s.Require().EqualError(err, "asimovrpc: invalid option WithSlog: logger is nil")
+}
@@ -0,0 +1,273 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// ZeroAddress - source of minted and destination of burned tokens in Transfer events, not tracked by snapshots
+const ZeroAddress = "0x0000000000000000000000000000000000000000"
+
+// Snapshot - token balances of holders at block height, replayed from Transfer events
+type Snapshot struct {
+	Token    string              `json:"token"`
+	Height   int                 `json:"height"` // last block included, -1 - no blocks
+	Balances map[string]*big.Int `json:"balances"`
+
+	changed map[string]*big.Int // balances before UpdateSnapshot changed them
+}
+
+// SnapshotChange - balance of a holder changed by UpdateSnapshot
+type SnapshotChange struct {
+	Address string
+	Before  big.Int
+	After   big.Int
+}
+
+// SnapshotMismatch - holder whose snapshot balance differs from balanceOf of the token contract
+type SnapshotMismatch struct {
+	Address  string
+	Snapshot big.Int
+	Chain    big.Int
+}
+
+// SnapshotMismatchError - sampled snapshot balances differ from the token contract
+type SnapshotMismatchError struct {
+	Token      string
+	Height     int
+	Mismatches []SnapshotMismatch
+}
+
+func (err SnapshotMismatchError) Error() string {
+	first := err.Mismatches[0]
+	return fmt.Sprintf("Snapshot of %s at height %d differs from balanceOf of %d holders: %s has %s, balanceOf %s",
+		err.Token, err.Height, len(err.Mismatches), first.Address, first.Snapshot.String(), first.Chain.String())
+}
+
+// NewSnapshot returns empty snapshot of token before genesis
+func NewSnapshot(token string) *Snapshot {
+	return &Snapshot{Token: token, Height: -1, Balances: map[string]*big.Int{}}
+}
+
+// ReadSnapshot reads snapshot written with WriteJSON, for example a checkpoint to resume
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	snapshot := new(Snapshot)
+	if err := json.NewDecoder(r).Decode(snapshot); err != nil {
+		return nil, err
+	}
+	if snapshot.Balances == nil {
+		snapshot.Balances = map[string]*big.Int{}
+	}
+
+	return snapshot, nil
+}
+
+// Snapshot replays Transfer events of token from genesis to height, see ResumeSnapshot
+func (rpc *AsimovRPC) Snapshot(ctx context.Context, token string, height int) (*Snapshot, error) {
+	return rpc.ResumeSnapshot(ctx, NewSnapshot(token), height)
+}
+
+// ResumeSnapshot replays Transfer events of the checkpoint token from the block after the checkpoint height
+// to height, updating checkpoint. Events are applied block by block, so when replay fails the checkpoint
+// holds balances at its Height and can be resumed.
+func (rpc *AsimovRPC) ResumeSnapshot(ctx context.Context, checkpoint *Snapshot, height int) (*Snapshot, error) {
+	if height < checkpoint.Height {
+		return checkpoint, fmt.Errorf("Snapshot is at height %d, above %d", checkpoint.Height, height)
+	}
+
+	params := FilterParams{Address: []string{checkpoint.Token}, Topics: [][]string{{TransferTopic}}}
+	it := rpc.AsimovGetLogsRange(ctx, params, checkpoint.Height+1, height)
+
+	var pending []Log
+	for it.Next() {
+		log := it.Item()
+		if len(pending) > 0 && pending[0].BlockNumber != log.BlockNumber {
+			if err := checkpoint.apply(pending); err != nil {
+				return checkpoint, err
+			}
+			pending = pending[:0]
+		}
+		pending = append(pending, log)
+	}
+	// the iterator fails only between chunks of whole blocks, so the pending block is complete
+	if err := checkpoint.apply(pending); err != nil {
+		return checkpoint, err
+	}
+	if err := it.Err(); err != nil {
+		return checkpoint, err
+	}
+	checkpoint.Height = height
+
+	return checkpoint, nil
+}
+
+// UpdateSnapshot applies Transfer events after the snapshot height up to height (see ResumeSnapshot) and
+// returns balances changed by them, sorted by address. Up to sample changed holders are then checked against
+// balanceOf at height, failing with SnapshotMismatchError. When replay fails, changes applied so far are returned.
+func (rpc *AsimovRPC) UpdateSnapshot(ctx context.Context, snapshot *Snapshot, height, sample int) ([]SnapshotChange, error) {
+	snapshot.changed = map[string]*big.Int{}
+	_, err := rpc.ResumeSnapshot(ctx, snapshot, height)
+	changed := snapshot.changed
+	snapshot.changed = nil
+
+	var changes []SnapshotChange
+	for address, before := range changed {
+		change := SnapshotChange{Address: address, Before: *before}
+		if after := snapshot.Balances[address]; after != nil {
+			change.After.Set(after)
+		}
+		if change.Before.Cmp(&change.After) != 0 {
+			changes = append(changes, change)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Address < changes[j].Address })
+	if err != nil {
+		return changes, err
+	}
+
+	addresses := make([]string, len(changes))
+	for i, change := range changes {
+		addresses[i] = change.Address
+	}
+
+	return changes, rpc.verifySnapshot(ctx, snapshot, addresses, sample)
+}
+
+// VerifySnapshot checks balances of up to sample random holders against balanceOf at the snapshot height,
+// failing with SnapshotMismatchError
+func (rpc *AsimovRPC) VerifySnapshot(ctx context.Context, snapshot *Snapshot, sample int) error {
+	return rpc.verifySnapshot(ctx, snapshot, snapshot.Holders(), sample)
+}
+
+func (rpc *AsimovRPC) verifySnapshot(ctx context.Context, snapshot *Snapshot, addresses []string, sample int) error {
+	if snapshot.Height < 0 || sample <= 0 || len(addresses) == 0 {
+		return nil
+	}
+
+	// partial Fisher-Yates shuffle picks sample addresses
+	addresses = append([]string{}, addresses...)
+	if sample > len(addresses) {
+		sample = len(addresses)
+	}
+	for i := 0; i < sample; i++ {
+		j := i + int(rpc.rand.Int63n(int64(len(addresses)-i)))
+		addresses[i], addresses[j] = addresses[j], addresses[i]
+	}
+	addresses = addresses[:sample]
+
+	balances, err := rpc.balances(ctx, snapshot.Token, addresses, Number(snapshot.Height))
+	if err != nil {
+		return err
+	}
+
+	mismatch := SnapshotMismatchError{Token: snapshot.Token, Height: snapshot.Height}
+	for i, address := range addresses {
+		var expected big.Int
+		if balance := snapshot.Balances[address]; balance != nil {
+			expected.Set(balance)
+		}
+		if expected.Cmp(&balances[i]) != 0 {
+			mismatch.Mismatches = append(mismatch.Mismatches, SnapshotMismatch{Address: address, Snapshot: expected, Chain: balances[i]})
+		}
+	}
+	if len(mismatch.Mismatches) > 0 {
+		sort.Slice(mismatch.Mismatches, func(i, j int) bool { return mismatch.Mismatches[i].Address < mismatch.Mismatches[j].Address })
+		return mismatch
+	}
+
+	return nil
+}
+
+// apply applies transfers of one block
+func (s *Snapshot) apply(logs []Log) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	type transfer struct {
+		from, to string
+		amount   big.Int
+	}
+	transfers := make([]transfer, 0, len(logs))
+	for _, log := range logs {
+		if log.Removed || len(log.Topics) != 3 || !strings.EqualFold(log.Topics[0], TransferTopic) {
+			continue // ERC721 transfers index the token ID
+		}
+		amount, err := ParseBigInt(log.Data)
+		if err != nil {
+			return fmt.Errorf("Transfer in transaction %s has invalid amount %s", log.TransactionHash, log.Data)
+		}
+		transfers = append(transfers, transfer{topicAddress(log.Topics[1]), topicAddress(log.Topics[2]), amount})
+	}
+
+	for _, t := range transfers {
+		s.add(t.from, new(big.Int).Neg(&t.amount))
+		s.add(t.to, &t.amount)
+	}
+	s.Height = logs[0].BlockNumber
+
+	return nil
+}
+
+func (s *Snapshot) add(address string, amount *big.Int) {
+	address = strings.ToLower(address)
+	if address == ZeroAddress {
+		return
+	}
+
+	balance, ok := s.Balances[address]
+	if _, recorded := s.changed[address]; s.changed != nil && !recorded {
+		s.changed[address] = new(big.Int)
+		if ok {
+			s.changed[address].Set(balance)
+		}
+	}
+	if !ok {
+		balance = new(big.Int)
+		s.Balances[address] = balance
+	}
+	if balance.Add(balance, amount).Sign() == 0 {
+		delete(s.Balances, address)
+	}
+}
+
+// Holders returns addresses with non-zero balance, sorted
+func (s *Snapshot) Holders() []string {
+	holders := make([]string, 0, len(s.Balances))
+	for address := range s.Balances {
+		holders = append(holders, address)
+	}
+	sort.Strings(holders)
+
+	return holders
+}
+
+// WriteJSON writes snapshot as JSON, balances are decimal numbers
+func (s *Snapshot) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(s)
+}
+
+// WriteCSV writes address,balance rows sorted by address
+func (s *Snapshot) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"address", "balance"}); err != nil {
+		return err
+	}
+	for _, address := range s.Holders() {
+		if err := writer.Write([]string{address, s.Balances[address].String()}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
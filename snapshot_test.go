@@ -0,0 +1,185 @@
+package asimovrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+const snapshotToken = "0x631f62ca646771cd0c78e80e4eaf1d2ddf8fe414bf"
+
+func transferLog(block, index int, from, to string, amount int) map[string]interface{} {
+	return map[string]interface{}{
+		"address":         snapshotToken,
+		"blockNumber":     IntToHex(block),
+		"logIndex":        IntToHex(index),
+		"transactionHash": fmt.Sprintf("0x%x%x", block, index),
+		"data":            fmt.Sprintf("0x%064x", amount),
+		"topics": []string{
+			TransferTopic,
+			"0x000000000000000000000000" + from[2:],
+			"0x000000000000000000000000" + to[2:],
+		},
+	}
+}
+
+func snapshotNode(failFrom *int) *asimovrpctest.Node {
+	alice, bob := "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	logs := []map[string]interface{}{
+		transferLog(1, 0, ZeroAddress, alice, 100),
+		transferLog(3, 0, alice, bob, 30),
+		transferLog(3, 1, bob, alice, 5),
+		transferLog(5, 0, alice, bob, 75),
+		transferLog(6, 0, bob, ZeroAddress, 10),
+	}
+
+	node := asimovrpctest.NewNode()
+	node.HandleFunc("flow_getLogs", func(params []json.RawMessage) (interface{}, error) {
+		var filter FilterParams
+		json.Unmarshal(params[0], &filter)
+		from, to := filter.FromBlock.Int(), filter.ToBlock.Int()
+		if *failFrom >= 0 && from >= *failFrom {
+			return nil, asimovrpctest.Error{Code: -32000, Message: "node is shutting down"}
+		}
+
+		result := []map[string]interface{}{}
+		for _, log := range logs {
+			block, _ := ParseInt(log["blockNumber"].(string))
+			if block >= from && block <= to {
+				result = append(result, log)
+			}
+		}
+		return result, nil
+	})
+
+	return node
+}
+
+func TestSnapshot(t *testing.T) {
+	failFrom := -1
+	node := snapshotNode(&failFrom)
+	defer node.Close()
+	rpc := New(node.URL, WithLogsChunkSize(2))
+
+	snapshot, err := rpc.Snapshot(context.Background(), snapshotToken, 4)
+	require.Nil(t, err)
+	require.Equal(t, 4, snapshot.Height)
+	require.Equal(t, map[string]*big.Int{
+		"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa": big.NewInt(75),
+		"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb": big.NewInt(25),
+	}, snapshot.Balances)
+
+	snapshot, err = rpc.ResumeSnapshot(context.Background(), snapshot, 6)
+	require.Nil(t, err)
+	require.Equal(t, 6, snapshot.Height)
+	require.Equal(t, []string{"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"}, snapshot.Holders())
+	require.Equal(t, int64(90), snapshot.Balances["0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"].Int64())
+
+	_, err = rpc.ResumeSnapshot(context.Background(), snapshot, 5)
+	require.EqualError(t, err, "Snapshot is at height 6, above 5")
+}
+
+func TestSnapshotResume(t *testing.T) {
+	failFrom := 4
+	node := snapshotNode(&failFrom)
+	defer node.Close()
+	rpc := New(node.URL, WithLogsChunkSize(2))
+
+	snapshot, err := rpc.Snapshot(context.Background(), snapshotToken, 6)
+	require.EqualError(t, err, "Error -32000 (node is shutting down)")
+	require.Equal(t, 3, snapshot.Height)
+
+	var checkpoint bytes.Buffer
+	require.Nil(t, snapshot.WriteJSON(&checkpoint))
+	snapshot, err = ReadSnapshot(&checkpoint)
+	require.Nil(t, err)
+	require.Equal(t, int64(75), snapshot.Balances["0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"].Int64())
+
+	failFrom = -1
+	calls := len(node.Calls("flow_getLogs"))
+	snapshot, err = rpc.ResumeSnapshot(context.Background(), snapshot, 6)
+	require.Nil(t, err)
+	require.Equal(t, 6, snapshot.Height)
+	require.Equal(t, int64(90), snapshot.Balances["0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"].Int64())
+	// blocks 4-6 in chunks of 2
+	require.Len(t, node.Calls("flow_getLogs"), calls+2)
+}
+
+func TestSnapshotExport(t *testing.T) {
+	snapshot := NewSnapshot(snapshotToken)
+	snapshot.Height = 10
+	snapshot.Balances["0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"] = big.NewInt(25)
+	snapshot.Balances["0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"], _ = new(big.Int).SetString("1000000000000000000000000", 10)
+
+	var csv bytes.Buffer
+	require.Nil(t, snapshot.WriteCSV(&csv))
+	require.Equal(t, "address,balance\n"+
+		"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa,1000000000000000000000000\n"+
+		"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb,25\n", csv.String())
+
+	var data bytes.Buffer
+	require.Nil(t, snapshot.WriteJSON(&data))
+	require.JSONEq(t, `{
+		"token": "`+snapshotToken+`",
+		"height": 10,
+		"balances": {
+			"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa": 1000000000000000000000000,
+			"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb": 25
+		}
+	}`, data.String())
+
+	read, err := ReadSnapshot(&data)
+	require.Nil(t, err)
+	require.Equal(t, snapshot, read)
+}
+
+func TestUpdateSnapshot(t *testing.T) {
+	failFrom := -1
+	node := snapshotNode(&failFrom)
+	defer node.Close()
+
+	chain := map[string]int64{"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb": 90}
+	node.HandleFunc("flow_call", func(params []json.RawMessage) (interface{}, error) {
+		var call struct {
+			Data string `json:"data"`
+		}
+		json.Unmarshal(params[0], &call)
+		return fmt.Sprintf("0x%064x", chain[call.Data[len(call.Data)-40:]]), nil
+	})
+	rpc := New(node.URL, WithLogsChunkSize(2), WithSeed(1))
+
+	snapshot, err := rpc.Snapshot(context.Background(), snapshotToken, 3)
+	require.Nil(t, err)
+
+	changes, err := rpc.UpdateSnapshot(context.Background(), snapshot, 6, 10)
+	require.Nil(t, err)
+	require.Equal(t, []SnapshotChange{
+		{Address: "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", Before: *big.NewInt(75), After: big.Int{}},
+		{Address: "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", Before: *big.NewInt(25), After: *big.NewInt(90)},
+	}, changes)
+	require.Len(t, node.Calls("flow_call"), 2)
+	for _, call := range node.Calls("flow_call") {
+		require.Equal(t, `"0x6"`, string(call.Params[1]))
+	}
+
+	changes, err = rpc.UpdateSnapshot(context.Background(), snapshot, 6, 10)
+	require.Nil(t, err)
+	require.Empty(t, changes)
+
+	require.Nil(t, rpc.VerifySnapshot(context.Background(), snapshot, 1))
+	snapshot.Balances["0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"] = big.NewInt(91)
+	err = rpc.VerifySnapshot(context.Background(), snapshot, 5)
+	require.EqualError(t, err, "Snapshot of "+snapshotToken+" at height 6 differs from balanceOf of 1 holders: "+
+		"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb has 91, balanceOf 90")
+	var mismatch SnapshotMismatchError
+	require.True(t, errors.As(err, &mismatch))
+	require.Equal(t, int64(90), mismatch.Mismatches[0].Chain.Int64())
+}
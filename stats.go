@@ -0,0 +1,209 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets - upper bounds of latency histogram buckets
+var DefaultLatencyBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// LatencyBucket - histogram bucket counting calls not slower than UpperBound (0 - no bound)
+type LatencyBucket struct {
+	UpperBound time.Duration
+	Count      int
+}
+
+// MethodStats - in-process statistics of a single method
+type MethodStats struct {
+	Calls         int
+	Errors        int
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+	Buckets       []LatencyBucket
+	RequestBytes  int64
+	ResponseBytes int64
+}
+
+// Mean returns average call duration
+func (s MethodStats) Mean() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+
+	return s.TotalDuration / time.Duration(s.Calls)
+}
+
+type stats struct {
+	mu      sync.Mutex
+	buckets []time.Duration
+	methods map[string]*MethodStats
+	tags    map[string]map[string]*stats // tag key -> tag value -> stats
+}
+
+func newStats(buckets []time.Duration) *stats {
+	sorted := append([]time.Duration{}, buckets...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return &stats{
+		buckets: sorted,
+		methods: map[string]*MethodStats{},
+		tags:    map[string]map[string]*stats{},
+	}
+}
+
+// tagged returns s followed by stats of every tag
+func (s *stats) tagged(tags Tags) []*stats {
+	if len(tags) == 0 {
+		return []*stats{s}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := append(make([]*stats, 0, len(tags)+1), s)
+	for key, value := range tags {
+		values, ok := s.tags[key]
+		if !ok {
+			values = map[string]*stats{}
+			s.tags[key] = values
+		}
+		t, ok := values[value]
+		if !ok {
+			t = newStats(s.buckets)
+			values[value] = t
+		}
+		result = append(result, t)
+	}
+
+	return result
+}
+
+func (s *stats) method(method string) *MethodStats {
+	m, ok := s.methods[method]
+	if !ok {
+		m = &MethodStats{Buckets: make([]LatencyBucket, len(s.buckets)+1)}
+		for i, bound := range s.buckets {
+			m.Buckets[i].UpperBound = bound
+		}
+		s.methods[method] = m
+	}
+
+	return m
+}
+
+func (s *stats) observe(method string, duration time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := s.method(method)
+	m.Calls++
+	if err != nil {
+		m.Errors++
+	}
+	m.TotalDuration += duration
+	if duration > m.MaxDuration {
+		m.MaxDuration = duration
+	}
+
+	i := sort.Search(len(s.buckets), func(i int) bool { return duration <= s.buckets[i] })
+	m.Buckets[i].Count++
+}
+
+func (s *stats) payload(method string, request, response int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := s.method(method)
+	m.RequestBytes += int64(request)
+	m.ResponseBytes += int64(response)
+}
+
+func (s *stats) snapshot() map[string]MethodStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make(map[string]MethodStats, len(s.methods))
+	for method, m := range s.methods {
+		copied := *m
+		copied.Buckets = append([]LatencyBucket{}, m.Buckets...)
+		result[method] = copied
+	}
+
+	return result
+}
+
+// Stats returns per-method call statistics collected since client creation
+func (rpc *AsimovRPC) Stats() map[string]MethodStats {
+	return rpc.stats.snapshot()
+}
+
+// StatsByTag returns per-method call statistics of calls tagged with key, grouped by tag value
+func (rpc *AsimovRPC) StatsByTag(key string) map[string]map[string]MethodStats {
+	rpc.stats.mu.Lock()
+	values := make(map[string]*stats, len(rpc.stats.tags[key]))
+	for value, s := range rpc.stats.tags[key] {
+		values[value] = s
+	}
+	rpc.stats.mu.Unlock()
+
+	result := make(map[string]map[string]MethodStats, len(values))
+	for value, s := range values {
+		result[value] = s.snapshot()
+	}
+
+	return result
+}
+
+func (rpc *AsimovRPC) observe(ctx context.Context, method string, params []interface{}, duration time.Duration, err error) {
+	tags := TagsFromContext(ctx)
+	for _, s := range rpc.stats.tagged(tags) {
+		s.observe(method, duration, err)
+	}
+
+	rpc.mu.RLock()
+	threshold := rpc.slowQueryThreshold
+	rpc.mu.RUnlock()
+
+	if threshold <= 0 || duration < threshold {
+		return
+	}
+	params = redactParams(method, params)
+	if rpc.structured != nil {
+		fields := []interface{}{"method", method, "duration", duration, "threshold", threshold, "params", params}
+		if len(tags) > 0 {
+			fields = append(fields, "tags", tags.String())
+		}
+		var asimovErr AsimovError
+		if errors.As(err, &asimovErr) {
+			fields = append(fields, "error_code", asimovErr.Code)
+		}
+		if err != nil {
+			fields = append(fields, "error", err.Error())
+		}
+		rpc.structured.logRecord(levelWarn, "asimovrpc slow call", fields...)
+		return
+	}
+
+	message := fmt.Sprintf("Slow call %s took %s (threshold %s)\nParams: %v\nError: %v", method, duration, threshold, params, err)
+	if len(tags) > 0 {
+		message += fmt.Sprintf("\nTags: %s", tags)
+	}
+	rpc.log.Println(message)
+}
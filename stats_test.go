@@ -0,0 +1,53 @@
+package asimovrpc
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsObserve(t *testing.T) {
+	s := newStats([]time.Duration{time.Second, 10 * time.Millisecond})
+	s.observe("flow_call", 5*time.Millisecond, nil)
+	s.observe("flow_call", 20*time.Millisecond, errors.New("error"))
+	s.observe("flow_call", 2*time.Second, nil)
+
+	snapshot := s.snapshot()
+	require.Len(t, snapshot, 1)
+
+	m := snapshot["flow_call"]
+	require.Equal(t, 3, m.Calls)
+	require.Equal(t, 1, m.Errors)
+	require.Equal(t, 2*time.Second, m.MaxDuration)
+	require.Equal(t, 675*time.Millisecond, m.Mean())
+	require.Equal(t, []LatencyBucket{
+		{UpperBound: 10 * time.Millisecond, Count: 1},
+		{UpperBound: time.Second, Count: 1},
+		{UpperBound: 0, Count: 1},
+	}, m.Buckets)
+	require.Equal(t, time.Duration(0), MethodStats{}.Mean())
+}
+
+func (s *AsimovRPCTestSuite) TestStats() {
+	log := new(bufferLogger)
+	rpc := New(s.rpc.url, WithLogger(log), WithSlowQueryThreshold(time.Nanosecond))
+
+	s.registerResponse(`"0x1"`, func([]byte) {})
+	_, err := rpc.AsimovBlockNumber()
+	s.Require().Nil(err)
+
+	s.registerResponseError(errors.New("error"))
+	_, err = rpc.AsimovBlockNumber()
+	s.Require().NotNil(err)
+
+	stats := rpc.Stats()["flow_blockNumber"]
+	s.Require().Equal(2, stats.Calls)
+	s.Require().Equal(1, stats.Errors)
+	s.Require().Len(stats.Buckets, len(DefaultLatencyBuckets)+1)
+
+	s.Require().Len(log.lines, 2)
+	s.Require().True(strings.HasPrefix(log.lines[0], "Slow call flow_blockNumber took "))
+}
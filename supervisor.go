@@ -0,0 +1,274 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrComponentExited - supervised component stopped on its own without reporting an error
+var ErrComponentExited = errors.New("component exited")
+
+// RestartPolicy - how a Supervisor restarts failed components
+type RestartPolicy struct {
+	MinBackoff  time.Duration // delay before the first restart, doubled after every failure
+	MaxBackoff  time.Duration
+	MaxRestarts int // restarts allowed within Window before the component is given up (0 - unlimited)
+	Window      time.Duration
+	Clock       Clock // nil - SystemClock
+}
+
+// DefaultRestartPolicy - restart policy used by NewSupervisor for zero fields of policy.
+// MaxRestarts is taken from it only when all other fields but Clock are zero, as 0 means unlimited.
+var DefaultRestartPolicy = RestartPolicy{
+	MinBackoff:  time.Second,
+	MaxBackoff:  time.Minute,
+	MaxRestarts: 10,
+	Window:      10 * time.Minute,
+}
+
+// ComponentFactory creates a fresh component for every (re)start
+type ComponentFactory func(ctx context.Context) (Component, error)
+
+// ComponentHealth - state of supervised component
+type ComponentHealth struct {
+	Name      string
+	Running   bool
+	Restarts  int
+	LastError error
+	GaveUp    bool // restart storm cap reached, the component is not restarted anymore
+}
+
+// GiveUpError - supervisor stopped restarting component after too many restarts
+type GiveUpError struct {
+	Name     string
+	Restarts int
+	Err      error
+}
+
+func (err GiveUpError) Error() string {
+	return fmt.Sprintf("Supervisor gave up %s after %d restarts: %v", err.Name, err.Restarts, err.Err)
+}
+
+// Unwrap returns the last component error
+func (err GiveUpError) Unwrap() error {
+	return err.Err
+}
+
+type supervised struct {
+	name    string
+	factory ComponentFactory
+	health  ComponentHealth
+}
+
+// Supervisor - Component running other components, restarting them with backoff when they stop unexpectedly
+type Supervisor struct {
+	policy RestartPolicy
+
+	mu       sync.Mutex
+	children []*supervised
+	started  bool
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	errors   chan error
+	done     chan struct{}
+}
+
+// NewSupervisor creates supervisor with given restart policy
+func NewSupervisor(policy RestartPolicy) *Supervisor {
+	if (RestartPolicy{Clock: policy.Clock}) == policy {
+		policy.MaxRestarts = DefaultRestartPolicy.MaxRestarts
+	}
+	if policy.MinBackoff == 0 {
+		policy.MinBackoff = DefaultRestartPolicy.MinBackoff
+	}
+	if policy.MaxBackoff == 0 {
+		policy.MaxBackoff = DefaultRestartPolicy.MaxBackoff
+	}
+	if policy.Window == 0 {
+		policy.Window = DefaultRestartPolicy.Window
+	}
+	if policy.Clock == nil {
+		policy.Clock = SystemClock
+	}
+
+	return &Supervisor{
+		policy: policy,
+		errors: make(chan error, 16),
+		done:   make(chan struct{}),
+	}
+}
+
+// Add registers component created by factory, components must be added before Start
+func (s *Supervisor) Add(name string, factory ComponentFactory) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.children = append(s.children, &supervised{name: name, factory: factory, health: ComponentHealth{Name: name}})
+}
+
+// Start starts all components
+func (s *Supervisor) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return ErrAlreadyStarted
+	}
+	s.started = true
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, child := range s.children {
+		s.wg.Add(1)
+		go s.supervise(ctx, child)
+	}
+	go func() {
+		s.wg.Wait()
+		close(s.done)
+	}()
+
+	return nil
+}
+
+// Stop stops all components and waits for them
+func (s *Supervisor) Stop() {
+	s.mu.Lock()
+	started, cancel := s.started, s.cancel
+	if !started {
+		s.started = true
+		close(s.done)
+	}
+	s.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	<-s.done
+}
+
+// Errors returns channel of component errors, errors are dropped while the channel is full
+func (s *Supervisor) Errors() <-chan error {
+	return s.errors
+}
+
+// Done returns channel closed once all components have stopped
+func (s *Supervisor) Done() <-chan struct{} {
+	return s.done
+}
+
+// Health returns state of all components
+func (s *Supervisor) Health() []ComponentHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	health := make([]ComponentHealth, len(s.children))
+	for i, child := range s.children {
+		health[i] = child.health
+	}
+
+	return health
+}
+
+func (s *Supervisor) report(err error) {
+	select {
+	case s.errors <- err:
+	default:
+	}
+}
+
+func (s *Supervisor) update(child *supervised, update func(health *ComponentHealth)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	update(&child.health)
+}
+
+func (s *Supervisor) supervise(ctx context.Context, child *supervised) {
+	defer s.wg.Done()
+
+	backoff := s.policy.MinBackoff
+	var restarts []time.Time
+	for {
+		component, err := child.factory(ctx)
+		if err == nil {
+			err = component.Start(ctx)
+		}
+		if err == nil {
+			s.update(child, func(health *ComponentHealth) { health.Running = true })
+			err = s.run(ctx, child, component)
+			s.update(child, func(health *ComponentHealth) { health.Running = false })
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		now := s.policy.Clock.Now()
+		restarts = append(restarts, now)
+		for len(restarts) > 0 && now.Sub(restarts[0]) > s.policy.Window {
+			restarts = restarts[1:]
+		}
+		if s.policy.MaxRestarts > 0 && len(restarts) > s.policy.MaxRestarts {
+			var total int
+			s.update(child, func(health *ComponentHealth) {
+				health.LastError = err
+				health.GaveUp = true
+				total = health.Restarts
+			})
+			s.report(GiveUpError{Name: child.name, Restarts: total, Err: err})
+			return
+		}
+
+		s.update(child, func(health *ComponentHealth) {
+			health.LastError = err
+			health.Restarts++
+		})
+		s.report(fmt.Errorf("%s stopped: %v", child.name, err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.policy.Clock.After(backoff):
+		}
+		if backoff *= 2; backoff > s.policy.MaxBackoff {
+			backoff = s.policy.MaxBackoff
+		}
+	}
+}
+
+// run forwards component errors until it stops, returning the last one
+func (s *Supervisor) run(ctx context.Context, child *supervised, component Component) error {
+	errs := component.Errors()
+	var last error
+	for {
+		select {
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			last = err
+			s.update(child, func(health *ComponentHealth) { health.LastError = err })
+			s.report(fmt.Errorf("%s: %v", child.name, err))
+		case <-component.Done():
+			// the error which ended component may still be buffered
+			select {
+			case err, ok := <-errs:
+				if ok {
+					last = err
+					s.update(child, func(health *ComponentHealth) { health.LastError = err })
+					s.report(fmt.Errorf("%s: %v", child.name, err))
+				}
+			default:
+			}
+			if last == nil {
+				last = ErrComponentExited
+			}
+			return last
+		case <-ctx.Done():
+			component.Stop()
+			return ctx.Err()
+		}
+	}
+}
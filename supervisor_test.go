@@ -0,0 +1,113 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeComponent struct {
+	fail   error
+	errors chan error
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newFakeComponent(fail error) *fakeComponent {
+	return &fakeComponent{fail: fail, errors: make(chan error, 1), done: make(chan struct{})}
+}
+
+func (c *fakeComponent) Start(ctx context.Context) error {
+	if c.fail != nil {
+		c.errors <- c.fail
+		c.Stop()
+	}
+	return nil
+}
+
+func (c *fakeComponent) Stop() {
+	c.once.Do(func() { close(c.done) })
+}
+
+func (c *fakeComponent) Errors() <-chan error {
+	return c.errors
+}
+
+func (c *fakeComponent) Done() <-chan struct{} {
+	return c.done
+}
+
+func TestSupervisorRestartsWithBackoff(t *testing.T) {
+	clock := asimovrpctest.NewFakeClock(time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC))
+	supervisor := NewSupervisor(RestartPolicy{
+		MinBackoff:  time.Second,
+		MaxBackoff:  time.Minute,
+		MaxRestarts: 2,
+		Window:      time.Hour,
+		Clock:       clock,
+	})
+
+	failure := errors.New("connection reset")
+	starts := 0
+	supervisor.Add("heads", func(ctx context.Context) (Component, error) {
+		starts++
+		return newFakeComponent(failure), nil
+	})
+	supervisor.Add("logs", func(ctx context.Context) (Component, error) {
+		return newFakeComponent(nil), nil
+	})
+	require.Nil(t, supervisor.Start(context.Background()))
+	require.Equal(t, ErrAlreadyStarted, supervisor.Start(context.Background()))
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	clock.BlockUntil(1)
+	clock.Advance(2 * time.Second)
+
+	var giveUp GiveUpError
+	for giveUp.Name == "" {
+		select {
+		case err := <-supervisor.Errors():
+			errors.As(err, &giveUp)
+		case <-time.After(time.Second):
+			t.Fatal("supervisor did not give up")
+		}
+	}
+	require.Equal(t, "heads", giveUp.Name)
+	require.Equal(t, 2, giveUp.Restarts)
+	require.Equal(t, failure, errors.Unwrap(giveUp))
+	require.Equal(t, 3, starts)
+
+	require.Equal(t, ComponentHealth{Name: "heads", Restarts: 2, LastError: failure, GaveUp: true}, supervisor.Health()[0])
+	require.Eventually(t, func() bool { return supervisor.Health()[1].Running }, time.Second, time.Millisecond)
+
+	supervisor.Stop()
+	waitDone(t, supervisor)
+	require.False(t, supervisor.Health()[1].Running)
+}
+
+func TestNewSupervisorDefaults(t *testing.T) {
+	clock := asimovrpctest.NewFakeClock(time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC))
+
+	policy := NewSupervisor(RestartPolicy{Clock: clock}).policy
+	require.Equal(t, RestartPolicy{MinBackoff: time.Second, MaxBackoff: time.Minute, MaxRestarts: 10, Window: 10 * time.Minute, Clock: clock}, policy)
+
+	policy = NewSupervisor(RestartPolicy{MaxRestarts: 3}).policy
+	require.Equal(t, RestartPolicy{MinBackoff: time.Second, MaxBackoff: time.Minute, MaxRestarts: 3, Window: 10 * time.Minute, Clock: SystemClock}, policy)
+
+	// unlimited restarts are kept
+	policy = NewSupervisor(RestartPolicy{MinBackoff: time.Millisecond}).policy
+	require.Equal(t, RestartPolicy{MinBackoff: time.Millisecond, MaxBackoff: time.Minute, Window: 10 * time.Minute, Clock: SystemClock}, policy)
+}
+
+func TestSupervisorStopBeforeStart(t *testing.T) {
+	supervisor := NewSupervisor(RestartPolicy{})
+	supervisor.Stop()
+	waitDone(t, supervisor)
+	require.Equal(t, ErrAlreadyStarted, supervisor.Start(context.Background()))
+}
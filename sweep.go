@@ -0,0 +1,129 @@
+package asimovrpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// TransferGas - gas of a plain value transfer to an address without code
+const TransferGas = 21000
+
+// Reasons of addresses skipped by Sweep
+const (
+	SweepSkipEmpty     = "balance does not cover the fee"
+	SweepSkipMinValue  = "value is below the minimum"
+	SweepSkipFeeBudget = "fee budget is exhausted"
+)
+
+// Sweep - settings of sweeping balances of deposit addresses into a treasury address
+type Sweep struct {
+	Treasury string
+	GasPrice *big.Int // nil - the FeeStandard suggestion of SuggestFees
+	MinValue *big.Int // addresses whose balance minus fee is below it are left alone, nil - any positive value
+	MaxFees  *big.Int // total fees the sweep may spend, nil - unlimited
+	DryRun   bool     // report what would be swept without sending transactions
+}
+
+// SweepItem - outcome of sweeping one address
+type SweepItem struct {
+	Address string
+	Balance big.Int
+	Fee     big.Int // paid, or to be paid in a dry run, zero if skipped or failed
+	Value   big.Int // balance - fee sent, or to be sent in a dry run, zero if skipped or failed
+	Hash    string  // "" - not sent
+	Skipped string  // reason the address was not swept, one of SweepSkip*
+	Err     error   // signing or sending failed
+}
+
+// SweepReport - outcome of Sweep, items are ordered by balance, largest first
+type SweepReport struct {
+	Items []SweepItem
+	Value big.Int // sent, or to be sent in a dry run, to the treasury
+	Fees  big.Int
+}
+
+// Sweep sends the whole balance, less the transfer fee, of each signer's address to sweep.Treasury.
+// Balances and nonces are fetched in concurrent batches. The largest balances are swept first, so a fee budget
+// set with MaxFees is spent where it moves the most value. Failures of single transfers are reported
+// in their items; the returned error is set only when no transfer could be attempted.
+func (rpc *AsimovRPC) Sweep(ctx context.Context, signers []TransactionSigner, sweep Sweep) (*SweepReport, error) {
+	if sweep.Treasury == "" {
+		return nil, fmt.Errorf("Sweep treasury address is not set")
+	}
+	client := rpc.WithContext(ctx)
+
+	balances := make([]string, len(signers))
+	nonces := make([]string, len(signers))
+	batch := client.NewBatch()
+	for i, signer := range signers {
+		batch.Add("flow_getBalance", &balances[i], signer.Address(), Latest())
+		batch.Add("flow_getTransactionCount", &nonces[i], signer.Address(), "pending")
+	}
+	if err := batch.fetch(ctx); err != nil {
+		return nil, err
+	}
+
+	gasPrice := sweep.GasPrice
+	if gasPrice == nil {
+		fees, err := client.SuggestFees(ctx, FeeStandard)
+		if err != nil {
+			return nil, err
+		}
+		gasPrice = &fees.GasPrice
+	}
+	fee := new(big.Int).Mul(gasPrice, big.NewInt(TransferGas))
+
+	type account struct {
+		signer TransactionSigner
+		nonce  int
+		item   SweepItem
+	}
+	accounts := make([]account, len(signers))
+	for i, signer := range signers {
+		for _, j := range []int{2 * i, 2*i + 1} {
+			if err := batch.Err(j); err != nil {
+				return nil, err
+			}
+		}
+		balance, err := ParseBigInt(balances[i])
+		if err != nil {
+			return nil, err
+		}
+		nonce, err := ParseInt(nonces[i])
+		if err != nil {
+			return nil, err
+		}
+		accounts[i] = account{signer: signer, nonce: nonce, item: SweepItem{Address: signer.Address(), Balance: balance}}
+	}
+	sort.SliceStable(accounts, func(i, j int) bool { return accounts[i].item.Balance.Cmp(&accounts[j].item.Balance) > 0 })
+
+	report := &SweepReport{Items: make([]SweepItem, len(accounts))}
+	for i := range accounts {
+		item := &accounts[i].item
+		value := new(big.Int).Sub(&item.Balance, fee)
+		switch {
+		case value.Sign() <= 0:
+			item.Skipped = SweepSkipEmpty
+		case sweep.MinValue != nil && value.Cmp(sweep.MinValue) < 0:
+			item.Skipped = SweepSkipMinValue
+		case sweep.MaxFees != nil && new(big.Int).Add(&report.Fees, fee).Cmp(sweep.MaxFees) > 0:
+			item.Skipped = SweepSkipFeeBudget
+		}
+
+		if item.Skipped == "" && !sweep.DryRun {
+			transaction := T{To: sweep.Treasury, Gas: TransferGas, GasPrice: gasPrice, Value: value, Nonce: accounts[i].nonce}
+			item.Hash, item.Err = client.SendTransactionLocal(transaction, accounts[i].signer)
+		}
+		if item.Skipped == "" && item.Err == nil {
+			item.Fee.Set(fee)
+			item.Value.Set(value)
+			report.Value.Add(&report.Value, value)
+			report.Fees.Add(&report.Fees, fee)
+		}
+		report.Items[i] = *item
+	}
+
+	return report, nil
+}
@@ -0,0 +1,108 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func sweepNode(t *testing.T, balances map[string]int64) (*asimovrpctest.Node, []TransactionSigner) {
+	node := asimovrpctest.NewNode()
+	node.Handle("flow_chainId", "0x1")
+	node.Handle("flow_getTransactionCount", "0x7")
+
+	var signers []TransactionSigner
+	for i := 1; i <= 3; i++ {
+		signer, err := NewPrivateKeySigner("0x" + strings.Repeat(fmt.Sprintf("%02x", i), 32))
+		require.Nil(t, err)
+		signers = append(signers, signer)
+	}
+	node.HandleFunc("flow_getBalance", func(params []json.RawMessage) (interface{}, error) {
+		var address string
+		json.Unmarshal(params[0], &address)
+		for i, signer := range signers {
+			if signer.Address() == address {
+				return IntToHex(int(balances[fmt.Sprint(i)])), nil
+			}
+		}
+		return "0x0", nil
+	})
+	sent := 0
+	node.HandleFunc("flow_sendRawTransaction", func(params []json.RawMessage) (interface{}, error) {
+		sent++
+		if sent == 2 {
+			return nil, asimovrpctest.Error{Code: -32000, Message: "insufficient funds for gas * price + value"}
+		}
+		return fmt.Sprintf("0x%d", sent), nil
+	})
+
+	return node, signers
+}
+
+func TestSweep(t *testing.T) {
+	// fee is 21000 × 10 = 210000
+	node, signers := sweepNode(t, map[string]int64{"0": 1000000, "1": 100000, "2": 5000000})
+	defer node.Close()
+
+	report, err := New(node.URL).Sweep(context.Background(), signers, Sweep{Treasury: "0x" + strings.Repeat("aa", 20), GasPrice: big.NewInt(10)})
+	require.Nil(t, err)
+	require.Len(t, report.Items, 3)
+
+	// largest balance first, the second transfer is rejected
+	first, second, third := report.Items[0], report.Items[1], report.Items[2]
+	require.Equal(t, signers[2].Address(), first.Address)
+	require.Equal(t, "0x1", first.Hash)
+	require.Equal(t, "4790000", first.Value.String())
+	require.Equal(t, "210000", first.Fee.String())
+
+	require.Equal(t, signers[0].Address(), second.Address)
+	require.True(t, errors.Is(second.Err, ErrInsufficientFunds), "%v", second.Err)
+	require.Equal(t, 0, second.Value.Sign())
+
+	require.Equal(t, signers[1].Address(), third.Address)
+	require.Equal(t, SweepSkipEmpty, third.Skipped)
+	require.Equal(t, "", third.Hash)
+
+	require.Equal(t, "4790000", report.Value.String())
+	require.Equal(t, "210000", report.Fees.String())
+	require.Len(t, node.Calls("flow_sendRawTransaction"), 2)
+}
+
+func TestSweepDryRun(t *testing.T) {
+	node, signers := sweepNode(t, map[string]int64{"0": 1000000, "1": 400000, "2": 5000000})
+	defer node.Close()
+	node.Handle("flow_feeHistory", map[string]interface{}{
+		"oldestBlock":   "0x1",
+		"baseFeePerGas": []string{"0x5", "0x5"},
+		"gasUsedRatio":  []float64{0.5},
+		"reward":        [][]string{{"0x5", "0x5", "0x5"}},
+	})
+
+	sweep := Sweep{
+		Treasury: "0x" + strings.Repeat("aa", 20),
+		MinValue: big.NewInt(200000),
+		MaxFees:  big.NewInt(300000),
+		DryRun:   true,
+	}
+	report, err := New(node.URL).Sweep(context.Background(), signers, sweep)
+	require.Nil(t, err)
+
+	// gas price 5 + 5 from fee history
+	require.Equal(t, "", report.Items[0].Skipped)
+	require.Equal(t, "4790000", report.Items[0].Value.String())
+	require.Equal(t, SweepSkipFeeBudget, report.Items[1].Skipped)
+	require.Equal(t, SweepSkipMinValue, report.Items[2].Skipped)
+	require.Equal(t, "210000", report.Fees.String())
+	require.Len(t, node.Calls("flow_sendRawTransaction"), 0)
+
+	_, err = New(node.URL).Sweep(context.Background(), signers, Sweep{})
+	require.EqualError(t, err, "Sweep treasury address is not set")
+}
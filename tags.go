@@ -0,0 +1,91 @@
+package asimovrpc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Well-known tag keys
+const (
+	TagTenant    = "tenant"
+	TagRequestID = "request_id"
+)
+
+// RequestIDHeader - header carrying TagRequestID to the node, so calls can be traced through proxies
+const RequestIDHeader = "X-Request-ID"
+
+// Tags - per-call values such as tenant or request ID. Tags attached to the call context
+// attribute the call in Stats (see StatsByTag), debug and slow call logs.
+type Tags map[string]string
+
+type tagsKey struct{}
+
+// WithTags returns context carrying tags merged over the tags already attached to ctx
+func WithTags(ctx context.Context, tags Tags) context.Context {
+	merged := Tags{}
+	for key, value := range TagsFromContext(ctx) {
+		merged[key] = value
+	}
+	for key, value := range tags {
+		merged[key] = value
+	}
+
+	return context.WithValue(ctx, tagsKey{}, merged)
+}
+
+// WithTenant returns context tagged with tenant ID
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return WithTags(ctx, Tags{TagTenant: tenant})
+}
+
+// WithRequestID returns context tagged with request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return WithTags(ctx, Tags{TagRequestID: id})
+}
+
+// TagsFromContext returns tags attached to ctx (nil - no tags)
+func TagsFromContext(ctx context.Context) Tags {
+	tags, _ := ctx.Value(tagsKey{}).(Tags)
+	return tags
+}
+
+func (tags Tags) String() string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = fmt.Sprintf("%s=%s", key, tags[key])
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+// WithDefaultTags set tags attached to every call, tags of the call context take precedence
+func WithDefaultTags(tags Tags) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		rpc.tags = tags
+	}
+}
+
+// tagged returns ctx carrying default tags of the client
+func (rpc *AsimovRPC) tagged(ctx context.Context) context.Context {
+	if len(rpc.tags) == 0 {
+		return ctx
+	}
+
+	merged := Tags{}
+	for key, value := range rpc.tags {
+		merged[key] = value
+	}
+	for key, value := range TagsFromContext(ctx) {
+		merged[key] = value
+	}
+
+	return context.WithValue(ctx, tagsKey{}, merged)
+}
@@ -0,0 +1,57 @@
+package asimovrpc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestWithTags(t *testing.T) {
+	require.Nil(t, TagsFromContext(context.Background()))
+
+	ctx := WithTenant(context.Background(), "acme")
+	tagged := WithTags(WithRequestID(ctx, "r-1"), Tags{"job": "indexer"})
+
+	require.Equal(t, Tags{TagTenant: "acme"}, TagsFromContext(ctx))
+	require.Equal(t, Tags{TagTenant: "acme", TagRequestID: "r-1", "job": "indexer"}, TagsFromContext(tagged))
+	require.Equal(t, "job=indexer request_id=r-1 tenant=acme", TagsFromContext(tagged).String())
+}
+
+func (s *AsimovRPCTestSuite) TestTagsAttribution() {
+	log := new(bufferLogger)
+	rpc := New(s.rpc.url, WithLogger(log), WithDebug(true), WithDebugFormat(DebugJSON))
+
+	var requestIDs []string
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		requestIDs = append(requestIDs, request.Header.Get(RequestIDHeader))
+		return httpmock.NewStringResponse(200, echoID(request, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`)), nil
+	})
+
+	ctx := WithRequestID(WithTenant(context.Background(), "acme"), "r-1")
+	_, err := rpc.CallContext(ctx, "flow_blockNumber")
+	s.Require().Nil(err)
+	_, err = rpc.WithContext(WithTenant(context.Background(), "globex")).AsimovBlockNumber()
+	s.Require().Nil(err)
+	_, err = rpc.Call("flow_blockNumber")
+	s.Require().Nil(err)
+
+	s.Require().Equal([]string{"r-1", "", ""}, requestIDs)
+	s.Require().Equal(3, rpc.Stats()["flow_blockNumber"].Calls)
+
+	tenants := rpc.StatsByTag(TagTenant)
+	s.Require().Len(tenants, 2)
+	s.Require().Equal(1, tenants["acme"]["flow_blockNumber"].Calls)
+	s.Require().Equal(1, tenants["globex"]["flow_blockNumber"].Calls)
+	s.Require().True(tenants["acme"]["flow_blockNumber"].ResponseBytes > 0)
+	s.Require().Empty(rpc.StatsByTag("job"))
+
+	s.Require().Len(log.lines, 3)
+	s.Require().Equal("acme", gjson.Get(log.lines[0], "tags.tenant").String())
+	s.Require().Equal("r-1", gjson.Get(log.lines[0], "tags.request_id").String())
+	s.Require().False(gjson.Get(log.lines[2], "tags").Exists())
+}
@@ -0,0 +1,180 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"unsafe"
+)
+
+// CallTracer - name of the built-in tracer returning CallFrame results
+const CallTracer = "callTracer"
+
+// TraceConfig - options of debug_trace* methods. Without Tracer the struct logger is used,
+// its results decode into ExecutionTrace.
+type TraceConfig struct {
+	Tracer           string          `json:"tracer,omitempty"`
+	TracerConfig     json.RawMessage `json:"tracerConfig,omitempty"` // e.g. {"onlyTopCall": true} for CallTracer
+	Timeout          string          `json:"timeout,omitempty"`      // e.g. "10s"
+	DisableStack     bool            `json:"disableStack,omitempty"`
+	DisableStorage   bool            `json:"disableStorage,omitempty"`
+	EnableMemory     bool            `json:"enableMemory,omitempty"`
+	EnableReturnData bool            `json:"enableReturnData,omitempty"`
+}
+
+// ExecutionTrace - result of the struct logger
+type ExecutionTrace struct {
+	Gas         int         `json:"gas"`
+	Failed      bool        `json:"failed"`
+	ReturnValue string      `json:"returnValue"`
+	StructLogs  []StructLog `json:"structLogs"`
+}
+
+// StructLog - EVM state after an executed opcode
+type StructLog struct {
+	PC      int               `json:"pc"`
+	Op      string            `json:"op"`
+	Gas     int               `json:"gas"`
+	GasCost int               `json:"gasCost"`
+	Depth   int               `json:"depth"`
+	Error   string            `json:"error,omitempty"`
+	Stack   []string          `json:"stack,omitempty"`
+	Memory  []string          `json:"memory,omitempty"`
+	Storage map[string]string `json:"storage,omitempty"`
+}
+
+// TransactionTrace - tracer result of a transaction, decode it with CallFrame or ExecutionTrace
+// depending on the tracer
+type TransactionTrace struct {
+	TxHash string          `json:"txHash"`
+	Result json.RawMessage `json:"result"`
+	Error  string          `json:"error,omitempty"` // tracing of the transaction failed
+}
+
+// CallFrame decodes result of CallTracer
+func (t TransactionTrace) CallFrame() (*CallFrame, error) {
+	if t.Error != "" {
+		return nil, fmt.Errorf("Trace of %s failed: %s", t.TxHash, t.Error)
+	}
+
+	frame := new(CallFrame)
+	if err := json.Unmarshal(t.Result, frame); err != nil {
+		return nil, err
+	}
+
+	return frame, nil
+}
+
+// ExecutionTrace decodes result of the struct logger
+func (t TransactionTrace) ExecutionTrace() (*ExecutionTrace, error) {
+	if t.Error != "" {
+		return nil, fmt.Errorf("Trace of %s failed: %s", t.TxHash, t.Error)
+	}
+
+	trace := new(ExecutionTrace)
+	if err := json.Unmarshal(t.Result, trace); err != nil {
+		return nil, err
+	}
+
+	return trace, nil
+}
+
+// DebugAPI - debug namespace methods, exposed by nodes started with the debug API enabled
+type DebugAPI struct {
+	namespace
+}
+
+// Debug returns debug namespace client
+func (rpc *AsimovRPC) Debug() DebugAPI {
+	return DebugAPI{namespace{rpc: rpc, name: "debug"}}
+}
+
+// TraceTransaction replays mined transaction hash with debug_traceTransaction, config nil - struct logger.
+// Reverted transactions are traced too: see ExecutionTrace.Failed and CallFrame.Error.
+func (api DebugAPI) TraceTransaction(hash string, config *TraceConfig) (*TransactionTrace, error) {
+	params := []interface{}{hash}
+	if config != nil {
+		params = append(params, config)
+	}
+
+	trace := &TransactionTrace{TxHash: hash}
+	err := api.call("debug_traceTransaction", &trace.Result, params...)
+	return trace, err
+}
+
+// TraceBlockByNumber replays all transactions of block number with debug_traceBlockByNumber
+func (api DebugAPI) TraceBlockByNumber(number BlockNumber, config *TraceConfig) ([]TransactionTrace, error) {
+	return api.traceBlock("debug_traceBlockByNumber", number, config)
+}
+
+// TraceBlockByHash replays all transactions of block hash with debug_traceBlockByHash
+func (api DebugAPI) TraceBlockByHash(hash string, config *TraceConfig) ([]TransactionTrace, error) {
+	return api.traceBlock("debug_traceBlockByHash", hash, config)
+}
+
+func (api DebugAPI) traceBlock(method string, block interface{}, config *TraceConfig) ([]TransactionTrace, error) {
+	params := []interface{}{block}
+	if config != nil {
+		params = append(params, config)
+	}
+
+	var traces []TransactionTrace
+	err := api.call(method, &traces, params...)
+	return traces, err
+}
+
+// AsimovTraceTransaction replays mined transaction hash, see DebugAPI.TraceTransaction
+func (rpc *AsimovRPC) AsimovTraceTransaction(hash string, config *TraceConfig) (*TransactionTrace, error) {
+	return rpc.Debug().TraceTransaction(hash, config)
+}
+
+// AsimovTraceBlockByNumber replays all transactions of block number, see DebugAPI.TraceBlockByNumber
+func (rpc *AsimovRPC) AsimovTraceBlockByNumber(number BlockNumber, config *TraceConfig) ([]TransactionTrace, error) {
+	return rpc.Debug().TraceBlockByNumber(number, config)
+}
+
+// AsimovTraceBlockByHash replays all transactions of block hash, see DebugAPI.TraceBlockByHash
+func (rpc *AsimovRPC) AsimovTraceBlockByHash(hash string, config *TraceConfig) ([]TransactionTrace, error) {
+	return rpc.Debug().TraceBlockByHash(hash, config)
+}
+
+// CallFrame - call trace frame in the callTracer result shape
+type CallFrame struct {
+	Type    string
+	From    string
+	To      string
+	Value   big.Int
+	Gas     int
+	GasUsed int
+	Input   string
+	Output  string
+	Error   string
+	Revert  string // decoded revert reason of a reverted call
+	Calls   []CallFrame
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (frame *CallFrame) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyCallFrame)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return err
+	}
+
+	*frame = *(*CallFrame)(unsafe.Pointer(proxy))
+
+	return nil
+}
+
+type proxyCallFrame struct {
+	Type    string      `json:"type"`
+	From    string      `json:"from"`
+	To      string      `json:"to"`
+	Value   hexBig      `json:"value"`
+	Gas     hexInt      `json:"gas"`
+	GasUsed hexInt      `json:"gasUsed"`
+	Input   string      `json:"input"`
+	Output  string      `json:"output"`
+	Error   string      `json:"error"`
+	Revert  string      `json:"revertReason"`
+	Calls   []CallFrame `json:"calls"`
+}
@@ -0,0 +1,119 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mistdex/mist-asimov-rpc/annotations"
+)
+
+// MethodDecoder decodes call input sent to contract address into a readable call, e.g. "transfer(0x1, 100)"
+type MethodDecoder func(to, input string) (string, bool)
+
+// TraceFormatter - renders call traces for humans
+type TraceFormatter struct {
+	Labels  annotations.Store
+	Methods MethodDecoder
+}
+
+// FormattedFrame - call frame with resolved labels and decoded method
+type FormattedFrame struct {
+	Type      string           `json:"type"`
+	From      string           `json:"from"`
+	FromLabel string           `json:"fromLabel,omitempty"`
+	To        string           `json:"to"`
+	ToLabel   string           `json:"toLabel,omitempty"`
+	Method    string           `json:"method,omitempty"`
+	Value     string           `json:"value"`
+	Gas       int              `json:"gas"`
+	GasUsed   int              `json:"gasUsed"`
+	Error     string           `json:"error,omitempty"`
+	Calls     []FormattedFrame `json:"calls,omitempty"`
+}
+
+// Format resolves labels and methods of frame and all nested calls
+func (f TraceFormatter) Format(frame CallFrame) FormattedFrame {
+	formatted := FormattedFrame{
+		Type:    frame.Type,
+		From:    frame.From,
+		To:      frame.To,
+		Method:  f.method(frame),
+		Value:   frame.Value.String(),
+		Gas:     frame.Gas,
+		GasUsed: frame.GasUsed,
+		Error:   frame.Error,
+	}
+	if label := annotations.Label(f.Labels, frame.From); label != frame.From {
+		formatted.FromLabel = label
+	}
+	if label := annotations.Label(f.Labels, frame.To); label != frame.To {
+		formatted.ToLabel = label
+	}
+
+	for _, call := range frame.Calls {
+		formatted.Calls = append(formatted.Calls, f.Format(call))
+	}
+
+	return formatted
+}
+
+// JSON renders trace as indented JSON tree
+func (f TraceFormatter) JSON(frame CallFrame) ([]byte, error) {
+	return json.MarshalIndent(f.Format(frame), "", "  ")
+}
+
+// Text renders trace as indented tree, one call per line
+func (f TraceFormatter) Text(frame CallFrame) string {
+	builder := new(strings.Builder)
+	f.text(builder, f.Format(frame), 0)
+
+	return builder.String()
+}
+
+func (f TraceFormatter) text(builder *strings.Builder, frame FormattedFrame, depth int) {
+	builder.WriteString(strings.Repeat("  ", depth))
+	fmt.Fprintf(builder, "%s %s -> %s", frame.Type, party(frame.From, frame.FromLabel), party(frame.To, frame.ToLabel))
+	if frame.Method != "" {
+		fmt.Fprintf(builder, " %s", frame.Method)
+	}
+	if frame.Value != "0" {
+		fmt.Fprintf(builder, " value=%s", frame.Value)
+	}
+	fmt.Fprintf(builder, " gas=%d/%d", frame.GasUsed, frame.Gas)
+	if frame.Error != "" {
+		fmt.Fprintf(builder, " error=%q", frame.Error)
+	}
+	builder.WriteString("\n")
+
+	for _, call := range frame.Calls {
+		f.text(builder, call, depth+1)
+	}
+}
+
+func (f TraceFormatter) method(frame CallFrame) string {
+	input := strings.TrimPrefix(frame.Input, "0x")
+	if input == "" || frame.Type == "CREATE" || frame.Type == "CREATE2" {
+		return ""
+	}
+
+	if f.Methods != nil {
+		if method, ok := f.Methods(frame.To, frame.Input); ok {
+			return method
+		}
+	}
+
+	if len(input) >= 8 {
+		return "0x" + input[:8]
+	}
+
+	return "0x" + input
+}
+
+func party(address, label string) string {
+	if label == "" {
+		return address
+	}
+
+	return fmt.Sprintf("%s(%s)", label, address)
+}
@@ -0,0 +1,162 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mistdex/mist-asimov-rpc/abi"
+	"github.com/mistdex/mist-asimov-rpc/annotations"
+	"github.com/stretchr/testify/require"
+)
+
+const callTrace = `{
+	"type": "CALL",
+	"from": "0xaaa",
+	"to": "0xbbb",
+	"value": "0xde0b6b3a7640000",
+	"gas": "0x7a120",
+	"gasUsed": "0x5208",
+	"input": "0x38ed1739000000",
+	"output": "0x",
+	"calls": [{
+		"type": "STATICCALL",
+		"from": "0xbbb",
+		"to": "0xccc",
+		"gas": "0x1000",
+		"gasUsed": "0x100",
+		"input": "0x70a08231",
+		"output": "0x01"
+	}, {
+		"type": "CALL",
+		"from": "0xbbb",
+		"to": "0xddd",
+		"gas": "0x2000",
+		"gasUsed": "0x2000",
+		"input": "0xa9059cbb00",
+		"error": "execution reverted"
+	}]
+}`
+
+func TestCallFrameUnmarshal(t *testing.T) {
+	var frame CallFrame
+	require.Nil(t, json.Unmarshal([]byte(callTrace), &frame))
+
+	require.Equal(t, "CALL", frame.Type)
+	require.Equal(t, newBigInt("1000000000000000000"), frame.Value)
+	require.Equal(t, 500000, frame.Gas)
+	require.Equal(t, 21000, frame.GasUsed)
+	require.Len(t, frame.Calls, 2)
+	require.Equal(t, "STATICCALL", frame.Calls[0].Type)
+	require.Equal(t, 256, frame.Calls[0].GasUsed)
+	require.Equal(t, "execution reverted", frame.Calls[1].Error)
+}
+
+func TestTraceFormatter(t *testing.T) {
+	var frame CallFrame
+	require.Nil(t, json.Unmarshal([]byte(callTrace), &frame))
+
+	formatter := TraceFormatter{
+		Labels: annotations.NewMemoryStore(
+			annotations.Annotation{Address: "0xAAA", Label: "Trader"},
+			annotations.Annotation{Address: "0xbbb", Label: "Router"},
+		),
+		Methods: func(to, input string) (string, bool) {
+			if strings.HasPrefix(input, "0xa9059cbb") {
+				return "transfer(...)", true
+			}
+			return "", false
+		},
+	}
+
+	expected := strings.Join([]string{
+		"CALL Trader(0xaaa) -> Router(0xbbb) 0x38ed1739 value=1000000000000000000 gas=21000/500000",
+		"  STATICCALL Router(0xbbb) -> 0xccc 0x70a08231 gas=256/4096",
+		`  CALL Router(0xbbb) -> 0xddd transfer(...) gas=8192/8192 error="execution reverted"`,
+		"",
+	}, "\n")
+	require.Equal(t, expected, formatter.Text(frame))
+
+	data, err := formatter.JSON(frame)
+	require.Nil(t, err)
+	var formatted FormattedFrame
+	require.Nil(t, json.Unmarshal(data, &formatted))
+	require.Equal(t, "Trader", formatted.FromLabel)
+	require.Equal(t, "", formatted.Calls[0].ToLabel)
+	require.Equal(t, "transfer(...)", formatted.Calls[1].Method)
+	require.Equal(t, "0", formatted.Calls[1].Value)
+
+	require.Equal(t, "CALL 0xaaa -> 0xbbb 0x38ed1739 value=1000000000000000000 gas=21000/500000\n", TraceFormatter{}.Text(CallFrame{
+		Type: "CALL", From: "0xaaa", To: "0xbbb", Value: frame.Value, Gas: 500000, GasUsed: 21000, Input: "0x38ed1739000000",
+	}))
+
+	token, err := abi.Parse([]byte(`[{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}]}]`))
+	require.Nil(t, err)
+	input, err := token.EncodeCall("transfer", "0x00000000000000000000000000000000000000ee", 100)
+	require.Nil(t, err)
+	formatter = TraceFormatter{Methods: token.MethodDecoder()}
+	require.Equal(t, "transfer(0x00000000000000000000000000000000000000ee, 100)", formatter.Format(CallFrame{To: "0xddd", Input: input}).Method)
+}
+
+const structLoggerTrace = `{
+	"gas": 23400,
+	"failed": true,
+	"returnValue": "08c379a0",
+	"structLogs": [
+		{"pc": 0, "op": "PUSH1", "gas": 78600, "gasCost": 3, "depth": 1, "stack": []},
+		{"pc": 2, "op": "REVERT", "gas": 78597, "gasCost": 0, "depth": 1, "stack": ["0x0", "0x64"], "storage": {"00": "01"}}
+	]
+}`
+
+func (s *AsimovRPCTestSuite) TestAsimovTraceTransaction() {
+	s.registerResponse(structLoggerTrace, func(body []byte) {
+		s.methodEqual(body, "debug_traceTransaction")
+		s.paramsEqual(body, `["0xab"]`)
+	})
+
+	trace, err := s.rpc.AsimovTraceTransaction("0xab", nil)
+	s.Require().Nil(err)
+	execution, err := trace.ExecutionTrace()
+	s.Require().Nil(err)
+	s.Require().True(execution.Failed)
+	s.Require().Equal(23400, execution.Gas)
+	s.Require().Len(execution.StructLogs, 2)
+	s.Require().Equal(StructLog{PC: 2, Op: "REVERT", Gas: 78597, Depth: 1, Stack: []string{"0x0", "0x64"}, Storage: map[string]string{"00": "01"}}, execution.StructLogs[1])
+
+	s.registerResponse(`{"type": "CALL", "from": "0xaaa", "to": "0xbbb", "gas": "0x10", "gasUsed": "0x10", "error": "execution reverted", "revertReason": "not owner"}`, func(body []byte) {
+		s.paramsEqual(body, `["0xab", {"tracer": "callTracer", "tracerConfig": {"onlyTopCall": true}, "timeout": "5s"}]`)
+	})
+
+	trace, err = s.rpc.AsimovTraceTransaction("0xab", &TraceConfig{Tracer: CallTracer, TracerConfig: json.RawMessage(`{"onlyTopCall": true}`), Timeout: "5s"})
+	s.Require().Nil(err)
+	frame, err := trace.CallFrame()
+	s.Require().Nil(err)
+	s.Require().Equal("execution reverted", frame.Error)
+	s.Require().Equal("not owner", frame.Revert)
+}
+
+func (s *AsimovRPCTestSuite) TestAsimovTraceBlock() {
+	result := `[{"txHash": "0x1", "result": ` + callTrace + `}, {"txHash": "0x2", "error": "execution timeout"}]`
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "debug_traceBlockByNumber")
+		s.paramsEqual(body, `["0xa", {"tracer": "callTracer"}]`)
+	})
+
+	traces, err := s.rpc.AsimovTraceBlockByNumber(10, &TraceConfig{Tracer: CallTracer})
+	s.Require().Nil(err)
+	s.Require().Len(traces, 2)
+	frame, err := traces[0].CallFrame()
+	s.Require().Nil(err)
+	s.Require().Len(frame.Calls, 2)
+	_, err = traces[1].CallFrame()
+	s.Require().EqualError(err, "Trace of 0x2 failed: execution timeout")
+
+	s.registerResponse(`[]`, func(body []byte) {
+		s.methodEqual(body, "debug_traceBlockByHash")
+		s.paramsEqual(body, `["0xbeef"]`)
+	})
+
+	traces, err = s.rpc.AsimovTraceBlockByHash("0xbeef", nil)
+	s.Require().Nil(err)
+	s.Require().Empty(traces)
+}
@@ -0,0 +1,153 @@
+package asimovrpc
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/mistdex/mist-asimov-rpc/abi"
+)
+
+// Sources of token transfers
+const (
+	TransferFromEvent = "event"
+	TransferFromTrace = "trace"
+)
+
+// standardToken - transfer methods of ERC20 tokens, used for traced tokens without an ABI
+var standardToken, _ = abi.Parse([]byte(`[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"transferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}
+]`))
+
+// TokenTransfer - token amount moved by a transaction
+type TokenTransfer struct {
+	Token  string
+	From   string
+	To     string
+	Amount big.Int
+	Source string // TransferFromEvent or TransferFromTrace
+}
+
+// TransferExtractor - decodes token transfers of transactions from Transfer events and, for tokens
+// emitting no events, from call traces
+type TransferExtractor struct {
+	RPC Client
+	// Traced - tokens whose transfers are read from debug_traceTransaction call traces, by address.
+	// Successful transfer and transferFrom calls are decoded with the token ABI (nil - ERC20 methods).
+	// Trace transfers of a token are used only if the transaction has no Transfer event of it.
+	Traced map[string]*abi.ABI
+}
+
+// TokenTransfers returns token transfers of mined transaction hash decoded from its Transfer events
+func (rpc *AsimovRPC) TokenTransfers(ctx context.Context, hash string) ([]TokenTransfer, error) {
+	return TransferExtractor{RPC: rpc}.Transfers(ctx, hash)
+}
+
+// Transfers returns transfers of mined transaction hash: Transfer events in log order followed by
+// transfers of traced tokens in call order
+func (e TransferExtractor) Transfers(ctx context.Context, hash string) ([]TokenTransfer, error) {
+	rpc := bind(e.RPC, ctx)
+	receipt, err := rpc.AsimovGetTransactionReceipt(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	var transfers []TokenTransfer
+	emitted := map[string]bool{}
+	for _, log := range receipt.Logs {
+		if log.Removed || len(log.Topics) != 3 || !strings.EqualFold(log.Topics[0], TransferTopic) {
+			continue // ERC721 transfers index the token ID
+		}
+		amount, err := ParseBigInt(log.Data)
+		if err != nil {
+			continue
+		}
+		transfers = append(transfers, TokenTransfer{
+			Token:  log.Address,
+			From:   topicAddress(log.Topics[1]),
+			To:     topicAddress(log.Topics[2]),
+			Amount: amount,
+			Source: TransferFromEvent,
+		})
+		emitted[strings.ToLower(log.Address)] = true
+	}
+
+	if len(e.Traced) == 0 || receipt.Status == "0x0" {
+		return transfers, nil
+	}
+
+	traced := make(map[string]*abi.ABI, len(e.Traced))
+	for token, contract := range e.Traced {
+		if !emitted[strings.ToLower(token)] {
+			if contract == nil {
+				contract = standardToken
+			}
+			traced[strings.ToLower(token)] = contract
+		}
+	}
+	if len(traced) == 0 {
+		return transfers, nil
+	}
+
+	trace, err := rpc.AsimovTraceTransaction(hash, &TraceConfig{Tracer: CallTracer})
+	if err != nil {
+		return nil, err
+	}
+	frame, err := trace.CallFrame()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(transfers, tracedTransfers(*frame, traced)...), nil
+}
+
+// tracedTransfers returns transfers of successful calls to traced tokens in frame and its subcalls
+func tracedTransfers(frame CallFrame, traced map[string]*abi.ABI) []TokenTransfer {
+	if frame.Error != "" {
+		return nil // reverted calls moved nothing
+	}
+
+	var transfers []TokenTransfer
+	if contract := traced[strings.ToLower(frame.To)]; contract != nil && (frame.Type == "CALL" || frame.Type == "") {
+		if transfer, ok := decodeTransferCall(contract, frame); ok {
+			transfers = append(transfers, transfer)
+		}
+	}
+	for _, call := range frame.Calls {
+		transfers = append(transfers, tracedTransfers(call, traced)...)
+	}
+
+	return transfers
+}
+
+func decodeTransferCall(contract *abi.ABI, frame CallFrame) (TokenTransfer, bool) {
+	method, args, err := contract.DecodeInput(frame.Input)
+	if err != nil {
+		return TokenTransfer{}, false
+	}
+
+	transfer := TokenTransfer{Token: frame.To, Source: TransferFromTrace}
+	var amount interface{}
+	switch {
+	case method.Name == "transfer" && len(args) == 2:
+		transfer.From, transfer.To, amount = frame.From, stringValue(args[0]), args[1]
+	case method.Name == "transferFrom" && len(args) == 3:
+		transfer.From, transfer.To, amount = stringValue(args[0]), stringValue(args[1]), args[2]
+	default:
+		return TokenTransfer{}, false
+	}
+
+	value, ok := amount.(*big.Int)
+	if !ok || transfer.To == "" {
+		return TokenTransfer{}, false
+	}
+	transfer.Amount.Set(value)
+
+	return transfer, true
+}
+
+func stringValue(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
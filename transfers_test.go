@@ -0,0 +1,75 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/mistdex/mist-asimov-rpc/abi"
+)
+
+func transferInput(selector string, words ...string) string {
+	input := selector
+	for _, word := range words {
+		input += strings.Repeat("0", 64-len(word)) + word
+	}
+	return input
+}
+
+func (s *AsimovRPCTestSuite) TestTokenTransfers() {
+	token := "0x" + strings.Repeat("11", 20)
+	recipient := strings.Repeat("22", 20)
+	owner := strings.Repeat("33", 20)
+	trace := fmt.Sprintf(`{"type": "CALL", "from": "0xaaa", "to": "0xbbb", "calls": [
+		{"type": "CALL", "from": "0xbbb", "to": "%[1]s", "input": "%[2]s"},
+		{"type": "CALL", "from": "0xbbb", "to": "%[1]s", "input": "%[3]s", "error": "execution reverted"},
+		{"type": "STATICCALL", "from": "0xbbb", "to": "%[1]s", "input": "%[2]s"},
+		{"type": "CALL", "from": "0xbbb", "to": "0xccc", "input": "%[2]s"},
+		{"type": "CALL", "from": "0xbbb", "to": "0xbbb", "calls": [
+			{"type": "CALL", "from": "0xbbb", "to": "%[1]s", "input": "%[3]s"}
+		]}
+	]}`, token,
+		transferInput("0xa9059cbb", recipient, "64"),
+		transferInput("0x23b872dd", owner, recipient, "c8"))
+
+	var methods []string
+	s.registerResponses(map[string]string{
+		"flow_getTransactionReceipt": explainReceipt,
+		"debug_traceTransaction":     trace,
+	}, func(body []byte) {
+		methods = append(methods, gjson.GetBytes(body, "method").String())
+	})
+
+	transfers, err := s.rpc.TokenTransfers(context.Background(), "0x01")
+	s.Require().Nil(err)
+	s.Require().Len(transfers, 1)
+	s.Require().Equal("0xccc", transfers[0].Token)
+	s.Require().Equal("0x0000000000000000000000000000000000000bbb", transfers[0].From)
+	s.Require().Equal("0x0000000000000000000000000000000000000ddd", transfers[0].To)
+	s.Require().Equal(int64(100), transfers[0].Amount.Int64())
+	s.Require().Equal(TransferFromEvent, transfers[0].Source)
+	s.Require().Equal([]string{"flow_getTransactionReceipt"}, methods)
+
+	// 0xccc emitted events, its calls are not counted twice
+	extractor := TransferExtractor{RPC: s.rpc, Traced: map[string]*abi.ABI{token: nil, "0xCCC": nil}}
+	transfers, err = extractor.Transfers(context.Background(), "0x01")
+	s.Require().Nil(err)
+	s.Require().Len(transfers, 3)
+	s.Require().Equal(TokenTransfer{Token: token, From: "0xbbb", To: "0x" + recipient, Source: TransferFromTrace}, withoutAmount(transfers[1]))
+	s.Require().Equal(int64(100), transfers[1].Amount.Int64())
+	s.Require().Equal(TokenTransfer{Token: token, From: "0x" + owner, To: "0x" + recipient, Source: TransferFromTrace}, withoutAmount(transfers[2]))
+	s.Require().Equal(int64(200), transfers[2].Amount.Int64())
+
+	s.registerResponses(map[string]string{"flow_getTransactionReceipt": explainReceipt}, func([]byte) {})
+	_, err = extractor.Transfers(context.Background(), "0x01")
+	s.Require().True(errors.Is(err, ErrMethodNotFound))
+}
+
+func withoutAmount(transfer TokenTransfer) TokenTransfer {
+	transfer.Amount = big.Int{}
+	return transfer
+}
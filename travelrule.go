@@ -0,0 +1,215 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TravelRuleParty - originator or beneficiary of a transfer
+type TravelRuleParty struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`           // on-chain address
+	Account string `json:"account,omitempty"` // account number at the VASP
+	VASP    string `json:"vasp,omitempty"`    // identifier of the virtual asset service provider
+	Country string `json:"country,omitempty"`
+}
+
+// TravelRule - off-chain originator and beneficiary information of a transfer
+type TravelRule struct {
+	Originator  TravelRuleParty   `json:"originator"`
+	Beneficiary TravelRuleParty   `json:"beneficiary"`
+	Extra       map[string]string `json:"extra,omitempty"`
+}
+
+// MetadataEnvelope - metadata attached to a sent transaction
+type MetadataEnvelope struct {
+	Hash       string     `json:"hash"`
+	Method     string     `json:"method"`
+	Created    time.Time  `json:"created"`
+	TravelRule TravelRule `json:"travelRule"`
+}
+
+// MetadataStore - pluggable storage of metadata envelopes, hashes are matched case-insensitively
+type MetadataStore interface {
+	Put(envelope MetadataEnvelope) error
+	Get(hash string) (MetadataEnvelope, bool, error)
+}
+
+// MemoryMetadataStore - in-memory MetadataStore safe for concurrent use
+type MemoryMetadataStore struct {
+	mu        sync.RWMutex
+	envelopes map[string]MetadataEnvelope
+}
+
+// NewMemoryMetadataStore creates empty store
+func NewMemoryMetadataStore() *MemoryMetadataStore {
+	return &MemoryMetadataStore{envelopes: map[string]MetadataEnvelope{}}
+}
+
+// Put adds or replaces envelope of its transaction
+func (s *MemoryMetadataStore) Put(envelope MetadataEnvelope) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.envelopes[strings.ToLower(envelope.Hash)] = envelope
+	return nil
+}
+
+// Get returns envelope of transaction hash
+func (s *MemoryMetadataStore) Get(hash string) (MetadataEnvelope, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	envelope, ok := s.envelopes[strings.ToLower(hash)]
+	return envelope, ok, nil
+}
+
+// All returns envelopes sorted by hash
+func (s *MemoryMetadataStore) All() []MetadataEnvelope {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]MetadataEnvelope, 0, len(s.envelopes))
+	for _, envelope := range s.envelopes {
+		result = append(result, envelope)
+	}
+	sort.Slice(result, func(i, j int) bool { return strings.ToLower(result[i].Hash) < strings.ToLower(result[j].Hash) })
+
+	return result
+}
+
+// ErrNoMetadataStore - travel rule metadata attached to a call of client without WithMetadataStore
+var ErrNoMetadataStore = errors.New("metadata store is not set")
+
+// MetadataStoreError - transaction was sent, but its metadata could not be stored
+type MetadataStoreError struct {
+	Hash string
+	Err  error
+}
+
+func (err MetadataStoreError) Error() string {
+	return fmt.Sprintf("Transaction %s sent, but its metadata was not stored: %v", err.Hash, err.Err)
+}
+
+// Unwrap returns the store error
+func (err MetadataStoreError) Unwrap() error {
+	return err.Err
+}
+
+// WithMetadataStore set store of metadata attached to sent transactions with WithTravelRule
+func WithMetadataStore(store MetadataStore) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if store == nil {
+			rpc.invalidOption("WithMetadataStore", "store is nil")
+			return
+		}
+		rpc.metadataStore = store
+	}
+}
+
+type travelRuleKey struct{}
+
+// WithTravelRule attaches travel rule metadata to transactions sent with ctx, for example:
+//
+//	ctx := asimovrpc.WithTravelRule(ctx, rule)
+//	hash, err := client.WithContext(ctx).SendTransactionLocal(transaction, signer)
+//
+// Once flow_sendTransaction or flow_sendRawTransaction succeeds, the metadata is stored with the
+// WithMetadataStore store under the transaction hash; clients without a store reject the transaction
+// with ErrNoMetadataStore before sending it. If the store fails, the transaction hash is returned
+// together with MetadataStoreError. Batched sends get their metadata stored the same way, store
+// errors are returned by Batch.Err of the request.
+func WithTravelRule(ctx context.Context, rule TravelRule) context.Context {
+	return context.WithValue(ctx, travelRuleKey{}, rule)
+}
+
+// TravelRuleFromContext returns travel rule metadata attached to ctx
+func TravelRuleFromContext(ctx context.Context) (TravelRule, bool) {
+	rule, ok := ctx.Value(travelRuleKey{}).(TravelRule)
+	return rule, ok
+}
+
+// checkMetadataStore rejects a send method with travel rule metadata before it is sent if there is no store for it
+func (rpc *AsimovRPC) checkMetadataStore(ctx context.Context, method string) error {
+	if method != "flow_sendTransaction" && method != "flow_sendRawTransaction" {
+		return nil
+	}
+	if _, ok := TravelRuleFromContext(ctx); ok && rpc.metadataStore == nil {
+		return ErrNoMetadataStore
+	}
+
+	return nil
+}
+
+// storeMetadata stores travel rule metadata of ctx under the hash returned by a send method
+func (rpc *AsimovRPC) storeMetadata(ctx context.Context, method string, result json.RawMessage) error {
+	if method != "flow_sendTransaction" && method != "flow_sendRawTransaction" {
+		return nil
+	}
+	rule, ok := TravelRuleFromContext(ctx)
+	if !ok || rpc.metadataStore == nil {
+		return nil
+	}
+
+	var hash string
+	if err := json.Unmarshal(result, &hash); err != nil {
+		return err
+	}
+
+	envelope := MetadataEnvelope{Hash: hash, Method: method, Created: rpc.clock.Now(), TravelRule: rule}
+	if err := rpc.metadataStore.Put(envelope); err != nil {
+		return MetadataStoreError{Hash: hash, Err: err}
+	}
+
+	return nil
+}
+
+// sentHash returns hash of a transaction sent with a failing metadata store together with the store error
+func sentHash(hash string, err error) (string, error) {
+	var storeErr MetadataStoreError
+	if errors.As(err, &storeErr) {
+		return storeErr.Hash, err
+	}
+
+	return hash, err
+}
+
+// TransactionMetadata returns metadata attached to transaction hash, nil if there is none
+func (rpc *AsimovRPC) TransactionMetadata(hash string) (*MetadataEnvelope, error) {
+	if rpc.metadataStore == nil {
+		return nil, ErrNoMetadataStore
+	}
+
+	envelope, ok, err := rpc.metadataStore.Get(hash)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &envelope, nil
+}
+
+// ReceiptWithMetadata - transaction receipt with metadata attached when the transaction was sent
+type ReceiptWithMetadata struct {
+	*TransactionReceipt
+	Metadata *MetadataEnvelope // nil - no metadata attached
+}
+
+// ReceiptWithMetadata returns receipt of transaction hash together with its metadata
+func (rpc *AsimovRPC) ReceiptWithMetadata(ctx context.Context, hash string) (*ReceiptWithMetadata, error) {
+	receipt, err := rpc.WithContext(ctx).AsimovGetTransactionReceipt(hash)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := rpc.TransactionMetadata(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReceiptWithMetadata{TransactionReceipt: receipt, Metadata: metadata}, nil
+}
@@ -0,0 +1,113 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+type failingMetadataStore struct {
+	*MemoryMetadataStore
+}
+
+func (failingMetadataStore) Put(MetadataEnvelope) error {
+	return errors.New("disk full")
+}
+
+func testTravelRule() TravelRule {
+	return TravelRule{
+		Originator:  TravelRuleParty{Name: "Alice", Address: "0x1", VASP: "vasp-a"},
+		Beneficiary: TravelRuleParty{Name: "Bob", Address: "0x2", Account: "42", VASP: "vasp-b", Country: "DE"},
+	}
+}
+
+func TestTravelRule(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_sendTransaction", "0xAB")
+	node.Handle("flow_getTransactionReceipt", map[string]interface{}{"transactionHash": "0xab", "status": "0x1"})
+
+	store := NewMemoryMetadataStore()
+	rpc := New(node.URL, WithMetadataStore(store))
+
+	ctx := WithTravelRule(context.Background(), testTravelRule())
+	hash, err := rpc.WithContext(ctx).AsimovSendTransaction(T{From: "0x1", To: "0x2"})
+	require.Nil(t, err)
+	require.Equal(t, "0xAB", hash)
+
+	envelopes := store.All()
+	require.Len(t, envelopes, 1)
+	require.Equal(t, "0xAB", envelopes[0].Hash)
+	require.Equal(t, "flow_sendTransaction", envelopes[0].Method)
+	require.Equal(t, testTravelRule(), envelopes[0].TravelRule)
+	require.False(t, envelopes[0].Created.IsZero())
+
+	receipt, err := rpc.ReceiptWithMetadata(context.Background(), "0xab")
+	require.Nil(t, err)
+	require.Equal(t, "0xab", receipt.TransactionHash)
+	require.Equal(t, "Bob", receipt.Metadata.TravelRule.Beneficiary.Name)
+
+	// calls without travel rule store nothing
+	_, err = rpc.AsimovSendTransaction(T{From: "0x1", To: "0x3"})
+	require.Nil(t, err)
+	require.Len(t, store.All(), 1)
+
+	metadata, err := rpc.TransactionMetadata("0xcd")
+	require.Nil(t, err)
+	require.Nil(t, metadata)
+}
+
+func TestTravelRuleStoreFailure(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_sendRawTransaction", "0xab")
+
+	ctx := WithTravelRule(context.Background(), testTravelRule())
+	_, err := New(node.URL).WithContext(ctx).AsimovSendRawTransaction("0x" + strings.Repeat("00", 10))
+	require.Equal(t, ErrNoMetadataStore, err)
+	require.Len(t, node.Calls("flow_sendRawTransaction"), 0)
+
+	rpc := New(node.URL, WithMetadataStore(failingMetadataStore{NewMemoryMetadataStore()}))
+	hash, err := rpc.WithContext(ctx).AsimovSendRawTransaction("0x" + strings.Repeat("00", 10))
+	require.EqualError(t, err, "Transaction 0xab sent, but its metadata was not stored: disk full")
+	require.Equal(t, "0xab", hash)
+	require.Len(t, node.Calls("flow_sendRawTransaction"), 1)
+
+	_, err = New(node.URL).TransactionMetadata("0xab")
+	require.Equal(t, ErrNoMetadataStore, err)
+
+	_, err = NewClient(node.URL, WithMetadataStore(nil))
+	require.EqualError(t, err, "asimovrpc: invalid option WithMetadataStore: store is nil")
+}
+
+func TestTravelRuleBatch(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_sendRawTransaction", "0xab")
+	node.Handle("flow_blockNumber", "0x1")
+
+	ctx := WithTravelRule(context.Background(), testTravelRule())
+	var hash string
+	batch := New(node.URL).NewBatch().Add("flow_sendRawTransaction", &hash, "0x00").Add("flow_blockNumber", nil)
+	require.Nil(t, batch.ExecuteContext(ctx))
+	require.Equal(t, ErrNoMetadataStore, batch.Err(0))
+	require.Nil(t, batch.Err(1))
+	require.Len(t, node.Calls("flow_sendRawTransaction"), 0)
+
+	store := NewMemoryMetadataStore()
+	batch = New(node.URL, WithMetadataStore(store)).NewBatch().Add("flow_sendRawTransaction", &hash, "0x00")
+	require.Nil(t, batch.ExecuteContext(ctx))
+	require.Nil(t, batch.Err(0))
+	require.Equal(t, "0xab", hash)
+	require.Len(t, store.All(), 1)
+	require.Equal(t, "flow_sendRawTransaction", store.All()[0].Method)
+
+	batch = New(node.URL, WithMetadataStore(failingMetadataStore{store})).NewBatch().Add("flow_sendRawTransaction", nil, "0x00")
+	require.Nil(t, batch.ExecuteContext(ctx))
+	require.EqualError(t, batch.Err(0), "Transaction 0xab sent, but its metadata was not stored: disk full")
+}
@@ -0,0 +1,236 @@
+package asimovrpc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// ErrChainIDUnknown - chain ID is neither configured by WithNetwork nor reported by the node
+var ErrChainIDUnknown = errors.New("chain id is unknown")
+
+// TransactionSigner signs transaction hashes with a key held outside of the node
+type TransactionSigner interface {
+	// Address returns 0x-prefixed 20-byte address of the signing key, see keyAddress
+	Address() string
+	// SignHash returns 65-byte signature R || S || V where V is the recovery id (0 or 1)
+	SignHash(hash []byte) ([]byte, error)
+}
+
+// PrivateKeySigner - TransactionSigner holding a secp256k1 private key in memory
+type PrivateKeySigner struct {
+	key     *secp256k1.PrivateKey
+	address string
+}
+
+// NewPrivateKeySigner creates signer from hex encoded private key
+func NewPrivateKeySigner(privateKey string) (*PrivateKeySigner, error) {
+	data, err := HexToBytes(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != 32 {
+		return nil, fmt.Errorf("private key must be 32 bytes, got %d", len(data))
+	}
+
+	key := secp256k1.PrivKeyFromBytes(data)
+
+	return &PrivateKeySigner{
+		key:     key,
+		address: keyAddress(key.PubKey()),
+	}, nil
+}
+
+// keyAddress returns 20-byte address of public key, the last 20 bytes of Keccak-256 of the uncompressed key.
+// Local signing identifies keys by it, not by the 21-byte 0x66-prefixed Asimov account address,
+// so From of locally signed transactions must be empty or 20 bytes.
+func keyAddress(key *secp256k1.PublicKey) string {
+	public := key.SerializeUncompressed()
+
+	return fmt.Sprintf("0x%x", Keccak256(public[1:])[12:])
+}
+
+// Address returns 20-byte address of the key
+func (s *PrivateKeySigner) Address() string {
+	return s.address
+}
+
+// SignHash signs 32-byte hash
+func (s *PrivateKeySigner) SignHash(hash []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, fmt.Errorf("hash must be 32 bytes, got %d", len(hash))
+	}
+
+	compact := ecdsa.SignCompact(s.key, hash, false)
+	return append(compact[1:], compact[0]-27), nil
+}
+
+// SigningHash returns EIP-155 hash of legacy transaction signed for chainID.
+// Typed transactions fail with UnknownTxTypeError, see SignTransaction.
+func SigningHash(transaction T, chainID int64) ([]byte, error) {
+	fields, err := legacyFields(transaction)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := rlpEncode(append(fields, big.NewInt(chainID), 0, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	return Keccak256(encoded), nil
+}
+
+// SignTransaction signs legacy transaction locally with EIP-155 replay protection.
+// The returned raw transaction can be broadcast with AsimovSendRawTransaction.
+// Asimov defines no typed transaction envelopes yet, so transactions with another Type than TxLegacy
+// fail with UnknownTxTypeError here, in SigningHash, SendTransactionLocal and PartialTransaction, instead of
+// being signed in a format the node may not accept.
+// From and To are 20-byte addresses: 21-byte Asimov account addresses fail, see keyAddress.
+func SignTransaction(transaction T, chainID int64, signer TransactionSigner) (*SignedTransaction, error) {
+	hash, err := SigningHash(transaction, chainID)
+	if err != nil {
+		return nil, err
+	}
+	if transaction.From != "" && !strings.EqualFold(transaction.From, signer.Address()) {
+		return nil, fmt.Errorf("transaction from %s does not match signer %s", transaction.From, signer.Address())
+	}
+	signature, err := signer.SignHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return assembleTransaction(transaction, chainID, signer.Address(), signature)
+}
+
+// assembleTransaction encodes legacy transaction with 65-byte signature R || S || V of its signing hash
+func assembleTransaction(transaction T, chainID int64, from string, signature []byte) (*SignedTransaction, error) {
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("signature must be 65 bytes, got %d", len(signature))
+	}
+
+	v := new(big.Int).SetInt64(chainID*2 + 35 + int64(signature[64]))
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:64])
+	fields, err := legacyFields(transaction)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := rlpEncode(append(fields, v, r, s))
+	if err != nil {
+		return nil, err
+	}
+
+	signed := &SignedTransaction{
+		Raw: fmt.Sprintf("0x%x", raw),
+		Tx: Transaction{
+			Hash:  fmt.Sprintf("0x%x", Keccak256(raw)),
+			Nonce: transaction.Nonce,
+			From:  from,
+			To:    transaction.To,
+			Gas:   transaction.Gas,
+			Input: transaction.Data,
+		},
+	}
+	if transaction.Value != nil {
+		signed.Tx.Value = *transaction.Value
+	}
+	if transaction.GasPrice != nil {
+		signed.Tx.GasPrice = *transaction.GasPrice
+	}
+
+	return signed, nil
+}
+
+// legacyFields returns RLP fields of unsigned legacy transaction, UnknownTxTypeError for typed transactions.
+// Empty To is a contract creation, so a malformed recipient is an error rather than being encoded as one.
+// From is not encoded but must be a key address too, see keyAddress.
+func legacyFields(transaction T) ([]interface{}, error) {
+	if transaction.Type != TxLegacy {
+		return nil, UnknownTxTypeError{Type: transaction.Type}
+	}
+	from, err := HexToBytes(transaction.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction from %q: %s", transaction.From, err)
+	}
+	if len(from) != 0 && len(from) != 20 {
+		return nil, fmt.Errorf("transaction from must be empty or 20 bytes, got %d", len(from))
+	}
+	to, err := HexToBytes(transaction.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction to %q: %s", transaction.To, err)
+	}
+	if len(to) != 0 && len(to) != 20 {
+		return nil, fmt.Errorf("transaction to must be empty or 20 bytes, got %d", len(to))
+	}
+	data, err := HexToBytes(transaction.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction data: %s", err)
+	}
+
+	return []interface{}{
+		transaction.Nonce,
+		transaction.GasPrice,
+		transaction.Gas,
+		to,
+		transaction.Value,
+		data,
+	}, nil
+}
+
+// ChainID returns chain ID of the configured network, or the chain ID reported by the node with
+// flow_chainId (cached with WithChainIDCache)
+func (rpc *AsimovRPC) ChainID() (int64, error) {
+	if network, ok := rpc.Network(); ok && network.ChainID != 0 {
+		return int64(network.ChainID), nil
+	}
+
+	id, err := rpc.AsimovChainID()
+	if err != nil {
+		return 0, err
+	}
+	if !id.IsInt64() {
+		return 0, ErrChainIDUnknown
+	}
+
+	return id.Int64(), nil
+}
+
+// SendTransactionLocal signs transaction with signer and broadcasts it with flow_sendRawTransaction,
+// so the node does not need an unlocked account. Transaction is screened with the WithScreener screener and checked
+// against WithGasCaps caps before signing.
+// The lifecycle attached to the client context with WithTxLifecycle is moved to signed and broadcast.
+func (rpc *AsimovRPC) SendTransactionLocal(transaction T, signer TransactionSigner) (string, error) {
+	if transaction.From == "" {
+		transaction.From = signer.Address()
+	}
+	if err := rpc.screen(rpc.context(), "flow_sendRawTransaction", transaction); err != nil {
+		return "", err
+	}
+	if err := rpc.capGas("flow_sendTransaction", []interface{}{transaction}); err != nil {
+		return "", err
+	}
+
+	chainID, err := rpc.ChainID()
+	if err != nil {
+		return "", err
+	}
+
+	signed, err := SignTransaction(transaction, chainID, signer)
+	if err != nil {
+		return "", err
+	}
+	if err := advanceLifecycle(rpc.context(), rpc.clock, signed.Tx.Hash, TxSigned); err != nil {
+		return "", err
+	}
+
+	hash, err := rpc.AsimovSendRawTransaction(signed.Raw)
+	if err != nil {
+		return hash, err
+	}
+
+	return hash, advanceLifecycle(rpc.context(), rpc.clock, hash, TxBroadcast)
+}
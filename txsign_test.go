@@ -0,0 +1,135 @@
+package asimovrpc
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestRLPEncode(t *testing.T) {
+	tests := []struct {
+		item     interface{}
+		expected string
+	}{
+		{[]byte{}, "80"},
+		{[]byte{0x7f}, "7f"},
+		{"dog", "83646f67"},
+		{0, "80"},
+		{1024, "820400"},
+		{big.NewInt(15), "0f"},
+		{(*big.Int)(nil), "80"},
+		{[]interface{}{}, "c0"},
+		{[]interface{}{"cat", "dog"}, "c88363617483646f67"},
+		{strings.Repeat("a", 56), "b838" + strings.Repeat("61", 56)},
+	}
+
+	for _, test := range tests {
+		encoded, err := rlpEncode(test.item)
+		require.Nil(t, err)
+		require.Equal(t, test.expected, hex.EncodeToString(encoded), "%v", test.item)
+	}
+
+	_, err := rlpEncode(-1)
+	require.NotNil(t, err)
+	_, err = rlpEncode(1.5)
+	require.NotNil(t, err)
+}
+
+// transaction from the EIP-155 specification example
+func eip155Transaction() T {
+	value, _ := new(big.Int).SetString("1000000000000000000", 10)
+	return T{
+		To:       "0x3535353535353535353535353535353535353535",
+		Gas:      21000,
+		GasPrice: big.NewInt(20000000000),
+		Value:    value,
+		Nonce:    9,
+	}
+}
+
+func TestSignTransaction(t *testing.T) {
+	signer, err := NewPrivateKeySigner("0x" + strings.Repeat("46", 32))
+	require.Nil(t, err)
+	require.Equal(t, "0x9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f", signer.Address())
+
+	hash, err := SigningHash(eip155Transaction(), 1)
+	require.Nil(t, err)
+	require.Equal(t, "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53", hex.EncodeToString(hash))
+
+	signed, err := SignTransaction(eip155Transaction(), 1, signer)
+	require.Nil(t, err)
+	require.Equal(t, "0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83", signed.Raw)
+	require.Equal(t, "0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788", signed.Tx.Hash)
+	require.Equal(t, signer.Address(), signed.Tx.From)
+
+	transaction := eip155Transaction()
+	transaction.From = "0x6247cf0412c6462da2a51d05139e2a3c6c630f0a"
+	_, err = SignTransaction(transaction, 1, signer)
+	require.NotNil(t, err)
+
+	for _, to := range []string{"0x353535353535353535353535353535353535353g", "0x3535"} {
+		transaction = eip155Transaction()
+		transaction.To = to
+		_, err = SignTransaction(transaction, 1, signer)
+		require.NotNil(t, err, to)
+	}
+	transaction = eip155Transaction()
+	transaction.Data = "0xzz"
+	_, err = SignTransaction(transaction, 1, signer)
+	require.NotNil(t, err)
+
+	transaction = eip155Transaction()
+	transaction.Type = 2
+	_, err = SignTransaction(transaction, 1, signer)
+	require.Equal(t, UnknownTxTypeError{Type: 2}, err)
+	_, err = SigningHash(transaction, 1)
+	require.Equal(t, UnknownTxTypeError{Type: 2}, err)
+	transaction.From = signer.Address()
+	_, err = NewPartialTransaction(transaction, 1, "")
+	require.Equal(t, UnknownTxTypeError{Type: 2}, err)
+	_, err = assembleTransaction(transaction, 1, signer.Address(), make([]byte, 65))
+	require.Equal(t, UnknownTxTypeError{Type: 2}, err)
+
+	// local signing identifies keys by 20-byte addresses, not by 21-byte Asimov account addresses
+	require.Len(t, signer.Address(), 42)
+	transaction = eip155Transaction()
+	transaction.From = "0x66" + strings.TrimPrefix(signer.Address(), "0x")
+	_, err = SignTransaction(transaction, 1, signer)
+	require.EqualError(t, err, "transaction from must be empty or 20 bytes, got 21")
+	_, err = NewPartialTransaction(transaction, 1, "")
+	require.EqualError(t, err, "transaction from must be empty or 20 bytes, got 21")
+	transaction.From, transaction.To = "", "0x66"+strings.TrimPrefix(transaction.To, "0x")
+	_, err = SignTransaction(transaction, 1, signer)
+	require.EqualError(t, err, "transaction to must be empty or 20 bytes, got 21")
+
+	_, err = NewPrivateKeySigner("0x46")
+	require.NotNil(t, err)
+}
+
+func (s *AsimovRPCTestSuite) TestSendTransactionLocal() {
+	signer, err := NewPrivateKeySigner("0x" + strings.Repeat("46", 32))
+	s.Require().Nil(err)
+
+	var raw string
+	s.registerResponses(map[string]string{
+		"flow_chainId":            `"0x1"`,
+		"flow_sendRawTransaction": `"0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788"`,
+	}, func(body []byte) {
+		if gjson.GetBytes(body, "method").String() == "flow_sendRawTransaction" {
+			raw = gjson.GetBytes(body, "params.0").String()
+		}
+	})
+
+	hash, err := s.rpc.SendTransactionLocal(eip155Transaction(), signer)
+	s.Require().Nil(err)
+	s.Require().Equal("0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788", hash)
+	s.Require().True(strings.HasPrefix(raw, "0xf86c0985"))
+
+	s.registerResponses(map[string]string{"flow_chainId": `"0x0"`}, func([]byte) {})
+	_, err = s.rpc.SendTransactionLocal(eip155Transaction(), signer)
+	s.Require().Equal(ErrChainIDUnknown, err)
+}
@@ -0,0 +1,68 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// TxType - transaction envelope type
+type TxType int
+
+// TxLegacy - untyped transaction
+const TxLegacy TxType = 0
+
+// TxDecoder decodes type-specific fields of a typed transaction from its raw JSON object
+type TxDecoder func(raw json.RawMessage) (interface{}, error)
+
+// UnknownTxTypeError - transaction type without registered decoder
+type UnknownTxTypeError struct {
+	Type TxType
+}
+
+func (err UnknownTxTypeError) Error() string {
+	return fmt.Sprintf("Unknown transaction type %d", int(err.Type))
+}
+
+var txTypes = struct {
+	sync.RWMutex
+	decoders map[TxType]TxDecoder
+}{decoders: map[TxType]TxDecoder{}}
+
+// RegisterTxType registers decoder for type-specific fields of transactions with given type
+func RegisterTxType(txType TxType, decoder TxDecoder) {
+	txTypes.Lock()
+	defer txTypes.Unlock()
+
+	txTypes.decoders[txType] = decoder
+}
+
+// Known returns true for legacy transactions and types with registered decoder
+func (txType TxType) Known() bool {
+	if txType == TxLegacy {
+		return true
+	}
+
+	txTypes.RLock()
+	defer txTypes.RUnlock()
+	_, ok := txTypes.decoders[txType]
+
+	return ok
+}
+
+// Payload decodes type-specific fields of typed transaction using registered decoder.
+// Legacy transactions have no payload; unknown types return UnknownTxTypeError and keep Raw intact.
+func (t Transaction) Payload() (interface{}, error) {
+	if t.Type == TxLegacy {
+		return nil, nil
+	}
+
+	txTypes.RLock()
+	decoder, ok := txTypes.decoders[t.Type]
+	txTypes.RUnlock()
+	if !ok {
+		return nil, UnknownTxTypeError{Type: t.Type}
+	}
+
+	return decoder(t.Raw)
+}
@@ -0,0 +1,57 @@
+package asimovrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionType(t *testing.T) {
+	var legacy Transaction
+	require.Nil(t, json.Unmarshal([]byte(`{"hash": "0x1", "nonce": "0x1", "gas": "0x5208"}`), &legacy))
+	require.Equal(t, TxLegacy, legacy.Type)
+	require.Nil(t, legacy.Raw)
+	payload, err := legacy.Payload()
+	require.Nil(t, err)
+	require.Nil(t, payload)
+
+	data := `{"hash": "0x2", "type": "0x7f", "gas": "0x5208", "assets": "0x0001"}`
+	var typed Transaction
+	require.Nil(t, json.Unmarshal([]byte(data), &typed))
+	require.Equal(t, TxType(127), typed.Type)
+	require.Equal(t, 21000, typed.Gas)
+	require.JSONEq(t, data, string(typed.Raw))
+	require.False(t, typed.Type.Known())
+
+	_, err = typed.Payload()
+	require.Equal(t, UnknownTxTypeError{Type: 127}, err)
+
+	RegisterTxType(127, func(raw json.RawMessage) (interface{}, error) {
+		var fields struct {
+			Assets string `json:"assets"`
+		}
+		err := json.Unmarshal(raw, &fields)
+		return fields.Assets, err
+	})
+	require.True(t, typed.Type.Known())
+	payload, err = typed.Payload()
+	require.Nil(t, err)
+	require.Equal(t, "0x0001", payload)
+}
+
+func TestBlockTransactionType(t *testing.T) {
+	var block proxyBlockWithTransactions
+	require.Nil(t, json.Unmarshal([]byte(`{"number": "0x1", "transactions": [{"hash": "0x1"}, {"hash": "0x2", "type": "0x2"}]}`), &block))
+
+	transactions := block.toBlock().Transactions
+	require.Equal(t, TxLegacy, transactions[0].Type)
+	require.Equal(t, TxType(2), transactions[1].Type)
+	require.JSONEq(t, `{"hash": "0x2", "type": "0x2"}`, string(transactions[1].Raw))
+}
+
+func TestTypedTransactionMarshal(t *testing.T) {
+	data, err := json.Marshal(T{From: "0x1", Type: 2})
+	require.Nil(t, err)
+	require.JSONEq(t, `{"from": "0x1", "type": "0x2"}`, string(data))
+}
@@ -37,6 +37,7 @@ type T struct {
 	Value    *big.Int
 	Data     string
 	Nonce    int
+	Type     TxType
 }
 
 // MarshalJSON implements the json.Unmarshaler interface.
@@ -62,10 +63,25 @@ func (t T) MarshalJSON() ([]byte, error) {
 	if t.Nonce > 0 {
 		params["nonce"] = IntToHex(t.Nonce)
 	}
+	if t.Type != TxLegacy {
+		params["type"] = IntToHex(int(t.Type))
+	}
 
 	return json.Marshal(params)
 }
 
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *T) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyT)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return err
+	}
+
+	*t = *(*T)(unsafe.Pointer(proxy))
+
+	return nil
+}
+
 // Transaction - transaction object
 type Transaction struct {
 	Hash             string
@@ -79,6 +95,8 @@ type Transaction struct {
 	Gas              int
 	GasPrice         big.Int
 	Input            string
+	Type             TxType
+	Raw              json.RawMessage
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -93,6 +111,12 @@ func (t *Transaction) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// SignedTransaction - transaction signed by the node
+type SignedTransaction struct {
+	Raw string      `json:"raw"`
+	Tx  Transaction `json:"tx"`
+}
+
 // Log - log object
 type Log struct {
 	Removed          bool
@@ -118,12 +142,18 @@ func (log *Log) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// FilterParams - Filter parameters object
+// FilterParams - Filter parameters object, nil blocks are left to the node (latest)
 type FilterParams struct {
-	FromBlock string     `json:"fromBlock,omitempty"`
-	ToBlock   string     `json:"toBlock,omitempty"`
-	Address   []string   `json:"address,omitempty"`
-	Topics    [][]string `json:"topics,omitempty"`
+	FromBlock *BlockNumber `json:"fromBlock,omitempty"`
+	ToBlock   *BlockNumber `json:"toBlock,omitempty"`
+	Address   []string     `json:"address,omitempty"`
+	Topics    [][]string   `json:"topics,omitempty"`
+}
+
+// Range returns copy of params filtering blocks from fromBlock to toBlock (inclusive)
+func (params FilterParams) Range(fromBlock, toBlock BlockNumber) FilterParams {
+	params.FromBlock, params.ToBlock = &fromBlock, &toBlock
+	return params
 }
 
 // TransactionReceipt - transaction receipt object
@@ -139,6 +169,8 @@ type TransactionReceipt struct {
 	LogsBloom         string
 	Root              string
 	Status            string
+	EffectiveGasPrice *big.Int // nil if the node does not report it
+	FeeAsset          string   // "" - NativeAsset
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -153,6 +185,26 @@ func (t *TransactionReceipt) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// FeeHistory - base fees, gas used ratios and priority fee percentiles of a range of blocks
+type FeeHistory struct {
+	OldestBlock   int
+	BaseFeePerGas []big.Int   // base fees of the blocks and of the block after the newest one
+	GasUsedRatio  []float64   // gas used / gas limit of the blocks
+	Reward        [][]big.Int // priority fees at the requested percentiles, per block
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (h *FeeHistory) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyFeeHistory)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return err
+	}
+
+	*h = *(*FeeHistory)(unsafe.Pointer(proxy))
+
+	return nil
+}
+
 // Block - block object
 type Block struct {
 	Number           int
@@ -175,6 +227,17 @@ type Block struct {
 	Transactions     []Transaction
 }
 
+type proxyT struct {
+	From     string  `json:"from"`
+	To       string  `json:"to"`
+	Gas      hexInt  `json:"gas"`
+	GasPrice *hexBig `json:"gasPrice"`
+	Value    *hexBig `json:"value"`
+	Data     string  `json:"data"`
+	Nonce    hexInt  `json:"nonce"`
+	Type     hexInt  `json:"type"`
+}
+
 type proxySyncing struct {
 	IsSyncing     bool   `json:"-"`
 	StartingBlock hexInt `json:"startingBlock"`
@@ -183,17 +246,40 @@ type proxySyncing struct {
 }
 
 type proxyTransaction struct {
-	Hash             string  `json:"hash"`
-	Nonce            hexInt  `json:"nonce"`
-	BlockHash        string  `json:"blockHash"`
-	BlockNumber      *hexInt `json:"blockNumber"`
-	TransactionIndex *hexInt `json:"transactionIndex"`
-	From             string  `json:"from"`
-	To               string  `json:"to"`
-	Value            hexBig  `json:"value"`
-	Gas              hexInt  `json:"gas"`
-	GasPrice         hexBig  `json:"gasPrice"`
-	Input            string  `json:"input"`
+	Hash             string          `json:"hash"`
+	Nonce            hexInt          `json:"nonce"`
+	BlockHash        string          `json:"blockHash"`
+	BlockNumber      *hexInt         `json:"blockNumber"`
+	TransactionIndex *hexInt         `json:"transactionIndex"`
+	From             string          `json:"from"`
+	To               string          `json:"to"`
+	Value            hexBig          `json:"value"`
+	Gas              hexInt          `json:"gas"`
+	GasPrice         hexBig          `json:"gasPrice"`
+	Input            string          `json:"input"`
+	Type             hexInt          `json:"type"`
+	Raw              json.RawMessage `json:"-"`
+}
+
+func (proxy *proxyTransaction) UnmarshalJSON(data []byte) error {
+	type plain proxyTransaction
+	if err := json.Unmarshal(data, (*plain)(proxy)); err != nil {
+		return err
+	}
+
+	// typed envelopes keep the original object, so fields unknown to this package are not lost
+	if TxType(proxy.Type) != TxLegacy {
+		proxy.Raw = append(json.RawMessage(nil), data...)
+	}
+
+	return nil
+}
+
+type proxyFeeHistory struct {
+	OldestBlock   hexInt     `json:"oldestBlock"`
+	BaseFeePerGas []hexBig   `json:"baseFeePerGas"`
+	GasUsedRatio  []float64  `json:"gasUsedRatio"`
+	Reward        [][]hexBig `json:"reward"`
 }
 
 type proxyLog struct {
@@ -209,17 +295,19 @@ type proxyLog struct {
 }
 
 type proxyTransactionReceipt struct {
-	TransactionHash   string `json:"transactionHash"`
-	TransactionIndex  hexInt `json:"transactionIndex"`
-	BlockHash         string `json:"blockHash"`
-	BlockNumber       hexInt `json:"blockNumber"`
-	CumulativeGasUsed hexInt `json:"cumulativeGasUsed"`
-	GasUsed           hexInt `json:"gasUsed"`
-	ContractAddress   string `json:"contractAddress,omitempty"`
-	Logs              []Log  `json:"logs"`
-	LogsBloom         string `json:"logsBloom"`
-	Root              string `json:"root"`
-	Status            string `json:"status,omitempty"`
+	TransactionHash   string  `json:"transactionHash"`
+	TransactionIndex  hexInt  `json:"transactionIndex"`
+	BlockHash         string  `json:"blockHash"`
+	BlockNumber       hexInt  `json:"blockNumber"`
+	CumulativeGasUsed hexInt  `json:"cumulativeGasUsed"`
+	GasUsed           hexInt  `json:"gasUsed"`
+	ContractAddress   string  `json:"contractAddress,omitempty"`
+	Logs              []Log   `json:"logs"`
+	LogsBloom         string  `json:"logsBloom"`
+	Root              string  `json:"root"`
+	Status            string  `json:"status,omitempty"`
+	EffectiveGasPrice *hexBig `json:"effectiveGasPrice,omitempty"`
+	FeeAsset          string  `json:"feeAsset,omitempty"`
 }
 
 type hexInt int
@@ -0,0 +1,88 @@
+package asimovrpc
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// OptionError - invalid or conflicting client option
+type OptionError struct {
+	Option  string
+	Message string
+}
+
+func (err OptionError) Error() string {
+	return fmt.Sprintf("asimovrpc: invalid option %s: %s", err.Option, err.Message)
+}
+
+// NewClient create new rpc client with given url, returning an error if url or options are invalid
+func NewClient(rawURL string, options ...func(rpc *AsimovRPC)) (*AsimovRPC, error) {
+	if rawURL == "" {
+		return nil, errors.New("asimovrpc: empty url")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("asimovrpc: invalid url %q: %v", rawURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("asimovrpc: invalid url %q: scheme and host required", rawURL)
+	}
+
+	rpc := newClient(rawURL, options...)
+	if err := rpc.validateOptions(); err != nil {
+		return nil, err
+	}
+	if rpc.chainID != nil {
+		rpc.chainID.prefetch(rpc)
+	}
+
+	return rpc, nil
+}
+
+// InvalidOption returns option failing NewClient with OptionError, so options defined outside the package
+// report invalid values like the options of the package
+func InvalidOption(option, message string) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		rpc.invalidOption(option, message)
+	}
+}
+
+func (rpc *AsimovRPC) invalidOption(option, message string) {
+	rpc.optionErrors = append(rpc.optionErrors, OptionError{Option: option, Message: message})
+}
+
+func (rpc *AsimovRPC) setTransport(option string, client httpClient) {
+	if rpc.transport != "" && rpc.transport != option {
+		rpc.invalidOption(option, "conflicts with "+rpc.transport)
+		return
+	}
+
+	rpc.transport = option
+	rpc.client = client
+}
+
+func (rpc *AsimovRPC) validateOptions() error {
+	if len(rpc.optionErrors) > 0 {
+		return rpc.optionErrors[0]
+	}
+
+	switch {
+	case rpc.timeout < 0:
+		return OptionError{Option: "WithTimeout", Message: "timeout is negative"}
+	case rpc.slowQueryThreshold < 0:
+		return OptionError{Option: "WithSlowQueryThreshold", Message: "threshold is negative"}
+	case rpc.debugPayloadLimit < 0:
+		return OptionError{Option: "WithDebugPayloadLimit", Message: "limit is negative"}
+	case rpc.debugFormat != DebugText && rpc.debugFormat != DebugJSON:
+		return OptionError{Option: "WithDebugFormat", Message: fmt.Sprintf("unknown format %d", rpc.debugFormat)}
+	case rpc.log == nil && rpc.structured == nil && (rpc.debugEnabled || rpc.slowQueryThreshold > 0):
+		return OptionError{Option: "WithLogger", Message: "logger is nil but debug or slow query logging is enabled"}
+	case rpc.failover != nil && rpc.endpoints != nil:
+		return OptionError{Option: "WithFailover", Message: "conflicts with WithEndpointProvider"}
+	case rpc.log == nil && rpc.structured == nil && rpc.budget != nil && len(rpc.budget.Warnings) > 0:
+		return OptionError{Option: "WithLogger", Message: "logger is nil but budget warnings are enabled"}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package asimovrpc
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient("")
+	require.EqualError(t, err, "asimovrpc: empty url")
+
+	_, err = NewClient("127.0.0.1:8545")
+	require.NotNil(t, err)
+
+	_, err = NewClient("http://127.0.0.1:8545", WithTimeout(-time.Second))
+	require.Equal(t, OptionError{Option: "WithTimeout", Message: "timeout is negative"}, err)
+
+	_, err = NewClient("http://127.0.0.1:8545", WithHttpClient(http.DefaultClient), WithDNSRoundRobin(time.Minute))
+	require.EqualError(t, err, "asimovrpc: invalid option WithDNSRoundRobin: conflicts with WithHttpClient")
+
+	_, err = NewClient("http://127.0.0.1:8545", WithHttpClient(nil))
+	require.IsType(t, OptionError{}, err)
+
+	_, err = NewClient("http://127.0.0.1:8545", WithHttpClient(http.DefaultClient), WithPostClient(http.DefaultClient))
+	require.EqualError(t, err, "asimovrpc: invalid option WithPostClient: conflicts with WithHttpClient")
+
+	_, err = NewClient("http://127.0.0.1:8545", WithLatencyBuckets(time.Second, 0))
+	require.IsType(t, OptionError{}, err)
+
+	_, err = NewClient("http://127.0.0.1:8545", WithEndpointProvider(nil, 0))
+	require.IsType(t, OptionError{}, err)
+
+	_, err = NewClient("http://127.0.0.1:8545", WithDebugFormat(DebugFormat(5)))
+	require.IsType(t, OptionError{}, err)
+
+	_, err = NewClient("http://127.0.0.1:8545", WithLogger(nil), WithDebug(true))
+	require.IsType(t, OptionError{}, err)
+
+	_, err = NewClient("http://127.0.0.1:8545", WithSlowQueryThreshold(-1), WithDebugPayloadLimit(-1))
+	require.Equal(t, "WithSlowQueryThreshold", err.(OptionError).Option)
+
+	rpc, err := NewClient("http://127.0.0.1:8545", WithHttpClient(http.DefaultClient), WithHttpClient(http.DefaultClient))
+	require.Nil(t, err)
+	require.Equal(t, "http://127.0.0.1:8545", rpc.URL())
+}
+
+// postOnly - client of v1 WithHttpClient
+type postOnly struct {
+	contentTypes []string
+}
+
+func (c *postOnly) Post(url, contentType string, body io.Reader) (*http.Response, error) {
+	c.contentTypes = append(c.contentTypes, contentType)
+	return http.Post(url, contentType, body)
+}
+
+func TestWithPostClient(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+	node.Handle("flow_blockNumber", "0x10")
+
+	client := &postOnly{}
+	rpc := New(node.URL, WithPostClient(client))
+	number, err := rpc.AsimovBlockNumber()
+	require.Nil(t, err)
+	require.Equal(t, 16, number)
+	require.Equal(t, []string{"application/json"}, client.contentTypes)
+
+	_, err = NewClient(node.URL, WithPostClient(nil))
+	require.EqualError(t, err, "asimovrpc: invalid option WithPostClient: client is nil")
+}
+
+func TestNewPanics(t *testing.T) {
+	require.PanicsWithValue(t, "asimovrpc: invalid option WithTimeout: timeout is negative", func() {
+		New("http://127.0.0.1:8545", WithTimeout(-time.Second))
+	})
+	require.NotPanics(t, func() {
+		New("")
+	})
+}
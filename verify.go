@@ -0,0 +1,74 @@
+package asimovrpc
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// DefaultResponseSignatureHeader - header with base64 encoded response signature checked by Ed25519Verifier
+const DefaultResponseSignatureHeader = "X-Asimov-Response-Signature"
+
+// ErrResponseSignature - response signature is missing or does not match any trusted key
+var ErrResponseSignature = errors.New("invalid response signature")
+
+// ResponseVerifier - verifies responses of trusted gateways before they are decoded
+type ResponseVerifier interface {
+	Verify(response *http.Response, body []byte) error
+}
+
+// Ed25519Verifier - verifies ed25519 signature over response body against trusted public keys
+type Ed25519Verifier struct {
+	Header string
+	Keys   []ed25519.PublicKey
+}
+
+// NewEd25519Verifier creates verifier reading signature from the default header
+func NewEd25519Verifier(keys ...ed25519.PublicKey) *Ed25519Verifier {
+	return &Ed25519Verifier{Header: DefaultResponseSignatureHeader, Keys: keys}
+}
+
+// Verify implements ResponseVerifier
+func (v *Ed25519Verifier) Verify(response *http.Response, body []byte) error {
+	signature, err := base64.StdEncoding.DecodeString(response.Header.Get(v.Header))
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return ErrResponseSignature
+	}
+
+	for _, key := range v.Keys {
+		if len(key) == ed25519.PublicKeySize && ed25519.Verify(key, body, signature) {
+			return nil
+		}
+	}
+
+	return ErrResponseSignature
+}
+
+// WithResponseVerifier verify responses of all endpoints without own verifier
+func WithResponseVerifier(verifier ResponseVerifier) func(rpc *AsimovRPC) {
+	return WithEndpointVerifier("", verifier)
+}
+
+// WithEndpointVerifier verify responses received from given endpoint url
+func WithEndpointVerifier(endpoint string, verifier ResponseVerifier) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if verifier == nil {
+			rpc.invalidOption("WithResponseVerifier", "verifier is nil")
+			return
+		}
+		if rpc.verifiers == nil {
+			rpc.verifiers = map[string]ResponseVerifier{}
+		}
+		rpc.verifiers[endpoint] = verifier
+	}
+}
+
+func (rpc *AsimovRPC) verifier(endpoint string) ResponseVerifier {
+	if verifier, ok := rpc.verifiers[endpoint]; ok {
+		return verifier
+	}
+
+	return rpc.verifiers[""]
+}
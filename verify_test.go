@@ -0,0 +1,59 @@
+package asimovrpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+)
+
+func TestEd25519Verifier(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(bytes.NewReader(make([]byte, 64)))
+	require.Nil(t, err)
+	other, _, err := ed25519.GenerateKey(bytes.NewReader(bytes.Repeat([]byte{1}, 64)))
+	require.Nil(t, err)
+
+	body := []byte(`{"jsonrpc":"2.0", "id":1, "result": "0x1"}`)
+	tampered := []byte(`{"jsonrpc":"2.0", "id":1, "result": "0x2"}`)
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(private, body))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(DefaultResponseSignatureHeader, signature)
+		if r.URL.Path == "/tampered" {
+			w.Write(tampered)
+			return
+		}
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	rpc := NewAsimovRPC(server.URL, WithResponseVerifier(NewEd25519Verifier(other, public)))
+	number, err := rpc.AsimovBlockNumber()
+	require.Nil(t, err)
+	require.Equal(t, 1, number)
+
+	rpc = NewAsimovRPC(server.URL+"/tampered", WithResponseVerifier(NewEd25519Verifier(public)))
+	_, err = rpc.AsimovBlockNumber()
+	require.Equal(t, ErrResponseSignature, err)
+
+	rpc = NewAsimovRPC(server.URL, WithResponseVerifier(NewEd25519Verifier(other)))
+	_, err = rpc.AsimovBlockNumber()
+	require.Equal(t, ErrResponseSignature, err)
+
+	verifier := NewEd25519Verifier(public)
+	require.Equal(t, ErrResponseSignature, verifier.Verify(&http.Response{Header: http.Header{}}, body))
+}
+
+func TestEndpointVerifier(t *testing.T) {
+	verifier := NewEd25519Verifier()
+	rpc := NewAsimovRPC("http://127.0.0.1:8545", WithEndpointVerifier("http://127.0.0.1:8546", verifier))
+	require.Nil(t, rpc.verifier("http://127.0.0.1:8545"))
+	require.Equal(t, verifier, rpc.verifier("http://127.0.0.1:8546"))
+
+	_, err := NewClient("http://127.0.0.1:8545", WithResponseVerifier(nil))
+	require.EqualError(t, err, "asimovrpc: invalid option WithResponseVerifier: verifier is nil")
+}
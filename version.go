@@ -0,0 +1,31 @@
+package asimovrpc
+
+import (
+	"runtime/debug"
+)
+
+const modulePath = "github.com/mistdex/mist-asimov-rpc"
+
+// Version returns version of this package as recorded in the build info ("devel" if unknown)
+func Version() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "devel"
+	}
+
+	if info.Main.Path == modulePath && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			return dep.Version
+		}
+	}
+
+	return "devel"
+}
+
+// DefaultUserAgent returns User-Agent header sent when none is configured
+func DefaultUserAgent() string {
+	return "mist-asimov-rpc/" + Version()
+}
@@ -0,0 +1,168 @@
+package asimovrpc
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultPollInterval - interval between polls of WaitForBlock, WaitForSync and WaitForTransactionReceipt
+const DefaultPollInterval = time.Second
+
+// PollJitter - fraction of poll interval randomly added or subtracted to spread polling of many clients
+const PollJitter = 0.2
+
+// WaitFor calls cond every interval (with jitter) until it returns true, an error or ctx is done
+func WaitFor(ctx context.Context, interval time.Duration, cond func() (bool, error)) error {
+	return waitFor(ctx, SystemClock, defaultRand, interval, cond)
+}
+
+func waitFor(ctx context.Context, clock Clock, random *lockedRand, interval time.Duration, cond func() (bool, error)) error {
+	for {
+		done, err := cond()
+		if err != nil && ctx.Err() != nil {
+			return ctx.Err() // the call failed because ctx is done
+		}
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(jitter(random, interval)):
+		}
+	}
+}
+
+func jitter(random *lockedRand, interval time.Duration) time.Duration {
+	spread := int64(float64(interval) * PollJitter)
+	if spread <= 0 {
+		return interval
+	}
+
+	return interval - time.Duration(spread) + time.Duration(random.Int63n(2*spread+1))
+}
+
+// WithPollInterval set interval between polls of WaitForBlock, WaitForSync and WaitForTransactionReceipt
+func WithPollInterval(interval time.Duration) func(rpc *AsimovRPC) {
+	return func(rpc *AsimovRPC) {
+		if interval <= 0 {
+			rpc.invalidOption("WithPollInterval", "interval must be positive")
+			return
+		}
+		rpc.pollInterval = interval
+	}
+}
+
+// WaitForBlock waits until node reaches block height, returning the current block number
+func (rpc *AsimovRPC) WaitForBlock(ctx context.Context, height int) (int, error) {
+	var number int
+	rpc = rpc.WithContext(ctx)
+	err := waitFor(ctx, rpc.clock, rpc.rand, rpc.pollInterval, func() (bool, error) {
+		var err error
+		number, err = rpc.AsimovBlockNumber()
+		return number >= height, err
+	})
+
+	return number, err
+}
+
+// WaitForSync waits until node is not syncing
+func (rpc *AsimovRPC) WaitForSync(ctx context.Context) error {
+	rpc = rpc.WithContext(ctx)
+	return waitFor(ctx, rpc.clock, rpc.rand, rpc.pollInterval, func() (bool, error) {
+		syncing, err := rpc.AsimovSyncing()
+		if err != nil {
+			return false, err
+		}
+		return !syncing.IsSyncing, nil
+	})
+}
+
+// WaitForTransactionReceipt waits until receipt of transaction exists and confirmations blocks
+// are mined on top of its block (0 - return as soon as it is mined). The receipt is fetched
+// on every poll, so a receipt disappearing or moving to another block after a reorg restarts the wait.
+// The lifecycle attached to ctx with WithTxLifecycle follows the transaction from pending to confirmed or failed.
+// With a lifecycle, polls without receipt also check that the node still knows the transaction and
+// that its nonce was not taken by another one, see WithTxLifecycle.
+func (rpc *AsimovRPC) WaitForTransactionReceipt(ctx context.Context, hash string, confirmations int) (*TransactionReceipt, error) {
+	var receipt *TransactionReceipt
+	rpc = rpc.WithContext(ctx)
+	err := waitFor(ctx, rpc.clock, rpc.rand, rpc.pollInterval, func() (bool, error) {
+		var err error
+		receipt, err = rpc.AsimovGetTransactionReceipt(hash)
+		if err != nil {
+			return false, err
+		}
+		if receipt.BlockHash == "" {
+			if _, ok := TxLifecycleFromContext(ctx); !ok {
+				return false, nil
+			}
+			state, err := rpc.pendingState(hash)
+			if err != nil {
+				return false, err
+			}
+			if err := advanceLifecycle(ctx, rpc.clock, hash, state); err != nil {
+				return false, err
+			}
+			switch state {
+			case TxDropped:
+				return false, ErrTransactionDropped
+			case TxReplaced:
+				return false, ErrTransactionReplaced
+			}
+			return false, nil
+		}
+		if err := advanceLifecycle(ctx, rpc.clock, hash, TxMined); err != nil {
+			return false, err
+		}
+		if confirmations <= 0 {
+			return true, nil
+		}
+
+		number, err := rpc.AsimovBlockNumber()
+		return number-receipt.BlockNumber >= confirmations, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if receipt.Status == "0x0" {
+		err = advanceLifecycle(ctx, rpc.clock, hash, TxFailed)
+	} else {
+		err = advanceLifecycle(ctx, rpc.clock, hash, TxConfirmed)
+	}
+
+	return receipt, err
+}
+
+// pendingState returns state of transaction hash without receipt: pending, dropped if the node
+// does not know it or replaced if the nonce of its sender was used by a mined transaction
+func (rpc *AsimovRPC) pendingState(hash string) (TxState, error) {
+	transaction, err := rpc.AsimovGetTransactionByHash(hash)
+	if err != nil {
+		return TxPending, err
+	}
+	if transaction.Hash == "" {
+		return TxDropped, nil
+	}
+
+	mined, err := rpc.AsimovGetTransactionCount(transaction.From, Latest())
+	if err != nil {
+		return TxPending, err
+	}
+	if mined <= transaction.Nonce {
+		return TxPending, nil
+	}
+
+	// the transaction itself may have been mined since its receipt was asked for
+	receipt, err := rpc.AsimovGetTransactionReceipt(hash)
+	if err != nil || receipt.BlockHash != "" {
+		return TxPending, err
+	}
+
+	return TxReplaced, nil
+}
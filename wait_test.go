@@ -0,0 +1,121 @@
+package asimovrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestWaitFor(t *testing.T) {
+	calls := 0
+	err := WaitFor(context.Background(), time.Millisecond, func() (bool, error) {
+		calls++
+		return calls == 3, nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, 3, calls)
+
+	err = WaitFor(context.Background(), time.Millisecond, func() (bool, error) {
+		return false, errors.New("poll error")
+	})
+	require.EqualError(t, err, "poll error")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = WaitFor(ctx, time.Millisecond, func() (bool, error) {
+		return false, nil
+	})
+	require.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		d := jitter(defaultRand, 100*time.Millisecond)
+		require.True(t, d >= 80*time.Millisecond && d <= 120*time.Millisecond, d)
+	}
+	require.Equal(t, time.Duration(1), jitter(defaultRand, 1))
+}
+
+func (s *AsimovRPCTestSuite) TestWaitForBlock() {
+	rpc := New(s.rpc.url, WithPollInterval(time.Millisecond))
+
+	number := 0
+	s.registerResponseFunc(func() string {
+		number++
+		return fmt.Sprintf(`"0x%x"`, number)
+	})
+
+	current, err := rpc.WaitForBlock(context.Background(), 3)
+	s.Require().Nil(err)
+	s.Require().Equal(3, current)
+}
+
+func (s *AsimovRPCTestSuite) TestWaitForSync() {
+	rpc := New(s.rpc.url, WithPollInterval(time.Millisecond))
+
+	calls := 0
+	s.registerResponseFunc(func() string {
+		calls++
+		if calls < 2 {
+			return `{"startingBlock": "0x1", "currentBlock": "0x2", "highestBlock": "0x3"}`
+		}
+		return `false`
+	})
+
+	s.Require().Nil(rpc.WaitForSync(context.Background()))
+	s.Require().Equal(2, calls)
+
+	_, err := NewClient("http://127.0.0.1:8545", WithPollInterval(0))
+	s.Require().EqualError(err, "asimovrpc: invalid option WithPollInterval: interval must be positive")
+}
+
+func (s *AsimovRPCTestSuite) TestWaitForTransactionReceipt() {
+	rpc := New(s.rpc.url, WithPollInterval(time.Millisecond))
+
+	// receipt appears in block 10, is dropped by a reorg and mined again in block 11
+	receipts := []string{
+		`null`,
+		`{"transactionHash": "0xab", "blockHash": "0x0a", "blockNumber": "0xa"}`,
+		`null`,
+		`{"transactionHash": "0xab", "blockHash": "0x0b", "blockNumber": "0xb"}`,
+		`{"transactionHash": "0xab", "blockHash": "0x0b", "blockNumber": "0xb"}`,
+	}
+	heads := []string{`"0xa"`, `"0xc"`, `"0xd"`}
+	polls := 0
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		var result string
+		if gjson.GetBytes(s.getBody(request), "method").String() == "flow_getTransactionReceipt" {
+			result, receipts = receipts[0], receipts[1:]
+			polls++
+		} else {
+			result, heads = heads[0], heads[1:]
+		}
+		return httpmock.NewStringResponse(200, echoID(request, fmt.Sprintf(`{"jsonrpc":"2.0", "id":1, "result": %s}`, result))), nil
+	})
+
+	receipt, err := rpc.WaitForTransactionReceipt(context.Background(), "0xab", 2)
+	s.Require().Nil(err)
+	s.Require().Equal("0x0b", receipt.BlockHash)
+	s.Require().Equal(11, receipt.BlockNumber)
+	s.Require().Equal(5, polls)
+	s.Require().Empty(heads)
+
+	s.registerResponse(`{"transactionHash": "0xab", "blockHash": "0x0b", "blockNumber": "0xb"}`, func([]byte) {})
+	receipt, err = rpc.WaitForTransactionReceipt(context.Background(), "0xab", 0)
+	s.Require().Nil(err)
+	s.Require().Equal(11, receipt.BlockNumber)
+
+	s.registerResponse(`null`, func([]byte) {})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = rpc.WaitForTransactionReceipt(ctx, "0xab", 0)
+	s.Require().Equal(context.DeadlineExceeded, err)
+}
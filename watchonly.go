@@ -0,0 +1,149 @@
+package asimovrpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// WatchOnly - addresses tracked without their keys: balances and transaction history, for auditors and
+// monitoring dashboards. It never signs; use SendTransactionLocal with a TransactionSigner to spend.
+type WatchOnly struct {
+	rpc *AsimovRPC
+
+	mu        sync.RWMutex
+	addresses []string
+	watched   map[string]bool
+}
+
+// WatchOnly creates watch-only set of addresses, duplicates are ignored
+func (rpc *AsimovRPC) WatchOnly(addresses ...string) *WatchOnly {
+	w := &WatchOnly{rpc: rpc, watched: map[string]bool{}}
+	w.Add(addresses...)
+
+	return w
+}
+
+// Add starts watching addresses
+func (w *WatchOnly) Add(addresses ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, address := range addresses {
+		if key := strings.ToLower(address); !w.watched[key] {
+			w.watched[key] = true
+			w.addresses = append(w.addresses, address)
+		}
+	}
+}
+
+// Addresses returns watched addresses in the order they were added
+func (w *WatchOnly) Addresses() []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return append([]string(nil), w.addresses...)
+}
+
+// Watches reports whether address is watched, addresses are matched case-insensitively
+func (w *WatchOnly) Watches(address string) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return address != "" && w.watched[strings.ToLower(address)]
+}
+
+// WatchedBalance - balance of a watched address
+type WatchedBalance struct {
+	Address string
+	Balance big.Int
+}
+
+// Balances returns balances of watched addresses at block, read in a batch
+func (w *WatchOnly) Balances(ctx context.Context, block BlockNumber) ([]WatchedBalance, error) {
+	addresses := w.Addresses()
+	balances, err := w.rpc.balances(ctx, "", addresses, block)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]WatchedBalance, len(addresses))
+	for i, address := range addresses {
+		result[i] = WatchedBalance{Address: address, Balance: balances[i]}
+	}
+
+	return result, nil
+}
+
+// Total returns sum of balances of watched addresses at block
+func (w *WatchOnly) Total(ctx context.Context, block BlockNumber) (big.Int, error) {
+	balances, err := w.Balances(ctx, block)
+	if err != nil {
+		return big.Int{}, err
+	}
+
+	var total big.Int
+	for i := range balances {
+		total.Add(&total, &balances[i].Balance)
+	}
+
+	return total, nil
+}
+
+// History returns iterator over transactions sent from or to watched addresses between fromBlock and
+// toBlock (inclusive), in block order. Nodes keep no index of transactions by address, so blocks are
+// scanned with their transactions, a batch of WithBatchSize blocks at a time as the iterator needs them.
+func (w *WatchOnly) History(ctx context.Context, fromBlock, toBlock int) *PageIterator[Transaction] {
+	client := w.rpc.WithContext(ctx)
+	size := client.batchSize
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+
+	return Paginate(ctx, func(ctx context.Context, cursor string) ([]Transaction, string, error) {
+		from := fromBlock
+		if cursor != "" {
+			from, _ = strconv.Atoi(cursor)
+		}
+		if from > toBlock {
+			return nil, "", nil
+		}
+		to := from + size - 1
+		if to > toBlock {
+			to = toBlock
+		}
+
+		blocks := make([]*proxyBlockWithTransactions, to-from+1)
+		batch := client.NewBatch()
+		for i := range blocks {
+			batch.Add("flow_getBlockByNumber", &blocks[i], IntToHex(from+i), true)
+		}
+		if err := batch.ExecuteContext(ctx); err != nil {
+			return nil, "", err
+		}
+
+		var transactions []Transaction
+		for i, block := range blocks {
+			if err := batch.Err(i); err != nil {
+				return nil, "", err
+			}
+			if block == nil {
+				return nil, "", fmt.Errorf("Block %d not found", from+i)
+			}
+			for _, transaction := range block.toBlock().Transactions {
+				if w.Watches(transaction.From) || w.Watches(transaction.To) {
+					transactions = append(transactions, transaction)
+				}
+			}
+		}
+
+		next := ""
+		if to < toBlock {
+			next = strconv.Itoa(to + 1)
+		}
+		return transactions, next, nil
+	})
+}
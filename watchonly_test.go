@@ -0,0 +1,89 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mistdex/mist-asimov-rpc/asimovrpctest"
+)
+
+func TestWatchOnly(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	balances := map[string]string{"0xaa": "0x64", "0xbb": "0x0"}
+	node.HandleFunc("flow_getBalance", func(params []json.RawMessage) (interface{}, error) {
+		var address string
+		json.Unmarshal(params[0], &address)
+		return balances[address], nil
+	})
+
+	watch := New(node.URL).WatchOnly("0xaa", "0xAA")
+	watch.Add("0xbb")
+	require.Equal(t, []string{"0xaa", "0xbb"}, watch.Addresses())
+	require.True(t, watch.Watches("0xBB"))
+	require.False(t, watch.Watches("0xcc"))
+	require.False(t, watch.Watches(""))
+
+	result, err := watch.Balances(context.Background(), Latest())
+	require.Nil(t, err)
+	require.Len(t, result, 2)
+	require.Equal(t, "0xaa", result[0].Address)
+	require.Equal(t, "100", result[0].Balance.String())
+	require.Equal(t, 0, result[1].Balance.Sign())
+
+	total, err := watch.Total(context.Background(), Latest())
+	require.Nil(t, err)
+	require.Equal(t, "100", total.String())
+	require.True(t, node.AssertParams(t, "flow_getBalance", "0xbb", "latest"))
+}
+
+func TestWatchOnlyHistory(t *testing.T) {
+	node := asimovrpctest.NewNode()
+	defer node.Close()
+
+	// block n holds a transaction from 0xaa in even blocks, and one to 0xcc in every block
+	node.HandleFunc("flow_getBlockByNumber", func(params []json.RawMessage) (interface{}, error) {
+		var number string
+		json.Unmarshal(params[0], &number)
+		n, _ := ParseInt(number)
+		if n > 10 {
+			return nil, nil
+		}
+		transactions := []map[string]interface{}{
+			{"hash": fmt.Sprintf("0x%x1", n), "from": "0xdd", "to": "0xcc", "blockNumber": number},
+		}
+		if n%2 == 0 {
+			transactions = append(transactions, map[string]interface{}{"hash": fmt.Sprintf("0x%x2", n), "from": "0xAA", "to": "0xee", "blockNumber": number})
+		}
+		return map[string]interface{}{"number": number, "transactions": transactions}, nil
+	})
+	watch := New(node.URL, WithBatchSize(2)).WatchOnly("0xaa")
+
+	var hashes []string
+	it := watch.History(context.Background(), 3, 8)
+	for it.Next() {
+		hashes = append(hashes, it.Item().Hash)
+	}
+	require.Nil(t, it.Err())
+	require.Equal(t, []string{"0x42", "0x62", "0x82"}, hashes)
+	require.Len(t, node.Calls("flow_getBlockByNumber"), 6)
+
+	watch.Add("0xCC")
+
+	it = watch.History(context.Background(), 10, 10)
+	require.True(t, it.Next())
+	require.Equal(t, "0xa1", it.Item().Hash)
+	require.True(t, it.Next())
+	require.Equal(t, 10, *it.Item().BlockNumber)
+	require.False(t, it.Next())
+
+	it = watch.History(context.Background(), 10, 11)
+	for it.Next() {
+	}
+	require.EqualError(t, it.Err(), "Block 11 not found")
+}
@@ -0,0 +1,570 @@
+package asimovrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// DefaultWSWriteTimeout - deadline for writing a single WebSocket message
+const DefaultWSWriteTimeout = 10 * time.Second
+
+// DefaultWSPingInterval - interval of pings keeping idle WebSocket connections alive
+const DefaultWSPingInterval = 30 * time.Second
+
+// DefaultWSReadTimeout - WebSocket connection receiving nothing, pongs included, for this long is closed
+const DefaultWSReadTimeout = 60 * time.Second
+
+// DefaultSubscriptionBuffer - notifications buffered per subscription, a subscription whose buffer
+// is full is ended with ErrSubscriptionOverflow
+const DefaultSubscriptionBuffer = 128
+
+// ErrWSClosed - WebSocket connection was closed
+var ErrWSClosed = errors.New("websocket connection closed")
+
+// ErrSubscriptionOverflow - subscription was ended because its notifications were not read in time
+var ErrSubscriptionOverflow = errors.New("subscription buffer overflow")
+
+// ErrNotWebSocket - subscriptions are only available on ws:// and wss:// endpoints
+var ErrNotWebSocket = errors.New("subscriptions require a WebSocket endpoint")
+
+func isWebSocket(url string) bool {
+	return strings.HasPrefix(url, "ws://") || strings.HasPrefix(url, "wss://")
+}
+
+// wsTransport - sends JSON-RPC requests over one WebSocket connection per endpoint,
+// dialed lazily and redialed after failures
+type wsTransport struct {
+	mu           sync.Mutex
+	dialer       *websocket.Dialer
+	conns        map[string]*wsConn
+	shared       bool // connections of a ClientPool, closed by ClientPool.Close only
+	pingInterval time.Duration
+	readTimeout  time.Duration
+}
+
+func newWSTransport() *wsTransport {
+	return &wsTransport{
+		dialer:       websocket.DefaultDialer,
+		conns:        map[string]*wsConn{},
+		pingInterval: DefaultWSPingInterval,
+		readTimeout:  DefaultWSReadTimeout,
+	}
+}
+
+// conn returns open connection to url, dialing it without holding the lock, so a slow dial
+// does not stall calls to other endpoints. Connections read messages up to readLimit bytes (0 - no limit).
+func (t *wsTransport) conn(ctx context.Context, url string, header http.Header, readLimit int64) (*wsConn, error) {
+	t.mu.Lock()
+	if conn, ok := t.conns[url]; ok && !conn.isClosed() {
+		t.mu.Unlock()
+		return conn, nil
+	}
+	t.mu.Unlock()
+
+	dialHeader := http.Header{}
+	for name, values := range header {
+		if name != "Content-Type" {
+			dialHeader[name] = values
+		}
+	}
+	c, _, err := t.dialer.DialContext(ctx, url, dialHeader)
+	if err != nil {
+		return nil, err
+	}
+	if readLimit > 0 {
+		c.SetReadLimit(readLimit)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if conn, ok := t.conns[url]; ok && !conn.isClosed() {
+		c.Close() // a concurrent call dialed first
+		return conn, nil
+	}
+	conn := newWSConn(c, t.pingInterval, t.readTimeout)
+	t.conns[url] = conn
+	return conn, nil
+}
+
+// client returns httpClient sending requests of a client whose responses are limited to readLimit bytes
+func (t *wsTransport) client(readLimit int64) httpClient {
+	return wsClient{transport: t, readLimit: readLimit}
+}
+
+// wsClient - httpClient over wsTransport
+type wsClient struct {
+	transport *wsTransport
+	readLimit int64
+}
+
+// Do implements httpClient, answering with a synthetic 200 response carrying the JSON-RPC response
+func (c wsClient) Do(request *http.Request) (*http.Response, error) {
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := c.transport.conn(request.Context(), request.URL.String(), request.Header, c.readLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := conn.roundTrip(request.Context(), body, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(data)),
+		Request:    request,
+	}, nil
+}
+
+func (t *wsTransport) close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for url, conn := range t.conns {
+		conn.close(ErrWSClosed)
+		delete(t.conns, url)
+	}
+
+	return nil
+}
+
+type wsPending struct {
+	response     chan []byte
+	subscription *Subscription
+}
+
+type wsMessage struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Result json.RawMessage `json:"result"`
+	Params struct {
+		Subscription string          `json:"subscription"`
+		Result       json.RawMessage `json:"result"`
+	} `json:"params"`
+}
+
+// wsConn - single WebSocket connection with read/write pumps correlating responses by request ID.
+// The write pump pings the node every pingInterval, the read pump closes the connection if nothing
+// arrives for readTimeout.
+type wsConn struct {
+	conn         *websocket.Conn
+	send         chan []byte
+	pingInterval time.Duration
+	readTimeout  time.Duration
+
+	mu            sync.Mutex
+	nextID        int
+	pending       map[int]wsPending
+	subscriptions map[string]*Subscription
+	err           error
+	closed        chan struct{}
+}
+
+func newWSConn(conn *websocket.Conn, pingInterval, readTimeout time.Duration) *wsConn {
+	c := &wsConn{
+		conn:          conn,
+		send:          make(chan []byte),
+		pingInterval:  pingInterval,
+		readTimeout:   readTimeout,
+		pending:       map[int]wsPending{},
+		subscriptions: map[string]*Subscription{},
+		closed:        make(chan struct{}),
+	}
+	go c.readPump()
+	go c.writePump()
+
+	return c
+}
+
+func (c *wsConn) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *wsConn) close(err error) {
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return
+	}
+	c.err = err
+	subscriptions := c.subscriptions
+	c.subscriptions = map[string]*Subscription{}
+	close(c.closed)
+	c.mu.Unlock()
+
+	c.conn.Close()
+	for _, subscription := range subscriptions {
+		subscription.end(err)
+	}
+}
+
+func (c *wsConn) readPump() {
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(c.readTimeout))
+	})
+	for {
+		c.conn.SetReadDeadline(time.Now().Add(c.readTimeout))
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			c.close(err)
+			return
+		}
+
+		var message wsMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			continue
+		}
+
+		if message.Method == "flow_subscription" {
+			c.mu.Lock()
+			subscription := c.subscriptions[message.Params.Subscription]
+			c.mu.Unlock()
+			if subscription != nil && !subscription.deliver(message.Params.Result) {
+				// the read pump serves every caller of the connection, it can not wait for a slow consumer
+				subscription.overflow()
+			}
+			continue
+		}
+
+		if message.ID == nil {
+			continue
+		}
+		c.mu.Lock()
+		pending, ok := c.pending[*message.ID]
+		delete(c.pending, *message.ID)
+		if ok && pending.subscription != nil {
+			// register before answering, notifications may follow the response immediately
+			var id string
+			if json.Unmarshal(message.Result, &id) == nil && id != "" {
+				pending.subscription.ID = id
+				c.subscriptions[id] = pending.subscription
+			}
+		}
+		c.mu.Unlock()
+
+		if ok {
+			pending.response <- data
+		}
+	}
+}
+
+func (c *wsConn) writePump() {
+	ping := time.NewTicker(c.pingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-c.closed:
+			return
+		case <-ping.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(DefaultWSWriteTimeout)); err != nil {
+				c.close(err)
+				return
+			}
+		case data := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(DefaultWSWriteTimeout))
+			if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				c.close(err)
+				return
+			}
+		}
+	}
+}
+
+// roundTrip sends request with connection-unique ID and returns response carrying the original ID
+func (c *wsConn) roundTrip(ctx context.Context, body []byte, subscription *Subscription) ([]byte, error) {
+	var request map[string]json.RawMessage
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, err
+	}
+	originalID := request["id"]
+
+	c.mu.Lock()
+	if c.err != nil {
+		err := c.err
+		c.mu.Unlock()
+		return nil, err
+	}
+	c.nextID++
+	id := c.nextID
+	pending := wsPending{response: make(chan []byte, 1), subscription: subscription}
+	c.pending[id] = pending
+	c.mu.Unlock()
+
+	forget := func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}
+
+	request["id"], _ = json.Marshal(id)
+	data, err := json.Marshal(request)
+	if err != nil {
+		forget()
+		return nil, err
+	}
+
+	select {
+	case c.send <- data:
+	case <-ctx.Done():
+		forget()
+		return nil, ctx.Err()
+	case <-c.closed:
+		return nil, c.closeErr()
+	}
+
+	select {
+	case response := <-pending.response:
+		var message map[string]json.RawMessage
+		if err := json.Unmarshal(response, &message); err != nil {
+			return nil, err
+		}
+		if originalID != nil {
+			message["id"] = originalID
+		}
+		return json.Marshal(message)
+	case <-ctx.Done():
+		forget()
+		return nil, ctx.Err()
+	case <-c.closed:
+		return nil, c.closeErr()
+	}
+}
+
+func (c *wsConn) closeErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+func (c *wsConn) unsubscribe(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.subscriptions, id)
+}
+
+// Subscription - stream of flow_subscribe notifications
+type Subscription struct {
+	ID string
+
+	conn          *wsConn
+	notifications chan json.RawMessage
+	errors        chan error
+	done          chan struct{}
+	once          sync.Once
+}
+
+// Notifications returns channel of raw notification payloads
+func (s *Subscription) Notifications() <-chan json.RawMessage {
+	return s.notifications
+}
+
+// Err returns channel receiving the error which ended subscription, closed when the subscription ends
+func (s *Subscription) Err() <-chan error {
+	return s.errors
+}
+
+// Unsubscribe cancels subscription on the node and ends it
+func (s *Subscription) Unsubscribe() error {
+	select {
+	case <-s.done:
+		return nil
+	default:
+	}
+
+	s.conn.unsubscribe(s.ID)
+	s.end(nil)
+
+	body, err := json.Marshal(asimovRequest{ID: 1, JSONRPC: "2.0", Method: "flow_unsubscribe", Params: []interface{}{s.ID}})
+	if err != nil {
+		return err
+	}
+	data, err := s.conn.roundTrip(context.Background(), body, nil)
+	if err != nil {
+		return err
+	}
+
+	response := new(asimovResponse)
+	if err := json.Unmarshal(data, response); err != nil {
+		return err
+	}
+	if response.Error != nil {
+		return *response.Error
+	}
+
+	return nil
+}
+
+// Start unsubscribes when ctx is done. Subscriptions are running once Subscribe returns,
+// so Start only ties their lifetime to ctx.
+func (s *Subscription) Start(ctx context.Context) error {
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.Unsubscribe()
+		case <-s.done:
+		}
+	}()
+
+	return nil
+}
+
+// Stop unsubscribes ignoring errors of the node
+func (s *Subscription) Stop() {
+	s.Unsubscribe()
+}
+
+// Errors returns the same channel as Err
+func (s *Subscription) Errors() <-chan error {
+	return s.errors
+}
+
+// Done returns channel closed when subscription ends
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// deliver queues notification without blocking, returning false if the buffer is full
+func (s *Subscription) deliver(payload json.RawMessage) bool {
+	select {
+	case s.notifications <- payload:
+		return true
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// overflow ends subscription whose buffer is full with ErrSubscriptionOverflow and cancels it on the node
+func (s *Subscription) overflow() {
+	s.conn.unsubscribe(s.ID)
+	s.end(ErrSubscriptionOverflow)
+
+	body, err := json.Marshal(asimovRequest{ID: 1, JSONRPC: "2.0", Method: "flow_unsubscribe", Params: []interface{}{s.ID}})
+	if err == nil {
+		go s.conn.roundTrip(context.Background(), body, nil)
+	}
+}
+
+func (s *Subscription) end(err error) {
+	s.once.Do(func() {
+		if err != nil {
+			s.errors <- err
+		}
+		close(s.errors)
+		close(s.done)
+	})
+}
+
+// Subscribe starts flow_subscribe subscription of given kind on the WebSocket endpoint. Subscriptions
+// are checked against WithAllowedMethods and WithDeniedMethods, they do not pass through interceptors.
+// A subscription whose notifications are not read while DefaultSubscriptionBuffer of them is pending
+// is ended with ErrSubscriptionOverflow.
+func (rpc *AsimovRPC) Subscribe(ctx context.Context, kind string, params ...interface{}) (*Subscription, error) {
+	if err := rpc.allowed("flow_subscribe"); err != nil {
+		return nil, err
+	}
+	url := rpc.endpoint()
+	if !isWebSocket(url) {
+		return nil, ErrNotWebSocket
+	}
+
+	header := http.Header{"User-Agent": {rpc.userAgent}}
+	rpc.applyHeaders(ctx, header)
+	conn, err := rpc.ws.conn(ctx, url, header, rpc.maxResponseSize)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(asimovRequest{
+		ID:      1,
+		JSONRPC: "2.0",
+		Method:  "flow_subscribe",
+		Params:  append([]interface{}{kind}, params...),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &Subscription{
+		conn:          conn,
+		notifications: make(chan json.RawMessage, DefaultSubscriptionBuffer),
+		errors:        make(chan error, 1),
+		done:          make(chan struct{}),
+	}
+
+	data, err := conn.roundTrip(ctx, body, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(asimovResponse)
+	if err := json.Unmarshal(data, response); err != nil {
+		return nil, err
+	}
+	if response.Error != nil {
+		return nil, *response.Error
+	}
+
+	return subscription, nil
+}
+
+// SubscribeNewHeads subscribes to new block headers, decode notifications with ParseHead
+func (rpc *AsimovRPC) SubscribeNewHeads(ctx context.Context) (*Subscription, error) {
+	return rpc.Subscribe(ctx, "newHeads")
+}
+
+// SubscribeLogs subscribes to logs matching params, notifications decode into Log
+func (rpc *AsimovRPC) SubscribeLogs(ctx context.Context, params FilterParams) (*Subscription, error) {
+	return rpc.Subscribe(ctx, "logs", params)
+}
+
+// SubscribePendingTransactions subscribes to hashes of new pending transactions
+func (rpc *AsimovRPC) SubscribePendingTransactions(ctx context.Context) (*Subscription, error) {
+	return rpc.Subscribe(ctx, "newPendingTransactions")
+}
+
+// ParseHead decodes newHeads notification
+func ParseHead(data json.RawMessage) (*Block, error) {
+	proxy := new(proxyBlockWithoutTransactions)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return nil, err
+	}
+
+	block := proxy.toBlock()
+	return &block, nil
+}
+
+// Close closes WebSocket connections of the client, ending all subscriptions. Clients of a ClientPool
+// share connections with other tenants, their Close does nothing and ClientPool.Close closes them.
+func (rpc *AsimovRPC) Close() error {
+	if rpc.ws.shared {
+		return nil
+	}
+
+	return rpc.ws.close()
+}
@@ -0,0 +1,266 @@
+package asimovrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+// wsNode - minimal WebSocket node answering flow_blockNumber and pushing two notifications per subscription
+func wsNode(t *testing.T) (*httptest.Server, string) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var mu sync.Mutex
+		write := func(message string) {
+			mu.Lock()
+			defer mu.Unlock()
+			conn.WriteMessage(websocket.TextMessage, []byte(message))
+		}
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			id := gjson.GetBytes(data, "id").Raw
+			switch gjson.GetBytes(data, "method").String() {
+			case "flow_blockNumber":
+				write(`{"jsonrpc":"2.0","id":` + id + `,"result":"0x10"}`)
+			case "flow_subscribe":
+				write(`{"jsonrpc":"2.0","id":` + id + `,"result":"0xsub"}`)
+				if gjson.GetBytes(data, "params.0").String() == "flood" {
+					for i := 0; i <= DefaultSubscriptionBuffer; i++ {
+						write(`{"jsonrpc":"2.0","method":"flow_subscription","params":{"subscription":"0xsub","result":"0x1"}}`)
+					}
+					continue
+				}
+				write(`{"jsonrpc":"2.0","method":"flow_subscription","params":{"subscription":"0xsub","result":{"number":"0x11","hash":"0xh1"}}}`)
+				write(`{"jsonrpc":"2.0","method":"flow_subscription","params":{"subscription":"0xsub","result":{"number":"0x12","hash":"0xh2"}}}`)
+			case "flow_unsubscribe":
+				write(`{"jsonrpc":"2.0","id":` + id + `,"result":true}`)
+			case "close":
+				return
+			default:
+				write(`{"jsonrpc":"2.0","id":` + id + `,"error":{"code":-32601,"message":"method not found"}}`)
+			}
+		}
+	}))
+
+	return server, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestWebSocketCall(t *testing.T) {
+	server, url := wsNode(t)
+	defer server.Close()
+
+	rpc := NewAsimovRPC(url)
+	defer rpc.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			number, err := rpc.AsimovBlockNumber()
+			require.Nil(t, err)
+			require.Equal(t, 16, number)
+		}()
+	}
+	wg.Wait()
+
+	_, err := rpc.Call("flow_unknown")
+	require.Equal(t, AsimovError{Code: -32601, Message: "method not found"}, err)
+	require.Len(t, rpc.ws.conns, 1)
+}
+
+func TestWebSocketSubscription(t *testing.T) {
+	server, url := wsNode(t)
+	defer server.Close()
+
+	rpc := NewAsimovRPC(url)
+	defer rpc.Close()
+
+	subscription, err := rpc.SubscribeNewHeads(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, "0xsub", subscription.ID)
+
+	for _, expected := range []int{17, 18} {
+		select {
+		case notification := <-subscription.Notifications():
+			head, err := ParseHead(notification)
+			require.Nil(t, err)
+			require.Equal(t, expected, head.Number)
+		case <-time.After(time.Second):
+			t.Fatal("notification not delivered")
+		}
+	}
+
+	require.Nil(t, subscription.Unsubscribe())
+	_, open := <-subscription.Err()
+	require.False(t, open)
+}
+
+func TestWebSocketClosed(t *testing.T) {
+	server, url := wsNode(t)
+	defer server.Close()
+
+	rpc := NewAsimovRPC(url)
+	subscription, err := rpc.Subscribe(context.Background(), "newHeads")
+	require.Nil(t, err)
+
+	_, err = rpc.Call("close")
+	require.NotNil(t, err)
+	select {
+	case err := <-subscription.Err():
+		require.NotNil(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("subscription did not end")
+	}
+
+	// next call redials
+	number, err := rpc.AsimovBlockNumber()
+	require.Nil(t, err)
+	require.Equal(t, 16, number)
+	require.Nil(t, rpc.Close())
+}
+
+func TestWebSocketSubscriptionOverflow(t *testing.T) {
+	server, url := wsNode(t)
+	defer server.Close()
+
+	rpc := NewAsimovRPC(url)
+	defer rpc.Close()
+
+	subscription, err := rpc.Subscribe(context.Background(), "flood")
+	require.Nil(t, err)
+
+	// the slow subscription is ended, other calls on the connection are answered
+	select {
+	case err := <-subscription.Err():
+		require.Equal(t, ErrSubscriptionOverflow, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription was not ended")
+	}
+	number, err := rpc.AsimovBlockNumber()
+	require.Nil(t, err)
+	require.Equal(t, 16, number)
+	require.Len(t, subscription.Notifications(), DefaultSubscriptionBuffer)
+}
+
+func TestSubscribeNotAllowed(t *testing.T) {
+	server, url := wsNode(t)
+	defer server.Close()
+
+	rpc := NewAsimovRPC(url, WithDeniedMethods("flow_subscribe"))
+	defer rpc.Close()
+
+	_, err := rpc.SubscribeNewHeads(context.Background())
+	require.Equal(t, MethodNotAllowedError{Method: "flow_subscribe"}, err)
+}
+
+func TestSubscribeRequiresWebSocket(t *testing.T) {
+	rpc := NewAsimovRPC("http://127.0.0.1:8545")
+	_, err := rpc.SubscribeLogs(context.Background(), FilterParams{})
+	require.Equal(t, ErrNotWebSocket, err)
+}
+
+func TestParseHead(t *testing.T) {
+	head, err := ParseHead(json.RawMessage(`{"number": "0x1", "hash": "0xh", "gasUsed": "0x5208"}`))
+	require.Nil(t, err)
+	require.Equal(t, 1, head.Number)
+	require.Equal(t, 21000, head.GasUsed)
+}
+
+func TestWebSocketClientPool(t *testing.T) {
+	server, url := wsNode(t)
+	defer server.Close()
+
+	pool := NewClientPool(url)
+	acme, err := pool.Client("acme")
+	require.Nil(t, err)
+	globex, err := pool.Client("globex")
+	require.Nil(t, err)
+
+	subscription, err := globex.SubscribeNewHeads(context.Background())
+	require.Nil(t, err)
+	<-subscription.Notifications()
+
+	// a tenant can not close connections of the other tenants
+	require.Nil(t, acme.Close())
+	_, err = globex.AsimovBlockNumber()
+	require.Nil(t, err)
+	<-subscription.Notifications()
+
+	require.Nil(t, pool.Close())
+	require.Len(t, globex.ws.conns, 0)
+}
+
+func TestWebSocketKeepalive(t *testing.T) {
+	server, url := wsNode(t)
+	defer server.Close()
+
+	rpc := NewAsimovRPC(url)
+	defer rpc.Close()
+	rpc.ws.pingInterval, rpc.ws.readTimeout = 10*time.Millisecond, 50*time.Millisecond
+
+	// pongs keep the idle connection open past the read timeout
+	_, err := rpc.AsimovBlockNumber()
+	require.Nil(t, err)
+	conn := rpc.ws.conns[url]
+	time.Sleep(200 * time.Millisecond)
+	require.False(t, conn.isClosed())
+	_, err = rpc.AsimovBlockNumber()
+	require.Nil(t, err)
+	require.True(t, conn == rpc.ws.conns[url])
+
+	// a node which stopped answering is detected by the read deadline
+	upgrader := websocket.Upgrader{}
+	silent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetPingHandler(func(string) error { return nil })
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer silent.Close()
+
+	rpc = NewAsimovRPC("ws" + strings.TrimPrefix(silent.URL, "http"))
+	defer rpc.Close()
+	rpc.ws.pingInterval, rpc.ws.readTimeout = 10*time.Millisecond, 50*time.Millisecond
+	subscription, err := rpc.SubscribeNewHeads(context.Background())
+	require.Nil(t, subscription)
+	require.NotNil(t, err)
+}
+
+func TestWebSocketReadLimit(t *testing.T) {
+	server, url := wsNode(t)
+	defer server.Close()
+
+	rpc := NewAsimovRPC(url, WithMaxResponseSize(16))
+	defer rpc.Close()
+
+	_, err := rpc.AsimovBlockNumber()
+	require.NotNil(t, err)
+	require.True(t, rpc.ws.conns[url].isClosed())
+}